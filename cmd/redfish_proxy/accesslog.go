@@ -0,0 +1,70 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Headers whose values are redacted in access logs.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Auth-Token",
+}
+
+// accessLogTransport logs every request proxied to Redfish targets.
+type accessLogTransport struct {
+	logger *slog.Logger
+	level  slog.Level
+	next   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (t *accessLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.String("target", req.URL.Host),
+		slog.String("remote_addr", req.RemoteAddr),
+		slog.Any("headers", redactHeaders(req.Header)),
+		slog.Duration("latency", time.Since(start)),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("err", err.Error()))
+		t.logger.LogAttrs(req.Context(), t.level, "Failed to proxy request", attrs...)
+
+		return resp, err
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	t.logger.LogAttrs(req.Context(), t.level, "Request proxied", attrs...)
+
+	return resp, nil
+}
+
+// redactHeaders returns a copy of headers with values of sensitive headers
+// redacted.
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+
+	for name, values := range headers {
+		if slices.Contains(sensitiveHeaders, name) {
+			redacted[name] = "<redacted>"
+
+			continue
+		}
+
+		redacted[name] = strings.Join(values, ",")
+	}
+
+	return redacted
+}
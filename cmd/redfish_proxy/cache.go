@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/prometheus/common/model"
+)
+
+// Session endpoints are never cached as responses of these
+// endpoints contain session specific information.
+var sessionServiceRegex = regexp.MustCompile(`^/redfish/v1/SessionService`)
+
+// CacheRule configures TTL of cached responses of Redfish API resources
+// whose path matches the regex.
+type CacheRule struct {
+	Path *regexp.Regexp
+	TTL  model.Duration
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *CacheRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp struct {
+		Path string         `yaml:"path"`
+		TTL  model.Duration `yaml:"ttl"`
+	}
+
+	if err := unmarshal(&tmp); err != nil {
+		return err
+	}
+
+	regex, err := regexp.Compile(tmp.Path)
+	if err != nil {
+		return fmt.Errorf("invalid path regex %s in cache rule: %w", tmp.Path, err)
+	}
+
+	if tmp.TTL <= 0 {
+		return fmt.Errorf("ttl must be positive for cache rule with path %s", tmp.Path)
+	}
+
+	r.Path = regex
+	r.TTL = tmp.TTL
+
+	return nil
+}
+
+// Cache configures caching of responses of Redfish targets.
+type Cache struct {
+	Rules        []CacheRule
+	ExcludePaths []*regexp.Regexp
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *Cache) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp struct {
+		Rules        []CacheRule `yaml:"rules"`
+		ExcludePaths []string    `yaml:"exclude_paths"`
+	}
+
+	if err := unmarshal(&tmp); err != nil {
+		return err
+	}
+
+	for _, path := range tmp.ExcludePaths {
+		regex, err := regexp.Compile(path)
+		if err != nil {
+			return fmt.Errorf("invalid exclude path regex %s in cache config: %w", path, err)
+		}
+
+		c.ExcludePaths = append(c.ExcludePaths, regex)
+	}
+
+	c.Rules = tmp.Rules
+
+	return nil
+}
+
+// cachedResponse is a response of Redfish target stored in cache.
+type cachedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// cachingTransport is a RoundTripper that caches GET responses of Redfish
+// targets based on configured cache rules.
+type cachingTransport struct {
+	rules   []CacheRule
+	exclude []*regexp.Regexp
+	cache   *ttlcache.Cache[string, *cachedResponse]
+	next    http.RoundTripper
+}
+
+// newCachingTransport returns a new instance of cachingTransport. The next
+// RoundTripper must be set before using the transport.
+func newCachingTransport(config *Cache) *cachingTransport {
+	return &cachingTransport{
+		rules:   config.Rules,
+		exclude: config.ExcludePaths,
+		cache:   ttlcache.New(ttlcache.WithDisableTouchOnHit[string, *cachedResponse]()),
+	}
+}
+
+// start starts automatic deletion of expired responses.
+func (t *cachingTransport) start() {
+	t.cache.Start()
+}
+
+// stop stops automatic deletion of expired responses.
+func (t *cachingTransport) stop() {
+	t.cache.Stop()
+}
+
+// ttl returns TTL of the request based on cache rules. Returns false if the
+// request must not be cached.
+func (t *cachingTransport) ttl(req *http.Request) (time.Duration, bool) {
+	if req.Method != http.MethodGet || sessionServiceRegex.MatchString(req.URL.Path) {
+		return 0, false
+	}
+
+	for _, regex := range t.exclude {
+		if regex.MatchString(req.URL.Path) {
+			return 0, false
+		}
+	}
+
+	// First matching rule wins
+	for _, rule := range t.rules {
+		if rule.Path.MatchString(req.URL.Path) {
+			return time.Duration(rule.TTL), true
+		}
+	}
+
+	return 0, false
+}
+
+// cacheKey returns cache key of the request. Key includes target URL, path and
+// query along with a hash of credentials so that cached responses are never
+// served to clients with different credentials.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	h.Write([]byte(req.Header.Get("Authorization")))
+	h.Write([]byte{0})
+	h.Write([]byte(req.Header.Get("X-Auth-Token")))
+
+	return req.URL.Scheme + "://" + req.URL.Host + req.URL.RequestURI() + "#" + hex.EncodeToString(h.Sum(nil))
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ttl, ok := t.ttl(req)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+
+	// Serve response from cache if present
+	if item := t.cache.Get(key); item != nil {
+		return item.Value().response(req), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	// Read body to cache it
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	value := &cachedResponse{statusCode: resp.StatusCode, header: resp.Header.Clone(), body: body}
+	t.cache.Set(key, value, ttl)
+
+	return value.response(req), nil
+}
+
+// response returns a new http.Response from cached response.
+func (r *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.statusCode, http.StatusText(r.statusCode)),
+		StatusCode:    r.statusCode,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/config"
+)
+
+// Redfish session related constants.
+const (
+	sessionsPath          = "/redfish/v1/SessionService/Sessions"
+	sessionTokenHeader    = "X-Auth-Token"
+	proxySessionID        = "redfish-proxy"
+	proxySessionToken     = "redfish-proxy-managed-session"
+	proxySessionLocation  = sessionsPath + "/" + proxySessionID
+	sessionRequestTimeout = 10 * time.Second
+)
+
+// Credentials configures credentials that proxy uses to create Redfish
+// sessions on behalf of clients.
+type Credentials struct {
+	Username     string        `yaml:"username"`
+	Password     config.Secret `yaml:"password"`
+	PasswordFile string        `yaml:"password_file"`
+}
+
+// validate checks if credentials are valid.
+func (c *Credentials) validate() error {
+	if c.Username == "" {
+		return errors.New("username is required in credentials")
+	}
+
+	if (c.Password == "") == (c.PasswordFile == "") {
+		return errors.New("exactly one of password and password_file must be set in credentials")
+	}
+
+	return nil
+}
+
+// password returns password of credentials. Password file is read every time
+// so that rotated passwords are picked up without restarting proxy.
+func (c *Credentials) password() (string, error) {
+	if c.PasswordFile == "" {
+		return string(c.Password), nil
+	}
+
+	content, err := os.ReadFile(c.PasswordFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password file: %w", err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// targetSession is a Redfish session created by proxy on a target.
+type targetSession struct {
+	target      Target
+	mu          sync.Mutex
+	token       string
+	location    string
+	initialized bool
+}
+
+// credentialsTransport is a RoundTripper that injects session tokens
+// of the sessions created by proxy into requests to Redfish targets that
+// have credentials configured. Clients' own credentials are stripped from
+// requests and session token of the proxy is never returned to clients.
+type credentialsTransport struct {
+	logger   *slog.Logger
+	sessions map[string]*targetSession // Map of target host to its session
+	next     http.RoundTripper
+}
+
+// newCredentialsTransport returns a new instance of credentialsTransport. Returns
+// nil if none of the targets have credentials configured. The next RoundTripper
+// must be set before using the transport.
+func newCredentialsTransport(logger *slog.Logger, targets []Target) *credentialsTransport {
+	sessions := make(map[string]*targetSession)
+
+	for _, target := range targets {
+		if target.Credentials != nil {
+			sessions[target.URL.Host] = &targetSession{target: target}
+		}
+	}
+
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	return &credentialsTransport{logger: logger, sessions: sessions}
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (t *credentialsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	session, ok := t.sessions[req.URL.Host]
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	// Sessions are managed by proxy. Respond to session creation and deletion
+	// requests of clients locally
+	if resp := proxySessionResponse(req); resp != nil {
+		return resp, nil
+	}
+
+	// Strip any credentials sent by client
+	req.Header.Del("Authorization")
+	req.Header.Del(sessionTokenHeader)
+
+	token, err := session.getToken(req.Context(), t.next, "")
+	if err != nil {
+		t.logger.Error("Failed to create Redfish session", "target", session.target.URL.Redacted(), "err", err)
+
+		return nil, err
+	}
+
+	req.Header.Set(sessionTokenHeader, token)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return stripSessionToken(resp), err
+	}
+
+	// Session might have expired. Refresh session and retry request once
+	// when request body can be replayed
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return stripSessionToken(resp), nil
+	}
+
+	resp.Body.Close()
+
+	t.logger.Debug("Refreshing Redfish session", "target", session.target.URL.Redacted())
+
+	if token, err = session.getToken(req.Context(), t.next, token); err != nil {
+		t.logger.Error("Failed to refresh Redfish session", "target", session.target.URL.Redacted(), "err", err)
+
+		return nil, err
+	}
+
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retryReq.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+
+	retryReq.Header.Set(sessionTokenHeader, token)
+
+	resp, err = t.next.RoundTrip(retryReq)
+
+	return stripSessionToken(resp), err
+}
+
+// stripSessionToken removes session token of proxy from response, if any, so
+// that it is never returned to clients.
+func stripSessionToken(resp *http.Response) *http.Response {
+	if resp != nil {
+		resp.Header.Del(sessionTokenHeader)
+	}
+
+	return resp
+}
+
+// close deletes all the sessions created by proxy on targets.
+func (t *credentialsTransport) close(ctx context.Context) {
+	for _, session := range t.sessions {
+		session.delete(ctx, t.next)
+	}
+}
+
+// getToken returns the session token of the target. A new session is created
+// when there is no session yet or when current token is same as stale token.
+// Comparing with stale token avoids creating multiple sessions when several
+// concurrent requests find that the session has expired.
+func (s *targetSession) getToken(ctx context.Context, tr http.RoundTripper, stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.initialized && (stale == "" || s.token != stale) {
+		return s.token, nil
+	}
+
+	password, err := s.target.Credentials.password()
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]string{"UserName": s.target.Credentials.Username, "Password": password})
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sessionRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.target.URL.JoinPath(sessionsPath).String(), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// Drain body to reuse connection. Never log body as it might
+	// contain sensitive information
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("session creation failed with status code %d", resp.StatusCode)
+	}
+
+	token := resp.Header.Get(sessionTokenHeader)
+	if token == "" {
+		return "", errors.New("session token not found in response")
+	}
+
+	s.token = token
+	s.location = resp.Header.Get("Location")
+	s.initialized = true
+
+	return s.token, nil
+}
+
+// delete deletes the session on target if it exists.
+func (s *targetSession) delete(ctx context.Context, tr http.RoundTripper) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.initialized || s.location == "" {
+		return
+	}
+
+	// Location can be either absolute URL or a path
+	u, err := s.target.URL.Parse(s.location)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return
+	}
+
+	req.Header.Set(sessionTokenHeader, s.token)
+
+	if resp, err := tr.RoundTrip(req); err == nil {
+		resp.Body.Close()
+	}
+
+	s.initialized = false
+	s.token = ""
+}
+
+// proxySessionResponse returns a response for session creation and deletion
+// requests of clients. Returns nil for all other requests.
+func proxySessionResponse(req *http.Request) *http.Response {
+	path := strings.TrimSuffix(req.URL.Path, "/")
+
+	var code int
+
+	header := http.Header{}
+
+	var body string
+
+	switch {
+	case req.Method == http.MethodPost && path == sessionsPath:
+		code = http.StatusCreated
+		body = fmt.Sprintf(`{"@odata.id":"%s","Id":"%s","Name":"Redfish proxy session"}`, proxySessionLocation, proxySessionID)
+
+		header.Set("Content-Type", "application/json")
+		header.Set("Location", proxySessionLocation)
+		header.Set(sessionTokenHeader, proxySessionToken)
+	case req.Method == http.MethodDelete && path == proxySessionLocation:
+		code = http.StatusNoContent
+	default:
+		return nil
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode:    code,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
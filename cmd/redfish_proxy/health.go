@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// targetHealth keeps track of health of Redfish targets that have health
+// checks configured.
+type targetHealth struct {
+	logger  *slog.Logger
+	client  *http.Client
+	targets []Target
+	mu      sync.RWMutex
+	healthy map[string]bool // Map of target host to its health
+	up      *prometheus.GaugeVec
+	wg      sync.WaitGroup
+}
+
+// newTargetHealth returns a new instance of targetHealth.
+func newTargetHealth(logger *slog.Logger, targets []Target, tr http.RoundTripper) *targetHealth {
+	h := &targetHealth{
+		logger:  logger,
+		client:  &http.Client{Transport: tr},
+		healthy: make(map[string]bool),
+		up: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: appName,
+				Name:      "target_up",
+				Help:      "Health of Redfish target, 1=healthy, 0=unhealthy",
+			},
+			[]string{"target"},
+		),
+	}
+
+	// Only keep targets that have health checks configured
+	for _, target := range targets {
+		if target.HealthCheck != nil {
+			h.targets = append(h.targets, target)
+		}
+	}
+
+	return h
+}
+
+// start launches health checks of all targets in separate go routines until
+// ctx is cancelled.
+func (h *targetHealth) start(ctx context.Context) {
+	for _, target := range h.targets {
+		h.wg.Add(1)
+
+		go func(target Target) {
+			defer h.wg.Done()
+
+			ticker := time.NewTicker(time.Duration(target.HealthCheck.Interval))
+			defer ticker.Stop()
+
+			for {
+				// Check health as soon as go routine starts
+				h.update(target, h.probe(ctx, target))
+
+				select {
+				case <-ticker.C:
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(target)
+	}
+}
+
+// wait waits until all health checks are stopped.
+func (h *targetHealth) wait() {
+	h.wg.Wait()
+}
+
+// probe makes a request to health check path of target and returns true
+// if target responded. Any response with status code less than 500 is
+// considered healthy, as BMCs might respond with 401 when no credentials
+// are provided.
+func (h *targetHealth) probe(ctx context.Context, target Target) bool {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(target.HealthCheck.Timeout))
+	defer cancel()
+
+	u := target.URL.JoinPath(target.HealthCheck.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		h.logger.Error("Failed to create health check request", "target", target.URL.Redacted(), "err", err)
+
+		return false
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		h.logger.Debug("Health check of target failed", "target", target.URL.Redacted(), "err", err)
+
+		return false
+	}
+	defer resp.Body.Close()
+
+	// Drain body to reuse connection
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck
+
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// update sets health of target.
+func (h *targetHealth) update(target Target, healthy bool) {
+	h.mu.Lock()
+	if prev, ok := h.healthy[target.URL.Host]; (!ok || prev) && !healthy {
+		h.logger.Warn("Redfish target is unhealthy", "target", target.URL.Redacted())
+	} else if ok && !prev && healthy {
+		h.logger.Info("Redfish target is healthy again", "target", target.URL.Redacted())
+	}
+
+	h.healthy[target.URL.Host] = healthy
+	h.mu.Unlock()
+
+	if healthy {
+		h.up.WithLabelValues(target.URL.Redacted()).Set(1)
+	} else {
+		h.up.WithLabelValues(target.URL.Redacted()).Set(0)
+	}
+}
+
+// isHealthy returns true if the target with host is healthy. Targets without
+// health checks are always considered healthy.
+func (h *targetHealth) isHealthy(host string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if healthy, ok := h.healthy[host]; ok {
+		return healthy
+	}
+
+	return true
+}
+
+// healthAwareTransport is a RoundTripper that returns 503 immediately
+// for requests to unhealthy targets instead of waiting on connection.
+type healthAwareTransport struct {
+	health *targetHealth
+	next   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (t *healthAwareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.health.isHealthy(req.URL.Host) {
+		return t.next.RoundTrip(req)
+	}
+
+	body := "Redfish target " + (&url.URL{Scheme: req.URL.Scheme, Host: req.URL.Host}).String() + " is unhealthy"
+
+	return &http.Response{
+		Status:        "503 " + http.StatusText(http.StatusServiceUnavailable),
+		StatusCode:    http.StatusServiceUnavailable,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
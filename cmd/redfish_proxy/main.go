@@ -2,19 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/mahendrapaipuri/ceems/internal/common"
 	internal_runtime "github.com/mahendrapaipuri/ceems/internal/runtime"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/promslog"
 	"github.com/prometheus/common/promslog/flag"
 	"github.com/prometheus/common/version"
@@ -48,18 +52,41 @@ var (
 		"web.debug-server",
 		"Enable debug server (default: disabled).",
 	).Default("false").Bool()
+	enableAccessLog = app.Flag(
+		"web.access-log",
+		"Log method, path, target, status and latency of every proxied request (default: disabled).",
+	).Default("false").Bool()
+	accessLogLevel = app.Flag(
+		"web.access-log.level",
+		"Level of access log messages. One of: [debug, info, warn, error]",
+	).Default("info").Enum("debug", "info", "warn", "error")
 )
 
+// HealthCheck configures periodic health checks of a Redfish target.
+type HealthCheck struct {
+	Path     string         `yaml:"path"`
+	Interval model.Duration `yaml:"interval"`
+	Timeout  model.Duration `yaml:"timeout"`
+}
+
 type Target struct {
-	HostAddrs []string `yaml:"host_ip_addrs"`
-	URL       *url.URL `yaml:"url"`
+	HostAddrs             []string     `yaml:"host_ip_addrs"`
+	URL                   *url.URL     `yaml:"url"`
+	HealthCheck           *HealthCheck `yaml:"health_check"`
+	MaxConcurrentRequests int          `yaml:"max_concurrent_requests"`
+	RequestsPerSecond     float64      `yaml:"requests_per_second"`
+	Credentials           *Credentials `yaml:"credentials"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
 func (t *Target) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var tmp struct {
-		HostAddrs []string `yaml:"host_ip_addrs"`
-		URL       string   `yaml:"url"`
+		HostAddrs             []string     `yaml:"host_ip_addrs"`
+		URL                   string       `yaml:"url"`
+		HealthCheck           *HealthCheck `yaml:"health_check"`
+		MaxConcurrentRequests int          `yaml:"max_concurrent_requests"`
+		RequestsPerSecond     float64      `yaml:"requests_per_second"`
+		Credentials           *Credentials `yaml:"credentials"`
 	}
 
 	if err := unmarshal(&tmp); err != nil {
@@ -77,9 +104,71 @@ func (t *Target) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return fmt.Errorf("invalid url string: %s", tmp.URL)
 	}
 
+	// Validate CIDR ranges of host addresses
+	for _, addr := range tmp.HostAddrs {
+		if strings.Contains(addr, "/") {
+			if _, _, err := net.ParseCIDR(addr); err != nil {
+				return fmt.Errorf("invalid CIDR range %s in host_ip_addrs: %w", addr, err)
+			}
+		}
+	}
+
+	// Check rate limits
+	if tmp.MaxConcurrentRequests < 0 || tmp.RequestsPerSecond < 0 {
+		return fmt.Errorf("max_concurrent_requests and requests_per_second must be non-negative for target %s", tmp.URL)
+	}
+
+	// Check credentials
+	if tmp.Credentials != nil {
+		if err := tmp.Credentials.validate(); err != nil {
+			return fmt.Errorf("invalid credentials for target %s: %w", tmp.URL, err)
+		}
+	}
+
+	// Set defaults for health check
+	if tmp.HealthCheck != nil {
+		if tmp.HealthCheck.Path == "" {
+			tmp.HealthCheck.Path = "/redfish/v1/"
+		}
+
+		if tmp.HealthCheck.Interval <= 0 {
+			tmp.HealthCheck.Interval = model.Duration(30 * time.Second)
+		}
+
+		if tmp.HealthCheck.Timeout <= 0 || tmp.HealthCheck.Timeout > tmp.HealthCheck.Interval {
+			tmp.HealthCheck.Timeout = min(model.Duration(5*time.Second), tmp.HealthCheck.Interval)
+		}
+	}
+
 	// Set target
 	t.HostAddrs = tmp.HostAddrs
 	t.URL = u
+	t.HealthCheck = tmp.HealthCheck
+	t.MaxConcurrentRequests = tmp.MaxConcurrentRequests
+	t.RequestsPerSecond = tmp.RequestsPerSecond
+	t.Credentials = tmp.Credentials
+
+	return nil
+}
+
+// Transport configures the connections made by proxy to Redfish targets.
+type Transport struct {
+	MaxIdleConnsPerHost int            `yaml:"max_idle_conns_per_host"`
+	IdleConnTimeout     model.Duration `yaml:"idle_conn_timeout"`
+	EnableHTTP2         bool           `yaml:"enable_http2"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (t *Transport) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Transport
+
+	if err := unmarshal((*plain)(t)); err != nil {
+		return err
+	}
+
+	if t.MaxIdleConnsPerHost < 0 || t.IdleConnTimeout < 0 {
+		return errors.New("max_idle_conns_per_host and idle_conn_timeout must be non-negative")
+	}
 
 	return nil
 }
@@ -87,9 +176,11 @@ func (t *Target) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type Redfish struct {
 	Config struct {
 		Web struct {
-			Insecure bool `yaml:"insecure_skip_verify"`
+			Insecure  bool      `yaml:"insecure_skip_verify"`
+			Transport Transport `yaml:"transport"`
 		} `yaml:"web"`
 		Targets []Target `yaml:"targets"`
+		Cache   *Cache   `yaml:"cache"`
 	} `yaml:"redfish_config"`
 }
 
@@ -99,6 +190,8 @@ type WebConfig struct {
 	WebSystemdSocket  bool
 	WebConfigFile     string
 	EnableDebugServer bool
+	AccessLog         bool
+	AccessLogLevel    slog.Level
 }
 
 // Config makes a server config.
@@ -176,6 +269,15 @@ func main() {
 		}
 	}
 
+	// Get level of access log messages. Level has been validated by kingpin
+	var level slog.Level
+
+	if err := level.UnmarshalText([]byte(*accessLogLevel)); err != nil {
+		logger.Error("Invalid access log level", "level", *accessLogLevel, "err", err)
+
+		os.Exit(1)
+	}
+
 	// Make a new config based
 	config := &Config{
 		Logger: logger,
@@ -184,6 +286,8 @@ func main() {
 			WebSystemdSocket:  *systemdSocket,
 			WebConfigFile:     webConfigFilePath,
 			EnableDebugServer: *enableDebugServer,
+			AccessLog:         *enableAccessLog,
+			AccessLogLevel:    level,
 		},
 		Redfish: redfish,
 	}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/mahendrapaipuri/ceems/internal/common"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -74,6 +75,135 @@ redfish_config:
         - 192.168.1.1
         - 192.168.1.2
       url: http://172.134.1.1:80`,
+		},
+		{
+			name: "valid config with health check",
+			content: `
+---
+redfish_config:
+  targets:
+    - host_ip_addrs:
+        - 192.168.1.1
+      url: http://172.134.1.1:80
+      health_check:
+        interval: 10s`,
+		},
+		{
+			name: "valid config with rate limits",
+			content: `
+---
+redfish_config:
+  targets:
+    - host_ip_addrs:
+        - 192.168.1.1
+      url: http://172.134.1.1:80
+      max_concurrent_requests: 2
+      requests_per_second: 0.5`,
+		},
+		{
+			name: "valid config with CIDR ranges",
+			content: `
+---
+redfish_config:
+  targets:
+    - host_ip_addrs:
+        - 192.168.1.0/24
+        - 10.100.4.1
+      url: http://172.134.1.1:80`,
+		},
+		{
+			name: "invalid config due to malformed CIDR range",
+			err:  true,
+			content: `
+---
+redfish_config:
+  targets:
+    - host_ip_addrs:
+        - 192.168.1.0/33
+      url: http://172.134.1.1:80`,
+		},
+		{
+			name: "valid config with cache",
+			content: `
+---
+redfish_config:
+  cache:
+    rules:
+      - path: ^/redfish/v1/Chassis/[^/]+/Power$
+        ttl: 1m
+    exclude_paths:
+      - ^/redfish/v1/Systems`,
+		},
+		{
+			name: "invalid config due to malformed cache rule",
+			err:  true,
+			content: `
+---
+redfish_config:
+  cache:
+    rules:
+      - path: ^/redfish/v1/Chassis/[^/+/Power$
+        ttl: 1m`,
+		},
+		{
+			name: "valid config with credentials",
+			content: `
+---
+redfish_config:
+  targets:
+    - host_ip_addrs:
+        - 192.168.1.1
+      url: http://172.134.1.1:80
+      credentials:
+        username: admin
+        password_file: /etc/redfish_proxy/password`,
+		},
+		{
+			name: "invalid config due to missing password in credentials",
+			err:  true,
+			content: `
+---
+redfish_config:
+  targets:
+    - host_ip_addrs:
+        - 192.168.1.1
+      url: http://172.134.1.1:80
+      credentials:
+        username: admin`,
+		},
+		{
+			name: "invalid config due to negative rate limits",
+			err:  true,
+			content: `
+---
+redfish_config:
+  targets:
+    - host_ip_addrs:
+        - 192.168.1.1
+      url: http://172.134.1.1:80
+      max_concurrent_requests: -1`,
+		},
+		{
+			name: "valid config with transport",
+			content: `
+---
+redfish_config:
+  web:
+    insecure_skip_verify: true
+    transport:
+      max_idle_conns_per_host: 8
+      idle_conn_timeout: 2m
+      enable_http2: true`,
+		},
+		{
+			name: "invalid config due to negative idle connections",
+			err:  true,
+			content: `
+---
+redfish_config:
+  web:
+    transport:
+      max_idle_conns_per_host: -1`,
 		},
 		{
 			name: "invalid config due to malformed web url",
@@ -104,6 +234,13 @@ redfish_config:
 
 			if len(cfg.Config.Targets) > 0 {
 				assert.Equal(t, "http://172.134.1.1:80", cfg.Config.Targets[0].URL.String())
+
+				// Check defaults of health check
+				if hc := cfg.Config.Targets[0].HealthCheck; hc != nil {
+					assert.Equal(t, "/redfish/v1/", hc.Path)
+					assert.Equal(t, model.Duration(10*time.Second), hc.Interval)
+					assert.Equal(t, model.Duration(5*time.Second), hc.Timeout)
+				}
 			}
 		}
 	}
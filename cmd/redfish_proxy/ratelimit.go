@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Maximum duration a request is queued when limits of a Redfish target
+// are reached before returning 429 to the client.
+const rateLimitQueueTimeout = 2 * time.Second
+
+// targetLimiter limits the number of concurrent requests and rate of
+// requests made to a Redfish target.
+type targetLimiter struct {
+	target   string
+	sem      chan struct{} // nil when concurrency is not limited
+	interval time.Duration // Minimum interval between requests. 0 when rate is not limited
+	mu       sync.Mutex
+	next     time.Time // Time at which next request is allowed
+}
+
+// newTargetLimiter returns a new instance of targetLimiter. Returns nil if
+// target does not have any limits configured.
+func newTargetLimiter(target Target) *targetLimiter {
+	if target.MaxConcurrentRequests <= 0 && target.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	l := &targetLimiter{target: target.URL.Redacted()}
+
+	if target.MaxConcurrentRequests > 0 {
+		l.sem = make(chan struct{}, target.MaxConcurrentRequests)
+	}
+
+	if target.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / target.RequestsPerSecond)
+	}
+
+	return l
+}
+
+// acquire waits until request can be made to the target within the queue
+// timeout. When successful, returned release function must be called once
+// the request is finished.
+func (l *targetLimiter) acquire(ctx context.Context) (func(), bool) {
+	ctx, cancel := context.WithTimeout(ctx, rateLimitQueueTimeout)
+	defer cancel()
+
+	// Reserve a slot for request based on rate
+	if l.interval > 0 {
+		l.mu.Lock()
+
+		now := time.Now()
+		if l.next.Before(now) {
+			l.next = now
+		}
+
+		// Do not reserve slot if it is beyond queue timeout
+		wait := l.next.Sub(now)
+		if wait > rateLimitQueueTimeout {
+			l.mu.Unlock()
+
+			return nil, false
+		}
+
+		l.next = l.next.Add(l.interval)
+		l.mu.Unlock()
+
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return nil, false
+			}
+		}
+	}
+
+	if l.sem == nil {
+		return func() {}, true
+	}
+
+	select {
+	case l.sem <- struct{}{}:
+		var once sync.Once
+
+		return func() { once.Do(func() { <-l.sem }) }, true
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// releaseOnClose is a response body that releases the limiter slot when
+// the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close implements io.Closer interface.
+func (b *releaseOnClose) Close() error {
+	defer b.release()
+
+	return b.ReadCloser.Close()
+}
+
+// rateLimitedTransport is a RoundTripper that enforces limits of Redfish
+// targets and returns 429 when the limits are reached.
+type rateLimitedTransport struct {
+	limiters  map[string]*targetLimiter // Map of target host to its limiter
+	throttled *prometheus.CounterVec
+	next      http.RoundTripper
+}
+
+// newRateLimitedTransport returns a new instance of rateLimitedTransport.
+func newRateLimitedTransport(targets []Target, throttled *prometheus.CounterVec, next http.RoundTripper) *rateLimitedTransport {
+	limiters := make(map[string]*targetLimiter)
+
+	for _, target := range targets {
+		if l := newTargetLimiter(target); l != nil {
+			limiters[target.URL.Host] = l
+		}
+	}
+
+	return &rateLimitedTransport{limiters: limiters, throttled: throttled, next: next}
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l, ok := t.limiters[req.URL.Host]
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	release, ok := l.acquire(req.Context())
+	if !ok {
+		t.throttled.WithLabelValues(l.target).Inc()
+
+		body := "Too many requests to Redfish target " + l.target
+
+		return &http.Response{
+			Status:        "429 " + http.StatusText(http.StatusTooManyRequests),
+			StatusCode:    http.StatusTooManyRequests,
+			Proto:         req.Proto,
+			ProtoMajor:    req.ProtoMajor,
+			ProtoMinor:    req.ProtoMinor,
+			Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+
+		return nil, err
+	}
+
+	// Hold the slot until response is fully consumed by proxy
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
@@ -7,7 +7,17 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Defaults of connection pool of transport.
+const (
+	defaultMaxIdleConnsPerHost = 4
+	defaultIdleConnTimeout     = 90 * time.Second
 )
 
 // Header names.
@@ -16,9 +26,21 @@ const (
 	realIPHeaderName     = "X-Real-IP"
 )
 
+// cidrTarget is a Redfish target that serves all hosts within a CIDR range.
+type cidrTarget struct {
+	ipNet *net.IPNet
+	url   *url.URL
+}
+
 type rpConfig struct {
-	logger  *slog.Logger
-	redfish *Redfish
+	logger      *slog.Logger
+	redfish     *Redfish
+	health      *targetHealth
+	throttled   *prometheus.CounterVec
+	cache       *cachingTransport
+	credentials *credentialsTransport
+	accessLog   *slog.Logger
+	accessLevel slog.Level
 }
 
 // NewMultiHostReverseProxy returns a new instance of ReverseProxy that routes requests
@@ -27,34 +49,108 @@ func NewMultiHostReverseProxy(c *rpConfig) *httputil.ReverseProxy {
 	// Make a map of host addr to bmc url using config
 	targets := make(map[string]*url.URL)
 
+	var cidrs []cidrTarget
+
 	for _, target := range c.redfish.Config.Targets {
 		for _, ip := range target.HostAddrs {
+			// CIDR ranges have been validated while unmarshalling config
+			if _, ipNet, err := net.ParseCIDR(ip); err == nil {
+				cidrs = append(cidrs, cidrTarget{ipNet: ipNet, url: target.URL})
+
+				continue
+			}
+
 			targets[ip] = target.URL
 		}
 	}
 
+	// Sort CIDR ranges so that most specific range is matched first. For
+	// ranges with same prefix length, order in config is preserved
+	slices.SortStableFunc(cidrs, func(a, b cidrTarget) int {
+		aOnes, _ := a.ipNet.Mask.Size()
+		bOnes, _ := b.ipNet.Mask.Size()
+
+		return bOnes - aOnes
+	})
+
 	// Setup TLS check
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: c.redfish.Config.Web.Insecure}, //nolint:gosec
+	var tr http.RoundTripper = newTransport(c.redfish)
+
+	// Enforce rate limits of targets
+	if c.throttled != nil {
+		tr = newRateLimitedTransport(c.redfish.Config.Targets, c.throttled, tr)
+	}
+
+	// Inject session tokens of proxy for targets with credentials
+	if c.credentials != nil {
+		c.credentials.next = tr
+		tr = c.credentials
+	}
+
+	// Fail fast for requests to unhealthy targets
+	if c.health != nil {
+		tr = &healthAwareTransport{health: c.health, next: tr}
+	}
+
+	// Serve cached responses before checking health and rate limits
+	if c.cache != nil {
+		c.cache.next = tr
+		tr = c.cache
+	}
+
+	// Log all requests including the ones served from cache
+	if c.accessLog != nil {
+		tr = &accessLogTransport{logger: c.accessLog, level: c.accessLevel, next: tr}
 	}
 
 	director := func(req *http.Request) {
-		rewriteRequestURL(c.logger, req, targets)
+		rewriteRequestURL(c.logger, req, targets, cidrs)
 	}
 
 	return &httputil.ReverseProxy{Director: director, Transport: tr}
 }
 
+// newTransport returns a new transport for making requests to Redfish targets.
+// Connections to targets are kept alive and reused across requests.
+func newTransport(redfish *Redfish) *http.Transport {
+	config := redfish.Config.Web.Transport
+
+	maxIdleConnsPerHost := defaultMaxIdleConnsPerHost
+	if config.MaxIdleConnsPerHost > 0 {
+		maxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+
+	idleConnTimeout := defaultIdleConnTimeout
+	if config.IdleConnTimeout > 0 {
+		idleConnTimeout = time.Duration(config.IdleConnTimeout)
+	}
+
+	return &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		// As custom TLS config is used, HTTP/2 must be explicitly enabled
+		ForceAttemptHTTP2:   config.EnableHTTP2,
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+		IdleConnTimeout:     idleConnTimeout,
+		TLSHandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:     &tls.Config{InsecureSkipVerify: redfish.Config.Web.Insecure}, //nolint:gosec
+	}
+}
+
 // rewriteRequestURL rewrites the request URL to point to the target.
 //
 // We attempt to find the correct target using following methods:
 //
-// - Check X-BMC-Host header and build target URL based on web config
 // - Lookup RemoteAddr and find the target from map of provided targets
+// - Lookup RemoteAddr in CIDR ranges of provided targets
+// - Check X-BMC-Host header and build target URL based on web config
 //
-// Always X-BMC-Host header is checked for BMC hostname and if not found,
-// target URL is looked up from provided targets.
-func rewriteRequestURL(logger *slog.Logger, req *http.Request, targets map[string]*url.URL) {
+// Exact matches of remote address always take precedence over CIDR ranges. When
+// remote address matches multiple CIDR ranges, the most specific range (longest
+// prefix) wins and for ranges of same size, the first one in config wins.
+func rewriteRequestURL(logger *slog.Logger, req *http.Request, targets map[string]*url.URL, cidrs []cidrTarget) {
 	var target *url.URL
 
 	var remoteIPs []string
@@ -75,6 +171,19 @@ func rewriteRequestURL(logger *slog.Logger, req *http.Request, targets map[strin
 		}
 	}
 
+	// Exact matches always take precedence over CIDR ranges
+	for _, ip := range remoteIPs {
+		if addr := net.ParseIP(ip); addr != nil {
+			for _, cidr := range cidrs {
+				if cidr.ipNet.Contains(addr) {
+					target = cidr.url
+
+					goto rewrite_req
+				}
+			}
+		}
+	}
+
 	// If target is not found in map, check header
 	// Always use CanonicalHeaderKey as golang always canonicalize headers
 	// internally
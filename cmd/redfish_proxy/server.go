@@ -10,15 +10,24 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/exporter-toolkit/web"
 )
 
 // RedfishProxyServer struct implements HTTP server for proxy.
 type RedfishProxyServer struct {
-	logger    *slog.Logger
-	server    *http.Server
-	webConfig *web.FlagConfig
-	redfish   *Redfish
+	logger      *slog.Logger
+	server      *http.Server
+	webConfig   *web.FlagConfig
+	redfish     *Redfish
+	health      *targetHealth
+	throttled   *prometheus.CounterVec
+	cache       *cachingTransport
+	credentials *credentialsTransport
+	accessLog   *slog.Logger
+	accessLevel slog.Level
+	cancel      context.CancelFunc
 }
 
 // NewRedfishProxyServer creates new RedfishProxyServer struct instance.
@@ -47,7 +56,39 @@ func NewRedfishProxyServer(c *Config) *RedfishProxyServer {
 		router.PathPrefix("/debug/").Handler(http.DefaultServeMux).Methods(http.MethodGet).Host("localhost")
 	}
 
+	// Setup health checks of targets
+	server.health = newTargetHealth(c.Logger.With("subsystem", "health"), c.Redfish.Config.Targets, newTransport(c.Redfish))
+
+	// Setup counter of requests throttled by rate limits of targets
+	server.throttled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: appName,
+			Name:      "target_throttled_requests_total",
+			Help:      "Total number of requests rejected due to rate limits of Redfish target",
+		},
+		[]string{"target"},
+	)
+
+	// Setup cache of responses of targets
+	if c.Redfish.Config.Cache != nil {
+		server.cache = newCachingTransport(c.Redfish.Config.Cache)
+	}
+
+	// Setup access log of proxied requests
+	if c.Web.AccessLog {
+		server.accessLog = c.Logger.With("subsystem", "access")
+		server.accessLevel = c.Web.AccessLogLevel
+	}
+
+	// Setup sessions of targets with credentials
+	server.credentials = newCredentialsTransport(c.Logger.With("subsystem", "credentials"), c.Redfish.Config.Targets)
+
 	// Handle metrics path
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(server.health.up, server.throttled)
+	router.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{})).Methods(http.MethodGet)
+
+	// Handle proxy requests
 	router.PathPrefix("/").Handler(server.newProxyHandler())
 
 	return server
@@ -57,6 +98,17 @@ func NewRedfishProxyServer(c *Config) *RedfishProxyServer {
 func (s *RedfishProxyServer) Start() error {
 	s.logger.Info("Starting " + appName)
 
+	// Start health checks of targets
+	var ctx context.Context
+
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.health.start(ctx)
+
+	// Start deleting expired responses from cache
+	if s.cache != nil {
+		go s.cache.start()
+	}
+
 	if err := web.ListenAndServe(s.server, s.webConfig, s.logger); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		s.logger.Error("Failed to Listen and Serve HTTP server", "err", err)
 
@@ -70,6 +122,18 @@ func (s *RedfishProxyServer) Start() error {
 func (s *RedfishProxyServer) Shutdown(ctx context.Context) error {
 	s.logger.Info("Stopping " + appName)
 
+	// Stop health checks of targets and cache when server
+	// has been started
+	if s.cancel != nil {
+		s.cancel()
+		s.health.wait()
+
+		// Stop deleting expired responses from cache
+		if s.cache != nil {
+			s.cache.stop()
+		}
+	}
+
 	// First shutdown HTTP server to avoid accepting any incoming
 	// connections
 	// Do not return error here as we SHOULD ENSURE to close collectors
@@ -80,14 +144,25 @@ func (s *RedfishProxyServer) Shutdown(ctx context.Context) error {
 		return err
 	}
 
+	// Delete sessions created by proxy on targets
+	if s.credentials != nil {
+		s.credentials.close(ctx)
+	}
+
 	return nil
 }
 
 // newProxyHandler creates a new handler for proxying requests to redfish targets.
 func (s *RedfishProxyServer) newProxyHandler() *httputil.ReverseProxy {
 	config := &rpConfig{
-		logger:  s.logger.With("subsystem", "rp"),
-		redfish: s.redfish,
+		logger:      s.logger.With("subsystem", "rp"),
+		redfish:     s.redfish,
+		health:      s.health,
+		throttled:   s.throttled,
+		cache:       s.cache,
+		credentials: s.credentials,
+		accessLog:   s.accessLog,
+		accessLevel: s.accessLevel,
 	}
 
 	return NewMultiHostReverseProxy(config)
@@ -1,18 +1,25 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/mahendrapaipuri/ceems/internal/common"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -50,9 +57,11 @@ func TestNewRedfishProxyServerWithTargets(t *testing.T) {
 		Redfish: &Redfish{
 			Config: struct {
 				Web struct {
-					Insecure bool `yaml:"insecure_skip_verify"`
+					Insecure  bool      `yaml:"insecure_skip_verify"`
+					Transport Transport `yaml:"transport"`
 				} `yaml:"web"`
 				Targets []Target `yaml:"targets"`
+				Cache   *Cache   `yaml:"cache"`
 			}{
 				Targets: []Target{
 					{
@@ -129,12 +138,15 @@ func TestNewRedfishProxyServerWithWebConfig(t *testing.T) {
 		Redfish: &Redfish{
 			Config: struct {
 				Web struct {
-					Insecure bool `yaml:"insecure_skip_verify"`
+					Insecure  bool      `yaml:"insecure_skip_verify"`
+					Transport Transport `yaml:"transport"`
 				} `yaml:"web"`
 				Targets []Target `yaml:"targets"`
+				Cache   *Cache   `yaml:"cache"`
 			}{
 				Web: struct {
-					Insecure bool `yaml:"insecure_skip_verify"`
+					Insecure  bool      `yaml:"insecure_skip_verify"`
+					Transport Transport `yaml:"transport"`
 				}{
 					Insecure: true,
 				},
@@ -186,3 +198,682 @@ func TestNewRedfishProxyServerWithWebConfig(t *testing.T) {
 		assert.EqualValues(t, strings.Join([]string{remoteIPs[0]}, ","), string(bodyBytes))
 	}
 }
+
+func TestNewRedfishProxyServerWithHealthCheck(t *testing.T) {
+	// Start test targets
+	targets, remoteIPs := testTargets()
+
+	// Target URLs
+	var targetURLs []*url.URL
+
+	for _, t := range targets {
+		u, _ := url.Parse(t.URL)
+		targetURLs = append(targetURLs, u)
+	}
+
+	defer targets[0].Close()
+
+	// Stop second target to make it unhealthy
+	targets[1].Close()
+
+	healthCheck := &HealthCheck{
+		Path:     "/redfish/v1/",
+		Interval: model.Duration(100 * time.Millisecond),
+		Timeout:  model.Duration(100 * time.Millisecond),
+	}
+
+	// Test config
+	config := &Config{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Redfish: &Redfish{
+			Config: struct {
+				Web struct {
+					Insecure  bool      `yaml:"insecure_skip_verify"`
+					Transport Transport `yaml:"transport"`
+				} `yaml:"web"`
+				Targets []Target `yaml:"targets"`
+				Cache   *Cache   `yaml:"cache"`
+			}{
+				Targets: []Target{
+					{
+						HostAddrs:   []string{remoteIPs[0]},
+						URL:         targetURLs[0],
+						HealthCheck: healthCheck,
+					},
+					{
+						HostAddrs:   []string{remoteIPs[1]},
+						URL:         targetURLs[1],
+						HealthCheck: healthCheck,
+					},
+				},
+			},
+		},
+	}
+
+	p, l, err := common.GetFreePort()
+	require.NoError(t, err)
+	l.Close()
+
+	// Web addresses
+	config.Web.Addresses = []string{":" + strconv.FormatInt(int64(p), 10)}
+
+	// New instance
+	server := NewRedfishProxyServer(config)
+	defer server.Shutdown(context.Background())
+
+	// Start server
+	go func() {
+		server.Start()
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	// Make requests
+	client := http.Client{}
+
+	expectedCodes := []int{http.StatusOK, http.StatusServiceUnavailable}
+
+	for i, ip := range remoteIPs {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d", p), nil) //nolint:noctx
+		require.NoError(t, err)
+
+		req.Header.Add(realIPHeaderName, ip)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, expectedCodes[i], resp.StatusCode)
+	}
+
+	// Check health metrics
+	resp, err := client.Get(fmt.Sprintf("http://localhost:%d/metrics", p)) //nolint:noctx
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(bodyBytes), fmt.Sprintf(`redfish_proxy_target_up{target="%s"} 1`, targetURLs[0]))
+	assert.Contains(t, string(bodyBytes), fmt.Sprintf(`redfish_proxy_target_up{target="%s"} 0`, targetURLs[1]))
+}
+
+func TestNewRedfishProxyServerWithRateLimits(t *testing.T) {
+	// Start a slow test target that blocks until released
+	done := make(chan struct{})
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+		w.Write([]byte("OK"))
+	}))
+	defer target.Close()
+
+	targetURL, _ := url.Parse(target.URL)
+	remoteIP := "192.168.1.1"
+
+	// Test config
+	config := &Config{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Redfish: &Redfish{
+			Config: struct {
+				Web struct {
+					Insecure  bool      `yaml:"insecure_skip_verify"`
+					Transport Transport `yaml:"transport"`
+				} `yaml:"web"`
+				Targets []Target `yaml:"targets"`
+				Cache   *Cache   `yaml:"cache"`
+			}{
+				Targets: []Target{
+					{
+						HostAddrs:             []string{remoteIP},
+						URL:                   targetURL,
+						MaxConcurrentRequests: 1,
+					},
+				},
+			},
+		},
+	}
+
+	p, l, err := common.GetFreePort()
+	require.NoError(t, err)
+	l.Close()
+
+	// Web addresses
+	config.Web.Addresses = []string{":" + strconv.FormatInt(int64(p), 10)}
+
+	// New instance
+	server := NewRedfishProxyServer(config)
+	defer server.Shutdown(context.Background())
+
+	// Start server
+	go func() {
+		server.Start()
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	client := http.Client{}
+
+	newRequest := func() *http.Request {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d", p), nil) //nolint:noctx
+		require.NoError(t, err)
+
+		req.Header.Add(realIPHeaderName, remoteIP)
+
+		return req
+	}
+
+	// First request occupies the only slot of target
+	firstCode := make(chan int)
+
+	go func() {
+		resp, err := client.Do(newRequest())
+		if err != nil {
+			firstCode <- 0
+
+			return
+		}
+		defer resp.Body.Close()
+
+		firstCode <- resp.StatusCode
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+
+	// Second request must be rejected after queue timeout
+	resp, err := client.Do(newRequest())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	// Release first request
+	close(done)
+	assert.Equal(t, http.StatusOK, <-firstCode)
+
+	// Slot must be released after first request finished
+	resp, err = client.Do(newRequest())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Check throttled metrics
+	resp, err = client.Get(fmt.Sprintf("http://localhost:%d/metrics", p)) //nolint:noctx
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(bodyBytes), fmt.Sprintf(`redfish_proxy_target_throttled_requests_total{target="%s"} 1`, targetURL))
+}
+
+func TestTargetLimiterRate(t *testing.T) {
+	u, _ := url.Parse("http://localhost:8000")
+
+	// One request every 10 seconds
+	l := newTargetLimiter(Target{URL: u, RequestsPerSecond: 0.1})
+	require.NotNil(t, l)
+
+	// First request must be allowed immediately
+	release, ok := l.acquire(context.Background())
+	require.True(t, ok)
+	release()
+
+	// Next slot is beyond queue timeout and request must be rejected
+	_, ok = l.acquire(context.Background())
+	assert.False(t, ok)
+
+	// No limits configured
+	assert.Nil(t, newTargetLimiter(Target{URL: u}))
+}
+
+func TestNewRedfishProxyServerWithCIDRTargets(t *testing.T) {
+	// Start test targets
+	targets, remoteIPs := testTargets()
+
+	// Target URLs
+	var targetURLs []*url.URL
+
+	for _, t := range targets {
+		u, _ := url.Parse(t.URL)
+		targetURLs = append(targetURLs, u)
+
+		defer t.Close()
+	}
+
+	// Test config
+	config := &Config{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Redfish: &Redfish{
+			Config: struct {
+				Web struct {
+					Insecure  bool      `yaml:"insecure_skip_verify"`
+					Transport Transport `yaml:"transport"`
+				} `yaml:"web"`
+				Targets []Target `yaml:"targets"`
+				Cache   *Cache   `yaml:"cache"`
+			}{
+				Targets: []Target{
+					{
+						HostAddrs: []string{remoteIPs[0], "10.0.0.0/8"},
+						URL:       targetURLs[0],
+					},
+					{
+						HostAddrs: []string{"192.168.1.0/24", "10.1.0.0/16"},
+						URL:       targetURLs[1],
+					},
+				},
+			},
+		},
+	}
+
+	p, l, err := common.GetFreePort()
+	require.NoError(t, err)
+	l.Close()
+
+	// Web addresses
+	config.Web.Addresses = []string{":" + strconv.FormatInt(int64(p), 10)}
+
+	// New instance
+	server := NewRedfishProxyServer(config)
+	defer server.Shutdown(context.Background())
+
+	// Start server
+	go func() {
+		server.Start()
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	// Make requests
+	client := http.Client{}
+
+	// Map of client IPs to expected target
+	expected := map[string]string{
+		remoteIPs[0]:   remoteIPs[0], // Exact match takes precedence over CIDR
+		"192.168.1.50": remoteIPs[1],
+		"10.1.2.3":     remoteIPs[1], // Most specific range wins
+		"10.2.0.1":     remoteIPs[0],
+	}
+
+	for ip, target := range expected {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d", p), nil) //nolint:noctx
+		require.NoError(t, err)
+
+		req.Header.Add(realIPHeaderName, ip)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		bodyBytes, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, target, string(bodyBytes), ip)
+	}
+}
+
+func TestNewRedfishProxyServerWithCache(t *testing.T) {
+	// Start a test target that counts number of requests per path
+	var mu sync.Mutex
+
+	hits := make(map[string]int)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer target.Close()
+
+	targetURL, _ := url.Parse(target.URL)
+	remoteIP := "192.168.1.1"
+
+	// Test config
+	config := &Config{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Redfish: &Redfish{
+			Config: struct {
+				Web struct {
+					Insecure  bool      `yaml:"insecure_skip_verify"`
+					Transport Transport `yaml:"transport"`
+				} `yaml:"web"`
+				Targets []Target `yaml:"targets"`
+				Cache   *Cache   `yaml:"cache"`
+			}{
+				Targets: []Target{
+					{
+						HostAddrs: []string{remoteIP},
+						URL:       targetURL,
+					},
+				},
+				Cache: &Cache{
+					Rules: []CacheRule{
+						{Path: regexp.MustCompile(`^/redfish/v1/Chassis/[^/]+/Power$`), TTL: model.Duration(time.Minute)},
+						{Path: regexp.MustCompile(`^/redfish/v1/`), TTL: model.Duration(time.Minute)},
+					},
+					ExcludePaths: []*regexp.Regexp{regexp.MustCompile(`^/redfish/v1/Systems`)},
+				},
+			},
+		},
+	}
+
+	p, l, err := common.GetFreePort()
+	require.NoError(t, err)
+	l.Close()
+
+	// Web addresses
+	config.Web.Addresses = []string{":" + strconv.FormatInt(int64(p), 10)}
+
+	// New instance
+	server := NewRedfishProxyServer(config)
+	defer server.Shutdown(context.Background())
+
+	// Start server
+	go func() {
+		server.Start()
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	client := http.Client{}
+
+	doRequest := func(path, token string) {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d%s", p, path), nil) //nolint:noctx
+		require.NoError(t, err)
+
+		req.Header.Add(realIPHeaderName, remoteIP)
+		req.Header.Add("X-Auth-Token", token)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		bodyBytes, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, path, string(bodyBytes))
+	}
+
+	for range 3 {
+		doRequest("/redfish/v1/Chassis/1/Power", "foo")
+		doRequest("/redfish/v1/SessionService/Sessions", "foo")
+		doRequest("/redfish/v1/Systems/1", "foo")
+	}
+
+	// Different credentials must not be served from cache
+	doRequest("/redfish/v1/Chassis/1/Power", "bar")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, 2, hits["/redfish/v1/Chassis/1/Power"])
+	assert.Equal(t, 3, hits["/redfish/v1/SessionService/Sessions"])
+	assert.Equal(t, 3, hits["/redfish/v1/Systems/1"])
+}
+
+func TestNewRedfishProxyServerWithCredentials(t *testing.T) {
+	// Start a test target that manages sessions
+	var mu sync.Mutex
+
+	var validToken string
+
+	var numSessions int
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodPost && r.URL.Path == sessionsPath {
+			var creds map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&creds); err != nil || creds["UserName"] != "admin" || creds["Password"] != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			numSessions++
+			validToken = fmt.Sprintf("token-%d", numSessions)
+
+			w.Header().Set(sessionTokenHeader, validToken)
+			w.Header().Set("Location", fmt.Sprintf("%s/%d", sessionsPath, numSessions))
+			w.WriteHeader(http.StatusCreated)
+
+			return
+		}
+
+		if r.Header.Get(sessionTokenHeader) != validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		// Echo token back to check that it is stripped by proxy
+		w.Header().Set(sessionTokenHeader, validToken)
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer target.Close()
+
+	targetURL, _ := url.Parse(target.URL)
+	remoteIP := "192.168.1.1"
+
+	// Test config
+	config := &Config{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Redfish: &Redfish{
+			Config: struct {
+				Web struct {
+					Insecure  bool      `yaml:"insecure_skip_verify"`
+					Transport Transport `yaml:"transport"`
+				} `yaml:"web"`
+				Targets []Target `yaml:"targets"`
+				Cache   *Cache   `yaml:"cache"`
+			}{
+				Targets: []Target{
+					{
+						HostAddrs:   []string{remoteIP},
+						URL:         targetURL,
+						Credentials: &Credentials{Username: "admin", Password: "secret"},
+					},
+				},
+			},
+		},
+	}
+
+	p, l, err := common.GetFreePort()
+	require.NoError(t, err)
+	l.Close()
+
+	// Web addresses
+	config.Web.Addresses = []string{":" + strconv.FormatInt(int64(p), 10)}
+
+	// New instance
+	server := NewRedfishProxyServer(config)
+	defer server.Shutdown(context.Background())
+
+	// Start server
+	go func() {
+		server.Start()
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	client := http.Client{}
+
+	doRequest := func(method, path string) *http.Response {
+		req, err := http.NewRequest(method, fmt.Sprintf("http://localhost:%d%s", p, path), nil) //nolint:noctx
+		require.NoError(t, err)
+
+		req.Header.Add(realIPHeaderName, remoteIP)
+		req.Header.Add(sessionTokenHeader, "client-token")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	// Session creation by client must be handled by proxy
+	resp := doRequest(http.MethodPost, sessionsPath)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, proxySessionToken, resp.Header.Get(sessionTokenHeader))
+
+	// Requests must use session of proxy and token must not be returned
+	resp = doRequest(http.MethodGet, "/redfish/v1/Chassis")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(sessionTokenHeader))
+
+	// Expire session on target and proxy must refresh it
+	mu.Lock()
+	validToken = "expired"
+	mu.Unlock()
+
+	resp = doRequest(http.MethodGet, "/redfish/v1/Chassis")
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/redfish/v1/Chassis", string(bodyBytes))
+
+	// Session deletion by client must be handled by proxy
+	resp = doRequest(http.MethodDelete, proxySessionLocation)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, 2, numSessions)
+}
+
+func TestAccessLogTransport(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer target.Close()
+
+	var buf bytes.Buffer
+
+	tr := &accessLogTransport{
+		logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+		level:  slog.LevelInfo,
+		next:   http.DefaultTransport,
+	}
+
+	req, err := http.NewRequest(http.MethodGet, target.URL+"/redfish/v1/Chassis", nil) //nolint:noctx
+	require.NoError(t, err)
+
+	req.Header.Add("Authorization", "Basic c2VjcmV0")
+	req.Header.Add("X-Auth-Token", "secret")
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := tr.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	targetURL, _ := url.Parse(target.URL)
+	assert.Equal(t, http.MethodGet, entry["method"])
+	assert.Equal(t, "/redfish/v1/Chassis", entry["path"])
+	assert.Equal(t, targetURL.Host, entry["target"])
+	assert.InDelta(t, http.StatusNotFound, entry["status"], 0)
+	assert.Contains(t, entry, "latency")
+	assert.Equal(
+		t,
+		map[string]any{"Authorization": "<redacted>", "X-Auth-Token": "<redacted>", "Accept": "application/json"},
+		entry["headers"],
+	)
+	assert.NotContains(t, buf.String(), "secret")
+
+	// Failed requests must be logged with error
+	buf.Reset()
+
+	target.Close()
+
+	_, err = tr.RoundTrip(req)
+	require.Error(t, err)
+	assert.Contains(t, buf.String(), "Failed to proxy request")
+}
+
+func TestNewTransport(t *testing.T) {
+	// Defaults
+	tr := newTransport(&Redfish{})
+	assert.Equal(t, defaultMaxIdleConnsPerHost, tr.MaxIdleConnsPerHost)
+	assert.Equal(t, defaultIdleConnTimeout, tr.IdleConnTimeout)
+	assert.False(t, tr.ForceAttemptHTTP2)
+
+	// Start a TLS target with self signed certificate that supports HTTP/2
+	// and counts new connections
+	var mu sync.Mutex
+
+	var conns int
+
+	target := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	}))
+	target.EnableHTTP2 = true
+	target.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	target.StartTLS()
+	defer target.Close()
+
+	for _, enableHTTP2 := range []bool{false, true} {
+		mu.Lock()
+		conns = 0
+		mu.Unlock()
+
+		redfish := &Redfish{}
+		redfish.Config.Web.Insecure = true
+		redfish.Config.Web.Transport = Transport{
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     model.Duration(time.Minute),
+			EnableHTTP2:         enableHTTP2,
+		}
+
+		tr := newTransport(redfish)
+		assert.Equal(t, 2, tr.MaxIdleConnsPerHost)
+		assert.Equal(t, time.Minute, tr.IdleConnTimeout)
+
+		client := http.Client{Transport: tr}
+
+		expectedProto := "HTTP/1.1"
+		if enableHTTP2 {
+			expectedProto = "HTTP/2.0"
+		}
+
+		for range 5 {
+			resp, err := client.Get(target.URL) //nolint:noctx
+			require.NoError(t, err)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, expectedProto, string(bodyBytes))
+		}
+
+		tr.CloseIdleConnections()
+
+		// Connection must be reused for all requests
+		mu.Lock()
+		assert.Equal(t, 1, conns, "http2: %t", enableHTTP2)
+		mu.Unlock()
+	}
+}
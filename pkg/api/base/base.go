@@ -5,6 +5,7 @@ package base
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -37,7 +38,7 @@ var (
 // This slice will not contain the DB columns that are ignored in the query.
 var (
 	UnitsDBTableColNames      = models.Unit{}.TagNames("json")
-	UsageDBTableColNames      = models.Usage{}.TagNames("json")
+	UsageDBTableColNames      = slices.DeleteFunc(models.Usage{}.TagNames("json"), func(col string) bool { return slices.Contains(models.UsageComputedColNames, col) })
 	ProjectsDBTableColNames   = models.Project{}.TagNames("json")
 	UsersDBTableColNames      = models.User{}.TagNames("json")
 	AdminUsersDBTableColNames = models.AdminUsers{}.TagNames("json")
@@ -39,6 +39,7 @@ type CEEMSAPIAppConfig struct {
 // SetDirectory joins any relative file paths with dir.
 func (c *CEEMSAPIAppConfig) SetDirectory(dir string) {
 	c.Server.Admin.SetDirectory(dir)
+	c.Server.Web.SetDirectory(dir)
 }
 
 // Validate validates the config.
@@ -84,6 +85,10 @@ func (b *CEEMSServer) Main() error {
 			"web.listen-address",
 			"Addresses on which to expose metrics and web interface.",
 		).Default(":9020").Strings()
+		grpcListenAddress = b.App.Flag(
+			"web.grpc-listen-address",
+			"Address on which to expose gRPC service. gRPC service is disabled when empty.",
+		).Default("").String()
 		webConfigFile = b.App.Flag(
 			"web.config.file",
 			"Path to configuration file that can enable TLS or authentication. See: https://github.com/prometheus/exporter-toolkit/blob/master/docs/web-configuration.md",
@@ -217,7 +222,7 @@ func (b *CEEMSServer) Main() error {
 		securityCfg := &security.Config{
 			RunAsUser:      "nobody",
 			Caps:           allCaps,
-			ReadPaths:      []string{webConfigFilePath, base.ConfigFilePath},
+			ReadPaths:      []string{webConfigFilePath, base.ConfigFilePath, config.Server.Data.ReplicaPath},
 			ReadWritePaths: []string{config.Server.Data.Path, config.Server.Data.BackupPath},
 		}
 
@@ -240,18 +245,32 @@ func (b *CEEMSServer) Main() error {
 		Updater:         updater.New,
 	}
 
+	// Get TSDB clients of clusters to proxy time series queries of units.
+	tsdbs, err := updater.TSDBs(logger)
+	if err != nil {
+		return err
+	}
+
 	// Make server config.
 	serverConfig := &ceems_http.Config{
 		Logger: logger,
 		Web: ceems_http.WebConfig{
 			Addresses:        *webListenAddresses,
+			GRPCAddress:      *grpcListenAddress,
 			WebSystemdSocket: *systemdSocket,
 			WebConfigFile:    webConfigFilePath,
 			RoutePrefix:      config.Server.Web.RoutePrefix,
 			RequestsLimit:    config.Server.Web.RequestsLimit,
 			MaxQueryPeriod:   config.Server.Web.MaxQueryPeriod,
+			CacheTTL:         config.Server.Web.CacheTTL,
+			MaxResultRows:    config.Server.Web.MaxResultRows,
+			QueryDuration:    config.Server.Web.QueryDuration,
+			SystemUsers:      config.Server.Web.SystemUsers,
+			APIKeys:          config.Server.Web.APIKeys,
 		},
-		DB: *dbConfig,
+		DB:         *dbConfig,
+		TSDBs:      tsdbs,
+		Collectors: append(updater.Collectors(), ceems_db.Collectors()...),
 	}
 
 	// Create server instance.
@@ -292,6 +311,10 @@ func (b *CEEMSServer) Main() error {
 
 			if err := collector.Collect(ctx); err != nil {
 				logger.Error("Failed to fetch data", "err", err)
+			} else {
+				// Flush usage cache so that new data is reflected in usage
+				// queries immediately rather than after cache TTL
+				apiServer.InvalidateUsageCache()
 			}
 
 			select {
@@ -335,6 +358,40 @@ func (b *CEEMSServer) Main() error {
 		}()
 	}
 
+	// Start DB maintenance go routine only when schedule is configured.
+	if schedule := config.Server.Data.MaintenanceSchedule; !schedule.IsZero() {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				next := schedule.Next(time.Now().In(config.Server.Data.Timezone.Location))
+				if next.IsZero() {
+					logger.Error("DB maintenance schedule never activates", "schedule", schedule)
+
+					return
+				}
+
+				timer := time.NewTimer(time.Until(next))
+
+				select {
+				case <-timer.C:
+					logger.Info("Running CEEMS DB maintenance", "schedule", schedule)
+
+					if err := collector.Maintain(ctx); err != nil {
+						logger.Error("Failed to run DB maintenance", "err", err)
+					}
+				case <-ctx.Done():
+					timer.Stop()
+					logger.Info("Received Interrupt. Stopping DB maintenance")
+
+					return
+				}
+			}
+		}()
+	}
+
 	// Initializing the server in a goroutine so that
 	// it won't block the graceful shutdown handling below.
 	go func() {
@@ -399,6 +456,16 @@ func createDirs(config *CEEMSAPIAppConfig) (*CEEMSAPIAppConfig, error) {
 		}
 	}
 
+	if config.Server.Data.ReplicaPath != "" {
+		if config.Server.Data.ReplicaPath, err = filepath.Abs(config.Server.Data.ReplicaPath); err != nil {
+			return nil, fmt.Errorf(
+				"failed to get absolute path for data.replica_path=%s: %w",
+				config.Server.Data.ReplicaPath,
+				err,
+			)
+		}
+	}
+
 	// Check if config.Data.Path/config.Data.BackupPath exists and create one if it does not.
 	if _, err := os.Stat(config.Server.Data.Path); os.IsNotExist(err) {
 		if err := os.MkdirAll(config.Server.Data.Path, 0o750); err != nil {
@@ -65,8 +65,9 @@ func TestCEEMSConfigNestedDataDirs(t *testing.T) {
 	config := &CEEMSAPIAppConfig{
 		CEEMSAPIServerConfig{
 			Data: db.DataConfig{
-				Path:       dataDir,
-				BackupPath: backupDataDir,
+				Path:        dataDir,
+				BackupPath:  backupDataDir,
+				ReplicaPath: "replica",
 			},
 		},
 	}
@@ -83,6 +84,7 @@ func TestCEEMSConfigNestedDataDirs(t *testing.T) {
 	// Check if paths are absolute
 	assert.True(t, filepath.IsAbs(config.Server.Data.Path), "data path is not absolute")
 	assert.True(t, filepath.IsAbs(config.Server.Data.BackupPath), "backup path is not absolute")
+	assert.True(t, filepath.IsAbs(config.Server.Data.ReplicaPath), "replica path is not absolute")
 }
 
 func TestCEEMSConfigMalformedData(t *testing.T) {
@@ -104,6 +106,60 @@ ceems_api_server:
 	assert.Error(t, err)
 }
 
+func TestCEEMSConfigCacheTTL(t *testing.T) {
+	tmpDir := t.TempDir()
+	dataDir := filepath.Join(tmpDir, "data")
+
+	// Make config file
+	configFileTmpl := `
+---
+ceems_api_server:
+  data:
+    path: %s
+  web:
+    cache_ttl: %s`
+
+	// Default TTL must be used when it is not configured
+	configFilePath := makeConfigFile(fmt.Sprintf("---\nceems_api_server:\n  web:\n    requests_limit: 10\n  data:\n    path: %s", dataDir), tmpDir)
+	config, err := common.MakeConfig[CEEMSAPIAppConfig](configFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, time.Duration(config.Server.Web.CacheTTL))
+
+	configFilePath = makeConfigFile(fmt.Sprintf(configFileTmpl, dataDir, "1h"), tmpDir)
+	config, err = common.MakeConfig[CEEMSAPIAppConfig](configFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, time.Duration(config.Server.Web.CacheTTL))
+
+	// TTL must be at least one second
+	configFilePath = makeConfigFile(fmt.Sprintf(configFileTmpl, dataDir, "500ms"), tmpDir)
+	_, err = common.MakeConfig[CEEMSAPIAppConfig](configFilePath)
+	assert.Error(t, err)
+}
+
+func TestCEEMSConfigMaxResultRows(t *testing.T) {
+	tmpDir := t.TempDir()
+	dataDir := filepath.Join(tmpDir, "data")
+
+	// Make config file
+	configFileTmpl := `
+---
+ceems_api_server:
+  data:
+    path: %s
+  web:
+    max_result_rows: %d`
+
+	configFilePath := makeConfigFile(fmt.Sprintf(configFileTmpl, dataDir, 1000), tmpDir)
+	config, err := common.MakeConfig[CEEMSAPIAppConfig](configFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, config.Server.Web.MaxResultRows)
+
+	// Negative values are not allowed
+	configFilePath = makeConfigFile(fmt.Sprintf(configFileTmpl, dataDir, -1), tmpDir)
+	_, err = common.MakeConfig[CEEMSAPIAppConfig](configFilePath)
+	assert.Error(t, err)
+}
+
 func TestCEEMSServerMain(t *testing.T) {
 	tmpDir := t.TempDir()
 	dataDir := filepath.Join(tmpDir, "data")
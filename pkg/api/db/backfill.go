@@ -0,0 +1,374 @@
+//go:build cgo
+// +build cgo
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/internal/common"
+	"github.com/mahendrapaipuri/ceems/pkg/api/base"
+	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	"github.com/mahendrapaipuri/ceems/pkg/api/updater"
+)
+
+// Maximum number of units that are backfilled in each pass.
+const backfillBatchSize = 100
+
+// Aggregate metrics that are backfilled.
+var backfillMetrics = []string{
+	"AveCPUUsage", "AveCPUMemUsage", "TotalCPUEnergyUsage", "TotalCPUEmissions",
+	"AveGPUUsage", "AveGPUMemUsage", "TotalGPUEnergyUsage", "TotalGPUEmissions",
+	"TotalIOWriteStats", "TotalIOReadStats", "TotalIngressStats", "TotalOutgressStats",
+}
+
+// Total metrics that are added to usage tables when units are backfilled.
+// Average metrics cannot be corrected without the weights of each update and
+// hence, they are not updated.
+var backfillUsageMetrics = []string{
+	"TotalCPUEnergyUsage", "TotalCPUEmissions", "TotalGPUEnergyUsage", "TotalGPUEmissions",
+	"TotalIOWriteStats", "TotalIOReadStats", "TotalIngressStats", "TotalOutgressStats",
+}
+
+// metricValue returns the value of metric of unit.
+func metricValue(unit models.Unit, metric string) models.MetricMap {
+	switch metric {
+	case "AveCPUUsage":
+		return unit.AveCPUUsage
+	case "AveCPUMemUsage":
+		return unit.AveCPUMemUsage
+	case "TotalCPUEnergyUsage":
+		return unit.TotalCPUEnergyUsage
+	case "TotalCPUEmissions":
+		return unit.TotalCPUEmissions
+	case "AveGPUUsage":
+		return unit.AveGPUUsage
+	case "AveGPUMemUsage":
+		return unit.AveGPUMemUsage
+	case "TotalGPUEnergyUsage":
+		return unit.TotalGPUEnergyUsage
+	case "TotalGPUEmissions":
+		return unit.TotalGPUEmissions
+	case "TotalIOWriteStats":
+		return unit.TotalIOWriteStats
+	case "TotalIOReadStats":
+		return unit.TotalIOReadStats
+	case "TotalIngressStats":
+		return unit.TotalIngressStats
+	case "TotalOutgressStats":
+		return unit.TotalOutgressStats
+	}
+
+	return nil
+}
+
+// setMetricValue sets the value of metric of unit.
+func setMetricValue(unit *models.Unit, metric string, value models.MetricMap) {
+	switch metric {
+	case "AveCPUUsage":
+		unit.AveCPUUsage = value
+	case "AveCPUMemUsage":
+		unit.AveCPUMemUsage = value
+	case "TotalCPUEnergyUsage":
+		unit.TotalCPUEnergyUsage = value
+	case "TotalCPUEmissions":
+		unit.TotalCPUEmissions = value
+	case "AveGPUUsage":
+		unit.AveGPUUsage = value
+	case "AveGPUMemUsage":
+		unit.AveGPUMemUsage = value
+	case "TotalGPUEnergyUsage":
+		unit.TotalGPUEnergyUsage = value
+	case "TotalGPUEmissions":
+		unit.TotalGPUEmissions = value
+	case "TotalIOWriteStats":
+		unit.TotalIOWriteStats = value
+	case "TotalIOReadStats":
+		unit.TotalIOReadStats = value
+	case "TotalIngressStats":
+		unit.TotalIngressStats = value
+	case "TotalOutgressStats":
+		unit.TotalOutgressStats = value
+	}
+}
+
+// diffMetricMap returns the difference between current and existing metric maps.
+func diffMetricMap(current, existing models.MetricMap) models.MetricMap {
+	diff := make(models.MetricMap, len(current))
+	for name, value := range current {
+		diff[name] = value - existing[name]
+	}
+
+	return diff
+}
+
+// hasMetrics returns true if any of aggregate metrics of unit is not empty.
+func hasMetrics(unit models.Unit) bool {
+	for _, metric := range backfillMetrics {
+		if len(metricValue(unit, metric)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pendingBackfillUnits returns finished units that are flagged for backfill.
+func (s *stats) pendingBackfillUnits(ctx context.Context) ([]models.Unit, error) {
+	metricCols := make([]string, len(backfillMetrics))
+	for i, metric := range backfillMetrics {
+		metricCols[i] = base.UnitsDBTableStructFieldColNameMap[metric]
+	}
+
+	query := fmt.Sprintf(
+		"SELECT id,cluster_id,uuid,project,username,started_at_ts,ended_at_ts,tags,updater_tags,%s FROM %s WHERE json_extract(updater_tags, '$.%s') = '%s' AND ended_at_ts > 0 ORDER BY ended_at_ts LIMIT %d",
+		strings.Join(metricCols, ","),
+		base.UnitsDBTableName,
+		updater.BackfillTagKey,
+		updater.BackfillPending,
+		backfillBatchSize,
+	) // #nosec
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var units []models.Unit
+
+	for rows.Next() {
+		var unit models.Unit
+
+		metrics := make([]models.MetricMap, len(backfillMetrics))
+
+		dest := []any{
+			&unit.ID, &unit.ClusterID, &unit.UUID, &unit.Project, &unit.User,
+			&unit.StartedAtTS, &unit.EndedAtTS, &unit.Tags, &unit.UpdaterTags,
+		}
+		for i := range metrics {
+			dest = append(dest, &metrics[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		for i, metric := range backfillMetrics {
+			setMetricValue(&unit, metric, metrics[i])
+		}
+
+		units = append(units, unit)
+	}
+
+	return units, rows.Err()
+}
+
+// backfill re-fetches aggregate metrics of finished units that were flagged for
+// backfill by updaters, for instance, when TSDB was unavailable during their
+// updates. Metrics of units are estimated over their entire lifetime and stored
+// in DB. Total metrics are added to usage tables as well. Units are marked as
+// backfilled so that subsequent passes will not update them again.
+func (s *stats) backfill(ctx context.Context, clusters map[string]models.Cluster) error {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "Backfill", s.logger)
+
+	units, err := s.pendingBackfillUnits(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch units pending backfill: %w", err)
+	}
+
+	if len(units) == 0 {
+		return nil
+	}
+
+	var backfilledUnits, existingUnits []models.Unit
+
+	for _, unit := range units {
+		cluster, ok := clusters[unit.ClusterID]
+		if !ok {
+			continue
+		}
+
+		// Updaters will mutate unit and hence, pass a copy without the tags set
+		// by updaters
+		unitCopy := models.Unit{
+			ID:          unit.ID,
+			ClusterID:   unit.ClusterID,
+			UUID:        unit.UUID,
+			Project:     unit.Project,
+			User:        unit.User,
+			StartedAtTS: unit.StartedAtTS,
+			EndedAtTS:   unit.EndedAtTS,
+			Tags:        maps.Clone(unit.Tags),
+		}
+
+		updatedUnits := s.updater.Update(
+			ctx,
+			time.UnixMilli(unit.StartedAtTS).In(s.storage.timeLocation),
+			time.UnixMilli(unit.EndedAtTS).In(s.storage.timeLocation),
+			[]models.ClusterUnits{{Cluster: cluster, Units: []models.Unit{unitCopy}}},
+		)
+		if len(updatedUnits) == 0 || len(updatedUnits[0].Units) == 0 {
+			continue
+		}
+
+		updatedUnit := updatedUnits[0].Units[0]
+
+		// If updater flagged the unit again, TSDB is still unavailable. Retry in
+		// next pass
+		if flag, ok := updatedUnit.UpdaterTags[updater.BackfillTagKey]; ok && flag == updater.BackfillPending {
+			continue
+		}
+
+		updatedUnit.UpdaterTags = make(models.Tag)
+
+		// If there is no data in TSDB for unit's lifetime, retain existing metrics
+		// and mark unit so that it will not be retried again
+		if hasMetrics(updatedUnit) {
+			updatedUnit.UpdaterTags[updater.BackfillTagKey] = updater.BackfillDone
+		} else {
+			for _, metric := range backfillMetrics {
+				setMetricValue(&updatedUnit, metric, metricValue(unit, metric))
+			}
+
+			updatedUnit.UpdaterTags[updater.BackfillTagKey] = updater.BackfillNoData
+		}
+
+		backfilledUnits = append(backfilledUnits, updatedUnit)
+		existingUnits = append(existingUnits, unit)
+	}
+
+	if len(backfilledUnits) == 0 {
+		return nil
+	}
+
+	// Begin transcation
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin SQL transcation: %w", err)
+	}
+
+	if err := s.execBackfillStatements(ctx, tx, backfilledUnits, existingUnits); err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit SQL transcation: %w", err)
+	}
+
+	s.logger.Info("Backfilled metrics of units", "num_units", len(backfilledUnits))
+
+	return nil
+}
+
+// execBackfillStatements updates metrics of backfilled units in DB. Only the
+// difference between backfilled and existing total metrics of units are added
+// to usage tables to avoid counting partial metrics twice.
+func (s *stats) execBackfillStatements(
+	ctx context.Context,
+	tx *sql.Tx,
+	units []models.Unit,
+	existingUnits []models.Unit,
+) error {
+	// Statement to update units
+	unitCols := make([]string, len(backfillMetrics))
+	for i, metric := range backfillMetrics {
+		unitCols[i] = fmt.Sprintf("%[1]s = :%[1]s", base.UnitsDBTableStructFieldColNameMap[metric])
+	}
+
+	unitsStmt, err := tx.PrepareContext(ctx, fmt.Sprintf( //nolint:sqlclosecheck
+		"UPDATE %s SET %s, updater_tags = :updater_tags WHERE id = :id",
+		base.UnitsDBTableName,
+		strings.Join(unitCols, ", "),
+	)) // #nosec
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement for table %s: %w", base.UnitsDBTableName, err)
+	}
+	defer unitsStmt.Close()
+
+	// Statements to add total metrics to usage tables
+	usageCols := make([]string, len(backfillUsageMetrics))
+	for i, metric := range backfillUsageMetrics {
+		usageCols[i] = fmt.Sprintf("%[1]s = add_metric_map(%[1]s, :%[1]s)", base.UsageDBTableStructFieldColNameMap[metric])
+	}
+
+	usageStmt, err := tx.PrepareContext(ctx, fmt.Sprintf( //nolint:sqlclosecheck
+		"UPDATE %s SET %s WHERE cluster_id = :cluster_id AND username = :username AND project = :project",
+		base.UsageDBTableName,
+		strings.Join(usageCols, ", "),
+	)) // #nosec
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement for table %s: %w", base.UsageDBTableName, err)
+	}
+	defer usageStmt.Close()
+
+	dailyUsageStmt, err := tx.PrepareContext(ctx, fmt.Sprintf( //nolint:sqlclosecheck
+		"UPDATE %s SET %s WHERE cluster_id = :cluster_id AND username = :username AND project = :project AND last_updated_at = :last_updated_at",
+		base.DailyUsageDBTableName,
+		strings.Join(usageCols, ", "),
+	)) // #nosec
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement for table %s: %w", base.DailyUsageDBTableName, err)
+	}
+	defer dailyUsageStmt.Close()
+
+	for i, unit := range units {
+		args := []any{
+			sql.Named("id", unit.ID),
+			sql.Named(base.UnitsDBTableStructFieldColNameMap["UpdaterTags"], unit.UpdaterTags),
+		}
+
+		for _, metric := range backfillMetrics {
+			// Use empty maps for missing metrics to keep JSON columns valid
+			value := metricValue(unit, metric)
+			if value == nil {
+				value = make(models.MetricMap)
+			}
+
+			args = append(args, sql.Named(base.UnitsDBTableStructFieldColNameMap[metric], value))
+		}
+
+		if _, err := unitsStmt.ExecContext(ctx, args...); err != nil {
+			s.logger.Error("Failed to backfill unit in DB", "cluster_id", unit.ClusterID, "uuid", unit.UUID, "err", err)
+
+			continue
+		}
+
+		usageArgs := []any{
+			sql.Named(base.UsageDBTableStructFieldColNameMap["ClusterID"], unit.ClusterID),
+			sql.Named(base.UsageDBTableStructFieldColNameMap["User"], unit.User),
+			sql.Named(base.UsageDBTableStructFieldColNameMap["Project"], unit.Project),
+		}
+
+		for _, metric := range backfillUsageMetrics {
+			value := diffMetricMap(metricValue(unit, metric), metricValue(existingUnits[i], metric))
+			usageArgs = append(usageArgs, sql.Named(base.UsageDBTableStructFieldColNameMap[metric], value))
+		}
+
+		if _, err := usageStmt.ExecContext(ctx, usageArgs...); err != nil {
+			s.logger.Error("Failed to backfill usage in DB", "cluster_id", unit.ClusterID, "uuid", unit.UUID, "err", err)
+		}
+
+		// Final update of unit is aggregated into daily usage of the day unit has
+		// ended and hence, add backfilled metrics to that day
+		endedDay := time.UnixMilli(unit.EndedAtTS).In(s.storage.timeLocation).Truncate(24 * time.Hour)
+		dailyUsageArgs := append(
+			usageArgs,
+			sql.Named(base.UsageDBTableStructFieldColNameMap["LastUpdatedAt"], endedDay.Format(base.DatetimeLayout)),
+		)
+
+		if _, err := dailyUsageStmt.ExecContext(ctx, dailyUsageArgs...); err != nil {
+			s.logger.Error("Failed to backfill daily usage in DB", "cluster_id", unit.ClusterID, "uuid", unit.UUID, "err", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+//go:build cgo
+// +build cgo
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/pkg/api/base"
+	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	"github.com/mahendrapaipuri/ceems/pkg/api/updater"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBackfillUpdater struct {
+	numUpdates int
+}
+
+func (m *mockBackfillUpdater) Update(
+	_ context.Context,
+	startTime time.Time,
+	endTime time.Time,
+	units []models.ClusterUnits,
+) []models.ClusterUnits {
+	m.numUpdates++
+
+	for i := range units {
+		for j := range units[i].Units {
+			units[i].Units[j].TotalCPUEnergyUsage = models.MetricMap{"total": models.JSONFloat(endTime.Sub(startTime).Hours())}
+		}
+	}
+
+	return units
+}
+
+func TestBackfill(t *testing.T) {
+	tmpDir := t.TempDir()
+	c, err := prepareMockConfig(tmpDir)
+	require.NoError(t, err, "failed to create mock config")
+
+	ctx := context.Background()
+
+	s, err := New(c)
+	require.NoError(t, err, "failed to create new stats")
+
+	defer s.Stop()
+
+	// Insert units that are pending backfill
+	currentTime := time.Now()
+	cluster := models.Cluster{ID: "slurm-0", Updaters: []string{"backfill"}}
+	units := []models.ClusterUnits{
+		{
+			Cluster: cluster,
+			Units: []models.Unit{
+				{
+					UUID:                "1",
+					Project:             "prj1",
+					User:                "usr1",
+					StartedAtTS:         currentTime.Add(-2 * time.Hour).UnixMilli(),
+					EndedAtTS:           currentTime.UnixMilli(),
+					TotalCPUEnergyUsage: models.MetricMap{"total": 0.5},
+					Tags:                models.Tag{"foo": "bar"},
+					UpdaterTags:         models.Tag{updater.BackfillTagKey: updater.BackfillPending},
+				},
+				{
+					UUID:        "2",
+					Project:     "prj1",
+					User:        "usr1",
+					StartedAtTS: currentTime.Add(-time.Hour).UnixMilli(),
+					UpdaterTags: models.Tag{updater.BackfillTagKey: updater.BackfillPending},
+				},
+			},
+		},
+	}
+
+	tx, err := s.db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, s.execStatements(ctx, tx, currentTime.Add(-3*time.Hour), currentTime, units, nil, nil))
+	require.NoError(t, tx.Commit())
+
+	// Subsequent updates without updater tags must not remove the backfill flag
+	units[0].Units[0].TotalCPUEnergyUsage = models.MetricMap{}
+	for i := range units[0].Units {
+		units[0].Units[i].UpdaterTags = nil
+	}
+
+	tx, err = s.db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, s.execStatements(ctx, tx, currentTime, currentTime, units, nil, nil))
+	require.NoError(t, tx.Commit())
+
+	mockUpdater := &mockBackfillUpdater{}
+	s.updater = &updater.UnitUpdater{
+		Updaters: map[string]updater.Updater{"backfill": mockUpdater},
+		Logger:   s.logger,
+	}
+
+	// Backfill must be idempotent
+	for range 2 {
+		require.NoError(t, s.backfill(ctx, map[string]models.Cluster{cluster.ID: cluster}))
+	}
+
+	// Only finished unit must be backfilled and only once
+	assert.Equal(t, 1, mockUpdater.numUpdates)
+
+	var energy, tags, updaterTags string
+
+	err = s.db.QueryRow(
+		fmt.Sprintf("SELECT total_cpu_energy_usage_kwh,tags,updater_tags FROM %s WHERE uuid = '1'", base.UnitsDBTableName),
+	).Scan(&energy, &tags, &updaterTags)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"total": 2}`, energy)
+	assert.JSONEq(t, `{"foo": "bar"}`, tags)
+	assert.JSONEq(t, `{"tsdb_backfill": "done"}`, updaterTags)
+
+	// Only difference of backfilled metrics must be added to usage
+	err = s.db.QueryRow(
+		fmt.Sprintf("SELECT total_cpu_energy_usage_kwh FROM %s WHERE project = 'prj1'", base.UsageDBTableName),
+	).Scan(&energy)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"total": 2}`, energy)
+}
@@ -46,6 +46,7 @@ var StatementsFS embed.FS
 var (
 	ErrBackupInt = errors.New("backup_interval of less than 1 day is not supported")
 	ErrUpdateInt = errors.New("update_interval and/or max_update_interval must be more than 0s")
+	ErrRetention = errors.New("retention period of cluster must be at least 1 day")
 )
 
 type Timezone struct {
@@ -143,15 +144,18 @@ func (c *AdminConfig) SetDirectory(dir string) {
 
 // DataConfig is the container for the data related config.
 type DataConfig struct {
-	Path               string         `yaml:"path"`
-	BackupPath         string         `yaml:"backup_path"`
-	RetentionPeriod    model.Duration `yaml:"retention_period"`
-	UpdateInterval     model.Duration `yaml:"update_interval"`
-	MaxUpdateInterval  model.Duration `yaml:"max_update_interval"`
-	BackupInterval     model.Duration `yaml:"backup_interval"`
-	LastUpdate         DateTime       `yaml:"update_from"`
-	Timezone           Timezone       `yaml:"time_zone"`
-	SkipDeleteOldUnits bool
+	Path                string                    `yaml:"path"`
+	BackupPath          string                    `yaml:"backup_path"`
+	ReplicaPath         string                    `yaml:"replica_path"`
+	RetentionPeriod     model.Duration            `yaml:"retention_period"`
+	ClusterRetention    map[string]model.Duration `yaml:"cluster_retention_periods"`
+	UpdateInterval      model.Duration            `yaml:"update_interval"`
+	MaxUpdateInterval   model.Duration            `yaml:"max_update_interval"`
+	BackupInterval      model.Duration            `yaml:"backup_interval"`
+	MaintenanceSchedule *Schedule                 `yaml:"maintenance_schedule"`
+	LastUpdate          DateTime                  `yaml:"update_from"`
+	Timezone            Timezone                  `yaml:"time_zone"`
+	SkipDeleteOldUnits  bool
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -192,6 +196,13 @@ func (c *DataConfig) Validate() error {
 		return ErrBackupInt
 	}
 
+	// Retention periods are applied in days
+	for clusterID, period := range c.ClusterRetention {
+		if time.Duration(period) < 24*time.Hour {
+			return fmt.Errorf("%w: %s", ErrRetention, clusterID)
+		}
+	}
+
 	return nil
 }
 
@@ -209,6 +220,7 @@ type storageConfig struct {
 	dbPath             string
 	dbBackupPath       string
 	retentionPeriod    time.Duration
+	clusterRetention   map[string]time.Duration
 	maxUpdateInterval  time.Duration
 	lastUpdateTime     time.Time
 	timeLocation       *time.Location
@@ -354,11 +366,18 @@ func New(c *Config) (*stats, error) {
 		grafanaAdminTeamsIDs: c.Admin.Grafana.TeamsIDs,
 	}
 
+	// Retention periods of clusters that override the global one
+	clusterRetention := make(map[string]time.Duration, len(c.Data.ClusterRetention))
+	for clusterID, period := range c.Data.ClusterRetention {
+		clusterRetention[clusterID] = time.Duration(period)
+	}
+
 	// Storage config
 	storageConfig := &storageConfig{
 		dbPath:             dbPath,
 		dbBackupPath:       c.Data.BackupPath,
 		retentionPeriod:    time.Duration(c.Data.RetentionPeriod),
+		clusterRetention:   clusterRetention,
 		maxUpdateInterval:  time.Duration(c.Data.MaxUpdateInterval),
 		lastUpdateTime:     c.Data.LastUpdate.Time,
 		timeLocation:       c.Data.Timezone.Location,
@@ -539,47 +558,125 @@ func (s *stats) collect(ctx context.Context, startTime, endTime time.Time) error
 	// Keep track of last updated time upon successful DB ops
 	s.storage.lastUpdateTime = endTime
 
+	// Backfill metrics of units that were updated when TSDB was unavailable
+	clusters := make(map[string]models.Cluster, len(units))
+	for _, clusterUnits := range units {
+		clusters[clusterUnits.Cluster.ID] = clusterUnits.Cluster
+	}
+
+	if err := s.backfill(ctx, clusters); err != nil {
+		s.logger.Error("Failed to backfill metrics of units", "err", err)
+	}
+
 	return nil
 }
 
-// Delete old entries in DB.
+// Delete old entries in DB. Entries of clusters that have their own retention
+// period are purged based on that period and the rest are purged based on
+// global retention period.
 func (s *stats) purgeExpiredUnits(ctx context.Context, tx *sql.Tx) error {
 	// Measure elapsed time
 	defer common.TimeTrack(time.Now(), "DB cleanup", s.logger)
 
+	var unitsDeleted, usageDeleted, dailyUsageDeleted int
+
+	// Purge entries of clusters with their own retention periods
+	clusterIDs := make([]string, 0, len(s.storage.clusterRetention))
+
+	for clusterID, period := range s.storage.clusterRetention {
+		clusterIDs = append(clusterIDs, clusterID)
+
+		units, usage, dailyUsage, err := s.purgeEntries(ctx, tx, period, "cluster_id = ?", clusterID)
+		if err != nil {
+			return err
+		}
+
+		unitsDeleted += units
+		usageDeleted += usage
+		dailyUsageDeleted += dailyUsage
+	}
+
+	// Purge entries of rest of the clusters using global retention period
+	clause := "1 = 1"
+
+	args := make([]any, len(clusterIDs))
+	for i, clusterID := range clusterIDs {
+		args[i] = clusterID
+	}
+
+	if len(clusterIDs) > 0 {
+		clause = fmt.Sprintf("cluster_id NOT IN (%s)", strings.TrimSuffix(strings.Repeat("?,", len(clusterIDs)), ","))
+	}
+
+	units, usage, dailyUsage, err := s.purgeEntries(ctx, tx, s.storage.retentionPeriod, clause, args...)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debug(
+		"DB update", "units_deleted", unitsDeleted+units, "usage_deleted", usageDeleted+usage,
+		"daily_usage_deleted", dailyUsageDeleted+dailyUsage,
+	)
+
+	return nil
+}
+
+// purgeEntries deletes units, usage and daily usage entries that are older than
+// retention period and match the clause. It returns the number of deleted units,
+// usage and daily usage entries.
+func (s *stats) purgeEntries(
+	ctx context.Context,
+	tx *sql.Tx,
+	retentionPeriod time.Duration,
+	clause string,
+	args ...any,
+) (int, int, int, error) {
 	// Purge expired units
 	deleteUnitsQuery := fmt.Sprintf(
-		"DELETE FROM %s WHERE started_at <= date('now', '-%d day')",
+		"DELETE FROM %s WHERE started_at <= date('now', '-%d day') AND %s",
 		base.UnitsDBTableName,
-		int(s.storage.retentionPeriod.Hours()/24),
+		int(retentionPeriod.Hours()/24),
+		clause,
 	) // #nosec
-	if _, err := tx.ExecContext(ctx, deleteUnitsQuery); err != nil {
-		return err
-	}
 
-	// Get changes
-	var unitsDeleted int
-	if err := tx.QueryRowContext(ctx, "SELECT changes()").Scan(&unitsDeleted); err == nil {
-		s.logger.Debug("DB update", "units_deleted", unitsDeleted)
+	res, err := tx.ExecContext(ctx, deleteUnitsQuery, args...)
+	if err != nil {
+		return 0, 0, 0, err
 	}
 
+	unitsDeleted, _ := res.RowsAffected()
+
 	// Purge stale usage data
 	deleteUsageQuery := fmt.Sprintf(
-		"DELETE FROM %s WHERE last_updated_at <= date('now', '-%d day')",
+		"DELETE FROM %s WHERE last_updated_at <= date('now', '-%d day') AND %s",
 		base.UsageDBTableName,
-		int(s.storage.retentionPeriod.Hours()/24),
+		int(retentionPeriod.Hours()/24),
+		clause,
 	) // #nosec
-	if _, err := tx.ExecContext(ctx, deleteUsageQuery); err != nil {
-		return err
+
+	res, err = tx.ExecContext(ctx, deleteUsageQuery, args...)
+	if err != nil {
+		return 0, 0, 0, err
 	}
 
-	// Get changes
-	var usageDeleted int
-	if err := tx.QueryRowContext(ctx, "SELECT changes()").Scan(&usageDeleted); err == nil {
-		s.logger.Debug("DB update", "usage_deleted", usageDeleted)
+	usageDeleted, _ := res.RowsAffected()
+
+	// Purge stale daily usage data
+	deleteDailyUsageQuery := fmt.Sprintf(
+		"DELETE FROM %s WHERE last_updated_at <= date('now', '-%d day') AND %s",
+		base.DailyUsageDBTableName,
+		int(retentionPeriod.Hours()/24),
+		clause,
+	) // #nosec
+
+	res, err = tx.ExecContext(ctx, deleteDailyUsageQuery, args...)
+	if err != nil {
+		return 0, 0, 0, err
 	}
 
-	return nil
+	dailyUsageDeleted, _ := res.RowsAffected()
+
+	return int(unitsDeleted), int(usageDeleted), int(dailyUsageDeleted), nil
 }
 
 // Insert unit stat into DB.
@@ -621,9 +718,19 @@ func (s *stats) execStatements(
 				continue
 			}
 
+			// Updater tags are merged into existing ones and hence, they must
+			// always be a JSON object
+			updaterTags := unit.UpdaterTags
+			if updaterTags == nil {
+				updaterTags = make(models.Tag)
+			}
+
 			// s.logger.Debug("Inserting unit", "id", unit.Jobid)
 			// Use named parameters to not to repeat the values
-			if _, err = stmts[base.UnitsDBTableName].ExecContext(
+			// Statement returns the ignore flag of unit after update
+			var ignore int
+
+			if err = stmts[base.UnitsDBTableName].QueryRowContext(
 				ctx,
 				sql.Named(base.UnitsDBTableStructFieldColNameMap["ResourceManager"], unit.ResourceManager),
 				sql.Named(base.UnitsDBTableStructFieldColNameMap["ClusterID"], cluster.Cluster.ID),
@@ -658,10 +765,18 @@ func (s *stats) execStatements(
 				sql.Named(base.UnitsDBTableStructFieldColNameMap["Ignore"], unit.Ignore),
 				sql.Named(base.UnitsDBTableStructFieldColNameMap["NumUpdates"], 1),
 				sql.Named(base.UnitsDBTableStructFieldColNameMap["LastUpdatedAt"], currentTime.Format(base.DatetimeLayout)),
-			); err != nil {
+				sql.Named(base.UnitsDBTableStructFieldColNameMap["UpdaterTags"], updaterTags),
+			).Scan(&ignore); err != nil {
 				s.logger.Error("Failed to insert unit in DB", "cluster_id", cluster.Cluster.ID, "uuid", unit.UUID, "err", err)
 			}
 
+			// Usage of units ignored by admin users is excluded from usage tables.
+			// When the flag is unset, lifetime usage of unit is added back to usage
+			// tables by the API server
+			if ignore == models.UnitIgnoredByAdmin {
+				continue
+			}
+
 			// If the unit has started in this update period, increment num units
 			// Or if we start with empty DB, we need to increment for num units for all discovered units
 			unitIncr = 0
@@ -739,6 +854,7 @@ func (s *stats) execStatements(
 				sql.Named(base.UsersDBTableStructFieldColNameMap["UID"], user.UID),
 				sql.Named(base.UsersDBTableStructFieldColNameMap["Name"], user.Name),
 				sql.Named(base.UsersDBTableStructFieldColNameMap["Projects"], user.Projects),
+				sql.Named(base.UsersDBTableStructFieldColNameMap["Roles"], user.Roles),
 				sql.Named(base.UsersDBTableStructFieldColNameMap["Tags"], user.Tags),
 				sql.Named(base.UsersDBTableStructFieldColNameMap["LastUpdatedAt"], user.LastUpdatedAt),
 			); err != nil {
@@ -757,6 +873,7 @@ func (s *stats) execStatements(
 				sql.Named(base.ProjectsDBTableStructFieldColNameMap["UID"], project.UID),
 				sql.Named(base.ProjectsDBTableStructFieldColNameMap["Name"], project.Name),
 				sql.Named(base.ProjectsDBTableStructFieldColNameMap["Users"], project.Users),
+				sql.Named(base.ProjectsDBTableStructFieldColNameMap["Roles"], project.Roles),
 				sql.Named(base.ProjectsDBTableStructFieldColNameMap["Tags"], project.Tags),
 				sql.Named(base.ProjectsDBTableStructFieldColNameMap["LastUpdatedAt"], project.LastUpdatedAt),
 			); err != nil {
@@ -1050,3 +1050,95 @@ func TestUnitStatsDeleteOldUnits(t *testing.T) {
 	require.NoError(t, err, "failed to query DB")
 	assert.Equal(t, 0, numRows, "expected 0 rows after deletion")
 }
+
+func TestUnitStatsDeleteOldUnitsPerCluster(t *testing.T) {
+	tmpDir := t.TempDir()
+	c, err := prepareMockConfig(tmpDir)
+	require.NoError(t, err, "failed to create mock config")
+
+	// Set a shorter retention period for one of the clusters
+	c.Data.RetentionPeriod = model.Duration(30 * 24 * time.Hour)
+	c.Data.ClusterRetention = map[string]model.Duration{"short": model.Duration(7 * 24 * time.Hour)}
+
+	// Make new stats DB
+	s, err := New(c)
+	defer s.Stop()
+	require.NoError(t, err, "failed to create new stats")
+
+	// Add units started 10 days ago on both clusters. Only the one on cluster
+	// with shorter retention period must be deleted
+	startedAt := time.Now().Add(-10 * 24 * time.Hour).Format(base.DatetimeLayout)
+	units := []models.ClusterUnits{
+		{
+			Cluster: models.Cluster{ID: "short"},
+			Units:   []models.Unit{{UUID: "1111", StartedAt: startedAt}},
+		},
+		{
+			Cluster: models.Cluster{ID: "default"},
+			Units:   []models.Unit{{UUID: "2222", StartedAt: startedAt}},
+		},
+	}
+	ctx := context.Background()
+	tx, err := s.db.Begin()
+	require.NoError(t, err)
+	err = s.execStatements(ctx, tx, time.Now().Add(-time.Minute), time.Now(), units, nil, nil)
+	require.NoError(t, err)
+
+	// Add usage and daily usage entries updated 10 days ago on both clusters
+	for _, table := range []string{base.UsageDBTableName, base.DailyUsageDBTableName} {
+		for _, clusterID := range []string{"short", "default"} {
+			_, err = tx.ExecContext(
+				ctx,
+				fmt.Sprintf("INSERT INTO %s (cluster_id, username, project, last_updated_at) VALUES (?, 'usr', 'old', ?);", table),
+				clusterID, startedAt,
+			)
+			require.NoError(t, err)
+		}
+	}
+
+	// Now clean up DB for old units
+	err = s.purgeExpiredUnits(ctx, tx)
+	require.NoError(t, err, "failed to delete old entries in DB")
+	tx.Commit()
+
+	// Query for remaining units
+	var numRows int
+
+	for clusterID, expected := range map[string]int{"short": 0, "default": 1} {
+		err = s.db.QueryRow(
+			fmt.Sprintf("SELECT COUNT(uuid) FROM %s WHERE cluster_id = ?;", base.UnitsDBTableName), clusterID,
+		).Scan(&numRows)
+		require.NoError(t, err, "failed to query DB")
+		assert.Equal(t, expected, numRows, clusterID)
+
+		// Old usage and daily usage entries must be purged with same retention period
+		for _, table := range []string{base.UsageDBTableName, base.DailyUsageDBTableName} {
+			err = s.db.QueryRow(
+				fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE cluster_id = ? AND project = 'old';", table), clusterID,
+			).Scan(&numRows)
+			require.NoError(t, err, "failed to query DB")
+			assert.Equal(t, expected, numRows, "%s: %s", table, clusterID)
+		}
+	}
+}
+
+func TestUnitStatsDBMaintenance(t *testing.T) {
+	tmpDir := t.TempDir()
+	c, err := prepareMockConfig(tmpDir)
+	require.NoError(t, err, "failed to create mock config")
+
+	// Make new stats DB
+	s, err := New(c)
+	require.NoError(t, err, "failed to create new stats")
+
+	defer s.Stop()
+
+	// Populate DB and run maintenance
+	require.NoError(t, populateDBWithMockData(s))
+	require.NoError(t, s.Maintain(context.Background()), "failed to run DB maintenance")
+
+	// Query planner statistics must be present
+	var numStats int
+	require.NoError(t, s.db.QueryRow("SELECT COUNT(*) FROM sqlite_stat1").Scan(&numStats))
+	assert.Positive(t, numStats)
+}
@@ -0,0 +1,79 @@
+//go:build cgo
+// +build cgo
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/internal/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SQLite auto_vacuum mode in which free pages can be reclaimed using
+// incremental_vacuum pragma.
+const autoVacuumIncremental = 2
+
+// Metrics of DB maintenance.
+var (
+	maintenanceLastRun = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "ceems_api",
+		Subsystem: "db_maintenance",
+		Name:      "last_run_timestamp_seconds",
+		Help:      "Unix timestamp of last successful DB maintenance",
+	})
+	maintenanceDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "ceems_api",
+		Subsystem: "db_maintenance",
+		Name:      "duration_seconds",
+		Help:      "Duration of last successful DB maintenance in seconds",
+	})
+)
+
+// Collectors returns collectors of DB metrics.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{maintenanceLastRun, maintenanceDuration}
+}
+
+// Maintain reclaims free pages in DB and refreshes statistics of query planner.
+func (s *stats) Maintain(ctx context.Context) error {
+	return s.maintain(ctx)
+}
+
+// maintain runs DB maintenance tasks. When DB is in incremental auto vacuum
+// mode, free pages are reclaimed using incremental_vacuum pragma which does not
+// rebuild the entire DB. Else a full VACUUM is made.
+func (s *stats) maintain(ctx context.Context) error {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "DB maintenance", s.logger)
+
+	start := time.Now()
+
+	var autoVacuum int
+	if err := s.db.QueryRowContext(ctx, "PRAGMA auto_vacuum").Scan(&autoVacuum); err != nil {
+		return err
+	}
+
+	if autoVacuum == autoVacuumIncremental {
+		s.logger.Debug("Starting incremental vacuum of DB")
+
+		if _, err := s.db.ExecContext(ctx, "PRAGMA incremental_vacuum"); err != nil {
+			return err
+		}
+	} else if err := s.vacuum(ctx); err != nil {
+		return err
+	}
+
+	// Refresh statistics of query planner
+	if _, err := s.db.ExecContext(ctx, "ANALYZE"); err != nil {
+		return err
+	}
+
+	maintenanceLastRun.Set(float64(time.Now().Unix()))
+	maintenanceDuration.Set(time.Since(start).Seconds())
+
+	s.logger.Info("DB maintenance finished", "duration", time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN "roles" text default '{}';
+ALTER TABLE projects ADD COLUMN "roles" text default '{}';
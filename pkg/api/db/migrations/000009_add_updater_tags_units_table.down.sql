@@ -0,0 +1 @@
+ALTER TABLE units DROP COLUMN "updater_tags";
@@ -0,0 +1 @@
+ALTER TABLE units ADD COLUMN "updater_tags" text default '{}';
@@ -0,0 +1,181 @@
+//go:build cgo
+// +build cgo
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule is returned when cron expression is invalid.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Maximum duration to look for next activation of a schedule.
+const maxScheduleLookup = 366 * 24 * time.Hour
+
+// Bounds of each field of cron expression.
+var scheduleFieldBounds = [5][2]int{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 6},  // day of week
+}
+
+// Schedule is a cron like schedule with five fields: minute, hour, day of
+// month, month and day of week. Each field supports `*`, single values,
+// ranges `a-b`, steps `*/n` or `a-b/n` and comma separated lists of them.
+type Schedule struct {
+	expr        string
+	fields      [5]map[int]bool
+	domWildcard bool
+	dowWildcard bool
+}
+
+// ParseSchedule parses cron expression into Schedule.
+func ParseSchedule(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(scheduleFieldBounds) {
+		return nil, fmt.Errorf("%w: %s: expected 5 fields", ErrInvalidSchedule, expr)
+	}
+
+	s := &Schedule{
+		expr:        expr,
+		domWildcard: parts[2] == "*",
+		dowWildcard: parts[4] == "*",
+	}
+
+	for i, part := range parts {
+		values, err := parseScheduleField(part, scheduleFieldBounds[i][0], scheduleFieldBounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidSchedule, expr, err)
+		}
+
+		s.fields[i] = values
+	}
+
+	return s, nil
+}
+
+// parseScheduleField returns the set of values of a cron field.
+func parseScheduleField(field string, lower, upper int) (map[int]bool, error) {
+	values := make(map[int]bool)
+
+	for _, item := range strings.Split(field, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+
+		step := 1
+
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %s", stepExpr)
+			}
+		}
+
+		start, end := lower, upper
+
+		if rangeExpr != "*" {
+			startExpr, endExpr, isRange := strings.Cut(rangeExpr, "-")
+
+			var err error
+			if start, err = strconv.Atoi(startExpr); err != nil {
+				return nil, fmt.Errorf("invalid value %s", startExpr)
+			}
+
+			end = start
+
+			if isRange {
+				if end, err = strconv.Atoi(endExpr); err != nil {
+					return nil, fmt.Errorf("invalid value %s", endExpr)
+				}
+			} else if hasStep {
+				end = upper
+			}
+		}
+
+		if start < lower || end > upper || start > end {
+			return nil, fmt.Errorf("value %s out of bounds [%d, %d]", rangeExpr, lower, upper)
+		}
+
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+// matches returns true if schedule must be activated at time t.
+func (s *Schedule) matches(t time.Time) bool {
+	if !s.fields[0][t.Minute()] || !s.fields[1][t.Hour()] || !s.fields[3][int(t.Month())] {
+		return false
+	}
+
+	dom := s.fields[2][t.Day()]
+	dow := s.fields[4][int(t.Weekday())]
+
+	// Like cron, when both day of month and day of week are restricted, the
+	// schedule is activated when either of them matches
+	switch {
+	case s.domWildcard && s.dowWildcard:
+		return true
+	case s.domWildcard:
+		return dow
+	case s.dowWildcard:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next returns the next activation time of schedule after t. A zero time is
+// returned when schedule is not activated within next year.
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+
+	for end := t.Add(maxScheduleLookup); next.Before(end); next = next.Add(time.Minute) {
+		if s.matches(next) {
+			return next
+		}
+	}
+
+	return time.Time{}
+}
+
+// IsZero returns true if schedule is not configured.
+func (s *Schedule) IsZero() bool {
+	return s == nil || s.expr == ""
+}
+
+// String implements fmt.Stringer interface.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (s *Schedule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp string
+
+	if err := unmarshal(&tmp); err != nil {
+		return err
+	}
+
+	// Empty schedule means never activated
+	if strings.TrimSpace(tmp) == "" {
+		return nil
+	}
+
+	schedule, err := ParseSchedule(tmp)
+	if err != nil {
+		return err
+	}
+
+	*s = *schedule
+
+	return nil
+}
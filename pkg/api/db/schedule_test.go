@@ -0,0 +1,63 @@
+//go:build cgo
+// +build cgo
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseSchedule(t *testing.T) {
+	for _, expr := range []string{"* * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "* * 0 * *"} {
+		_, err := ParseSchedule(expr)
+		require.ErrorIs(t, err, ErrInvalidSchedule, expr)
+	}
+
+	_, err := ParseSchedule("0,30 1-5/2 * 1-12 0")
+	require.NoError(t, err)
+}
+
+func TestScheduleNext(t *testing.T) {
+	// 2025-01-15 is a Wednesday
+	now := time.Date(2025, 1, 15, 10, 17, 42, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 15, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2025, 1, 16, 3, 0, 0, 0, time.UTC)},
+		{"30 2 * * 0", time.Date(2025, 1, 19, 2, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 5", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+
+	for _, test := range tests {
+		schedule, err := ParseSchedule(test.expr)
+		require.NoError(t, err, test.expr)
+		assert.Equal(t, test.expected, schedule.Next(now), test.expr)
+	}
+}
+
+func TestScheduleUnmarshal(t *testing.T) {
+	var config struct {
+		Schedule *Schedule `yaml:"schedule"`
+	}
+
+	require.NoError(t, yaml.Unmarshal([]byte("schedule: 0 3 * * 0"), &config))
+	assert.False(t, config.Schedule.IsZero())
+	assert.Equal(t, "0 3 * * 0", config.Schedule.String())
+
+	config.Schedule = nil
+	require.NoError(t, yaml.Unmarshal([]byte("schedule: ''"), &config))
+	assert.True(t, config.Schedule.IsZero())
+
+	require.Error(t, yaml.Unmarshal([]byte("schedule: 0 3 * *"), &config))
+}
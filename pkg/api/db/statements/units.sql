@@ -1,4 +1,4 @@
-INSERT INTO units (cluster_id,resource_manager,uuid,name,project,groupname,username,created_at,started_at,ended_at,created_at_ts,started_at_ts,ended_at_ts,elapsed,state,allocation,total_time_seconds,avg_cpu_usage,avg_cpu_mem_usage,total_cpu_energy_usage_kwh,total_cpu_emissions_gms,avg_gpu_usage,avg_gpu_mem_usage,total_gpu_energy_usage_kwh,total_gpu_emissions_gms,total_io_write_stats,total_io_read_stats,total_ingress_stats,total_outgress_stats,tags,ignore,num_updates,last_updated_at) VALUES (:cluster_id,:resource_manager,:uuid,:name,:project,:groupname,:username,:created_at,:started_at,:ended_at,:created_at_ts,:started_at_ts,:ended_at_ts,:elapsed,:state,:allocation,:total_time_seconds,:avg_cpu_usage,:avg_cpu_mem_usage,:total_cpu_energy_usage_kwh,:total_cpu_emissions_gms,:avg_gpu_usage,:avg_gpu_mem_usage,:total_gpu_energy_usage_kwh,:total_gpu_emissions_gms,:total_io_write_stats,:total_io_read_stats,:total_ingress_stats,:total_outgress_stats,:tags,:ignore,:num_updates,:last_updated_at) ON CONFLICT(cluster_id,uuid,started_at) DO UPDATE SET
+INSERT INTO units (cluster_id,resource_manager,uuid,name,project,groupname,username,created_at,started_at,ended_at,created_at_ts,started_at_ts,ended_at_ts,elapsed,state,allocation,total_time_seconds,avg_cpu_usage,avg_cpu_mem_usage,total_cpu_energy_usage_kwh,total_cpu_emissions_gms,avg_gpu_usage,avg_gpu_mem_usage,total_gpu_energy_usage_kwh,total_gpu_emissions_gms,total_io_write_stats,total_io_read_stats,total_ingress_stats,total_outgress_stats,tags,ignore,num_updates,last_updated_at,updater_tags) VALUES (:cluster_id,:resource_manager,:uuid,:name,:project,:groupname,:username,:created_at,:started_at,:ended_at,:created_at_ts,:started_at_ts,:ended_at_ts,:elapsed,:state,:allocation,:total_time_seconds,:avg_cpu_usage,:avg_cpu_mem_usage,:total_cpu_energy_usage_kwh,:total_cpu_emissions_gms,:avg_gpu_usage,:avg_gpu_mem_usage,:total_gpu_energy_usage_kwh,:total_gpu_emissions_gms,:total_io_write_stats,:total_io_read_stats,:total_ingress_stats,:total_outgress_stats,:tags,:ignore,:num_updates,:last_updated_at,:updater_tags) ON CONFLICT(cluster_id,uuid,started_at) DO UPDATE SET
   ended_at = :ended_at,
   ended_at_ts = :ended_at_ts,
   elapsed = :elapsed,
@@ -17,6 +17,8 @@ INSERT INTO units (cluster_id,resource_manager,uuid,name,project,groupname,usern
   total_ingress_stats = add_metric_map(total_ingress_stats, :total_ingress_stats),
   total_outgress_stats = add_metric_map(total_outgress_stats, :total_outgress_stats),
   tags = :tags,
-  ignore = :ignore,
+  ignore = MAX(COALESCE(ignore, 0), :ignore),
   num_updates = num_updates + :num_updates,
-  last_updated_at = :last_updated_at
+  last_updated_at = :last_updated_at,
+  updater_tags = json_patch(COALESCE(updater_tags, '{}'), :updater_tags)
+RETURNING ignore
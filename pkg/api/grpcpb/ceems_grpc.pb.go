@@ -0,0 +1,224 @@
+// Protobuf definitions of gRPC service of CEEMS API server.
+//
+// Messages mirror the models of HTTP API and the field names are same as
+// the JSON keys of HTTP responses.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: ceems.proto
+
+package grpcpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CEEMS_Units_FullMethodName       = "/ceems.v1.CEEMS/Units"
+	CEEMS_Usage_FullMethodName       = "/ceems.v1.CEEMS/Usage"
+	CEEMS_VerifyUnits_FullMethodName = "/ceems.v1.CEEMS/VerifyUnits"
+)
+
+// CEEMSClient is the client API for CEEMS service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CEEMS service exposes the core queries of CEEMS API server. Each RPC is
+// served by the same handler as its HTTP counterpart and hence, same query
+// parameters, authentication and errors apply.
+type CEEMSClient interface {
+	// Units returns compute units. It is the counterpart of `/units` and
+	// `/units/admin` endpoints.
+	Units(ctx context.Context, in *UnitsRequest, opts ...grpc.CallOption) (*UnitsResponse, error)
+	// Usage returns usage statistics. It is the counterpart of `/usage/{mode}`
+	// and `/usage/{mode}/admin` endpoints.
+	Usage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error)
+	// VerifyUnits verifies the ownership of units by current user. It is the
+	// counterpart of `/units/verify` endpoint. An OK status means the current
+	// user is the owner of all queried units.
+	VerifyUnits(ctx context.Context, in *VerifyUnitsRequest, opts ...grpc.CallOption) (*VerifyUnitsResponse, error)
+}
+
+type cEEMSClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCEEMSClient(cc grpc.ClientConnInterface) CEEMSClient {
+	return &cEEMSClient{cc}
+}
+
+func (c *cEEMSClient) Units(ctx context.Context, in *UnitsRequest, opts ...grpc.CallOption) (*UnitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnitsResponse)
+	err := c.cc.Invoke(ctx, CEEMS_Units_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cEEMSClient) Usage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageResponse)
+	err := c.cc.Invoke(ctx, CEEMS_Usage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cEEMSClient) VerifyUnits(ctx context.Context, in *VerifyUnitsRequest, opts ...grpc.CallOption) (*VerifyUnitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyUnitsResponse)
+	err := c.cc.Invoke(ctx, CEEMS_VerifyUnits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CEEMSServer is the server API for CEEMS service.
+// All implementations must embed UnimplementedCEEMSServer
+// for forward compatibility.
+//
+// CEEMS service exposes the core queries of CEEMS API server. Each RPC is
+// served by the same handler as its HTTP counterpart and hence, same query
+// parameters, authentication and errors apply.
+type CEEMSServer interface {
+	// Units returns compute units. It is the counterpart of `/units` and
+	// `/units/admin` endpoints.
+	Units(context.Context, *UnitsRequest) (*UnitsResponse, error)
+	// Usage returns usage statistics. It is the counterpart of `/usage/{mode}`
+	// and `/usage/{mode}/admin` endpoints.
+	Usage(context.Context, *UsageRequest) (*UsageResponse, error)
+	// VerifyUnits verifies the ownership of units by current user. It is the
+	// counterpart of `/units/verify` endpoint. An OK status means the current
+	// user is the owner of all queried units.
+	VerifyUnits(context.Context, *VerifyUnitsRequest) (*VerifyUnitsResponse, error)
+	mustEmbedUnimplementedCEEMSServer()
+}
+
+// UnimplementedCEEMSServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCEEMSServer struct{}
+
+func (UnimplementedCEEMSServer) Units(context.Context, *UnitsRequest) (*UnitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Units not implemented")
+}
+func (UnimplementedCEEMSServer) Usage(context.Context, *UsageRequest) (*UsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Usage not implemented")
+}
+func (UnimplementedCEEMSServer) VerifyUnits(context.Context, *VerifyUnitsRequest) (*VerifyUnitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyUnits not implemented")
+}
+func (UnimplementedCEEMSServer) mustEmbedUnimplementedCEEMSServer() {}
+func (UnimplementedCEEMSServer) testEmbeddedByValue()               {}
+
+// UnsafeCEEMSServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CEEMSServer will
+// result in compilation errors.
+type UnsafeCEEMSServer interface {
+	mustEmbedUnimplementedCEEMSServer()
+}
+
+func RegisterCEEMSServer(s grpc.ServiceRegistrar, srv CEEMSServer) {
+	// If the following call pancis, it indicates UnimplementedCEEMSServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CEEMS_ServiceDesc, srv)
+}
+
+func _CEEMS_Units_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CEEMSServer).Units(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CEEMS_Units_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CEEMSServer).Units(ctx, req.(*UnitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CEEMS_Usage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CEEMSServer).Usage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CEEMS_Usage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CEEMSServer).Usage(ctx, req.(*UsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CEEMS_VerifyUnits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyUnitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CEEMSServer).VerifyUnits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CEEMS_VerifyUnits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CEEMSServer).VerifyUnits(ctx, req.(*VerifyUnitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CEEMS_ServiceDesc is the grpc.ServiceDesc for CEEMS service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CEEMS_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ceems.v1.CEEMS",
+	HandlerType: (*CEEMSServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Units",
+			Handler:    _CEEMS_Units_Handler,
+		},
+		{
+			MethodName: "Usage",
+			Handler:    _CEEMS_Usage_Handler,
+		},
+		{
+			MethodName: "VerifyUnits",
+			Handler:    _CEEMS_VerifyUnits_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ceems.proto",
+}
@@ -351,6 +351,26 @@ const docTemplate = `{
                 }
             }
         },
+        "/status/buildinfo": {
+            "get": {
+                "description": "This endpoint returns the build information of the server like version,\nrevision, branch and Go version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Build information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-models_BuildInfo"
+                        }
+                    }
+                }
+            }
+        },
         "/units": {
             "get": {
                 "security": [
@@ -358,7 +378,7 @@ const docTemplate = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "This user endpoint will fetch compute units of the current user. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nIf multiple query parameters are passed, for instance, ` + "`" + `?uuid=\u003cuuid\u003e\u0026project=\u003cproject\u003e` + "`" + `,\nthe intersection of query parameters are used to fetch compute units rather than\nthe union. That means if the compute unit's ` + "`" + `uuid` + "`" + ` does not belong to the queried\nproject, null response will be returned.\n\nIn order to return the running compute units as well, use the query parameter ` + "`" + `running` + "`" + `.\n\nIf ` + "`" + `to` + "`" + ` query parameter is not provided, current time will be used. If ` + "`" + `from` + "`" + `\nquery parameter is not used, a default query window of 24 hours will be used.\nIt means if ` + "`" + `to` + "`" + ` is provided, ` + "`" + `from` + "`" + ` will be calculated as ` + "`" + `to` + "`" + ` - 24hrs. If query\nparameter ` + "`" + `timezone` + "`" + ` is provided, the unit's created, start and end time strings\nwill be presented in that time zone.\n\nTo limit the number of fields in the response, use ` + "`" + `field` + "`" + ` query parameter. By default, all\nfields will be included in the response if they are _non-empty_.",
+                "description": "This user endpoint will fetch compute units of the current user. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nIf multiple query parameters are passed, for instance, ` + "`" + `?uuid=\u003cuuid\u003e\u0026project=\u003cproject\u003e` + "`" + `,\nthe intersection of query parameters are used to fetch compute units rather than\nthe union. That means if the compute unit's ` + "`" + `uuid` + "`" + ` does not belong to the queried\nproject, null response will be returned.\n\nIn order to return the running compute units as well, use the query parameter ` + "`" + `running` + "`" + `.\nTo return only running or only finished compute units, use the query parameter\n` + "`" + `status` + "`" + ` with ` + "`" + `active` + "`" + ` or ` + "`" + `terminated` + "`" + ` value, respectively. When ` + "`" + `status=active` + "`" + `,\nthe query window is ignored as active units have not ended yet.\n\nTo filter compute units by their state as reported by the resource manager, use the\nquery parameter ` + "`" + `state` + "`" + `, for instance, ` + "`" + `state=FAILED\u0026state=TIMEOUT` + "`" + `. States set by\nSLURM with extra information like ` + "`" + `CANCELLED by \u003cuid\u003e` + "`" + ` are matched as well.\n\nTo filter compute units by their metrics, use query parameters of form\n` + "`" + `min_\u003cfield\u003e[.\u003ckey\u003e]` + "`" + ` and ` + "`" + `max_\u003cfield\u003e[.\u003ckey\u003e]` + "`" + ` where ` + "`" + `field` + "`" + ` is one of the metric\nfields like ` + "`" + `total_cpu_energy_usage_kwh` + "`" + `, ` + "`" + `avg_gpu_usage` + "`" + `, _etc._ For instance,\n` + "`" + `min_total_cpu_energy_usage_kwh=10` + "`" + ` returns compute units that consumed at least\n10 kWh and ` + "`" + `max_total_time_seconds.walltime=3600` + "`" + ` returns compute units that ran\nfor at most one hour. When ` + "`" + `key` + "`" + ` is not provided, the condition must be satisfied\nby any of the values of the metric.\n\nIf ` + "`" + `to` + "`" + ` query parameter is not provided, current time will be used. If ` + "`" + `from` + "`" + `\nquery parameter is not used, a default query window of 24 hours will be used.\nIt means if ` + "`" + `to` + "`" + ` is provided, ` + "`" + `from` + "`" + ` will be calculated as ` + "`" + `to` + "`" + ` - 24hrs. If query\nparameter ` + "`" + `timezone` + "`" + ` is provided, the unit's created, start and end time strings\nwill be presented in that time zone.\n\nTo limit the number of fields in the response, use ` + "`" + `field` + "`" + ` query parameter. By default, all\nfields will be included in the response if they are _non-empty_.\n\nResults can be paginated using ` + "`" + `limit` + "`" + ` and ` + "`" + `offset` + "`" + ` query parameters. When ` + "`" + `limit` + "`" + `\nis provided, response will include a ` + "`" + `pagination` + "`" + ` object with total number of units\nand the offset of next page, if any. Maximum allowed ` + "`" + `limit` + "`" + ` is 10000.\n\nFor iterating over large number of units, use ` + "`" + `cursor` + "`" + ` query parameter instead of\n` + "`" + `offset` + "`" + `. The value of ` + "`" + `next_cursor` + "`" + ` in ` + "`" + `pagination` + "`" + ` object of the response must be\nused as ` + "`" + `cursor` + "`" + ` to fetch the next page. Cursor cannot be used along with ` + "`" + `offset` + "`" + `.",
                 "produces": [
                     "application/json"
                 ],
@@ -410,6 +430,37 @@ const docTemplate = `{
                         "name": "running",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "active",
+                            "terminated",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status of units",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "State of units",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag of units of form key:value",
+                        "name": "tag",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "From timestamp",
@@ -437,6 +488,40 @@ const docTemplate = `{
                         "description": "Fields to return in response",
                         "name": "field",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include units of fields in response",
+                        "name": "include_units",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of units to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of units to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned in the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Order of units of form column[:asc|desc]",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -474,7 +559,7 @@ const docTemplate = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "This admin endpoint will fetch compute units of _any_ user, compute unit and/or project. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nIf multiple query parameters are passed, for instance, ` + "`" + `?uuid=\u003cuuid\u003e\u0026user=\u003cuser\u003e` + "`" + `,\nthe intersection of query parameters are used to fetch compute units rather than\nthe union. That means if the compute unit's ` + "`" + `uuid` + "`" + ` does not belong to the queried\nuser, null response will be returned.\n\nIn order to return the running compute units as well, use the query parameter ` + "`" + `running` + "`" + `.\n\nIf ` + "`" + `to` + "`" + ` query parameter is not provided, current time will be used. If ` + "`" + `from` + "`" + `\nquery parameter is not used, a default query window of 24 hours will be used.\nIt means if ` + "`" + `to` + "`" + ` is provided, ` + "`" + `from` + "`" + ` will be calculated as ` + "`" + `to` + "`" + ` - 24hrs. If query\nparameter ` + "`" + `timezone` + "`" + ` is provided, the unit's created, start and end time strings\nwill be presented in that time zone.\n\nTo limit the number of fields in the response, use ` + "`" + `field` + "`" + ` query parameter. By default, all\nfields will be included in the response if they are _non-empty_.",
+                "description": "This admin endpoint will fetch compute units of _any_ user, compute unit and/or project. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nIf multiple query parameters are passed, for instance, ` + "`" + `?uuid=\u003cuuid\u003e\u0026user=\u003cuser\u003e` + "`" + `,\nthe intersection of query parameters are used to fetch compute units rather than\nthe union. That means if the compute unit's ` + "`" + `uuid` + "`" + ` does not belong to the queried\nuser, null response will be returned.\n\nIn order to return the running compute units as well, use the query parameter ` + "`" + `running` + "`" + `.\nTo return only running or only finished compute units, use the query parameter\n` + "`" + `status` + "`" + ` with ` + "`" + `active` + "`" + ` or ` + "`" + `terminated` + "`" + ` value, respectively. When ` + "`" + `status=active` + "`" + `,\nthe query window is ignored as active units have not ended yet.\n\nTo filter compute units by their state as reported by the resource manager, use the\nquery parameter ` + "`" + `state` + "`" + `, for instance, ` + "`" + `state=FAILED\u0026state=TIMEOUT` + "`" + `. States set by\nSLURM with extra information like ` + "`" + `CANCELLED by \u003cuid\u003e` + "`" + ` are matched as well.\n\nTo filter compute units by their metrics, use query parameters of form\n` + "`" + `min_\u003cfield\u003e[.\u003ckey\u003e]` + "`" + ` and ` + "`" + `max_\u003cfield\u003e[.\u003ckey\u003e]` + "`" + ` where ` + "`" + `field` + "`" + ` is one of the metric\nfields like ` + "`" + `total_cpu_energy_usage_kwh` + "`" + `, ` + "`" + `avg_gpu_usage` + "`" + `, _etc._ For instance,\n` + "`" + `min_total_cpu_energy_usage_kwh=10` + "`" + ` returns compute units that consumed at least\n10 kWh and ` + "`" + `max_total_time_seconds.walltime=3600` + "`" + ` returns compute units that ran\nfor at most one hour. When ` + "`" + `key` + "`" + ` is not provided, the condition must be satisfied\nby any of the values of the metric.\n\nIf ` + "`" + `to` + "`" + ` query parameter is not provided, current time will be used. If ` + "`" + `from` + "`" + `\nquery parameter is not used, a default query window of 24 hours will be used.\nIt means if ` + "`" + `to` + "`" + ` is provided, ` + "`" + `from` + "`" + ` will be calculated as ` + "`" + `to` + "`" + ` - 24hrs. If query\nparameter ` + "`" + `timezone` + "`" + ` is provided, the unit's created, start and end time strings\nwill be presented in that time zone.\n\nTo limit the number of fields in the response, use ` + "`" + `field` + "`" + ` query parameter. By default, all\nfields will be included in the response if they are _non-empty_.\n\nResults can be paginated using ` + "`" + `limit` + "`" + ` and ` + "`" + `offset` + "`" + ` query parameters. When ` + "`" + `limit` + "`" + `\nis provided, response will include a ` + "`" + `pagination` + "`" + ` object with total number of units\nand the offset of next page, if any. Maximum allowed ` + "`" + `limit` + "`" + ` is 10000.\n\nFor iterating over large number of units, use ` + "`" + `cursor` + "`" + ` query parameter instead of\n` + "`" + `offset` + "`" + `. The value of ` + "`" + `next_cursor` + "`" + ` in ` + "`" + `pagination` + "`" + ` object of the response must be\nused as ` + "`" + `cursor` + "`" + ` to fetch the next page. Cursor cannot be used along with ` + "`" + `offset` + "`" + `.",
                 "produces": [
                     "application/json"
                 ],
@@ -536,6 +621,37 @@ const docTemplate = `{
                         "name": "running",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "active",
+                            "terminated",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status of units",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "State of units",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag of units of form key:value",
+                        "name": "tag",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "From timestamp",
@@ -563,6 +679,40 @@ const docTemplate = `{
                         "description": "Fields to return in response",
                         "name": "field",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include units of fields in response",
+                        "name": "include_units",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of units to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of units to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned in the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Order of units of form column[:asc|desc]",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -593,21 +743,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/units/verify": {
+        "/units/count": {
             "get": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "This endpoint will check if the current user is the owner of the\nqueried UUIDs. The current user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nA response of 200 means that the current user is the owner of the queried UUIDs.\nAny other response code should be treated as the current user not being the owner\nof the queried units.\n\nThe ownership check passes if any of the following conditions are ` + "`" + `true` + "`" + `:\n- If the current user is the _direct_ owner of the compute unit.\n- If the current user belongs to the same account/project/namespace as\nthe compute unit. This means the users belonging to the same project can\naccess each others compute units.\n\nThe above checks must pass for **all** the queried units.\nIf the check does not pass for at least one queried unit, a response 403 will be\nreturned.\n\nAny 500 response codes should be treated as failed check as well.",
+                "description": "This user endpoint will return the number of compute units of the current\nuser that match the query parameters. The current user is always identified\nby the header ` + "`" + `X-Grafana-User` + "`" + ` in the request.\n\nThe query parameters are same as the ones of ` + "`" + `/units` + "`" + ` endpoint and\nthe number of compute units is returned as the only element of ` + "`" + `data` + "`" + `.\nThis endpoint avoids fetching all the compute units when only the number\nof compute units is needed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "units"
                 ],
-                "summary": "Verify unit ownership",
+                "summary": "User endpoint for counting compute units",
                 "parameters": [
                     {
                         "type": "string",
@@ -616,6 +766,16 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cluster ID",
+                        "name": "cluster_id",
+                        "in": "query"
+                    },
                     {
                         "type": "array",
                         "items": {
@@ -632,8 +792,25 @@ const docTemplate = `{
                             "type": "string"
                         },
                         "collectionFormat": "multi",
-                        "description": "Cluster ID",
-                        "name": "cluster_id",
+                        "description": "Project",
+                        "name": "project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to count running units",
+                        "name": "running",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "terminated",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status of units",
+                        "name": "status",
                         "in": "query"
                     },
                     {
@@ -642,8 +819,30 @@ const docTemplate = `{
                             "type": "string"
                         },
                         "collectionFormat": "multi",
-                        "description": "Timestamps",
-                        "name": "time",
+                        "description": "State of units",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag of units of form key:value",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To timestamp",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -651,7 +850,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.Response-any"
+                            "$ref": "#/definitions/http.Response-int"
                         }
                     },
                     "401": {
@@ -675,21 +874,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/usage/{mode}": {
+        "/units/count/admin": {
             "get": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "This endpoint will return the usage statistics current user. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nA path parameter ` + "`" + `mode` + "`" + ` is required to return the kind of usage statistics.\nCurrently, two modes of statistics are supported:\n- ` + "`" + `current` + "`" + `: In this mode the usage between two time periods is returned\nbased on ` + "`" + `from` + "`" + ` and ` + "`" + `to` + "`" + ` query parameters.\n- ` + "`" + `global` + "`" + `: In this mode the _total_ usage statistics are returned. For\ninstance, if the retention period of the DB is set to 2 years, usage\nstatistics of last 2 years will be returned.\n\nThe statistics can be limited to certain projects by passing ` + "`" + `project` + "`" + ` query,\nparameter.\n\nIf ` + "`" + `to` + "`" + ` query parameter is not provided, current time will be used. If ` + "`" + `from` + "`" + `\nquery parameter is not used, a default query window of 24 hours will be used.\nIt means if ` + "`" + `to` + "`" + ` is provided, ` + "`" + `from` + "`" + ` will be calculated as ` + "`" + `to` + "`" + ` - 24hrs.\n\nTo limit the number of fields in the response, use ` + "`" + `field` + "`" + ` query parameter. By default, all\nfields will be included in the response if they are _non-empty_.\n\nThe ` + "`" + `current` + "`" + ` usage mode can be slow query depending the requested\nwindow interval. This is mostly due to the fact that the CEEMS DB\nuses custom JSON types to store metric data and usage statistics\nneeds to aggregate metrics over these JSON types using custom aggregate\nfunctions which can be slow.\n\nTherefore the query results are cached for 15 min to avoid load on server.\nURL string is used as the cache key. Thus, the query parameters\n` + "`" + `from` + "`" + ` and ` + "`" + `to` + "`" + ` are rounded to the nearest timestamp that are\nmultiple of 900 sec (15 min). The first query will make a DB query and\ncache results and subsequent queries, for a given user and same URL\nquery parameters, will return the same cached result until the cache\nis invalidated after 15 min.",
+                "description": "This admin endpoint will return the number of compute units of _any_ user,\ncompute unit and/or project that match the query parameters. The current\nuser is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in the request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nThe query parameters are same as the ones of ` + "`" + `/units/admin` + "`" + ` endpoint and\nthe number of compute units is returned as the only element of ` + "`" + `data` + "`" + `.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "usage"
+                    "units"
                 ],
-                "summary": "Usage statistics",
+                "summary": "Admin endpoint for counting compute units.",
                 "parameters": [
                     {
                         "type": "string",
@@ -699,15 +898,14 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "current",
-                            "global"
-                        ],
-                        "type": "string",
-                        "description": "Whether to get usage stats within a period or global",
-                        "name": "mode",
-                        "in": "path",
-                        "required": true
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cluster ID",
+                        "name": "cluster_id",
+                        "in": "query"
                     },
                     {
                         "type": "array",
@@ -715,8 +913,8 @@ const docTemplate = `{
                             "type": "string"
                         },
                         "collectionFormat": "multi",
-                        "description": "cluster ID",
-                        "name": "cluster_id",
+                        "description": "Unit UUID",
+                        "name": "uuid",
                         "in": "query"
                     },
                     {
@@ -730,15 +928,30 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "From timestamp",
-                        "name": "from",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "User name",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to count running units",
+                        "name": "running",
                         "in": "query"
                     },
                     {
+                        "enum": [
+                            "active",
+                            "terminated",
+                            "all"
+                        ],
                         "type": "string",
-                        "description": "To timestamp",
-                        "name": "to",
+                        "description": "Status of units",
+                        "name": "status",
                         "in": "query"
                     },
                     {
@@ -747,8 +960,30 @@ const docTemplate = `{
                             "type": "string"
                         },
                         "collectionFormat": "multi",
-                        "description": "Fields to return in response",
-                        "name": "field",
+                        "description": "State of units",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag of units of form key:value",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To timestamp",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -756,7 +991,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.Response-models_Usage"
+                            "$ref": "#/definitions/http.Response-int"
                         }
                     },
                     "401": {
@@ -765,6 +1000,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.Response-any"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -774,21 +1015,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/usage/{mode}/admin": {
+        "/units/export": {
             "get": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "This admin endpoint will return the usage statistics of _queried_ user. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nA path parameter ` + "`" + `mode` + "`" + ` is required to return the kind of usage statistics.\nCurrently, two modes of statistics are supported:\n- ` + "`" + `current` + "`" + `: In this mode the usage between two time periods is returned\nbased on ` + "`" + `from` + "`" + ` and ` + "`" + `to` + "`" + ` query parameters.\n- ` + "`" + `global` + "`" + `: In this mode the _total_ usage statistics are returned. For\ninstance, if the retention period of the DB is set to 2 years, usage\nstatistics of last 2 years will be returned.\n\nThe statistics can be limited to certain projects by passing ` + "`" + `project` + "`" + ` query,\nparameter.\n\nIf ` + "`" + `to` + "`" + ` query parameter is not provided, current time will be used. If ` + "`" + `from` + "`" + `\nquery parameter is not used, a default query window of 24 hours will be used.\nIt means if ` + "`" + `to` + "`" + ` is provided, ` + "`" + `from` + "`" + ` will be calculated as ` + "`" + `to` + "`" + ` - 24hrs.\n\nTo limit the number of fields in the response, use ` + "`" + `field` + "`" + ` query parameter. By default, all\nfields will be included in the response if they are _non-empty_.\n\nThe ` + "`" + `current` + "`" + ` usage mode can be slow query depending the requested\nwindow interval. This is mostly due to the fact that the CEEMS DB\nuses custom JSON types to store metric data and usage statistics\nneeds to aggregate metrics over these JSON types using custom aggregate\nfunctions which can be slow.\n\nTherefore the query results are cached for 15 min to avoid load on server.\nURL string is used as the cache key. Thus, the query parameters\n` + "`" + `from` + "`" + ` and ` + "`" + `to` + "`" + ` are rounded to the nearest timestamp that are\nmultiple of 900 sec (15 min). The first query will make a DB query and\ncache results and subsequent queries, for a given user and same URL\nquery parameters, will return the same cached result until the cache\nis invalidated after 15 min.",
+                "description": "This user endpoint will stream compute units of the current user that\nmatch the query parameters as CSV. The current user is always identified\nby the header ` + "`" + `X-Grafana-User` + "`" + ` in the request.\n\nThe query parameters are same as the ones of ` + "`" + `/units` + "`" + ` endpoint. The\nfirst record of the response contains the names of the fields and the\ncompute units are streamed as they are read from the DB. Thus, this endpoint\nis suitable for exporting large number of compute units.",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "usage"
+                    "units"
                 ],
-                "summary": "Admin Usage statistics",
+                "summary": "User endpoint for exporting compute units as CSV",
                 "parameters": [
                     {
                         "type": "string",
@@ -797,24 +1038,13 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "enum": [
-                            "current",
-                            "global"
-                        ],
-                        "type": "string",
-                        "description": "Whether to get usage stats within a period or global",
-                        "name": "mode",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "array",
                         "items": {
                             "type": "string"
                         },
                         "collectionFormat": "multi",
-                        "description": "cluster ID",
+                        "description": "Cluster ID",
                         "name": "cluster_id",
                         "in": "query"
                     },
@@ -823,9 +1053,9 @@ const docTemplate = `{
                         "items": {
                             "type": "string"
                         },
-                        "collectionFormat": "csv",
-                        "description": "Project",
-                        "name": "project",
+                        "collectionFormat": "multi",
+                        "description": "Unit UUID",
+                        "name": "uuid",
                         "in": "query"
                     },
                     {
@@ -834,8 +1064,962 @@ const docTemplate = `{
                             "type": "string"
                         },
                         "collectionFormat": "multi",
-                        "description": "Username",
-                        "name": "user",
+                        "description": "Project",
+                        "name": "project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to export running units",
+                        "name": "running",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "terminated",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status of units",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "State of units",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag of units of form key:value",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To timestamp",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Fields to export",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Order of units of form column[:asc|desc]",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/units/export/admin": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This admin endpoint will stream compute units of _any_ user, compute unit\nand/or project that match the query parameters as CSV. The current user is\nalways identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in the request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nThe query parameters are same as the ones of ` + "`" + `/units/admin` + "`" + ` endpoint. The\nfirst record of the response contains the names of the fields and the\ncompute units are streamed as they are read from the DB. Thus, this endpoint\nis suitable for exporting large number of compute units, for instance, for\nannual reports.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "Admin endpoint for exporting compute units as CSV.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cluster ID",
+                        "name": "cluster_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Unit UUID",
+                        "name": "uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Project",
+                        "name": "project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "User name",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to export running units",
+                        "name": "running",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "terminated",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status of units",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "State of units",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag of units of form key:value",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To timestamp",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Fields to export",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Order of units of form column[:asc|desc]",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/units/ignore/admin": {
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This admin endpoint will set or unset the ignore flag of compute units\nidentified by ` + "`" + `uuid` + "`" + ` query parameters. The current user is always identified\nby the header ` + "`" + `X-Grafana-User` + "`" + ` in the request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nIgnored units are not returned by the units endpoints. When query parameter\n` + "`" + `ignore` + "`" + ` is ` + "`" + `false` + "`" + `, the ignore flag of the units is unset. When one or more\n` + "`" + `cluster_id` + "`" + ` query parameters are provided, only units of those clusters\nare updated.\n\nUsage of ignored units is subtracted from usage tables and it is added\nback when the ignore flag is unset. The response contains the number of\nupdated rows of units, usage and daily usage tables.\n",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "Admin endpoint to mark compute units as ignored",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Unit UUID",
+                        "name": "uuid",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cluster ID",
+                        "name": "cluster_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Ignore flag",
+                        "name": "ignore",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-models_AffectedRows"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/units/search": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This user endpoint will fetch compute units of the current user identified\nby query parameters in JSON body of the request. The current user is always\nidentified by the header ` + "`" + `X-Grafana-User` + "`" + ` in the request.\n\nThis endpoint is meant to fetch a large number of compute units that do not fit\nin the URL as ` + "`" + `uuid` + "`" + ` query parameters. The body must be a JSON object with query\nparameters of units endpoint as keys and list of their values as values, for\ninstance, ` + "`" + `{\"uuid\": [\"1000\", \"1001\"], \"project\": [\"foo\"]}` + "`" + `. Query parameters\nin the body are merged with the ones in the URL and they have the same effect as\nin units endpoint. Only compute units of the current user are returned. A maximum\nof 10000 ` + "`" + `uuid` + "`" + ` values can be provided in the body.\n",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "User endpoint for searching compute units",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Query parameters of units",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-models_Unit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/units/search/admin": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This admin endpoint will fetch compute units of _any_ user, compute units\nidentified by query parameters in JSON body of the request. The current\nuser is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in the request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nThis endpoint is meant to fetch a large number of compute units that do not fit\nin the URL as ` + "`" + `uuid` + "`" + ` query parameters. The body must be a JSON object with query\nparameters of units admin endpoint as keys and list of their values as values, for\ninstance, ` + "`" + `{\"uuid\": [\"1000\", \"1001\"], \"cluster_id\": [\"slurm-0\"]}` + "`" + `. Query parameters\nin the body are merged with the ones in the URL and they have the same effect as\nin units admin endpoint. A maximum of 10000 ` + "`" + `uuid` + "`" + ` values can be provided in the body.\n",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "Admin endpoint for searching compute units",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Query parameters of units",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-models_Unit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/units/verify": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This endpoint will check if the current user is the owner of the\nqueried UUIDs. The current user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nA response of 200 means that the current user is the owner of the queried UUIDs.\nAny other response code should be treated as the current user not being the owner\nof the queried units.\n\nThe ownership check passes if any of the following conditions are ` + "`" + `true` + "`" + `:\n- If the current user is the _direct_ owner of the compute unit.\n- If the current user belongs to the same account/project/namespace as\nthe compute unit. This means the users belonging to the same project can\naccess each others compute units.\n\nThe above checks must pass for **all** the queried units.\nIf the check does not pass for at least one queried unit, a response 403 will be\nreturned.\n\nAny 500 response codes should be treated as failed check as well.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "Verify unit ownership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Unit UUID",
+                        "name": "uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cluster ID",
+                        "name": "cluster_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Timestamps",
+                        "name": "time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/units/{uuid}/timeseries": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This endpoint will fetch time series of the queried metrics of the compute\nunit from the TSDB of the cluster between start and end times of the\ncompute unit. The current user is always identified by the header\n` + "`" + `X-Grafana-User` + "`" + ` in the request.\n\nThe current user must pass the same ownership check as the one of\n` + "`" + `/units/verify` + "`" + ` endpoint for the queried compute unit. For running compute\nunits, current time is used as end time.\n\nThe query parameter ` + "`" + `metric` + "`" + ` must be used to specify the names of metrics\nto fetch. The resolution of time series can be set using ` + "`" + `step` + "`" + ` query\nparameter which defaults to ` + "`" + `1m` + "`" + `. When the step results in too many points\nfor the life time of the compute unit, it will be increased accordingly.\nEach series is returned with its labels as a compute unit can have several series\nof the same metric, for instance, one for each node or GPU.\n\nIf the TSDB is not configured for the cluster, a response 503 will be returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "Endpoint for fetching time series of a compute unit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster ID",
+                        "name": "cluster_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Metric names",
+                        "name": "metric",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Query resolution step",
+                        "name": "step",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-models_Timeseries"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/usage/cache/admin": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This admin endpoint will remove cached results of usage queries. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nResults of usage queries are cached using the query URL, _e.g.,_\n` + "`" + `/api/v1/usage/current?cluster_id=slurm-0\u0026from=1735686000` + "`" + ` as key. If\none or more ` + "`" + `prefix` + "`" + ` query parameters are provided, only cached results\nwhose key starts with one of the prefixes are removed. Else entire\ncache is flushed. Query parameters in the keys are always sorted by\ntheir names.\n",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usage"
+                ],
+                "summary": "Admin endpoint to invalidate usage cache",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cache key prefix",
+                        "name": "prefix",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/usage/{mode}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This endpoint will return the usage statistics current user. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nA path parameter ` + "`" + `mode` + "`" + ` is required to return the kind of usage statistics.\nCurrently, two modes of statistics are supported:\n- ` + "`" + `current` + "`" + `: In this mode the usage between two time periods is returned\nbased on ` + "`" + `from` + "`" + ` and ` + "`" + `to` + "`" + ` query parameters.\n- ` + "`" + `global` + "`" + `: In this mode the _total_ usage statistics are returned. For\ninstance, if the retention period of the DB is set to 2 years, usage\nstatistics of last 2 years will be returned.\n\nThe statistics can be limited to certain projects by passing ` + "`" + `project` + "`" + ` query,\nparameter.\n\nIn ` + "`" + `current` + "`" + ` mode, the statistics can be limited to only running or only finished\ncompute units by passing ` + "`" + `status` + "`" + ` query parameter with ` + "`" + `active` + "`" + ` or ` + "`" + `terminated` + "`" + `\nvalue, respectively. By default, all units are included.\n\nIn ` + "`" + `current` + "`" + ` mode, the statistics are always aggregated by ` + "`" + `username` + "`" + ` and ` + "`" + `project` + "`" + `.\nAdditional dimensions can be added using ` + "`" + `groupby` + "`" + ` query parameter which must be one\nof ` + "`" + `cluster_id` + "`" + `, ` + "`" + `resource_manager` + "`" + `, ` + "`" + `project` + "`" + `, ` + "`" + `groupname` + "`" + `, ` + "`" + `username` + "`" + ` or ` + "`" + `day` + "`" + `. The\ncolumns used for aggregating statistics are returned in ` + "`" + `groupby` + "`" + ` of the response.\nWhen grouped by ` + "`" + `day` + "`" + `, usage is split into daily buckets over the query window and\nthe date of each bucket is returned in ` + "`" + `day` + "`" + ` field.\nBuckets are computed from daily usage statistics which do not have ` + "`" + `tag` + "`" + ` and\n` + "`" + `status` + "`" + ` of units and hence, these query parameters cannot be used along with it.\n\nIf ` + "`" + `to` + "`" + ` query parameter is not provided, current time will be used. If ` + "`" + `from` + "`" + `\nquery parameter is not used, a default query window of 24 hours will be used.\nIt means if ` + "`" + `to` + "`" + ` is provided, ` + "`" + `from` + "`" + ` will be calculated as ` + "`" + `to` + "`" + ` - 24hrs.\n\nTo limit the number of fields in the response, use ` + "`" + `field` + "`" + ` query parameter. By default, all\nfields will be included in the response if they are _non-empty_.\n\nThe ` + "`" + `current` + "`" + ` usage mode can be slow query depending the requested\nwindow interval. This is mostly due to the fact that the CEEMS DB\nuses custom JSON types to store metric data and usage statistics\nneeds to aggregate metrics over these JSON types using custom aggregate\nfunctions which can be slow.\n\nTherefore the query results are cached for 15 min, by default, to avoid load on\nserver. URL string is used as the cache key. Thus, the query parameters\n` + "`" + `from` + "`" + ` and ` + "`" + `to` + "`" + ` are rounded to the nearest timestamp that are\nmultiple of cache TTL. The first query will make a DB query and\ncache results and subsequent queries, for a given user and same URL\nquery parameters, will return the same cached result until the cache\nis invalidated after cache TTL. Cache TTL can be configured using\n` + "`" + `web.cache_ttl` + "`" + ` in the configuration file.\n\nCached responses of ` + "`" + `current` + "`" + ` mode include an ` + "`" + `ETag` + "`" + ` header. When the ETag is\nsent back in ` + "`" + `If-None-Match` + "`" + ` header and the usage has not changed, 304 response\nis returned without body.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usage"
+                ],
+                "summary": "Usage statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "current",
+                            "global"
+                        ],
+                        "type": "string",
+                        "description": "Whether to get usage stats within a period or global",
+                        "name": "mode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "cluster ID",
+                        "name": "cluster_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Project",
+                        "name": "project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To timestamp",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "terminated",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status of units",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag of units of form key:value. Only supported in current mode",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Additional columns to group usage by",
+                        "name": "groupby",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Fields to return in response",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include units of fields in response",
+                        "name": "include_units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-models_Usage"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/usage/{mode}/admin": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This admin endpoint will return the usage statistics of _queried_ user. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nA path parameter ` + "`" + `mode` + "`" + ` is required to return the kind of usage statistics.\nCurrently, two modes of statistics are supported:\n- ` + "`" + `current` + "`" + `: In this mode the usage between two time periods is returned\nbased on ` + "`" + `from` + "`" + ` and ` + "`" + `to` + "`" + ` query parameters.\n- ` + "`" + `global` + "`" + `: In this mode the _total_ usage statistics are returned. For\ninstance, if the retention period of the DB is set to 2 years, usage\nstatistics of last 2 years will be returned.\n\nThe statistics can be limited to certain projects by passing ` + "`" + `project` + "`" + ` query,\nparameter.\n\nIn ` + "`" + `current` + "`" + ` mode, the statistics can be limited to only running or only finished\ncompute units by passing ` + "`" + `status` + "`" + ` query parameter with ` + "`" + `active` + "`" + ` or ` + "`" + `terminated` + "`" + `\nvalue, respectively. By default, all units are included.\n\nIn ` + "`" + `current` + "`" + ` mode, the statistics are always aggregated by ` + "`" + `username` + "`" + ` and ` + "`" + `project` + "`" + `.\nAdditional dimensions can be added using ` + "`" + `groupby` + "`" + ` query parameter which must be one\nof ` + "`" + `cluster_id` + "`" + `, ` + "`" + `resource_manager` + "`" + `, ` + "`" + `project` + "`" + `, ` + "`" + `groupname` + "`" + `, ` + "`" + `username` + "`" + ` or ` + "`" + `day` + "`" + `. The\ncolumns used for aggregating statistics are returned in ` + "`" + `groupby` + "`" + ` of the response.\nWhen grouped by ` + "`" + `day` + "`" + `, usage is split into daily buckets over the query window and\nthe date of each bucket is returned in ` + "`" + `day` + "`" + ` field.\nBuckets are computed from daily usage statistics which do not have ` + "`" + `tag` + "`" + ` and\n` + "`" + `status` + "`" + ` of units and hence, these query parameters cannot be used along with it.\n\nIf ` + "`" + `to` + "`" + ` query parameter is not provided, current time will be used. If ` + "`" + `from` + "`" + `\nquery parameter is not used, a default query window of 24 hours will be used.\nIt means if ` + "`" + `to` + "`" + ` is provided, ` + "`" + `from` + "`" + ` will be calculated as ` + "`" + `to` + "`" + ` - 24hrs.\n\nTo limit the number of fields in the response, use ` + "`" + `field` + "`" + ` query parameter. By default, all\nfields will be included in the response if they are _non-empty_.\n\nThe ` + "`" + `current` + "`" + ` usage mode can be slow query depending the requested\nwindow interval. This is mostly due to the fact that the CEEMS DB\nuses custom JSON types to store metric data and usage statistics\nneeds to aggregate metrics over these JSON types using custom aggregate\nfunctions which can be slow.\n\nTherefore the query results are cached for 15 min, by default, to avoid load on\nserver. URL string is used as the cache key. Thus, the query parameters\n` + "`" + `from` + "`" + ` and ` + "`" + `to` + "`" + ` are rounded to the nearest timestamp that are\nmultiple of cache TTL. The first query will make a DB query and\ncache results and subsequent queries, for a given user and same URL\nquery parameters, will return the same cached result until the cache\nis invalidated after cache TTL. Cache TTL can be configured using\n` + "`" + `web.cache_ttl` + "`" + ` in the configuration file.\n\nCached responses of ` + "`" + `current` + "`" + ` mode include an ` + "`" + `ETag` + "`" + ` header. When the ETag is\nsent back in ` + "`" + `If-None-Match` + "`" + ` header and the usage has not changed, 304 response\nis returned without body.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usage"
+                ],
+                "summary": "Admin Usage statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "current",
+                            "global"
+                        ],
+                        "type": "string",
+                        "description": "Whether to get usage stats within a period or global",
+                        "name": "mode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "cluster ID",
+                        "name": "cluster_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Project",
+                        "name": "project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Username",
+                        "name": "user",
                         "in": "query"
                     },
                     {
@@ -850,6 +2034,37 @@ const docTemplate = `{
                         "name": "to",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "active",
+                            "terminated",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Status of units",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag of units of form key:value. Only supported in current mode",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Additional columns to group usage by",
+                        "name": "groupby",
+                        "in": "query"
+                    },
                     {
                         "type": "array",
                         "items": {
@@ -859,6 +2074,12 @@ const docTemplate = `{
                         "description": "Fields to return in response",
                         "name": "field",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include units of fields in response",
+                        "name": "include_units",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -868,6 +2089,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.Response-models_Usage"
                         }
                     },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -896,7 +2123,7 @@ const docTemplate = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "This endpoint will show details of the current user. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nThe details include list of projects that user is currently a part of.\n",
+                "description": "This endpoint will show details of the current user. The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nThe details include list of projects that user is currently a part of.\nThe role of the user in each project is included in ` + "`" + `roles` + "`" + `, when available.\n",
                 "produces": [
                     "application/json"
                 ],
@@ -951,74 +2178,318 @@ const docTemplate = `{
                     {
                         "BasicAuth": []
                     }
-                ],
-                "description": "This endpoint will show details of the queried user(s). The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nWhen the query parameter ` + "`" + `user` + "`" + ` is empty, all users will be returned\nin the response.\n\nThe details include list of projects that user is currently a part of.\n",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Admin endpoint for fetching user details of _any_ user.",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Current user name",
-                        "name": "X-Grafana-User",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "User name",
-                        "name": "user",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "Cluster ID",
-                        "name": "cluster_id",
-                        "in": "query"
+                ],
+                "description": "This endpoint will show details of the queried user(s). The\ncurrent user is always identified by the header ` + "`" + `X-Grafana-User` + "`" + ` in\nthe request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nWhen the query parameter ` + "`" + `user` + "`" + ` is empty, all users will be returned\nin the response.\n\nThe details include list of projects that user is currently a part of.\nThe role of the user in each project is included in ` + "`" + `roles` + "`" + `, when available.\n",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Admin endpoint for fetching user details of _any_ user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "User name",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cluster ID",
+                        "name": "cluster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-models_User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{name}/admin": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "This admin endpoint will delete or anonymize all the data of the user\nin the path across all clusters. The current user is always identified\nby the header ` + "`" + `X-Grafana-User` + "`" + ` in the request.\n\nThe user who is making the request must be in the list of admin users\nconfigured for the server.\n\nWhen query parameter ` + "`" + `mode` + "`" + ` is ` + "`" + `delete` + "`" + `, compute units, usage and user\nentries of the user are deleted and the user is removed from projects.\nWhen ` + "`" + `mode` + "`" + ` is ` + "`" + `anonymize` + "`" + `, user name is replaced by its SHA-256 hash\nin all these entries so that aggregate usage statistics are preserved.\nWhen one or more ` + "`" + `cluster_id` + "`" + ` query parameters are provided, only data\nof those clusters is purged.\n\nThe response contains the number of affected rows in each table. Note\nthat data that is still available on the cluster will be fetched again\nby the server in the next update.\n",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Admin endpoint to purge data of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current user name",
+                        "name": "X-Grafana-User",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "delete",
+                            "anonymize"
+                        ],
+                        "type": "string",
+                        "default": "delete",
+                        "description": "Purge mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Cluster ID",
+                        "name": "cluster_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-models_AffectedRows"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Response-any"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.Pagination": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "next_offset": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.Response-any": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {}
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errorType": {
+                    "$ref": "#/definitions/http.errorType"
+                },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.Response-int": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errorType": {
+                    "$ref": "#/definitions/http.errorType"
+                },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.Response-models_AffectedRows": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AffectedRows"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errorType": {
+                    "$ref": "#/definitions/http.errorType"
+                },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/http.Response-models_User"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/http.Response-any"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/http.Response-any"
-                        }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "http.Response-any": {
+        },
+        "http.Response-models_BuildInfo": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {
                     "type": "array",
-                    "items": {}
+                    "items": {
+                        "$ref": "#/definitions/models.BuildInfo"
+                    }
                 },
                 "error": {
                     "type": "string"
@@ -1026,9 +2497,24 @@ const docTemplate = `{
                 "errorType": {
                     "$ref": "#/definitions/http.errorType"
                 },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
                 "status": {
                     "type": "string"
                 },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "warnings": {
                     "type": "array",
                     "items": {
@@ -1040,6 +2526,9 @@ const docTemplate = `{
         "http.Response-models_Cluster": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {
                     "type": "array",
                     "items": {
@@ -1052,9 +2541,24 @@ const docTemplate = `{
                 "errorType": {
                     "$ref": "#/definitions/http.errorType"
                 },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
                 "status": {
                     "type": "string"
                 },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "warnings": {
                     "type": "array",
                     "items": {
@@ -1066,6 +2570,9 @@ const docTemplate = `{
         "http.Response-models_Project": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {
                     "type": "array",
                     "items": {
@@ -1078,9 +2585,24 @@ const docTemplate = `{
                 "errorType": {
                     "$ref": "#/definitions/http.errorType"
                 },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
                 "status": {
                     "type": "string"
                 },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "warnings": {
                     "type": "array",
                     "items": {
@@ -1092,6 +2614,9 @@ const docTemplate = `{
         "http.Response-models_Stat": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {
                     "type": "array",
                     "items": {
@@ -1104,9 +2629,68 @@ const docTemplate = `{
                 "errorType": {
                     "$ref": "#/definitions/http.errorType"
                 },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "http.Response-models_Timeseries": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Timeseries"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errorType": {
+                    "$ref": "#/definitions/http.errorType"
+                },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
                 "status": {
                     "type": "string"
                 },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "warnings": {
                     "type": "array",
                     "items": {
@@ -1118,6 +2702,9 @@ const docTemplate = `{
         "http.Response-models_Unit": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {
                     "type": "array",
                     "items": {
@@ -1130,9 +2717,24 @@ const docTemplate = `{
                 "errorType": {
                     "$ref": "#/definitions/http.errorType"
                 },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
                 "status": {
                     "type": "string"
                 },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "warnings": {
                     "type": "array",
                     "items": {
@@ -1144,6 +2746,9 @@ const docTemplate = `{
         "http.Response-models_Usage": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {
                     "type": "array",
                     "items": {
@@ -1156,9 +2761,24 @@ const docTemplate = `{
                 "errorType": {
                     "$ref": "#/definitions/http.errorType"
                 },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
                 "status": {
                     "type": "string"
                 },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "warnings": {
                     "type": "array",
                     "items": {
@@ -1170,6 +2790,9 @@ const docTemplate = `{
         "http.Response-models_User": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
                 "data": {
                     "type": "array",
                     "items": {
@@ -1182,9 +2805,24 @@ const docTemplate = `{
                 "errorType": {
                     "$ref": "#/definitions/http.errorType"
                 },
+                "groupby": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/http.Pagination"
+                },
                 "status": {
                     "type": "string"
                 },
+                "units": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "warnings": {
                     "type": "array",
                     "items": {
@@ -1222,10 +2860,52 @@ const docTemplate = `{
                 "errorNotAcceptable"
             ]
         },
+        "models.AffectedRows": {
+            "type": "object",
+            "properties": {
+                "rows": {
+                    "description": "Number of affected rows",
+                    "type": "integer"
+                },
+                "table": {
+                    "description": "Name of the DB table",
+                    "type": "string"
+                }
+            }
+        },
         "models.Allocation": {
             "type": "object",
             "additionalProperties": true
         },
+        "models.BuildInfo": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "description": "Git branch of the build",
+                    "type": "string"
+                },
+                "buildDate": {
+                    "description": "Date of the build",
+                    "type": "string"
+                },
+                "buildUser": {
+                    "description": "User who made the build",
+                    "type": "string"
+                },
+                "goVersion": {
+                    "description": "Go version used for the build",
+                    "type": "string"
+                },
+                "revision": {
+                    "description": "Git commit of the build",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version of the build",
+                    "type": "string"
+                }
+            }
+        },
         "models.Cluster": {
             "type": "object",
             "properties": {
@@ -1258,6 +2938,14 @@ const docTemplate = `{
                     "description": "Name of the resource manager that owns project. Eg slurm, openstack, kubernetes, etc",
                     "type": "string"
                 },
+                "roles": {
+                    "description": "Map of users of the project to their roles in the project",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Roles"
+                        }
+                    ]
+                },
                 "tags": {
                     "description": "List of meta data tags of the project",
                     "type": "array",
@@ -1274,6 +2962,10 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Roles": {
+            "type": "object",
+            "additionalProperties": true
+        },
         "models.Stat": {
             "type": "object",
             "properties": {
@@ -1311,6 +3003,27 @@ const docTemplate = `{
             "type": "object",
             "additionalProperties": true
         },
+        "models.Timeseries": {
+            "type": "object",
+            "properties": {
+                "labels": {
+                    "description": "Labels of the series except metric name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "metric": {
+                    "description": "Name of the metric",
+                    "type": "string"
+                },
+                "values": {
+                    "description": "Pairs of timestamp and value of the series",
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
         "models.Unit": {
             "type": "object",
             "properties": {
@@ -1535,6 +3248,18 @@ const docTemplate = `{
                     "description": "Identifier of the resource manager that owns compute unit. It is used to differentiate multiple clusters of same resource manager.",
                     "type": "string"
                 },
+                "day": {
+                    "description": "Day of usage when usage is grouped by day. It is computed at query time and not stored in DB",
+                    "type": "string"
+                },
+                "efficiency": {
+                    "description": "CPU and GPU efficiencies computed from average usages at query time. It is not stored in DB",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MetricMap"
+                        }
+                    ]
+                },
                 "groupname": {
                     "description": "User group",
                     "type": "string"
@@ -1649,6 +3374,14 @@ const docTemplate = `{
                     "description": "Name of the resource manager that owns user. Eg slurm, openstack, kubernetes, etc",
                     "type": "string"
                 },
+                "roles": {
+                    "description": "Map of projects of the user to user's role in the project",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Roles"
+                        }
+                    ]
+                },
                 "tags": {
                     "description": "List of meta data tags of the user",
                     "type": "array",
@@ -1678,7 +3411,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "",
 	Schemes:          []string{},
 	Title:            "CEEMS API",
-	Description:      "OpenAPI specification (OAS) for the CEEMS REST API.\n\nSee the Interactive Docs to try CEEMS API methods without writing code, and get\nthe complete schema of resources exposed by the API.\n\nIf basic auth is enabled, all the endpoints require authentication.\n\nAll the endpoints, except `health`, `swagger`, `debug` and `demo`,\nmust send a user-agent header.\n\nTimestamps must be specified in milliseconds, unless otherwise specified.",
+	Description:      "OpenAPI specification (OAS) for the CEEMS REST API.\n\nSee the Interactive Docs to try CEEMS API methods without writing code, and get\nthe complete schema of resources exposed by the API.\n\nIf basic auth is enabled, all the endpoints require authentication.\n\nAll the endpoints, except `health`, `status/buildinfo`, `swagger`, `debug` and `demo`,\nmust send a user-agent header.\n\nWhen API keys are configured, clients can authenticate using the API key\nin `X-Api-Key` header instead of user header. API keys grant read only access.\n\nTimestamps must be specified in milliseconds, unless otherwise specified.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -53,46 +53,139 @@ const (
 
 // Custom errors.
 var (
-	errNoUser            = errors.New("no user identified")
-	errNoPrivs           = errors.New("current user does not have admin privileges")
-	errInvalidRequest    = errors.New("invalid request")
-	errInvalidQueryField = errors.New("invalid query fields")
-	errMissingUUIDs      = errors.New("uuids missing in the request")
-	errNoAuth            = errors.New("user do not have permissions on uuids")
+	errNoUser             = errors.New("no user identified")
+	errNoPrivs            = errors.New("current user does not have admin privileges")
+	errInvalidAPIKey      = errors.New("invalid API key")
+	errReadOnlyAPIKey     = errors.New("API keys grant only read only access")
+	errInvalidRequest     = errors.New("invalid request")
+	errInvalidQueryField  = errors.New("invalid query fields")
+	errInvalidStatus      = errors.New("invalid status, must be one of active, terminated or all")
+	errInvalidState       = errors.New("invalid state of units")
+	errInvalidThreshold   = errors.New("invalid metric threshold")
+	errInvalidLimit       = errors.New("invalid limit, must be a positive integer")
+	errInvalidOffset      = errors.New("invalid offset, must be a non-negative integer")
+	errInvalidCursor      = errors.New("invalid cursor")
+	errCursorWithOffset   = errors.New("cursor and offset cannot be used together")
+	errInvalidGroupBy     = errors.New("invalid groupby, must be one of cluster_id, resource_manager, project, groupname, username or day")
+	errMissingUUIDs       = errors.New("uuids missing in the request")
+	errNoAuth             = errors.New("user do not have permissions on uuids")
+	errMissingMetrics     = errors.New("metrics missing in the request")
+	errInvalidMetric      = errors.New("invalid metric name")
+	errInvalidStep        = errors.New("invalid step, must be a positive duration")
+	errUnitNotFound       = errors.New("unit not found")
+	errNoTSDB             = errors.New("no TSDB configured for the cluster")
+	errInvalidPurgeMode   = errors.New("invalid mode, must be one of delete or anonymize")
+	errInvalidIgnore      = errors.New("invalid ignore, must be a boolean")
+	errInvalidTag         = errors.New("invalid tag, must be of form key:value")
+	errInvalidOrder       = errors.New("invalid order, must be of form column[:asc|desc]")
+	errCursorWithOrder    = errors.New("cursor and order cannot be used together")
+	errInvalidSearchBody  = errors.New("invalid search body, must be a JSON object of lists of strings")
+	errTooManyUUIDs       = errors.New("too many uuids in the request")
+	errTagNotSupported    = errors.New("tag query parameter is not supported as usage statistics do not have tags")
+	errStatusNotSupported = errors.New("status query parameter is not supported as daily usage statistics do not have status of units")
 )
 
-// Return error response for by setting errorString and errorType in response.
-func errorResponse[T any](w http.ResponseWriter, apiErr *apiError, logger *slog.Logger, data []T) {
-	var code int
+// errorCode is a machine stable code of an error in API response. Code is
+// formed as `CEEMS-<HTTP status code><index>` where index is two digit index
+// of error for a given HTTP status code. Index `00` is used for errors that are
+// not listed in errorCodes.
+type errorCode struct {
+	err    error
+	status int
+	index  int
+}
+
+// errorCodes is the catalog of error codes. Existing codes must never be
+// modified or reused as clients may depend on them.
+var errorCodes = []errorCode{
+	{errInvalidRequest, http.StatusBadRequest, 1},
+	{errInvalidQueryField, http.StatusBadRequest, 2},
+	{errInvalidStatus, http.StatusBadRequest, 3},
+	{errInvalidState, http.StatusBadRequest, 4},
+	{errInvalidThreshold, http.StatusBadRequest, 5},
+	{errInvalidLimit, http.StatusBadRequest, 6},
+	{errInvalidOffset, http.StatusBadRequest, 7},
+	{errInvalidCursor, http.StatusBadRequest, 8},
+	{errCursorWithOffset, http.StatusBadRequest, 9},
+	{errInvalidGroupBy, http.StatusBadRequest, 10},
+	{errMissingUUIDs, http.StatusBadRequest, 11},
+	{errMissingMetrics, http.StatusBadRequest, 12},
+	{errInvalidMetric, http.StatusBadRequest, 13},
+	{errInvalidStep, http.StatusBadRequest, 14},
+	{errInvalidPurgeMode, http.StatusBadRequest, 15},
+	{ErrMaxQueryWindow, http.StatusBadRequest, 16},
+	{ErrMalformedTimeStamp, http.StatusBadRequest, 17},
+	{errInvalidIgnore, http.StatusBadRequest, 18},
+	{errInvalidTag, http.StatusBadRequest, 19},
+	{errInvalidOrder, http.StatusBadRequest, 20},
+	{errCursorWithOrder, http.StatusBadRequest, 21},
+	{errInvalidSearchBody, http.StatusBadRequest, 22},
+	{errTooManyUUIDs, http.StatusBadRequest, 23},
+	{errTagNotSupported, http.StatusBadRequest, 24},
+	{errStatusNotSupported, http.StatusBadRequest, 25},
+	{errNoUser, http.StatusUnauthorized, 1},
+	{errInvalidAPIKey, http.StatusUnauthorized, 2},
+	{errNoPrivs, http.StatusForbidden, 1},
+	{errReadOnlyAPIKey, http.StatusForbidden, 2},
+	{errNoAuth, http.StatusForbidden, 3},
+	{errUnitNotFound, http.StatusNotFound, 1},
+	{errNoTSDB, http.StatusServiceUnavailable, 1},
+}
+
+// String returns the code.
+func (c errorCode) String() string {
+	return fmt.Sprintf("CEEMS-%d%02d", c.status, c.index)
+}
 
-	switch apiErr.typ { //nolint:exhaustive
+// apiErrorCode returns the code of error for a given HTTP status code.
+func apiErrorCode(err error, status int) string {
+	for _, c := range errorCodes {
+		if c.status == status && errors.Is(err, c.err) {
+			return c.String()
+		}
+	}
+
+	return errorCode{status: status}.String()
+}
+
+// apiErrorStatus returns the HTTP status code of an error type.
+func apiErrorStatus(typ errorType) int {
+	switch typ { //nolint:exhaustive
 	case errorBadData:
-		code = http.StatusBadRequest
+		return http.StatusBadRequest
 	case errorUnauthorized:
-		code = http.StatusUnauthorized
+		return http.StatusUnauthorized
 	case errorForbidden:
-		code = http.StatusForbidden
+		return http.StatusForbidden
 	case errorExec:
-		code = http.StatusUnprocessableEntity
+		return http.StatusUnprocessableEntity
 	case errorCanceled:
-		code = statusClientClosedConnection
+		return statusClientClosedConnection
 	case errorTimeout:
-		code = http.StatusServiceUnavailable
+		return http.StatusServiceUnavailable
 	case errorInternal:
-		code = http.StatusInternalServerError
+		return http.StatusInternalServerError
 	case errorNotFound:
-		code = http.StatusNotFound
+		return http.StatusNotFound
+	case errorUnavailable:
+		return http.StatusServiceUnavailable
 	case errorNotAcceptable:
-		code = http.StatusNotAcceptable
+		return http.StatusNotAcceptable
 	default:
-		code = http.StatusInternalServerError
+		return http.StatusInternalServerError
 	}
+}
+
+// Return error response for by setting errorString and errorType in response.
+func errorResponse[T any](w http.ResponseWriter, apiErr *apiError, logger *slog.Logger, data []T) {
+	code := apiErrorStatus(apiErr.typ)
 
 	w.WriteHeader(code)
 
 	response := Response[T]{
 		Status:    "error",
 		ErrorType: apiErr.typ,
+		Code:      apiErrorCode(apiErr.err, code),
 		Error:     apiErr.err.Error(),
 		Data:      data,
 	}
@@ -4,13 +4,74 @@
 package http
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestApiError(t *testing.T) {
 	e := apiError{typ: errorBadData, err: errors.New("bad data")}
 	assert.Equal(t, "bad_data: bad data", e.Error())
 }
+
+func TestApiErrorCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{
+			name:   "cataloged error",
+			err:    errNoPrivs,
+			status: http.StatusForbidden,
+			code:   "CEEMS-40301",
+		},
+		{
+			name:   "wrapped cataloged error",
+			err:    fmt.Errorf("query parameter 'from': %w", ErrMalformedTimeStamp),
+			status: http.StatusBadRequest,
+			code:   "CEEMS-40017",
+		},
+		{
+			name:   "unknown error",
+			err:    errors.New("failed to query DB"),
+			status: http.StatusInternalServerError,
+			code:   "CEEMS-50000",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.code, apiErrorCode(test.err, test.status), test.name)
+	}
+}
+
+func TestErrorResponseCode(t *testing.T) {
+	w := httptest.NewRecorder()
+	errorResponse[any](w, &apiError{errorUnauthorized, errNoUser}, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	var response Response[any]
+
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "CEEMS-40101", response.Code)
+	assert.Equal(t, errorUnauthorized, response.ErrorType)
+}
+
+func TestErrorCodesUnique(t *testing.T) {
+	codes := make(map[string]bool)
+
+	for _, c := range errorCodes {
+		assert.False(t, codes[c.String()], "duplicate error code %s", c)
+
+		codes[c.String()] = true
+	}
+}
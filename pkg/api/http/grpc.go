@@ -0,0 +1,393 @@
+//go:build cgo
+// +build cgo
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/pkg/api/base"
+	"github.com/mahendrapaipuri/ceems/pkg/api/grpcpb"
+	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// grpcService implements CEEMS gRPC service. RPCs use the same validation and
+// queriers as HTTP API and requests are authenticated by the interceptors of
+// gRPC server.
+type grpcService struct {
+	grpcpb.UnimplementedCEEMSServer
+
+	server      *CEEMSServer
+	routePrefix string
+}
+
+// newGRPCServer returns a HTTP server that serves CEEMS gRPC service. Both
+// HTTP/2 over TLS and cleartext HTTP/2 (h2c) are supported.
+func newGRPCServer(addr string, server *CEEMSServer, routePrefix string) *http.Server {
+	service := &grpcService{server: server, routePrefix: routePrefix}
+
+	// Requests are rate limited before authenticating them like HTTP requests
+	var interceptors []grpc.UnaryServerInterceptor
+	if server.limiter != nil {
+		interceptors = append(interceptors, service.rateLimitInterceptor)
+	}
+
+	interceptors = append(interceptors, server.amw.UnaryInterceptor)
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	grpcpb.RegisterCEEMSServer(grpcServer, service)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           h2c.NewHandler(grpcServer, &http2.Server{}),
+		ReadHeaderTimeout: 2 * time.Second,
+	}
+}
+
+// Units implements CEEMS gRPC service.
+func (s *grpcService) Units(ctx context.Context, req *grpcpb.UnitsRequest) (*grpcpb.UnitsResponse, error) {
+	path := unitsResourceName
+	if req.GetAdmin() {
+		path += "/admin"
+	}
+
+	r, user := s.request(ctx, path, paramsToValues(req.GetParams()))
+
+	// Admin users can query units of any user
+	queriedUsers := []string{user.dashboard}
+	if req.GetAdmin() {
+		queriedUsers = r.URL.Query()["user"]
+	}
+
+	result, apiErr := s.server.queryUnits(queriedUsers, r)
+	if apiErr != nil {
+		return nil, grpcError(apiErr)
+	}
+
+	units := make([]*grpcpb.Unit, len(result.units))
+
+	for i, unit := range result.units {
+		allocation, err := structpb.NewStruct(unit.Allocation)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert allocation of unit %s: %v", unit.UUID, err)
+		}
+
+		tags, err := structpb.NewStruct(unit.Tags)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert tags of unit %s: %v", unit.UUID, err)
+		}
+
+		units[i] = &grpcpb.Unit{
+			ClusterId:              unit.ClusterID,
+			ResourceManager:        unit.ResourceManager,
+			Uuid:                   unit.UUID,
+			Name:                   unit.Name,
+			Project:                unit.Project,
+			Groupname:              unit.Group,
+			Username:               unit.User,
+			CreatedAt:              unit.CreatedAt,
+			StartedAt:              unit.StartedAt,
+			EndedAt:                unit.EndedAt,
+			CreatedAtTs:            unit.CreatedAtTS,
+			StartedAtTs:            unit.StartedAtTS,
+			EndedAtTs:              unit.EndedAtTS,
+			Elapsed:                unit.Elapsed,
+			State:                  unit.State,
+			Allocation:             allocation,
+			TotalTimeSeconds:       metricMapToProto(unit.TotalTime),
+			AvgCpuUsage:            metricMapToProto(unit.AveCPUUsage),
+			AvgCpuMemUsage:         metricMapToProto(unit.AveCPUMemUsage),
+			TotalCpuEnergyUsageKwh: metricMapToProto(unit.TotalCPUEnergyUsage),
+			TotalCpuEmissionsGms:   metricMapToProto(unit.TotalCPUEmissions),
+			AvgGpuUsage:            metricMapToProto(unit.AveGPUUsage),
+			AvgGpuMemUsage:         metricMapToProto(unit.AveGPUMemUsage),
+			TotalGpuEnergyUsageKwh: metricMapToProto(unit.TotalGPUEnergyUsage),
+			TotalGpuEmissionsGms:   metricMapToProto(unit.TotalGPUEmissions),
+			TotalIoWriteStats:      metricMapToProto(unit.TotalIOWriteStats),
+			TotalIoReadStats:       metricMapToProto(unit.TotalIOReadStats),
+			TotalIngressStats:      metricMapToProto(unit.TotalIngressStats),
+			TotalOutgressStats:     metricMapToProto(unit.TotalOutgressStats),
+			Tags:                   tags,
+		}
+	}
+
+	return &grpcpb.UnitsResponse{
+		Data:       units,
+		Warnings:   result.warnings,
+		Units:      fieldUnits[models.Unit](r),
+		Pagination: paginationToProto(result.pagination),
+	}, nil
+}
+
+// Usage implements CEEMS gRPC service.
+func (s *grpcService) Usage(ctx context.Context, req *grpcpb.UsageRequest) (*grpcpb.UsageResponse, error) {
+	mode := req.GetMode()
+	if mode != currentUsage && mode != globalUsage {
+		return nil, grpcError(&apiError{errorBadData, errInvalidRequest})
+	}
+
+	path := fmt.Sprintf("%s/%s", usageResourceName, mode)
+	if req.GetAdmin() {
+		path += "/admin"
+	}
+
+	r, user := s.request(ctx, path, paramsToValues(req.GetParams()))
+
+	// Get fields query parameters if any
+	queriedFields := s.server.getQueriedFields(r.URL.Query(), base.UsageDBTableColNames)
+	if len(queriedFields) == 0 {
+		s.server.logger.Error("Invalid query fields", "loggedUser", user.dashboard)
+
+		return nil, grpcError(&apiError{errorBadData, errInvalidQueryField})
+	}
+
+	// Admin users can query usage of any user
+	users := []string{user.dashboard}
+	if req.GetAdmin() {
+		users = r.URL.Query()["user"]
+	}
+
+	var result *usageResult
+
+	var apiErr *apiError
+
+	if mode == currentUsage {
+		result, apiErr = s.server.fetchCurrentUsage(users, queriedFields, r)
+	} else {
+		result, apiErr = s.server.fetchGlobalUsage(users, queriedFields, r)
+	}
+
+	if apiErr != nil {
+		return nil, grpcError(apiErr)
+	}
+
+	usage := make([]*grpcpb.Usage, len(result.usage))
+
+	for i, u := range result.usage {
+		usage[i] = &grpcpb.Usage{
+			ClusterId:              u.ClusterID,
+			ResourceManager:        u.ResourceManager,
+			NumUnits:               u.NumUnits,
+			Project:                u.Project,
+			Groupname:              u.Group,
+			Username:               u.User,
+			TotalTimeSeconds:       metricMapToProto(u.TotalTime),
+			AvgCpuUsage:            metricMapToProto(u.AveCPUUsage),
+			AvgCpuMemUsage:         metricMapToProto(u.AveCPUMemUsage),
+			TotalCpuEnergyUsageKwh: metricMapToProto(u.TotalCPUEnergyUsage),
+			TotalCpuEmissionsGms:   metricMapToProto(u.TotalCPUEmissions),
+			AvgGpuUsage:            metricMapToProto(u.AveGPUUsage),
+			AvgGpuMemUsage:         metricMapToProto(u.AveGPUMemUsage),
+			TotalGpuEnergyUsageKwh: metricMapToProto(u.TotalGPUEnergyUsage),
+			TotalGpuEmissionsGms:   metricMapToProto(u.TotalGPUEmissions),
+			TotalIoWriteStats:      metricMapToProto(u.TotalIOWriteStats),
+			TotalIoReadStats:       metricMapToProto(u.TotalIOReadStats),
+			TotalIngressStats:      metricMapToProto(u.TotalIngressStats),
+			TotalOutgressStats:     metricMapToProto(u.TotalOutgressStats),
+			Efficiency:             metricMapToProto(u.Efficiency),
+			Day:                    u.Day,
+		}
+	}
+
+	return &grpcpb.UsageResponse{
+		Data:     usage,
+		Warnings: result.warnings,
+		Units:    fieldUnits[models.Usage](r),
+		Groupby:  result.groupby,
+	}, nil
+}
+
+// VerifyUnits implements CEEMS gRPC service.
+func (s *grpcService) VerifyUnits(
+	ctx context.Context,
+	req *grpcpb.VerifyUnitsRequest,
+) (*grpcpb.VerifyUnitsResponse, error) {
+	if len(req.GetUuid()) == 0 {
+		return nil, grpcError(&apiError{errorBadData, errMissingUUIDs})
+	}
+
+	user, _ := ctx.Value(authenticatedUserKey{}).(authenticatedUser)
+
+	// Check if user is owner of the queries uuids
+	if !VerifyOwnership(
+		ctx, user.dashboard, req.GetClusterId(), req.GetUuid(), req.GetTime(), s.server.db, s.server.logger,
+	) {
+		return nil, grpcError(&apiError{errorForbidden, errNoAuth})
+	}
+
+	return &grpcpb.VerifyUnitsResponse{}, nil
+}
+
+// request returns a HTTP request with query parameters of the RPC and user
+// headers of authenticated user in the context. It is equivalent to the request
+// that HTTP handlers of path receive after authentication middleware and it is
+// only used to pass query parameters to the queriers of HTTP API. Using the same
+// path and query parameters ensures that usage cache is shared with HTTP API.
+func (s *grpcService) request(ctx context.Context, path string, values url.Values) (*http.Request, authenticatedUser) {
+	user, _ := ctx.Value(authenticatedUserKey{}).(authenticatedUser)
+
+	// Set user in URL query as well as it is used as key for caching
+	values.Add("logged_user", user.logged)
+
+	r := (&http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{Path: s.routePrefix + path, RawQuery: values.Encode()},
+		Header: make(http.Header),
+	}).WithContext(ctx)
+
+	r.Header.Set(loggedUserHeader, user.logged)
+	r.Header.Set(dashboardUserHeader, user.dashboard)
+
+	if user.admin {
+		r.Header.Set(adminUserHeader, user.logged)
+	}
+
+	return r, user
+}
+
+// rateLimitInterceptor is a gRPC interceptor that rate limits requests by the
+// address of the client using the same rate limiter as HTTP API.
+func (s *grpcService) rateLimitInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	r := (&http.Request{Header: make(http.Header)}).WithContext(ctx)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		r.RemoteAddr = p.Addr.String()
+	}
+
+	// Headers set by rate limiter are not returned to gRPC clients
+	var allowed bool
+
+	s.server.limiter.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		allowed = true
+	})).ServeHTTP(newBufferedResponseWriter(), r)
+
+	if !allowed {
+		return nil, status.Error(codes.ResourceExhausted, http.StatusText(http.StatusTooManyRequests))
+	}
+
+	return handler(ctx, req)
+}
+
+// grpcError returns gRPC status error of an API error. The message contains the
+// same error code as the responses of HTTP API.
+func grpcError(apiErr *apiError) error {
+	code := apiErrorCode(apiErr.err, apiErrorStatus(apiErr.typ))
+
+	return status.Error(grpcCode(apiErr.typ), fmt.Sprintf("%s: %s", code, apiErr.err))
+}
+
+// grpcCode returns gRPC status code of error type of API.
+func grpcCode(typ errorType) codes.Code {
+	switch typ { //nolint:exhaustive
+	case errorBadData:
+		return codes.InvalidArgument
+	case errorUnauthorized:
+		return codes.Unauthenticated
+	case errorForbidden:
+		return codes.PermissionDenied
+	case errorExec:
+		return codes.FailedPrecondition
+	case errorCanceled:
+		return codes.Canceled
+	case errorTimeout:
+		return codes.DeadlineExceeded
+	case errorNotFound:
+		return codes.NotFound
+	case errorUnavailable:
+		return codes.Unavailable
+	case errorNotAcceptable:
+		return codes.Unimplemented
+	default:
+		return codes.Internal
+	}
+}
+
+// paramsToValues converts query parameters of gRPC requests to URL values.
+func paramsToValues(params map[string]*grpcpb.Values) url.Values {
+	values := make(url.Values, len(params))
+
+	for k, v := range params {
+		values[k] = v.GetValues()
+	}
+
+	return values
+}
+
+// metricMapToProto converts metric map to its protobuf counterpart.
+func metricMapToProto(m models.MetricMap) map[string]float64 {
+	if len(m) == 0 {
+		return nil
+	}
+
+	pm := make(map[string]float64, len(m))
+	for k, v := range m {
+		pm[k] = float64(v)
+	}
+
+	return pm
+}
+
+// paginationToProto converts pagination metadata to its protobuf counterpart.
+func paginationToProto(p *Pagination) *grpcpb.Pagination {
+	if p == nil {
+		return nil
+	}
+
+	pp := &grpcpb.Pagination{
+		Total:      int64(p.Total),
+		Limit:      int64(p.Limit),
+		Offset:     int64(p.Offset),
+		Cursor:     p.Cursor,
+		NextCursor: p.NextCursor,
+	}
+
+	if p.NextOffset != nil {
+		nextOffset := int64(*p.NextOffset)
+		pp.NextOffset = &nextOffset
+	}
+
+	return pp
+}
+
+// bufferedResponseWriter is a http.ResponseWriter that keeps the response in
+// memory.
+type bufferedResponseWriter struct {
+	header http.Header
+	body   bytes.Buffer
+	code   int
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{header: make(http.Header), code: http.StatusOK}
+}
+
+// Header implements http.ResponseWriter interface.
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// Write implements http.ResponseWriter interface.
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// WriteHeader implements http.ResponseWriter interface.
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	w.code = code
+}
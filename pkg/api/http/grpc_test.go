@@ -0,0 +1,94 @@
+//go:build cgo
+// +build cgo
+
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mahendrapaipuri/ceems/pkg/api/base"
+	"github.com/mahendrapaipuri/ceems/pkg/api/grpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCService(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	f, err := os.Create(filepath.Join(tmpDir, base.CEEMSDBName))
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	// Serve gRPC service on a test server using cleartext HTTP/2
+	grpcServer := newGRPCServer("", server, "/api/"+base.APIVersion+"/")
+	ts := httptest.NewServer(grpcServer.Handler)
+
+	defer ts.Close()
+
+	conn, err := grpc.NewClient(strings.TrimPrefix(ts.URL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	client := grpcpb.NewCEEMSClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), grafanaUserHeader, "foousr")
+
+	// Units
+	units, err := client.Units(ctx, &grpcpb.UnitsRequest{
+		Params: map[string]*grpcpb.Values{"uuid": {Values: []string{"1000"}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, units.GetData(), len(mockServerUnits))
+	assert.Equal(t, "1000", units.GetData()[0].GetUuid())
+	assert.Equal(t, "slurm-0", units.GetData()[0].GetClusterId())
+
+	// Usage
+	usage, err := client.Usage(ctx, &grpcpb.UsageRequest{Mode: "global"})
+	require.NoError(t, err)
+	require.Len(t, usage.GetData(), len(mockServerUsage))
+	assert.Equal(t, "foo", usage.GetData()[0].GetProject())
+
+	// Invalid query parameters must return invalid argument error
+	_, err = client.Usage(ctx, &grpcpb.UsageRequest{
+		Mode:   "current",
+		Params: map[string]*grpcpb.Values{"groupby": {Values: []string{"num_units"}}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "CEEMS-40010")
+
+	// Unknown mode must return invalid argument error
+	_, err = client.Usage(ctx, &grpcpb.UsageRequest{Mode: "unknown"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Verify without uuids
+	_, err = client.VerifyUnits(ctx, &grpcpb.VerifyUnitsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Requests without user must be denied by authentication middleware
+	_, err = client.Units(context.Background(), &grpcpb.UnitsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Admin endpoints must be denied for regular users
+	_, err = client.Units(ctx, &grpcpb.UnitsRequest{Admin: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Requests beyond rate limit must be denied
+	for range 10 {
+		_, err = client.Units(ctx, &grpcpb.UnitsRequest{})
+	}
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
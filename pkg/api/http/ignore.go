@@ -0,0 +1,334 @@
+//go:build cgo
+// +build cgo
+
+package http
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/pkg/api/base"
+	"github.com/mahendrapaipuri/ceems/pkg/api/db"
+	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+)
+
+// ignoredUnit contains the fields of a unit that are needed to update its
+// usage in usage tables.
+type ignoredUnit struct {
+	clusterID     string
+	user          string
+	project       string
+	startedAtTS   int64
+	endedAtTS     int64
+	lastUpdatedAt string
+	metrics       map[string]models.MetricMap
+}
+
+// usageMetricCols returns the total and average metric columns of usage tables.
+func usageMetricCols() []string {
+	var cols []string
+
+	for _, col := range base.UsageDBTableColNames {
+		if strings.HasPrefix(col, "total") || strings.HasPrefix(col, "avg") {
+			cols = append(cols, col)
+		}
+	}
+
+	return cols
+}
+
+// ignoreUnits sets or unsets the ignore flag of units identified by uuids. When
+// clusterIDs are provided, only units of those clusters are updated.
+//
+// The usage of units is subtracted from usage and daily usage tables when they
+// are ignored and added back when the flag is unset in the same transaction.
+// As daily usage of each unit is not stored in DB, usage of unit is split
+// between the days during which it has been running proportionally to the time
+// it ran on each day. Number of affected rows of each table is returned.
+func ignoreUnits(
+	ctx context.Context,
+	dbConn *sql.DB,
+	uuids []string,
+	clusterIDs []string,
+	ignore bool,
+	loc *time.Location,
+) ([]models.AffectedRows, error) {
+	flag := 0
+	if ignore {
+		flag = models.UnitIgnoredByAdmin
+	}
+
+	args := make([]any, 0, len(uuids)+len(clusterIDs))
+	for _, uuid := range uuids {
+		args = append(args, uuid)
+	}
+
+	clause := fmt.Sprintf("uuid IN (%s)", strings.TrimSuffix(strings.Repeat("?,", len(uuids)), ","))
+
+	// Restrict to clusters, if any
+	if len(clusterIDs) > 0 {
+		clause += fmt.Sprintf(
+			" AND cluster_id IN (%s)", strings.TrimSuffix(strings.Repeat("?,", len(clusterIDs)), ","),
+		)
+
+		for _, clusterID := range clusterIDs {
+			args = append(args, clusterID)
+		}
+	}
+
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin SQL transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// Units that are not ignored by admin users are aggregated into usage tables,
+	// including the ones ignored by updaters. Thus, usage of all of them must be
+	// subtracted when they are ignored and only usage of units ignored by admin
+	// users must be added back when the flag is unset.
+	usageClause := clause + fmt.Sprintf(" AND COALESCE(ignore, 0) != %d", models.UnitIgnoredByAdmin)
+	sign := -1.0
+
+	if !ignore {
+		usageClause = clause + fmt.Sprintf(" AND ignore = %d", models.UnitIgnoredByAdmin)
+		sign = 1
+	}
+
+	units, err := fetchIgnoredUnits(ctx, tx, usageClause, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET ignore = ? WHERE %s", base.UnitsDBTableName, clause) // #nosec
+
+	res, err := tx.ExecContext(ctx, query, append([]any{flag}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update ignore flag of units: %w", err)
+	}
+
+	unitRows, _ := res.RowsAffected()
+
+	usageRows, dailyUsageRows, err := updateIgnoredUsage(ctx, tx, units, sign, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit SQL transaction: %w", err)
+	}
+
+	return []models.AffectedRows{
+		{Table: base.UnitsDBTableName, Rows: unitRows},
+		{Table: base.UsageDBTableName, Rows: usageRows},
+		{Table: base.DailyUsageDBTableName, Rows: dailyUsageRows},
+	}, nil
+}
+
+// fetchIgnoredUnits returns the units that match the clause.
+func fetchIgnoredUnits(ctx context.Context, tx *sql.Tx, clause string, args ...any) ([]ignoredUnit, error) {
+	cols := usageMetricCols()
+
+	query := fmt.Sprintf(
+		"SELECT cluster_id,username,project,COALESCE(started_at_ts, 0),COALESCE(ended_at_ts, 0),COALESCE(last_updated_at, ''),%s FROM %s WHERE %s",
+		strings.Join(cols, ","), base.UnitsDBTableName, clause,
+	) // #nosec
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch units: %w", err)
+	}
+	defer rows.Close()
+
+	var units []ignoredUnit
+
+	for rows.Next() {
+		unit := ignoredUnit{metrics: make(map[string]models.MetricMap, len(cols))}
+		metrics := make([]models.MetricMap, len(cols))
+
+		dest := []any{&unit.clusterID, &unit.user, &unit.project, &unit.startedAtTS, &unit.endedAtTS, &unit.lastUpdatedAt}
+		for i := range metrics {
+			dest = append(dest, &metrics[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan unit: %w", err)
+		}
+
+		for i, col := range cols {
+			unit.metrics[col] = metrics[i]
+		}
+
+		units = append(units, unit)
+	}
+
+	return units, rows.Err()
+}
+
+// updateIgnoredUsage adds usage of units scaled by sign to usage and daily usage
+// tables. It returns the number of updated rows of usage and daily usage tables.
+func updateIgnoredUsage(
+	ctx context.Context,
+	tx *sql.Tx,
+	units []ignoredUnit,
+	sign float64,
+	loc *time.Location,
+) (int64, int64, error) {
+	if len(units) == 0 {
+		return 0, 0, nil
+	}
+
+	// Totals are added and averages are weighted by the time metrics of unit
+	cols := usageMetricCols()
+	setCols := []string{"num_units = num_units + :num_units"}
+
+	for _, col := range cols {
+		if weight, ok := db.Weights[col]; ok {
+			setCols = append(setCols, fmt.Sprintf(
+				"%[1]s = avg_metric_map(%[1]s, :%[1]s, CAST(json_extract(total_time_seconds, '$.%[2]s') AS REAL), :%[1]s_weight)",
+				col, weight,
+			))
+		} else {
+			setCols = append(setCols, fmt.Sprintf("%[1]s = add_metric_map(%[1]s, :%[1]s)", col))
+		}
+	}
+
+	usageStmt, err := tx.PrepareContext(ctx, fmt.Sprintf(
+		"UPDATE %s SET %s WHERE cluster_id = :cluster_id AND username = :username AND project = :project",
+		base.UsageDBTableName, strings.Join(setCols, ", "),
+	)) // #nosec
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prepare statement for table %s: %w", base.UsageDBTableName, err)
+	}
+	defer usageStmt.Close()
+
+	dailyUsageStmt, err := tx.PrepareContext(ctx, fmt.Sprintf(
+		"UPDATE %s SET %s WHERE cluster_id = :cluster_id AND username = :username AND project = :project AND last_updated_at = :last_updated_at",
+		base.DailyUsageDBTableName, strings.Join(setCols, ", "),
+	)) // #nosec
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prepare statement for table %s: %w", base.DailyUsageDBTableName, err)
+	}
+	defer dailyUsageStmt.Close()
+
+	var usageRows, dailyUsageRows int64
+
+	for _, unit := range units {
+		res, err := usageStmt.ExecContext(ctx, unit.usageArgs(cols, sign, sign)...)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to update usage of unit: %w", err)
+		}
+
+		rows, _ := res.RowsAffected()
+		usageRows += rows
+
+		// Number of units is incremented in daily usage on the day unit has started
+		startDay := dayOf(unit.startedAtTS, loc)
+
+		for day, fraction := range unit.dailyFractions(loc) {
+			numUnits := 0.0
+			if day == startDay {
+				numUnits = sign
+			}
+
+			args := append(
+				unit.usageArgs(cols, numUnits, sign*fraction),
+				sql.Named("last_updated_at", day),
+			)
+
+			res, err := dailyUsageStmt.ExecContext(ctx, args...)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to update daily usage of unit: %w", err)
+			}
+
+			rows, _ := res.RowsAffected()
+			dailyUsageRows += rows
+		}
+	}
+
+	return usageRows, dailyUsageRows, nil
+}
+
+// usageArgs returns the named arguments of usage statements where metrics of
+// unit are scaled by factor.
+func (u ignoredUnit) usageArgs(cols []string, numUnits float64, factor float64) []any {
+	args := []any{
+		sql.Named("cluster_id", u.clusterID),
+		sql.Named("username", u.user),
+		sql.Named("project", u.project),
+		sql.Named("num_units", int64(numUnits)),
+	}
+
+	for _, col := range cols {
+		// Averages are not scaled but their weights are
+		if weight, ok := db.Weights[col]; ok {
+			args = append(
+				args,
+				sql.Named(col, u.metrics[col]),
+				sql.Named(col+"_weight", float64(u.metrics["total_time_seconds"][weight])*factor),
+			)
+
+			continue
+		}
+
+		scaled := make(models.MetricMap, len(u.metrics[col]))
+		for name, value := range u.metrics[col] {
+			scaled[name] = value * models.JSONFloat(factor)
+		}
+
+		args = append(args, sql.Named(col, scaled))
+	}
+
+	return args
+}
+
+// dailyFractions returns the fraction of lifetime of unit on each day. Days are
+// formatted in the same way as in daily usage table.
+func (u ignoredUnit) dailyFractions(loc *time.Location) map[string]float64 {
+	end := time.UnixMilli(u.endedAtTS)
+
+	// For running units, use the time at which unit was last updated
+	if u.endedAtTS == 0 {
+		lastUpdatedAt, err := time.ParseInLocation(base.DatetimeLayout, u.lastUpdatedAt, loc)
+		if err != nil {
+			return nil
+		}
+
+		end = lastUpdatedAt
+	}
+
+	start := time.UnixMilli(u.startedAtTS)
+
+	// When start time is unknown, attribute all usage to last day
+	if u.startedAtTS == 0 || !end.After(start) {
+		return map[string]float64{dayOf(end.UnixMilli(), loc): 1}
+	}
+
+	fractions := make(map[string]float64)
+	lifetime := end.Sub(start).Seconds()
+
+	for day := start.In(loc).Truncate(24 * time.Hour); day.Before(end); day = day.Add(24 * time.Hour) {
+		from := day
+		if from.Before(start) {
+			from = start
+		}
+
+		to := day.Add(24 * time.Hour)
+		if to.After(end) {
+			to = end
+		}
+
+		fractions[day.Format(base.DatetimeLayout)] = math.Max(to.Sub(from).Seconds(), 0) / lifetime
+	}
+
+	return fractions
+}
+
+// dayOf returns the day of timestamp formatted in the same way as in daily
+// usage table.
+func dayOf(ts int64, loc *time.Location) string {
+	return time.UnixMilli(ts).In(loc).Truncate(24 * time.Hour).Format(base.DatetimeLayout)
+}
@@ -0,0 +1,172 @@
+//go:build cgo
+// +build cgo
+
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/mahendrapaipuri/ceems/internal/common"
+	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Fields of usage exported as metrics.
+var usageMetricsFields = []string{
+	"cluster_id", "project", "username", "num_units",
+	"total_cpu_energy_usage_kwh", "total_cpu_emissions_gms",
+	"total_gpu_energy_usage_kwh", "total_gpu_emissions_gms",
+}
+
+// Usage metrics are always grouped by cluster, project and user.
+var usageMetricsGroupBy = []string{"cluster_id", "project", "username"}
+
+// usageCollector exports current usage statistics as Prometheus metrics.
+type usageCollector struct {
+	usage         []models.Usage
+	unitsDesc     *prometheus.Desc
+	energyDesc    *prometheus.Desc
+	emissionsDesc *prometheus.Desc
+}
+
+// newUsageCollector returns a new instance of usageCollector.
+func newUsageCollector(usage []models.Usage) *usageCollector {
+	return &usageCollector{
+		usage: usage,
+		unitsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName("ceems", "usage", "units"),
+			"Number of compute units of user in project",
+			[]string{"cluster", "project", "user"},
+			nil,
+		),
+		energyDesc: prometheus.NewDesc(
+			prometheus.BuildFQName("ceems", "usage", "energy_kwh"),
+			"Total energy usage in kWh of compute units of user in project",
+			[]string{"cluster", "project", "user", "device", "key"},
+			nil,
+		),
+		emissionsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName("ceems", "usage", "emissions_gms"),
+			"Total emissions in grams of compute units of user in project",
+			[]string{"cluster", "project", "user", "device", "key"},
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector interface.
+func (c *usageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.unitsDesc
+	ch <- c.energyDesc
+	ch <- c.emissionsDesc
+}
+
+// Collect implements prometheus.Collector interface.
+func (c *usageCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, u := range c.usage {
+		ch <- prometheus.MustNewConstMetric(
+			c.unitsDesc, prometheus.GaugeValue, float64(u.NumUnits), u.ClusterID, u.Project, u.User,
+		)
+
+		for device, metrics := range map[string][]models.MetricMap{
+			"cpu": {u.TotalCPUEnergyUsage, u.TotalCPUEmissions},
+			"gpu": {u.TotalGPUEnergyUsage, u.TotalGPUEmissions},
+		} {
+			for key, value := range metrics[0] {
+				ch <- prometheus.MustNewConstMetric(
+					c.energyDesc, prometheus.GaugeValue, float64(value), u.ClusterID, u.Project, u.User, device, key,
+				)
+			}
+
+			for key, value := range metrics[1] {
+				ch <- prometheus.MustNewConstMetric(
+					c.emissionsDesc, prometheus.GaugeValue, float64(value), u.ClusterID, u.Project, u.User, device, key,
+				)
+			}
+		}
+	}
+}
+
+// GET /metrics/usage
+// Export current usage statistics of all users as Prometheus metrics.
+func (s *CEEMSServer) usageMetrics(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "usage metrics endpoint", s.logger)
+
+	var usage []models.Usage
+
+	var err error
+
+	// Usage of all users is exported and hence, only admin users can access it
+	if r.Header.Get(adminUserHeader) == "" {
+		errorResponse[any](w, &apiError{errorForbidden, errNoPrivs}, s.logger, nil)
+
+		return
+	}
+
+	// Validate status query parameter before making any query
+	if _, err := s.getStatusQueryParam(&Query{}, r.URL.Query()); err != nil {
+		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
+
+		return
+	}
+
+	// Validate tag query parameters before making any query
+	if _, err := s.getTagQueryParams(&Query{}, r.URL.Query()); err != nil {
+		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
+
+		return
+	}
+
+	// Round `to` and `from` query parameters to cacheTTL
+	if err := s.roundQueryWindow(r); err != nil {
+		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
+
+		return
+	}
+
+	// Get query window time stamps
+	queryWindowTS, err := s.getQueryWindow(r)
+	if err != nil {
+		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
+
+		return
+	}
+
+	// Scrapes are served from usage cache to avoid querying DB on every scrape
+	cacheKey := r.URL.String()
+	if present := s.usageCache.Has(cacheKey); present {
+		usage = s.usageCache.Get(cacheKey).Value()
+
+		s.cacheHits.Inc()
+	} else {
+		s.cacheMisses.Inc()
+
+		var warnings []string
+
+		usage, warnings, err = s.queryCurrentUsage(nil, usageMetricsFields, usageMetricsGroupBy, queryWindowTS, r)
+		if err != nil {
+			s.logger.Error("Failed to fetch usage statistics for metrics", "err", err)
+			errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
+
+			return
+		}
+
+		if len(warnings) > 0 {
+			s.logger.Warn("Usage statistics for metrics are incomplete", "warnings", strings.Join(warnings, ","))
+		}
+
+		if len(usage) > 0 {
+			s.usageCache.Set(cacheKey, usage, ttlcache.DefaultTTL)
+		}
+	}
+
+	// Render usage in Prometheus exposition format
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(newUsageCollector(usage))
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true}).ServeHTTP(w, r)
+}
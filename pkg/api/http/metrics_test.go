@@ -0,0 +1,91 @@
+//go:build cgo
+// +build cgo
+
+package http
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mahendrapaipuri/ceems/pkg/api/base"
+	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageMetricsHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	f, err := os.Create(filepath.Join(tmpDir, base.CEEMSDBName))
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	var numQueries int
+
+	server.queriers.usage = func(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) ([]models.Usage, error) {
+		numQueries++
+
+		return []models.Usage{
+			{
+				ClusterID:           "slurm-0",
+				Project:             "foo",
+				User:                "foousr",
+				NumUnits:            10,
+				TotalCPUEnergyUsage: models.MetricMap{"total": 1.5},
+				TotalCPUEmissions:   models.MetricMap{"emaps_total": 20, "rte_total": 10},
+				TotalGPUEnergyUsage: models.MetricMap{"total": 3},
+			},
+		}, nil
+	}
+
+	expected := []string{
+		`ceems_usage_units{cluster="slurm-0",project="foo",user="foousr"} 10`,
+		`ceems_usage_energy_kwh{cluster="slurm-0",device="cpu",key="total",project="foo",user="foousr"} 1.5`,
+		`ceems_usage_energy_kwh{cluster="slurm-0",device="gpu",key="total",project="foo",user="foousr"} 3`,
+		`ceems_usage_emissions_gms{cluster="slurm-0",device="cpu",key="emaps_total",project="foo",user="foousr"} 20`,
+		`ceems_usage_emissions_gms{cluster="slurm-0",device="cpu",key="rte_total",project="foo",user="foousr"} 10`,
+	}
+
+	// Non admin users are forbidden
+	request := httptest.NewRequest(http.MethodGet, "/metrics/usage", nil)
+	request.Header.Set(loggedUserHeader, "foousr")
+
+	w := httptest.NewRecorder()
+	server.usageMetrics(w, request)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Second request must be served from cache
+	for range 2 {
+		request := httptest.NewRequest(http.MethodGet, "/metrics/usage?from=1735045414&to=1735049014", nil)
+		request.Header.Set(loggedUserHeader, "adm1")
+		request.Header.Set(adminUserHeader, "adm1")
+
+		w := httptest.NewRecorder()
+		server.usageMetrics(w, request)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		for _, line := range expected {
+			assert.Contains(t, string(data), line)
+		}
+	}
+
+	assert.Equal(t, 1, numQueries)
+}
@@ -5,13 +5,20 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"os"
 	"regexp"
 	"slices"
 	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // Headers.
@@ -21,6 +28,7 @@ const (
 	loggedUserHeader    = "X-Logged-User"
 	adminUserHeader     = "X-Admin-User"
 	ceemsUserHeader     = "X-Ceems-User" // Special header that will be included in requests from CEEMS LB
+	apiKeyHeader        = "X-Api-Key"
 )
 
 // Debug end point regex match.
@@ -28,6 +36,11 @@ var (
 	debugEndpoints = regexp.MustCompile("/debug/(.*)")
 )
 
+// Search end points only read data even if they use POST method.
+var (
+	searchEndpoints = regexp.MustCompile("/units/search(/admin)?$")
+)
+
 // Define our struct.
 type authenticationMiddleware struct {
 	logger          *slog.Logger
@@ -35,14 +48,128 @@ type authenticationMiddleware struct {
 	whitelistedURLs *regexp.Regexp
 	db              *sql.DB
 	adminUsers      func(context.Context, *sql.DB, *slog.Logger) []string
+	apiKeys         []apiKey
+}
+
+// apiKey contains SHA-256 hash of API key and the user it maps to.
+type apiKey struct {
+	user string
+	hash [sha256.Size]byte
+}
+
+// newAPIKeys returns API keys from config. Only hashes of keys are kept in
+// memory.
+func newAPIKeys(configs []APIKeyConfig) ([]apiKey, error) {
+	keys := make([]apiKey, len(configs))
+
+	for i, c := range configs {
+		key := string(c.Key)
+
+		if c.KeyFile != "" {
+			content, err := os.ReadFile(c.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read API key file of user %s: %w", c.User, err)
+			}
+
+			key = strings.TrimSpace(string(content))
+		}
+
+		keys[i] = apiKey{user: c.User, hash: sha256.Sum256([]byte(key))}
+	}
+
+	return keys, nil
+}
+
+// apiKeyUser returns the user that key maps to. Hashes of all configured keys
+// are compared in constant time to avoid leaking information about valid keys.
+func (amw *authenticationMiddleware) apiKeyUser(key string) (string, bool) {
+	hash := sha256.Sum256([]byte(key))
+
+	var user string
+
+	var found bool
+
+	for _, k := range amw.apiKeys {
+		if subtle.ConstantTimeCompare(hash[:], k.hash[:]) == 1 && !found {
+			user = k.user
+			found = true
+		}
+	}
+
+	return user, found
+}
+
+// authenticatedUser contains the users of an authenticated request.
+type authenticatedUser struct {
+	logged    string // User making the request
+	dashboard string // User whose resources are requested
+	admin     bool   // Whether logged user is an admin user
+	apiKey    bool   // Whether logged user is identified by API key
+}
+
+// authenticate identifies the users of a request from the values of user header,
+// dashboard user header and API key, if any. When API key is present, user is
+// identified by the key and user header is ignored.
+func (amw *authenticationMiddleware) authenticate(
+	ctx context.Context,
+	loggedUser string,
+	dashboardUser string,
+	key string,
+) (authenticatedUser, *apiError) {
+	user := authenticatedUser{logged: loggedUser}
+
+	// Never log the key itself
+	if key != "" {
+		var ok bool
+		if user.logged, ok = amw.apiKeyUser(key); !ok {
+			amw.logger.Error("Invalid API key. Denying authentication")
+
+			return user, &apiError{errorUnauthorized, errInvalidAPIKey}
+		}
+
+		user.apiKey = true
+	}
+
+	// Check if username is available
+	if user.logged == "" {
+		amw.logger.Error("Grafana user Header not found. Denying authentication")
+
+		return user, &apiError{errorUnauthorized, errNoUser}
+	}
+
+	// Fetch admin users from DB
+	admUsers := amw.adminUsers(ctx, amw.db, amw.logger)
+
+	// If current user is in list of admin users, get "actual" user from
+	// dashboard user. For normal users, this will be exactly same as their
+	// username.
+	// For admin users who can look at dashboard of "any" user this will be the
+	// username of the "impersonated" user and we take it into account
+	if slices.Contains(admUsers, user.logged) {
+		user.admin = true
+
+		if dashboardUser != "" {
+			amw.logger.Info("Admin user accessing dashboards", "loggedUser", user.logged, "dashboardUser", dashboardUser)
+
+			user.dashboard = dashboardUser
+		} else {
+			user.dashboard = user.logged
+		}
+	} else {
+		user.dashboard = user.logged
+	}
+
+	return user, nil
 }
 
 // Middleware function, which will be called for each request.
 func (amw *authenticationMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var loggedUser string
+		var user authenticatedUser
 
-		var admUsers []string
+		var apiErr *apiError
+
+		var key string
 
 		var q url.Values
 
@@ -73,63 +200,111 @@ func (amw *authenticationMiddleware) Middleware(next http.Handler) http.Handler
 		r.Header.Del(adminUserHeader)
 		r.Header.Del(loggedUserHeader)
 
-		// Check if username header is available
-		loggedUser = r.Header.Get(grafanaUserHeader)
-		if loggedUser == "" {
-			amw.logger.Error("Grafana user Header not found. Denying authentication")
+		// If request has an API key, user is identified by the key and any user
+		// header in the request is ignored. API keys grant read only access.
+		key = r.Header.Get(apiKeyHeader)
+		r.Header.Del(apiKeyHeader)
 
+		user, apiErr = amw.authenticate(r.Context(), r.Header.Get(grafanaUserHeader), r.Header.Get(dashboardUserHeader), key)
+		if apiErr != nil {
 			// Write an error and stop the handler chain
-			errorResponse[any](w, &apiError{errorUnauthorized, errNoUser}, amw.logger, nil)
+			errorResponse[any](w, apiErr, amw.logger, nil)
 
 			return
 		}
 
-		amw.logger.Info("middleware", "loggedUser", loggedUser, "url", r.URL)
+		if user.apiKey {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead &&
+				(r.Method != http.MethodPost || !searchEndpoints.MatchString(r.URL.Path)) {
+				amw.logger.Error("API key used for non read only request", "user", user.logged, "method", r.Method, "url", r.URL)
+
+				// Write an error and stop the handler chain
+				errorResponse[any](w, &apiError{errorForbidden, errReadOnlyAPIKey}, amw.logger, nil)
+
+				return
+			}
+
+			r.Header.Set(grafanaUserHeader, user.logged)
+		}
+
+		amw.logger.Info("middleware", "loggedUser", user.logged, "url", r.URL)
 
 		// Set logged user header
-		r.Header.Set(loggedUserHeader, loggedUser)
+		r.Header.Set(loggedUserHeader, user.logged)
 
 		// Set user in URL query as well as we will use it as key for caching
 		q = r.URL.Query()
-		q.Add("logged_user", loggedUser)
+		q.Add("logged_user", user.logged)
 		r.URL.RawQuery = q.Encode()
 
-		// Fetch admin users from DB
-		admUsers = amw.adminUsers(r.Context(), amw.db, amw.logger)
-
-		// If current user is in list of admin users, get "actual" user from
-		// X-Dashboard-User header. For normal users, this header will be exactly same
-		// as their username.
-		// For admin users who can look at dashboard of "any" user this will be the
-		// username of the "impersonated" user and we take it into account
-		if slices.Contains(admUsers, loggedUser) {
+		if user.admin {
 			// Set X-Admin-User header
-			r.Header.Set(adminUserHeader, loggedUser)
-
-			if dashboardUser := r.Header.Get(dashboardUserHeader); dashboardUser != "" {
-				amw.logger.Info(
-					"Admin user accessing dashboards", "loggedUser", loggedUser,
-					"dashboardUser", dashboardUser, "url", r.URL,
-				)
-			} else {
-				r.Header.Set(dashboardUserHeader, loggedUser)
-			}
-		} else {
+			r.Header.Set(adminUserHeader, user.logged)
+		} else if strings.HasSuffix(r.URL.Path, "admin") {
 			// Check if requested URI is not admin endpoints
-			if strings.HasSuffix(r.URL.Path, "admin") {
-				amw.logger.Error("Unprivileged user accessing admin endpoint", "user", loggedUser, "url", r.URL)
-
-				// Write an error and stop the handler chain
-				errorResponse[any](w, &apiError{errorForbidden, errNoPrivs}, amw.logger, nil)
+			amw.logger.Error("Unprivileged user accessing admin endpoint", "user", user.logged, "url", r.URL)
 
-				return
-			}
+			// Write an error and stop the handler chain
+			errorResponse[any](w, &apiError{errorForbidden, errNoPrivs}, amw.logger, nil)
 
-			r.Header.Set(dashboardUserHeader, loggedUser)
+			return
 		}
 
+		r.Header.Set(dashboardUserHeader, user.dashboard)
+
 	end:
 		// Pass down the request to the next middleware (or final handler)
 		next.ServeHTTP(w, r)
 	})
 }
+
+// authenticatedUserKey is the context key of authenticated user of gRPC requests.
+type authenticatedUserKey struct{}
+
+// UnaryInterceptor is a gRPC interceptor that authenticates requests using the
+// user headers and API key in the metadata of the request, in the same way as
+// Middleware does for HTTP requests. Authenticated user is passed to the RPC
+// in the context of request. All RPCs only read data and hence, API keys can
+// be used for all of them.
+func (amw *authenticationMiddleware) UnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	get := func(key string) string {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+
+		return ""
+	}
+
+	var user authenticatedUser
+
+	// If request has "special" CEEMS header, pass through. It must be
+	// coming from other CEEMS components
+	if len(md.Get(ceemsUserHeader)) > 0 {
+		user = authenticatedUser{logged: get(grafanaUserHeader), dashboard: get(dashboardUserHeader), admin: true}
+
+		return handler(context.WithValue(ctx, authenticatedUserKey{}, user), req)
+	}
+
+	user, apiErr := amw.authenticate(ctx, get(grafanaUserHeader), get(dashboardUserHeader), get(apiKeyHeader))
+	if apiErr != nil {
+		return nil, grpcError(apiErr)
+	}
+
+	amw.logger.Info("middleware", "loggedUser", user.logged, "method", info.FullMethod)
+
+	// Check if requested RPC is not admin RPC
+	if r, ok := req.(interface{ GetAdmin() bool }); ok && r.GetAdmin() && !user.admin {
+		amw.logger.Error("Unprivileged user accessing admin RPC", "user", user.logged, "method", info.FullMethod)
+
+		return nil, grpcError(&apiError{errorForbidden, errNoPrivs})
+	}
+
+	return handler(context.WithValue(ctx, authenticatedUserKey{}, user), req)
+}
@@ -5,15 +5,19 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func mockAdminUsers(_ context.Context, _ *sql.DB, _ *slog.Logger) []string {
@@ -26,6 +30,10 @@ func setupMiddleware() http.Handler {
 		logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
 		whitelistedURLs: regexp.MustCompile("/api/v1/(swagger|debug|health|demo)(.*)"),
 		adminUsers:      mockAdminUsers,
+		apiKeys: []apiKey{
+			{user: "usr2", hash: sha256.Sum256([]byte("usr2-key"))},
+			{user: "adm1", hash: sha256.Sum256([]byte("adm1-key"))},
+		},
 	}
 
 	// create a handler to use as "next" which will verify the request
@@ -139,3 +147,111 @@ func TestMiddlewareAdminFailurePresetHeader(t *testing.T) {
 	// Should not contain adminHeader
 	assert.Equal(t, "", req.Header.Get(adminUserHeader))
 }
+
+func TestMiddlewareAPIKey(t *testing.T) {
+	// Setup middleware handler
+	handlerToTest := setupMiddleware()
+
+	// Test cases
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		key    string
+		user   string
+		code   int
+	}{
+		{
+			name:   "valid key",
+			method: http.MethodGet,
+			url:    "/api/v1/units",
+			key:    "usr2-key",
+			user:   "usr2",
+			code:   200,
+		},
+		{
+			name:   "valid admin key",
+			method: http.MethodGet,
+			url:    "/api/v1/units/admin",
+			key:    "adm1-key",
+			user:   "adm1",
+			code:   200,
+		},
+		{
+			name:   "valid key on admin endpoint",
+			method: http.MethodGet,
+			url:    "/api/v1/units/admin",
+			key:    "usr2-key",
+			code:   403,
+		},
+		{
+			name:   "invalid key",
+			method: http.MethodGet,
+			url:    "/api/v1/units",
+			key:    "foo",
+			code:   401,
+		},
+		{
+			name:   "search request",
+			method: http.MethodPost,
+			url:    "/api/v1/units/search",
+			key:    "usr2-key",
+			user:   "usr2",
+			code:   200,
+		},
+		{
+			name:   "non read only post request",
+			method: http.MethodPost,
+			url:    "/api/v1/units",
+			key:    "usr2-key",
+			code:   403,
+		},
+		{
+			name:   "non read only request",
+			method: http.MethodDelete,
+			url:    "/api/v1/usage/cache/admin",
+			key:    "adm1-key",
+			code:   403,
+		},
+	}
+
+	for _, test := range tests {
+		// User header must be ignored when API key is present
+		req := httptest.NewRequest(test.method, test.url, nil)
+		req.Header.Set(apiKeyHeader, test.key)
+		req.Header.Set(grafanaUserHeader, "usr1")
+
+		// call the handler using a mock response recorder (we'll not use that anyway)
+		w := httptest.NewRecorder()
+		handlerToTest.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, test.code, res.StatusCode, test.name)
+
+		if test.code == 200 {
+			assert.Equal(t, test.user, req.Header.Get(loggedUserHeader), test.name)
+			assert.Equal(t, "", req.Header.Get(apiKeyHeader), test.name)
+		}
+	}
+}
+
+func TestNewAPIKeys(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("usr2-key\n"), 0o600))
+
+	keys, err := newAPIKeys([]APIKeyConfig{
+		{User: "usr1", Key: "usr1-key"},
+		{User: "usr2", KeyFile: keyFile},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []apiKey{
+		{user: "usr1", hash: sha256.Sum256([]byte("usr1-key"))},
+		{user: "usr2", hash: sha256.Sum256([]byte("usr2-key"))},
+	}, keys)
+
+	// Missing key file
+	_, err = newAPIKeys([]APIKeyConfig{{User: "usr3", KeyFile: filepath.Join(t.TempDir(), "missing")}})
+	require.Error(t, err)
+}
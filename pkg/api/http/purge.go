@@ -0,0 +1,150 @@
+//go:build cgo
+// +build cgo
+
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/mahendrapaipuri/ceems/pkg/api/base"
+	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+)
+
+// Modes of purging data of a user.
+const (
+	purgeModeDelete    = "delete"
+	purgeModeAnonymize = "anonymize"
+)
+
+// Tables and their columns that contain user name.
+var userColumns = [][2]string{
+	{base.UnitsDBTableName, "username"},
+	{base.UsageDBTableName, "username"},
+	{base.DailyUsageDBTableName, "username"},
+	{base.UsersDBTableName, "name"},
+}
+
+// purgeStmt is a statement that purges data of a user in a table.
+type purgeStmt struct {
+	table string
+	query string
+	args  []any
+}
+
+// anonymizeUser returns the anonymized name of user which is SHA-256 hash of
+// the name.
+func anonymizeUser(user string) string {
+	hash := sha256.Sum256([]byte(user))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// purgeUser deletes or anonymizes all the data of user in units, usage,
+// daily_usage, users and projects tables in a single transaction. When
+// clusterIDs are provided, only data of those clusters is purged. Number of
+// affected rows of each table is returned.
+func purgeUser(
+	ctx context.Context,
+	db *sql.DB,
+	user string,
+	mode string,
+	clusterIDs []string,
+) ([]models.AffectedRows, error) {
+	// Restrict to clusters, if any
+	var clusterClause string
+
+	clusterArgs := make([]any, len(clusterIDs))
+	for i, clusterID := range clusterIDs {
+		clusterArgs[i] = clusterID
+	}
+
+	if len(clusterIDs) > 0 {
+		clusterClause = fmt.Sprintf(
+			" AND cluster_id IN (%s)", strings.TrimSuffix(strings.Repeat("?,", len(clusterIDs)), ","),
+		)
+	}
+
+	// Key of user in roles of projects
+	rolesPath := fmt.Sprintf(`$."%s"`, user)
+
+	stmts := make([]purgeStmt, 0, len(userColumns)+1)
+
+	switch mode {
+	case purgeModeAnonymize:
+		anonUser := anonymizeUser(user)
+
+		for _, c := range userColumns {
+			stmts = append(stmts, purgeStmt{
+				table: c[0],
+				query: fmt.Sprintf("UPDATE %[1]s SET %[2]s = ? WHERE %[2]s = ?%[3]s", c[0], c[1], clusterClause), // #nosec
+				args:  append([]any{anonUser, user}, clusterArgs...),
+			})
+		}
+
+		// Replace user in list of users and roles of projects
+		stmts = append(stmts, purgeStmt{
+			table: base.ProjectsDBTableName,
+			query: fmt.Sprintf(
+				`UPDATE %[1]s SET users = (SELECT json_group_array(CASE WHEN value = ? THEN ? ELSE value END) FROM json_each(%[1]s.users)),
+roles = CASE WHEN json_type(roles, ?) IS NULL THEN roles ELSE json_set(json_remove(roles, ?), ?, roles -> ?) END
+WHERE EXISTS (SELECT 1 FROM json_each(%[1]s.users) WHERE value = ?)%[2]s`,
+				base.ProjectsDBTableName, clusterClause,
+			), // #nosec
+			args: append(
+				[]any{user, anonUser, rolesPath, rolesPath, fmt.Sprintf(`$."%s"`, anonUser), rolesPath, user},
+				clusterArgs...,
+			),
+		})
+	default:
+		for _, c := range userColumns {
+			stmts = append(stmts, purgeStmt{
+				table: c[0],
+				query: fmt.Sprintf("DELETE FROM %s WHERE %s = ?%s", c[0], c[1], clusterClause), // #nosec
+				args:  append([]any{user}, clusterArgs...),
+			})
+		}
+
+		// Remove user from list of users and roles of projects
+		stmts = append(stmts, purgeStmt{
+			table: base.ProjectsDBTableName,
+			query: fmt.Sprintf(
+				`UPDATE %[1]s SET users = (SELECT json_group_array(value) FROM json_each(%[1]s.users) WHERE value != ?),
+roles = CASE WHEN json_type(roles, ?) IS NULL THEN roles ELSE json_remove(roles, ?) END
+WHERE EXISTS (SELECT 1 FROM json_each(%[1]s.users) WHERE value = ?)%[2]s`,
+				base.ProjectsDBTableName, clusterClause,
+			), // #nosec
+			args: append([]any{user, rolesPath, rolesPath, user}, clusterArgs...),
+		})
+	}
+
+	// Execute all statements in a transaction
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() //nolint:errcheck
+
+	affectedRows := make([]models.AffectedRows, len(stmts))
+
+	for i, stmt := range stmts {
+		res, err := tx.ExecContext(ctx, stmt.query, stmt.args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to purge user data in %s table: %w", stmt.table, err)
+		}
+
+		rows, _ := res.RowsAffected()
+		affectedRows[i] = models.AffectedRows{Table: stmt.table, Rows: rows}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return affectedRows, nil
+}
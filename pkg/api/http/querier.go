@@ -3,8 +3,10 @@ package http
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"reflect"
 	"regexp"
@@ -17,10 +19,14 @@ import (
 
 var queryRegexp = regexp.MustCompile("SELECT (.*?) FROM (.*)")
 
+// Number of rows after which CSV records are flushed to writer.
+const csvFlushRows = 1000
+
 // Query builder struct.
 type Query struct {
 	builder strings.Builder
 	params  []string
+	numRows int
 }
 
 // Add query to builder.
@@ -34,6 +40,13 @@ func (q *Query) param(val []string) {
 	q.params = append(q.params, val...)
 }
 
+// Add limit to query. Limit is used as the maximum number of rows returned
+// by the query so that rows can be preallocated without counting them.
+func (q *Query) limit(n int) {
+	q.builder.WriteString(fmt.Sprintf(" LIMIT %d ", n))
+	q.numRows = n
+}
+
 // Add sub query to builder.
 func (q *Query) subQuery(sq Query) {
 	subQuery, subQueryParams := sq.get()
@@ -80,14 +93,13 @@ func projectsSubQuery(users []string) Query {
 func scanRows[T any](rows *sql.Rows, numRows int) ([]T, error) {
 	var columns []string
 
-	values := make([]T, numRows)
+	values := make([]T, 0, numRows)
 
 	var value T
 
 	var err error
 
 	scanErrs := 0
-	rowIdx := 0
 
 	// Get indexes
 	indexes := structset.CachedFieldIndexes(reflect.TypeOf(&value).Elem())
@@ -103,13 +115,7 @@ func scanRows[T any](rows *sql.Rows, numRows int) ([]T, error) {
 			scanErrs++
 		}
 
-		if numRows > 0 {
-			values[rowIdx] = value
-		} else {
-			values = append(values, value) //nolint:makezero
-		}
-
-		rowIdx++
+		values = append(values, value)
 	}
 
 	// If we failed to scan any rows, return error which will be included in warnings
@@ -180,10 +186,13 @@ func Querier[T any](ctx context.Context, dbConn *sql.DB, query Query, logger *sl
 
 	var err error
 
-	// If requested model is units, get number of rows
+	// If requested model is units, get number of rows. When query has a limit,
+	// use it as number of rows instead of counting them
 	switch any(*new(T)).(type) {
 	case models.Unit:
-		if numRows, err = countRows(ctx, dbConn, query); err != nil {
+		if query.numRows > 0 {
+			numRows = query.numRows
+		} else if numRows, err = countRows(ctx, dbConn, query); err != nil {
 			logger.Error("Failed to get rows count", "err", err)
 
 			return nil, err
@@ -229,3 +238,97 @@ func Querier[T any](ctx context.Context, dbConn *sql.DB, query Query, logger *sl
 
 	return scanRows[T](rows, numRows)
 }
+
+// CSVQuerier queries the DB and writes the rows as CSV records to the writer. Rows
+// are written as they are read from DB without building the full result set in
+// memory so that it is suitable for exporting large number of rows. First record
+// contains the column names. Returns the number of rows written.
+func CSVQuerier(ctx context.Context, dbConn *sql.DB, query Query, w io.Writer, logger *slog.Logger) (int, error) {
+	// Get query string and params
+	queryString, queryParams := query.get()
+
+	queryStmt, err := dbConn.Prepare(queryString)
+	if err != nil {
+		logger.Error("Failed prepare query statement",
+			"query", queryString, "queryParams", strings.Join(queryParams, ","), "err", err,
+		)
+
+		return 0, err
+	}
+	defer queryStmt.Close()
+
+	// queryParams has to be an inteface. Do casting here
+	qParams := make([]interface{}, len(queryParams))
+	for i, v := range queryParams {
+		qParams[i] = v
+	}
+
+	rows, err := queryStmt.QueryContext(ctx, qParams...)
+	if err != nil {
+		logger.Error("Failed to get rows",
+			"query", queryString, "queryParams", strings.Join(queryParams, ","), "err", err,
+		)
+
+		return 0, err
+	}
+	defer rows.Close()
+
+	logger.Debug("DB query", "query", queryString, "queryParams", strings.Join(queryParams, ","))
+
+	// Get columns
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("cannot fetch columns: %w", err)
+	}
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(columns); err != nil {
+		return 0, err
+	}
+
+	// Scan each row into raw bytes as values are only written to writer
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]any, len(columns))
+
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	record := make([]string, len(columns))
+
+	numRows := 0
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return numRows, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		for i, v := range values {
+			record[i] = string(v)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return numRows, err
+		}
+
+		numRows++
+
+		// Flush periodically so that records are streamed to client
+		if numRows%csvFlushRows == 0 {
+			if writer.Flush(); writer.Error() != nil {
+				return numRows, writer.Error()
+			}
+		}
+	}
+
+	// Ref: http://go-database-sql.org/errors.html
+	// Get all the errors during iteration
+	if err := rows.Err(); err != nil {
+		return numRows, err
+	}
+
+	writer.Flush()
+
+	return numRows, writer.Error()
+}
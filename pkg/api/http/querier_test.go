@@ -4,6 +4,7 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
@@ -174,6 +175,67 @@ func TestUnitsQuerier(t *testing.T) {
 	assert.Equal(t, expectedUnits, units)
 }
 
+func TestUnitsQuerierWithLimit(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	db, err := setupTestDB()
+	require.NoError(t, err, "failed to setup test DB")
+	defer db.Close()
+
+	// Total number of units
+	q := Query{}
+	q.query("SELECT * FROM " + base.UnitsDBTableName)
+
+	total, err := countRows(context.Background(), db, q)
+	require.NoError(t, err)
+
+	units, err := Querier[models.Unit](context.Background(), db, q, logger)
+	require.NoError(t, err)
+	assert.Len(t, units, total)
+
+	// Paginated queries must return only the units of page
+	for _, offset := range []int{0, 2, total - 1} {
+		q := Query{}
+		q.query("SELECT * FROM " + base.UnitsDBTableName + " ORDER BY cluster_id, uuid")
+		q.limit(2)
+		q.query(fmt.Sprintf("OFFSET %d ", offset))
+
+		units, err := Querier[models.Unit](context.Background(), db, q, logger)
+		require.NoError(t, err)
+		assert.Len(t, units, min(2, total-offset), "offset %d", offset)
+		assert.Equal(t, 2, cap(units), "offset %d", offset)
+	}
+}
+
+func TestCSVQuerier(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	db, err := setupTestDB()
+	require.NoError(t, err, "failed to setup test DB")
+	defer db.Close()
+
+	// Query
+	q := Query{}
+	q.query(
+		fmt.Sprintf(
+			"SELECT uuid,project,username,num_updates,total_time_seconds FROM %s WHERE ignore = 0 AND username in ('usr1') AND cluster_id in ('slurm-0') ORDER BY uuid",
+			base.UnitsDBTableName,
+		),
+	)
+
+	var buf bytes.Buffer
+
+	numRows, err := CSVQuerier(context.Background(), db, q, &buf, logger)
+	require.NoError(t, err)
+	assert.Equal(t, 2, numRows)
+
+	expected := `uuid,project,username,num_updates,total_time_seconds
+147973,acc2,usr1,1,"{""alloc_cpumemtime"":162856960,""alloc_cputime"":7952,""alloc_gpumemtime"":497,""alloc_gputime"":3976,""walltime"":497}"
+1479763,acc1,usr1,1,"{""alloc_cpumemtime"":970588160,""alloc_cputime"":23696,""alloc_gpumemtime"":2962,""alloc_gputime"":23696,""walltime"":2962}"
+`
+	assert.Equal(t, expected, buf.String())
+}
+
 func TestUsageQuerier(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
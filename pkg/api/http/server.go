@@ -7,15 +7,22 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"log/slog"
+	"maps"
+	"math"
 	"net/http"
 	_ "net/http/pprof" // #nosec
 	"net/url"
+	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -33,8 +40,12 @@ import (
 	"github.com/mahendrapaipuri/ceems/pkg/api/http/docs"
 	"github.com/mahendrapaipuri/ceems/pkg/api/models"
 	"github.com/mahendrapaipuri/ceems/pkg/sqlite3"
+	"github.com/mahendrapaipuri/ceems/pkg/tsdb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/common/config"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/version"
 	"github.com/prometheus/exporter-toolkit/web"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
@@ -56,14 +67,92 @@ const (
 	globalUsage  = "global"
 )
 
+// Known states of units reported by resource managers.
+var knownUnitStates = []string{
+	// SLURM job states
+	"BOOT_FAIL", "CANCELLED", "COMPLETED", "DEADLINE", "FAILED", "NODE_FAIL", "OUT_OF_MEMORY",
+	"PENDING", "PREEMPTED", "RUNNING", "REQUEUED", "RESIZING", "REVOKED", "STOPPED", "SUSPENDED", "TIMEOUT",
+	// Openstack server statuses
+	"ACTIVE", "BUILD", "DELETED", "ERROR", "HARD_REBOOT", "MIGRATING", "PASSWORD", "PAUSED",
+	"REBOOT", "REBUILD", "RESCUE", "RESIZE", "REVERT_RESIZE", "SHELVED", "SHELVED_OFFLOADED",
+	"SHUTOFF", "SOFT_DELETED", "UNKNOWN", "VERIFY_RESIZE",
+}
+
+// Regex that keys of metric maps in metric threshold query parameters must match.
+var metricKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// Columns of units table by which units can be ordered. Time columns are
+// ordered using their timestamp counterparts.
+var unitsOrderCols = map[string]string{
+	"cluster_id":       "cluster_id",
+	"resource_manager": "resource_manager",
+	"uuid":             "uuid",
+	"name":             "name",
+	"project":          "project",
+	"groupname":        "groupname",
+	"username":         "username",
+	"state":            "state",
+	"created_at":       "created_at_ts",
+	"started_at":       "started_at_ts",
+	"ended_at":         "ended_at_ts",
+}
+
+// Default order of units.
+const defaultUnitsOrder = " ORDER BY cluster_id ASC, uuid ASC "
+
+// Unit status filters.
+const (
+	unitStatusActive     = "active"
+	unitStatusTerminated = "terminated"
+	unitStatusAll        = "all"
+)
+
+// Namespace of metrics exposed by API server.
+const metricsNamespace = "ceems_api"
+
+// Response header that reports duration of DB query.
+const queryDurationHeader = "X-Ceems-Query-Duration"
+
+// Maximum number of units returned in a single page.
+const maxUnitsPageLimit = 10000
+
+// Limits of JSON body of units search requests.
+const (
+	maxSearchBodyBytes = 10 << 20
+	maxSearchUUIDs     = 10000
+)
+
+// Time series query related constants.
+const (
+	defaultTimeseriesStep = time.Minute
+	// TSDB rejects range queries with more than 11000 points per series
+	maxTimeseriesPoints = 11000
+)
+
+// Regex that metric names in time series queries must match.
+var metricNameRegex = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// APIKeyConfig contains an API key and the user identity that it maps to.
+type APIKeyConfig struct {
+	User    string        `yaml:"user"`
+	Key     config.Secret `yaml:"key"`
+	KeyFile string        `yaml:"key_file"`
+}
+
 // WebConfig makes HTTP web config from CLI args.
 type WebConfig struct {
 	Addresses        []string
+	GRPCAddress      string
 	WebSystemdSocket bool
 	WebConfigFile    string
 	RoutePrefix      string                  `yaml:"route_prefix"`
 	MaxQueryPeriod   model.Duration          `yaml:"max_query"`
 	RequestsLimit    int                     `yaml:"requests_limit"`
+	CacheTTL         model.Duration          `yaml:"cache_ttl"`
+	MaxResultRows    int                     `yaml:"max_result_rows"`
+	QueryDuration    bool                    `yaml:"query_duration_header"`
+	SystemUsers      []string                `yaml:"system_users"`
+	APIKeys          []APIKeyConfig          `yaml:"api_keys"`
 	URL              string                  `yaml:"url"`
 	HTTPClientConfig config.HTTPClientConfig `yaml:",inline"`
 }
@@ -73,6 +162,7 @@ func (c *WebConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// Set a default config
 	*c = WebConfig{
 		RoutePrefix: "/",
+		CacheTTL:    model.Duration(defaultCacheTTL),
 	}
 
 	type plain WebConfig
@@ -81,6 +171,25 @@ func (c *WebConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return err
 	}
 
+	// Query windows are rounded to cache TTL in seconds
+	if time.Duration(c.CacheTTL) < time.Second {
+		return errors.New("cache_ttl must be at least 1s")
+	}
+
+	if c.MaxResultRows < 0 {
+		return errors.New("max_result_rows must be non-negative")
+	}
+
+	for i, key := range c.APIKeys {
+		if key.User == "" {
+			return fmt.Errorf("user of api_keys[%d] must be set", i)
+		}
+
+		if (key.Key == "") == (key.KeyFile == "") {
+			return fmt.Errorf("exactly one of key or key_file of api_keys[%d] must be set", i)
+		}
+	}
+
 	// Set HTTPClientConfig in Web to empty struct as we do not and should not need
 	// CEEMS API server's client config on the server. The client config is only used
 	// in LB
@@ -93,11 +202,21 @@ func (c *WebConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// SetDirectory joins any relative file paths with dir.
+func (c *WebConfig) SetDirectory(dir string) {
+	for i := range c.APIKeys {
+		c.APIKeys[i].KeyFile = config.JoinDir(dir, c.APIKeys[i].KeyFile)
+	}
+}
+
 // Config makes a server config.
 type Config struct {
 	Logger *slog.Logger
 	Web    WebConfig
 	DB     db.Config
+	TSDBs  map[string]*tsdb.TSDB // Map of cluster ID to its TSDB client
+	// Collectors of updaters' metrics exported on metrics endpoint
+	Collectors []prometheus.Collector
 }
 
 type queriers struct {
@@ -108,6 +227,8 @@ type queriers struct {
 	cluster func(context.Context, *sql.DB, Query, *slog.Logger) ([]models.Cluster, error)
 	stat    func(context.Context, *sql.DB, Query, *slog.Logger) ([]models.Stat, error)
 	key     func(context.Context, *sql.DB, Query, *slog.Logger) ([]models.Key, error)
+	count   func(context.Context, *sql.DB, Query) (int, error)
+	export  func(context.Context, *sql.DB, Query, io.Writer, *slog.Logger) (int, error)
 }
 
 // CEEMSServer struct implements HTTP server for stats.
@@ -115,30 +236,58 @@ type CEEMSServer struct {
 	logger         *slog.Logger
 	server         *http.Server
 	webConfig      *web.FlagConfig
+	grpcServer     *http.Server
+	grpcWebConfig  *web.FlagConfig
+	amw            *authenticationMiddleware
+	limiter        *httprate.RateLimiter
 	db             *sql.DB
 	dbConfig       db.Config
 	maxQueryPeriod time.Duration
+	cacheTTL       time.Duration
+	maxResultRows  int
+	queryDuration  bool
+	systemUsers    []string
 	queriers       queriers
-	usageCache     *ttlcache.Cache[uint64, []models.Usage] // Cache that stores usage query results
+	tsdbs          map[string]*tsdb.TSDB
+	usageCache     *ttlcache.Cache[string, []models.Usage] // Cache that stores usage query results
+	cacheHits      prometheus.Counter
+	cacheMisses    prometheus.Counter
 	healthCheck    func(*sql.DB, *slog.Logger) bool
 }
 
 // Response defines the response model of CEEMSAPIServer.
 type Response[T any] struct {
-	Status    string    `json:"status"`
-	Data      []T       `json:"data"`
-	ErrorType errorType `json:"errorType,omitempty"`
-	Error     string    `json:"error,omitempty"`
-	Warnings  []string  `json:"warnings,omitempty"`
+	Status     string            `json:"status"`
+	Data       []T               `json:"data"`
+	ErrorType  errorType         `json:"errorType,omitempty"`
+	Code       string            `json:"code,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	Warnings   []string          `json:"warnings,omitempty"`
+	Units      map[string]string `json:"units,omitempty"`
+	Pagination *Pagination       `json:"pagination,omitempty"`
+	GroupBy    []string          `json:"groupby,omitempty"`
+}
+
+// Pagination contains the pagination metadata of the response.
+type Pagination struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextOffset *int   `json:"next_offset,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 var (
 	aggUsageQueries    = make(map[string]string, len(base.UsageDBTableColNames))
-	cacheTTL           = 15 * time.Minute
+	defaultCacheTTL    = 15 * time.Minute
 	defaultQueryWindow = 24 * time.Hour // One day
 )
 
 const (
+	// Pseudo column to group current usage into daily buckets.
+	groupByDay = "day"
+
 	// Query to get quick stats like active projects, groups, jobs, etc.
 	statsQuery = `cluster_id,resource_manager,COUNT(*) AS num_units,COUNT(CASE WHEN ended_at_ts > 0 THEN 1 END) as num_inactive_units,COUNT(CASE WHEN ended_at_ts = 0 THEN 1 END) as num_active_units,COUNT(DISTINCT project) AS num_projects,COUNT(DISTINCT username) AS num_users`
 )
@@ -164,6 +313,32 @@ func init() {
 			aggUsageQueries[col] = col
 		}
 	}
+
+	// Day is not a column of usage table and it is computed from the timestamp
+	// of rows of daily usage table which is the midnight of each day
+	aggUsageQueries[groupByDay] = "date(u.last_updated_at) AS day"
+}
+
+// queryDBPath returns the path of DB file that must be used for serving queries.
+// When a read replica of DB is configured, it will be used so that expensive
+// queries do not contend with the updater that writes to primary DB.
+func queryDBPath(c db.DataConfig, logger *slog.Logger) string {
+	primaryDBPath := filepath.Join(c.Path, base.CEEMSDBName)
+
+	if c.ReplicaPath == "" {
+		return primaryDBPath
+	}
+
+	replicaDBPath := filepath.Join(c.ReplicaPath, base.CEEMSDBName)
+	if _, err := os.Stat(replicaDBPath); err != nil {
+		logger.Warn("Failed to find replica DB. Using primary DB for queries", "replica", replicaDBPath, "err", err)
+
+		return primaryDBPath
+	}
+
+	logger.Info("Using replica DB for queries", "replica", replicaDBPath)
+
+	return replicaDBPath
 }
 
 // Ping DB for connection test.
@@ -198,6 +373,11 @@ func New(c *Config) (*CEEMSServer, func(), error) {
 		},
 		dbConfig:       c.DB,
 		maxQueryPeriod: time.Duration(c.Web.MaxQueryPeriod),
+		cacheTTL:       time.Duration(c.Web.CacheTTL),
+		maxResultRows:  c.Web.MaxResultRows,
+		queryDuration:  c.Web.QueryDuration,
+		systemUsers:    c.Web.SystemUsers,
+		tsdbs:          c.TSDBs,
 		queriers: queriers{
 			unit:    Querier[models.Unit],
 			usage:   Querier[models.Usage],
@@ -206,10 +386,17 @@ func New(c *Config) (*CEEMSServer, func(), error) {
 			cluster: Querier[models.Cluster],
 			stat:    Querier[models.Stat],
 			key:     Querier[models.Key],
+			count:   countRows,
+			export:  CSVQuerier,
 		},
 		healthCheck: getDBStatus,
 	}
 
+	// Use default cache TTL when it is not configured
+	if server.cacheTTL < time.Second {
+		server.cacheTTL = defaultCacheTTL
+	}
+
 	// Get route prefix based on external URL path
 	var routePrefix string
 	if c.Web.RoutePrefix != "/" {
@@ -242,23 +429,41 @@ func New(c *Config) (*CEEMSServer, func(), error) {
 
 	// Allow only GET methods
 	subRouter.HandleFunc("/health", server.health).Methods(http.MethodGet)
+	subRouter.HandleFunc("/status/buildinfo", server.buildInfo).Methods(http.MethodGet)
 	subRouter.HandleFunc("/"+usersResourceName, server.users).Methods(http.MethodGet)
 	subRouter.HandleFunc("/"+projectsResourceName, server.projects).Methods(http.MethodGet)
 	subRouter.HandleFunc("/"+unitsResourceName, server.units).Methods(http.MethodGet)
 	subRouter.HandleFunc(fmt.Sprintf("/%s/{mode:(?:current|global)}", usageResourceName), server.usage).
 		Methods(http.MethodGet)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/count", unitsResourceName), server.unitsCount).Methods(http.MethodGet)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/export", unitsResourceName), server.unitsExport).Methods(http.MethodGet)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/search", unitsResourceName), server.unitsSearch).Methods(http.MethodPost)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/{uuid}/timeseries", unitsResourceName), server.unitTimeseries).
+		Methods(http.MethodGet)
 	subRouter.HandleFunc(fmt.Sprintf("/%s/verify", unitsResourceName), server.verifyUnitsOwnership).
 		Methods(http.MethodGet)
 
 	// Admin end points
 	subRouter.HandleFunc(fmt.Sprintf("/%s/admin", usersResourceName), server.usersAdmin).Methods(http.MethodGet)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/{name}/admin", usersResourceName), server.purgeUserAdmin).
+		Methods(http.MethodDelete)
 	subRouter.HandleFunc(fmt.Sprintf("/%s/admin", projectsResourceName), server.projectsAdmin).Methods(http.MethodGet)
 	subRouter.HandleFunc(fmt.Sprintf("/%s/admin", clustersResourceName), server.clustersAdmin).Methods(http.MethodGet)
 	subRouter.HandleFunc(fmt.Sprintf("/%s/admin", unitsResourceName), server.unitsAdmin).Methods(http.MethodGet)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/ignore/admin", unitsResourceName), server.ignoreUnitsAdmin).
+		Methods(http.MethodPut)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/count/admin", unitsResourceName), server.unitsCountAdmin).
+		Methods(http.MethodGet)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/export/admin", unitsResourceName), server.unitsExportAdmin).
+		Methods(http.MethodGet)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/search/admin", unitsResourceName), server.unitsSearchAdmin).
+		Methods(http.MethodPost)
 	subRouter.HandleFunc(fmt.Sprintf("/%s/{mode:(?:current|global)}/admin", usageResourceName), server.usageAdmin).
 		Methods(http.MethodGet)
 	subRouter.HandleFunc(fmt.Sprintf("/%s/{mode:(?:current|global)}/admin", statsResourceName), server.statsAdmin).
 		Methods(http.MethodGet)
+	subRouter.HandleFunc(fmt.Sprintf("/%s/cache/admin", usageResourceName), server.usageCacheAdmin).
+		Methods(http.MethodDelete)
 
 	// A demo end point that returns mocked data for units and/or usage tables
 	subRouter.HandleFunc("/demo/{resource:(?:units|usage)}", server.demo).Methods(http.MethodGet)
@@ -276,7 +481,7 @@ func New(c *Config) (*CEEMSServer, func(), error) {
 	// Open DB connection
 	dsn := fmt.Sprintf(
 		"file:%s?%s",
-		filepath.Join(c.DB.Data.Path, base.CEEMSDBName),
+		queryDBPath(c.DB.Data, c.Logger),
 		"_mutex=no&mode=ro&_busy_timeout=5000",
 	)
 	if server.db, err = sql.Open(sqlite3.DriverName, dsn); err != nil {
@@ -286,27 +491,83 @@ func New(c *Config) (*CEEMSServer, func(), error) {
 	// Rate limit requests by RealIP
 	if c.Web.RequestsLimit > 0 {
 		c.Logger.Debug("Rate limiting settings", "reqs_per_minute", c.Web.RequestsLimit)
-		router.Use(httprate.LimitByRealIP(c.Web.RequestsLimit, time.Minute))
+		server.limiter = httprate.NewRateLimiter(
+			c.Web.RequestsLimit, time.Minute, httprate.WithKeyFuncs(httprate.KeyByRealIP),
+		)
+		router.Use(server.limiter.Handler)
+	}
+
+	// Load API keys
+	apiKeys, err := newAPIKeys(c.Web.APIKeys)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("failed to load API keys: %w", err)
 	}
 
 	// Add a middleware that verifies headers and pass them in requests
 	// The middleware will fetch admin users from Grafana periodically to update list
-	amw := authenticationMiddleware{
+	server.amw = &authenticationMiddleware{
 		logger:          c.Logger,
 		routerPrefix:    routePrefix,
-		whitelistedURLs: regexp.MustCompile(routePrefix + "(swagger|health|demo)(.*)"),
+		whitelistedURLs: regexp.MustCompile("^/metrics$|" + routePrefix + "(swagger|health|status/buildinfo|demo)(.*)"),
 		db:              server.db,
 		adminUsers:      adminUsers,
+		apiKeys:         apiKeys,
 	}
-	router.Use(amw.Middleware)
+	router.Use(server.amw.Middleware)
+
+	// Instantiate new cache for storing current usage query results with configured TTL
+	c.Logger.Debug("Usage cache settings", "ttl", server.cacheTTL)
 
-	// Instantiate new cache for storing current usage query results with TTL of 15 min
 	server.usageCache = ttlcache.New(
-		ttlcache.WithTTL[uint64, []models.Usage](cacheTTL),
+		ttlcache.WithTTL[string, []models.Usage](server.cacheTTL),
 	)
 	// starts automatic expired item deletion
 	go server.usageCache.Start()
 
+	// Setup metrics of usage cache
+	server.cacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "usage_cache",
+		Name:      "hits_total",
+		Help:      "Total number of current usage queries served from cache",
+	})
+	server.cacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "usage_cache",
+		Name:      "misses_total",
+		Help:      "Total number of current usage queries not found in cache",
+	})
+	cacheEntries := prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "usage_cache",
+			Name:      "entries",
+			Help:      "Current number of entries in usage cache",
+		},
+		func() float64 { return float64(server.usageCache.Len()) },
+	)
+
+	// Handle metrics path
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(server.cacheHits, server.cacheMisses, cacheEntries)
+	registry.MustRegister(c.Collectors...)
+	router.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{})).Methods(http.MethodGet)
+
+	// Handle usage metrics path. This is not whitelisted in auth middleware and
+	// hence, requests must include user header
+	router.HandleFunc("/metrics/usage", server.usageMetrics).Methods(http.MethodGet)
+
+	// Serve gRPC service on a separate address, when configured. Requests go
+	// through the same rate limiter and authentication as HTTP requests
+	if c.Web.GRPCAddress != "" {
+		server.grpcServer = newGRPCServer(c.Web.GRPCAddress, server, routePrefix)
+		server.grpcWebConfig = &web.FlagConfig{
+			WebListenAddresses: &[]string{c.Web.GRPCAddress},
+			WebSystemdSocket:   func() *bool { b := false; return &b }(), //nolint:nlreturn
+			WebConfigFile:      &c.Web.WebConfigFile,
+		}
+	}
+
 	return server, func() {}, nil
 }
 
@@ -321,9 +582,12 @@ func New(c *Config) (*CEEMSServer, func(), error) {
 //	@description
 //	@description	If basic auth is enabled, all the endpoints require authentication.
 //	@description
-//	@description	All the endpoints, except `health`, `swagger`, `debug` and `demo`,
+//	@description	All the endpoints, except `health`, `status/buildinfo`, `swagger`, `debug` and `demo`,
 //	@description	must send a user-agent header.
 //	@description
+//	@description	When API keys are configured, clients can authenticate using the API key
+//	@description	in `X-Api-Key` header instead of user header. API keys grant read only access.
+//	@description
 //	@description				Timestamps must be specified in milliseconds, unless otherwise specified.
 //
 //	@contact.name				Mahendra Paipuri
@@ -344,6 +608,17 @@ func (s *CEEMSServer) Start() error {
 
 	s.logger.Info("Starting " + base.CEEMSServerAppName)
 
+	if s.grpcServer != nil {
+		go func() {
+			s.logger.Info("Starting gRPC server", "address", s.grpcServer.Addr)
+
+			if err := web.ListenAndServe(s.grpcServer, s.grpcWebConfig, s.logger); err != nil &&
+				!errors.Is(err, http.ErrServerClosed) {
+				s.logger.Error("Failed to Listen and Serve gRPC server", "err", err)
+			}
+		}()
+	}
+
 	if err := web.ListenAndServe(s.server, s.webConfig, s.logger); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		s.logger.Error("Failed to Listen and Serve HTTP server", "err", err)
 
@@ -369,6 +644,14 @@ func (s *CEEMSServer) Shutdown(ctx context.Context) error {
 		return err
 	}
 
+	if s.grpcServer != nil {
+		if err := s.grpcServer.Shutdown(ctx); err != nil {
+			s.logger.Error("Failed to shutdown gRPC server", "err", err)
+
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -383,6 +666,16 @@ func (s *CEEMSServer) setHeaders(w http.ResponseWriter) {
 	w.Header().Set("X-Content-Type-Options", "nosniff")
 }
 
+// setQueryDurationHeader sets the duration of DB query since start in seconds
+// as a response header, when enabled in config.
+func (s *CEEMSServer) setQueryDurationHeader(start time.Time, w http.ResponseWriter) {
+	if !s.queryDuration {
+		return
+	}
+
+	w.Header().Set(queryDurationHeader, strconv.FormatFloat(time.Since(start).Seconds(), 'f', 6, 64))
+}
+
 // setWriteDeadline sets write deadline to the request.
 func (s *CEEMSServer) setWriteDeadline(deadline time.Duration, w http.ResponseWriter) {
 	// Response controller
@@ -420,6 +713,42 @@ func (s *CEEMSServer) health(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// buildInfo godoc
+//
+//	@Summary		Build information
+//	@Description	This endpoint returns the build information of the server like version,
+//	@Description	revision, branch and Go version.
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	Response[models.BuildInfo]
+//	@Router			/status/buildinfo [get]
+//
+// GET /status/buildinfo
+// Get build information of server.
+func (s *CEEMSServer) buildInfo(w http.ResponseWriter, r *http.Request) {
+	// Set headers
+	s.setHeaders(w)
+	w.WriteHeader(http.StatusOK)
+
+	response := Response[models.BuildInfo]{
+		Status: "success",
+		Data: []models.BuildInfo{
+			{
+				Version:   version.Version,
+				Revision:  version.GetRevision(),
+				Branch:    version.Branch,
+				BuildUser: version.BuildUser,
+				BuildDate: version.BuildDate,
+				GoVersion: version.GoVersion,
+			},
+		},
+	}
+	if err := json.NewEncoder(w).Encode(&response); err != nil {
+		s.logger.Error("Failed to encode response", "err", err)
+		w.Write([]byte("KO"))
+	}
+}
+
 // getCommonQueryParams fetches project and running query parameters and add them to query.
 func (s *CEEMSServer) getCommonQueryParams(q *Query, urlValues url.Values) Query {
 	// Get project query parameters if any
@@ -437,6 +766,288 @@ func (s *CEEMSServer) getCommonQueryParams(q *Query, urlValues url.Values) Query
 	return *q
 }
 
+// getStatusQueryParam fetches status query parameter and adds it to query.
+// Running units will have ended_at_ts as 0 and we use this in query to
+// filter active and terminated units.
+func (s *CEEMSServer) getStatusQueryParam(q *Query, urlValues url.Values) (Query, error) {
+	switch urlValues.Get("status") {
+	case "", unitStatusAll:
+	case unitStatusActive:
+		q.query(" AND ended_at_ts = 0 ")
+	case unitStatusTerminated:
+		q.query(" AND ended_at_ts > 0 ")
+	default:
+		return *q, errInvalidStatus
+	}
+
+	return *q, nil
+}
+
+// getStateQueryParam adds state query parameters to query. States are case
+// insensitive and must be one of knownUnitStates. As SLURM can add extra information
+// to states, like `CANCELLED by <uid>`, states that start with queried states
+// are matched as well.
+func (s *CEEMSServer) getStateQueryParam(q *Query, urlValues url.Values) (Query, error) {
+	if len(urlValues["state"]) == 0 {
+		return *q, nil
+	}
+
+	states := make([]string, len(urlValues["state"]))
+
+	for i, state := range urlValues["state"] {
+		states[i] = strings.ToUpper(state)
+		if !slices.Contains(knownUnitStates, states[i]) {
+			return *q, errInvalidState
+		}
+	}
+
+	q.query(" AND (state IN ")
+	q.param(states)
+
+	for _, state := range states {
+		q.query(" OR state LIKE ")
+		q.param([]string{state + " %"})
+	}
+
+	q.query(")")
+
+	return *q, nil
+}
+
+// getTagQueryParams adds tag query parameters to query. Tags must be of form
+// `<key>:<value>` and only units whose tags contain the key with the given
+// value are matched. When several tags are provided, units must match all of them.
+func (s *CEEMSServer) getTagQueryParams(q *Query, urlValues url.Values) (Query, error) {
+	if len(urlValues["tag"]) == 0 {
+		return *q, nil
+	}
+
+	for _, tag := range urlValues["tag"] {
+		key, value, ok := strings.Cut(tag, ":")
+		if !ok || !metricKeyRegex.MatchString(key) {
+			return *q, fmt.Errorf("%w: %s", errInvalidTag, tag)
+		}
+
+		// Tag values can be strings or integers and hence cast them to text
+		// before comparing
+		q.query(" AND CAST(json_extract(tags, ")
+		q.param([]string{"$." + key})
+		q.query(") AS TEXT) = ")
+		q.param([]string{value})
+	}
+
+	return *q, nil
+}
+
+// isMetricUnitsCol returns true if column of units table stores a metric map.
+func isMetricUnitsCol(col string) bool {
+	return strings.HasPrefix(col, "total") || strings.HasPrefix(col, "avg")
+}
+
+// getMetricThresholdQueryParams adds metric threshold query parameters to query.
+// Query parameters must be of form `min_<column>[.<key>]` or `max_<column>[.<key>]`
+// where column must be a metric column of units table. When key is provided, only
+// the value of that key in metric map is compared with threshold. Else the condition
+// is satisfied when value of any key in metric map satisfies the threshold.
+func (s *CEEMSServer) getMetricThresholdQueryParams(q *Query, urlValues url.Values) (Query, error) {
+	// Sort parameters to have deterministic queries
+	params := slices.Sorted(maps.Keys(urlValues))
+
+	for _, param := range params {
+		var op string
+
+		switch {
+		case strings.HasPrefix(param, "min_"):
+			op = ">="
+		case strings.HasPrefix(param, "max_"):
+			op = "<="
+		default:
+			continue
+		}
+
+		col, key, hasKey := strings.Cut(param[len("min_"):], ".")
+		if !isMetricUnitsCol(col) || !slices.Contains(base.UnitsDBTableColNames, col) {
+			return *q, fmt.Errorf("%w: unknown metric %s", errInvalidThreshold, col)
+		}
+
+		if hasKey && !metricKeyRegex.MatchString(key) {
+			return *q, fmt.Errorf("%w: invalid key %s of metric %s", errInvalidThreshold, key, col)
+		}
+
+		for _, v := range urlValues[param] {
+			threshold, err := strconv.ParseFloat(v, 64)
+			if err != nil || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+				return *q, fmt.Errorf("%w: invalid value %s of %s", errInvalidThreshold, v, param)
+			}
+
+			if hasKey {
+				q.query(fmt.Sprintf(" AND CAST(json_extract(%s, ", col))
+				q.param([]string{"$." + key})
+				q.query(fmt.Sprintf(") AS REAL) %s ", op))
+			} else {
+				q.query(fmt.Sprintf(" AND EXISTS (SELECT 1 FROM json_each(%s) WHERE CAST(value AS REAL) %s ", col, op))
+			}
+
+			q.param([]string{strconv.FormatFloat(threshold, 'f', -1, 64)})
+
+			if !hasKey {
+				q.query(")")
+			}
+		}
+	}
+
+	return *q, nil
+}
+
+// getUnitsOrderQueryParam returns the ORDER BY clause of units query built from
+// order query parameters of form `<column>[:asc|desc]`. Units are always ordered
+// by cluster_id and uuid after the requested columns to have a deterministic order.
+func (s *CEEMSServer) getUnitsOrderQueryParam(urlValues url.Values) (string, error) {
+	if len(urlValues["order"]) == 0 {
+		return defaultUnitsOrder, nil
+	}
+
+	var orders []string
+
+	for _, order := range urlValues["order"] {
+		name, direction, _ := strings.Cut(order, ":")
+
+		col, ok := unitsOrderCols[name]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", errInvalidOrder, order)
+		}
+
+		switch strings.ToLower(direction) {
+		case "", "asc":
+			direction = "ASC"
+		case "desc":
+			direction = "DESC"
+		default:
+			return "", fmt.Errorf("%w: %s", errInvalidOrder, order)
+		}
+
+		// Column names are validated and hence it is safe to add them to query
+		orders = append(orders, fmt.Sprintf("%s %s", col, direction))
+	}
+
+	for _, col := range []string{"cluster_id", "uuid"} {
+		if !slices.ContainsFunc(orders, func(o string) bool { return strings.HasPrefix(o, col+" ") }) {
+			orders = append(orders, col+" ASC")
+		}
+	}
+
+	return fmt.Sprintf(" ORDER BY %s ", strings.Join(orders, ", ")), nil
+}
+
+// getPaginationQueryParams returns limit, offset and cursor query parameters. Returns
+// nil when neither limit nor cursor query parameters are present, i.e., when pagination
+// is not requested. Limit is capped to maxUnitsPageLimit and defaults to it when only
+// cursor is present. Cursor and offset cannot be used together.
+func (s *CEEMSServer) getPaginationQueryParams(urlValues url.Values) (*Pagination, error) {
+	if !urlValues.Has("limit") && !urlValues.Has("cursor") {
+		return nil, nil //nolint:nilnil
+	}
+
+	var err error
+
+	limit := maxUnitsPageLimit
+
+	if urlValues.Has("limit") {
+		if limit, err = strconv.Atoi(urlValues.Get("limit")); err != nil || limit <= 0 {
+			return nil, errInvalidLimit
+		}
+	}
+
+	var offset int
+
+	if urlValues.Has("offset") {
+		if offset, err = strconv.Atoi(urlValues.Get("offset")); err != nil || offset < 0 {
+			return nil, errInvalidOffset
+		}
+	}
+
+	cursor := urlValues.Get("cursor")
+	if cursor != "" {
+		if urlValues.Has("offset") {
+			return nil, errCursorWithOffset
+		}
+
+		if _, err := decodeUnitsCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Pagination{Limit: min(limit, maxUnitsPageLimit), Offset: offset, Cursor: cursor}, nil
+}
+
+// getGroupByQueryParams returns the columns that current usage statistics must be
+// grouped by. Usage is always grouped by username and project and any additional
+// columns requested by `groupby` query parameter must be non-aggregated columns of
+// usage table or `day` to split usage into daily buckets.
+func (s *CEEMSServer) getGroupByQueryParams(urlValues url.Values) ([]string, error) {
+	groupby := []string{"username", "project"}
+
+	for _, col := range urlValues["groupby"] {
+		if col = strings.TrimSpace(col); col == "" {
+			continue
+		}
+
+		// Only columns of usage table are allowed to avoid SQL injection and
+		// aggregated metric columns cannot be used as dimensions.
+		if col != groupByDay && (!slices.Contains(base.UsageDBTableColNames, col) || isAggUsageCol(col)) {
+			return nil, errInvalidGroupBy
+		}
+
+		groupby = append(groupby, col)
+	}
+
+	// Remove duplicates values
+	slices.Sort(groupby)
+
+	return slices.Compact(groupby), nil
+}
+
+// isDailyUsageQuery returns true if current usage must be queried from daily usage
+// table. Units table has the usage of each unit over its lifetime and hence, usage
+// can only be split into daily buckets using daily usage table which has the usage
+// of each day.
+func isDailyUsageQuery(urlValues url.Values, groupby []string) bool {
+	if _, ok := urlValues["experimental"]; ok {
+		return true
+	}
+
+	return slices.Contains(groupby, groupByDay)
+}
+
+// isAggUsageCol returns true if column of usage table is an aggregated metric.
+func isAggUsageCol(col string) bool {
+	return strings.HasPrefix(col, "num") || strings.HasPrefix(col, "total") || strings.HasPrefix(col, "avg")
+}
+
+// encodeUnitsCursor returns an opaque cursor that encodes the cluster ID and
+// UUID of the last unit of a page.
+func encodeUnitsCursor(clusterID, uuid string) string {
+	// Marshalling slice of strings never fails
+	data, _ := json.Marshal([]string{clusterID, uuid})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeUnitsCursor returns the cluster ID and UUID encoded in the cursor.
+func decodeUnitsCursor(cursor string) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var tuple []string
+	if err := json.Unmarshal(data, &tuple); err != nil || len(tuple) != 2 {
+		return nil, errInvalidCursor
+	}
+
+	return tuple, nil
+}
+
 // getQueriedFields returns a slice of queried fields.
 func (s *CEEMSServer) getQueriedFields(urlValues url.Values, validFieldNames []string) []string {
 	// Get fields query parameters if any
@@ -457,6 +1068,18 @@ func (s *CEEMSServer) getQueriedFields(urlValues url.Values, validFieldNames []s
 	return queriedFields
 }
 
+// fieldUnits returns units of numeric fields of model T when `include_units`
+// query parameter is set to true. Returns nil otherwise.
+func fieldUnits[T any](r *http.Request) map[string]string {
+	if include, err := strconv.ParseBool(r.URL.Query().Get("include_units")); err != nil || !include {
+		return nil
+	}
+
+	var model T
+
+	return models.FieldUnits(model)
+}
+
 // timeLocation returns `time.Location` based on location name.
 func (s *CEEMSServer) timeLocation(l string) *time.Location {
 	if l == "" {
@@ -525,10 +1148,39 @@ func (s *CEEMSServer) getQueryWindow(r *http.Request) (map[string]string, error)
 	}, nil
 }
 
+// usageETag returns a strong ETag of usage computed from cache key and usage. As
+// cache key is the URL, ETag changes whenever the usage of same query changes
+// after cache entry expires.
+func usageETag(cacheKey string, usage []models.Usage) string {
+	h := sha256.New()
+	h.Write([]byte(cacheKey))
+	h.Write([]byte{0})
+
+	// Marshalling usage never fails as all fields are JSON encodable
+	if data, err := json.Marshal(usage); err == nil {
+		h.Write(data)
+	}
+
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// etagMatches returns true if any of the ETags in If-None-Match header matches
+// the ETag. Weak comparison is used as specified by RFC 9110.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
 // roundQueryWindow rounds `to` and `from` query parameters to nearest multiple of
 // `cacheTTL`.
 func (s *CEEMSServer) roundQueryWindow(r *http.Request) error {
-	cacheTTLSeconds := int64(cacheTTL.Seconds())
+	cacheTTLSeconds := int64(s.cacheTTL.Seconds())
 	q := r.URL.Query()
 
 	// Get to and from query parameters and do checks on them
@@ -604,40 +1256,19 @@ func (s *CEEMSServer) inTargetTimeLocation(tz string, units []models.Unit) []mod
 	return units
 }
 
-// unitsQuerier queries for compute units and write response.
-func (s *CEEMSServer) unitsQuerier(
-	queriedUsers []string,
-	w http.ResponseWriter,
-	r *http.Request,
-) {
+// unitsQueryBuilder builds the query that selects fields of compute units of
+// queried users. Conditions of the query are built from the query parameters of
+// the request.
+func (s *CEEMSServer) unitsQueryBuilder(q *Query, queriedUsers []string, fields []string, r *http.Request) error {
 	var queryWindowTS map[string]string
 
 	var err error
 
-	// Get current logged user and dashboard user from headers
-	loggedUser, _ := s.getUser(r)
-
-	// Set headers
-	s.setHeaders(w)
-
-	// Set write deadline
-	s.setWriteDeadline(5*time.Minute, w)
-
 	// Initialise utility vars
 	checkQueryWindow := true // Check query window size
 
-	// Get fields query parameters if any
-	queriedFields := s.getQueriedFields(r.URL.Query(), base.UnitsDBTableColNames)
-	if len(queriedFields) == 0 {
-		s.logger.Error("Invalid query fields", "loggedUser", loggedUser, "err", errInvalidQueryField)
-		errorResponse[any](w, &apiError{errorBadData, errInvalidQueryField}, s.logger, nil)
-
-		return
-	}
-
 	// Initialise query builder
-	q := Query{}
-	q.query(fmt.Sprintf("SELECT %s FROM %s", strings.Join(queriedFields, ","), base.UnitsDBTableName))
+	q.query(fmt.Sprintf("SELECT %s FROM %s", strings.Join(fields, ","), base.UnitsDBTableName))
 
 	// Query for only unignored units
 	q.query(" WHERE ignore = 0 ")
@@ -649,14 +1280,40 @@ func (s *CEEMSServer) unitsQuerier(
 	}
 
 	// Add common query parameters
-	q = s.getCommonQueryParams(&q, r.URL.Query())
+	*q = s.getCommonQueryParams(q, r.URL.Query())
 
-	// Check if running query param is included
-	// Running units will have ended_at_ts as 0 and we use this in query to
-	// fetch these units
-	if _, ok := r.URL.Query()["running"]; ok {
-		q.query(" OR ended_at_ts IN ")
-		q.param([]string{"0"})
+	// Add status query parameter
+	if *q, err = s.getStatusQueryParam(q, r.URL.Query()); err != nil {
+		return err
+	}
+
+	// Add state query parameter
+	if *q, err = s.getStateQueryParam(q, r.URL.Query()); err != nil {
+		return err
+	}
+
+	// Add tag query parameters
+	if *q, err = s.getTagQueryParams(q, r.URL.Query()); err != nil {
+		return err
+	}
+
+	// Add metric threshold query parameters
+	if *q, err = s.getMetricThresholdQueryParams(q, r.URL.Query()); err != nil {
+		return err
+	}
+
+	// Active units have not ended yet, so query window on ended_at
+	// is irrelevant
+	if r.URL.Query().Get("status") == unitStatusActive {
+		checkQueryWindow = false
+	}
+
+	// Check if running query param is included
+	// Running units will have ended_at_ts as 0 and we use this in query to
+	// fetch these units
+	if _, ok := r.URL.Query()["running"]; ok {
+		q.query(" OR ended_at_ts IN ")
+		q.param([]string{"0"})
 	}
 
 	// Check if uuid present in query params and add them
@@ -669,18 +1326,16 @@ func (s *CEEMSServer) unitsQuerier(
 		checkQueryWindow = false
 	}
 
-	// If we dont have to specific query window skip next section of code as it becomes
-	// irrelevant
+	// If we dont have to specific query window return query as query window
+	// becomes irrelevant
 	if !checkQueryWindow {
-		goto queryUnits
+		return nil
 	}
 
 	// Get query window time stamps
 	queryWindowTS, err = s.getQueryWindow(r)
 	if err != nil {
-		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
-
-		return
+		return err
 	}
 
 	// Add from and to to query only when checkQueryWindow is true
@@ -689,39 +1344,308 @@ func (s *CEEMSServer) unitsQuerier(
 	q.query(" AND ")
 	q.param([]string{queryWindowTS["to"]})
 
-queryUnits:
-	// Sort by uuid
-	q.query(" ORDER BY cluster_id ASC, uuid ASC ")
+	return nil
+}
+
+// unitsResult contains compute units fetched from DB along with the pagination
+// metadata and warnings, if any.
+type unitsResult struct {
+	units      []models.Unit
+	pagination *Pagination
+	warnings   []string
+}
+
+// queryUnits fetches compute units of queriedUsers from DB using query parameters
+// of r.
+func (s *CEEMSServer) queryUnits(queriedUsers []string, r *http.Request) (*unitsResult, *apiError) {
+	// Get current logged user and dashboard user from headers
+	loggedUser, _ := s.getUser(r)
+
+	// Get fields query parameters if any
+	queriedFields := s.getQueriedFields(r.URL.Query(), base.UnitsDBTableColNames)
+	if len(queriedFields) == 0 {
+		s.logger.Error("Invalid query fields", "loggedUser", loggedUser, "err", errInvalidQueryField)
+
+		return nil, &apiError{errorBadData, errInvalidQueryField}
+	}
+
+	// Get pagination query parameters
+	pagination, err := s.getPaginationQueryParams(r.URL.Query())
+	if err != nil {
+		s.logger.Error("Invalid pagination query parameters", "loggedUser", loggedUser, "err", err)
+
+		return nil, &apiError{errorBadData, err}
+	}
+
+	// Page size cannot exceed maximum number of rows of a response
+	if pagination != nil && s.maxResultRows > 0 && pagination.Limit > s.maxResultRows {
+		pagination.Limit = s.maxResultRows
+	}
+
+	// Get order query parameters
+	order, err := s.getUnitsOrderQueryParam(r.URL.Query())
+	if err != nil {
+		s.logger.Error("Invalid order query parameters", "loggedUser", loggedUser, "err", err)
+
+		return nil, &apiError{errorBadData, err}
+	}
+
+	// Cursor is a keyset on cluster ID and UUID of units and hence it can only
+	// be used when units are in default order
+	if pagination != nil && pagination.Cursor != "" && order != defaultUnitsOrder {
+		s.logger.Error("Invalid query parameters", "loggedUser", loggedUser, "err", errCursorWithOrder)
+
+		return nil, &apiError{errorBadData, errCursorWithOrder}
+	}
+
+	// Cluster ID and UUID of units are needed to compute the next cursor
+	if pagination != nil {
+		for _, f := range []string{"cluster_id", "uuid"} {
+			if !slices.Contains(queriedFields, f) {
+				queriedFields = append(queriedFields, f)
+			}
+		}
+	}
+
+	// Build query
+	q := Query{}
+	if err := s.unitsQueryBuilder(&q, queriedUsers, queriedFields, r); err != nil {
+		s.logger.Error("Invalid query parameters", "loggedUser", loggedUser, "err", err)
+
+		return nil, &apiError{errorBadData, err}
+	}
+
+	// Get total number of units before paginating them
+	if pagination != nil {
+		if pagination.Total, err = s.queriers.count(r.Context(), s.db, q); err != nil {
+			s.logger.Error("Failed to count units", "loggedUser", loggedUser, "err", err)
+
+			return nil, &apiError{errorInternal, err}
+		}
+	}
+
+	// When cursor is present, fetch only units after the one encoded in cursor.
+	// Units query is wrapped in a sub query so that the keyset condition applies
+	// to all the conditions of the units query
+	query := &q
+
+	if pagination != nil && pagination.Cursor != "" {
+		// Cursor has been validated already
+		tuple, _ := decodeUnitsCursor(pagination.Cursor)
+
+		cq := Query{}
+		cq.query("SELECT * FROM ")
+		cq.subQuery(q)
+		cq.query(" WHERE (cluster_id, uuid) > ")
+		cq.param(tuple)
+
+		query = &cq
+	}
+
+	// Sort units
+	query.query(order)
+
+	// Paginate units. Limit and offset are validated integers and hence it is
+	// safe to add them to query directly
+	if pagination != nil {
+		query.limit(pagination.Limit)
+		query.query(fmt.Sprintf("OFFSET %d ", pagination.Offset))
+	} else if s.maxResultRows > 0 {
+		// Fetch one extra row to know if results are truncated
+		query.limit(s.maxResultRows + 1)
+	}
 
 	// Get all user units in the given time window
-	units, err := s.queriers.unit(r.Context(), s.db, q, s.logger)
+	units, err := s.queriers.unit(r.Context(), s.db, *query, s.logger)
 	if units == nil && err != nil {
 		s.logger.Error("Failed to fetch units", "loggedUser", loggedUser, "err", err)
-		errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
 
-		return
+		return nil, &apiError{errorInternal, err}
+	}
+
+	result := &unitsResult{pagination: pagination}
+
+	if err != nil {
+		result.warnings = append(result.warnings, err.Error())
+	}
+
+	// Truncate units to maximum number of rows of a response
+	if pagination == nil && s.maxResultRows > 0 && len(units) > s.maxResultRows {
+		units = units[:s.maxResultRows]
+
+		result.warnings = append(
+			result.warnings,
+			fmt.Sprintf("results truncated to %d units. Use pagination to fetch all units", s.maxResultRows),
+		)
 	}
 
 	// Convert times to time zone provided in the query
-	units = s.inTargetTimeLocation(r.URL.Query().Get("timezone"), units)
+	result.units = s.inTargetTimeLocation(r.URL.Query().Get("timezone"), units)
+
+	// Add pagination metadata. When paginating by cursor, total number of units
+	// remaining is unknown and next cursor is returned as long as page is full.
+	// Cursor can only be used with default order and hence, next cursor is not
+	// returned for other orders
+	if pagination != nil && len(units) > 0 {
+		nextOffset := pagination.Offset + len(units)
+
+		morePages := nextOffset < pagination.Total
+		if pagination.Cursor != "" {
+			morePages = len(units) == pagination.Limit
+		} else if morePages {
+			pagination.NextOffset = &nextOffset
+		}
+
+		if morePages && order == defaultUnitsOrder {
+			last := units[len(units)-1]
+			pagination.NextCursor = encodeUnitsCursor(last.ClusterID, last.UUID)
+		}
+	}
+
+	return result, nil
+}
+
+// unitsQuerier queries for compute units and write response.
+func (s *CEEMSServer) unitsQuerier(
+	queriedUsers []string,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	// Set headers
+	s.setHeaders(w)
+
+	// Set write deadline
+	s.setWriteDeadline(5*time.Minute, w)
+
+	result, apiErr := s.queryUnits(queriedUsers, r)
+	if apiErr != nil {
+		errorResponse[any](w, apiErr, s.logger, nil)
+
+		return
+	}
 
 	// Write response
 	w.WriteHeader(http.StatusOK)
 
 	response := Response[models.Unit]{
-		Status: "success",
-		Data:   units,
+		Status:     "success",
+		Data:       result.units,
+		Units:      fieldUnits[models.Unit](r),
+		Warnings:   result.warnings,
+		Pagination: result.pagination,
+	}
+
+	if err := json.NewEncoder(w).Encode(&response); err != nil {
+		s.logger.Error("Failed to encode response", "err", err)
+		w.Write([]byte("KO"))
+	}
+}
+
+// unitsCountQuerier counts compute units and write response.
+func (s *CEEMSServer) unitsCountQuerier(
+	queriedUsers []string,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	// Get current logged user and dashboard user from headers
+	loggedUser, _ := s.getUser(r)
+
+	// Set headers
+	s.setHeaders(w)
+
+	// Build query using same conditions as units query
+	q := Query{}
+	if err := s.unitsQueryBuilder(&q, queriedUsers, []string{"COUNT(*)"}, r); err != nil {
+		s.logger.Error("Invalid query parameters", "loggedUser", loggedUser, "err", err)
+		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
+
+		return
 	}
+
+	// Get number of units
+	count, err := s.queriers.count(r.Context(), s.db, q)
 	if err != nil {
-		response.Warnings = append(response.Warnings, err.Error())
+		s.logger.Error("Failed to count units", "loggedUser", loggedUser, "err", err)
+		errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
+
+		return
 	}
 
+	// Write response
+	w.WriteHeader(http.StatusOK)
+
+	response := Response[int]{
+		Status: "success",
+		Data:   []int{count},
+	}
 	if err = json.NewEncoder(w).Encode(&response); err != nil {
 		s.logger.Error("Failed to encode response", "err", err)
 		w.Write([]byte("KO"))
 	}
 }
 
+// unitsExportQuerier streams units as CSV records in the response.
+func (s *CEEMSServer) unitsExportQuerier(
+	queriedUsers []string,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	// Get current logged user and dashboard user from headers
+	loggedUser, _ := s.getUser(r)
+
+	// Set headers
+	s.setHeaders(w)
+
+	// Exports can contain large number of units. Set a longer write deadline
+	s.setWriteDeadline(30*time.Minute, w)
+
+	// Get fields query parameters if any
+	queriedFields := s.getQueriedFields(r.URL.Query(), base.UnitsDBTableColNames)
+	if len(queriedFields) == 0 {
+		s.logger.Error("Invalid query fields", "loggedUser", loggedUser, "err", errInvalidQueryField)
+		errorResponse[any](w, &apiError{errorBadData, errInvalidQueryField}, s.logger, nil)
+
+		return
+	}
+
+	// Get order query parameters
+	order, err := s.getUnitsOrderQueryParam(r.URL.Query())
+	if err != nil {
+		s.logger.Error("Invalid order query parameters", "loggedUser", loggedUser, "err", err)
+		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
+
+		return
+	}
+
+	// Build query using same conditions as units query
+	q := Query{}
+	if err := s.unitsQueryBuilder(&q, queriedUsers, queriedFields, r); err != nil {
+		s.logger.Error("Invalid query parameters", "loggedUser", loggedUser, "err", err)
+		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
+
+		return
+	}
+
+	// Sort units
+	q.query(order)
+
+	// Set headers of CSV response
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="units.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	// Stream units to response. As response headers have already been written,
+	// errors can only be logged
+	numUnits, err := s.queriers.export(r.Context(), s.db, q, w, s.logger)
+	if err != nil {
+		s.logger.Error("Failed to export units", "loggedUser", loggedUser, "num_units", numUnits, "err", err)
+
+		return
+	}
+
+	s.logger.Debug("Units exported", "loggedUser", loggedUser, "num_units", numUnits)
+}
+
 // unitsAdmin    godoc
 //
 //	@Summary		Admin endpoint for fetching compute units.
@@ -738,6 +1662,103 @@ queryUnits:
 //	@Description	user, null response will be returned.
 //	@Description
 //	@Description	In order to return the running compute units as well, use the query parameter `running`.
+//	@Description	To return only running or only finished compute units, use the query parameter
+//	@Description	`status` with `active` or `terminated` value, respectively. When `status=active`,
+//	@Description	the query window is ignored as active units have not ended yet.
+//	@Description
+//	@Description	To filter compute units by their state as reported by the resource manager, use the
+//	@Description	query parameter `state`, for instance, `state=FAILED&state=TIMEOUT`. States set by
+//	@Description	SLURM with extra information like `CANCELLED by <uid>` are matched as well.
+//	@Description
+//	@Description	To filter compute units by their metrics, use query parameters of form
+//	@Description	`min_<field>[.<key>]` and `max_<field>[.<key>]` where `field` is one of the metric
+//	@Description	fields like `total_cpu_energy_usage_kwh`, `avg_gpu_usage`, _etc._ For instance,
+//	@Description	`min_total_cpu_energy_usage_kwh=10` returns compute units that consumed at least
+//	@Description	10 kWh and `max_total_time_seconds.walltime=3600` returns compute units that ran
+//	@Description	for at most one hour. When `key` is not provided, the condition must be satisfied
+//	@Description	by any of the values of the metric.
+//	@Description
+//	@Description	If `to` query parameter is not provided, current time will be used. If `from`
+//	@Description	query parameter is not used, a default query window of 24 hours will be used.
+//	@Description	It means if `to` is provided, `from` will be calculated as `to` - 24hrs. If query
+//	@Description	parameter `timezone` is provided, the unit's created, start and end time strings
+//	@Description	will be presented in that time zone.
+//	@Description
+//	@Description	To limit the number of fields in the response, use `field` query parameter. By default, all
+//	@Description	fields will be included in the response if they are _non-empty_.
+//	@Description
+//	@Description	Results can be paginated using `limit` and `offset` query parameters. When `limit`
+//	@Description	is provided, response will include a `pagination` object with total number of units
+//	@Description	and the offset of next page, if any. Maximum allowed `limit` is 10000.
+//	@Description
+//	@Description	For iterating over large number of units, use `cursor` query parameter instead of
+//	@Description	`offset`. The value of `next_cursor` in `pagination` object of the response must be
+//	@Description	used as `cursor` to fetch the next page. Cursor cannot be used along with `offset`.
+//	@Security		BasicAuth
+//	@Tags			units
+//	@Produce		json
+//	@Param			X-Grafana-User	header		string		true	"Current user name"
+//	@Param			cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
+//	@Param			uuid			query		[]string	false	"Unit UUID"		collectionFormat(multi)
+//	@Param			project			query		[]string	false	"Project"		collectionFormat(multi)
+//	@Param			user			query		[]string	false	"User name"		collectionFormat(multi)
+//	@Param			running			query		bool		false	"Whether to fetch running units"
+//	@Param			status			query		string		false	"Status of units"	Enums(active, terminated, all)
+//	@Param			state			query		[]string	false	"State of units"	collectionFormat(multi)
+//	@Param			tag				query		[]string	false	"Tag of units of form key:value"	collectionFormat(multi)
+//	@Param			from			query		string		false	"From timestamp"
+//	@Param			to				query		string		false	"To timestamp"
+//	@Param			timezone		query		string		false	"Time zone in IANA format"
+//	@Param			field			query		[]string	false	"Fields to return in response"	collectionFormat(multi)
+//	@Param			include_units	query		bool		false	"Whether to include units of fields in response"
+//	@Param			limit			query		int			false	"Maximum number of units to return"
+//	@Param			offset			query		int			false	"Number of units to skip"
+//	@Param			cursor			query		string		false	"Cursor returned in the previous page"
+//	@Param			order			query		[]string	false	"Order of units of form column[:asc|desc]"	collectionFormat(multi)
+//	@Success		200				{object}	Response[models.Unit]
+//	@Failure		401				{object}	Response[any]
+//	@Failure		403				{object}	Response[any]
+//	@Failure		500				{object}	Response[any]
+//	@Router			/units/admin [get]
+//
+// GET /units/admin
+// Get any unit of any user.
+func (s *CEEMSServer) unitsAdmin(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "units admin endpoint", s.logger)
+
+	// Query for units and write response
+	s.unitsQuerier(r.URL.Query()["user"], w, r)
+}
+
+// units         godoc
+//
+//	@Summary		User endpoint for fetching compute units
+//	@Description	This user endpoint will fetch compute units of the current user. The
+//	@Description	current user is always identified by the header `X-Grafana-User` in
+//	@Description	the request.
+//	@Description
+//	@Description	If multiple query parameters are passed, for instance, `?uuid=<uuid>&project=<project>`,
+//	@Description	the intersection of query parameters are used to fetch compute units rather than
+//	@Description	the union. That means if the compute unit's `uuid` does not belong to the queried
+//	@Description	project, null response will be returned.
+//	@Description
+//	@Description	In order to return the running compute units as well, use the query parameter `running`.
+//	@Description	To return only running or only finished compute units, use the query parameter
+//	@Description	`status` with `active` or `terminated` value, respectively. When `status=active`,
+//	@Description	the query window is ignored as active units have not ended yet.
+//	@Description
+//	@Description	To filter compute units by their state as reported by the resource manager, use the
+//	@Description	query parameter `state`, for instance, `state=FAILED&state=TIMEOUT`. States set by
+//	@Description	SLURM with extra information like `CANCELLED by <uid>` are matched as well.
+//	@Description
+//	@Description	To filter compute units by their metrics, use query parameters of form
+//	@Description	`min_<field>[.<key>]` and `max_<field>[.<key>]` where `field` is one of the metric
+//	@Description	fields like `total_cpu_energy_usage_kwh`, `avg_gpu_usage`, _etc._ For instance,
+//	@Description	`min_total_cpu_energy_usage_kwh=10` returns compute units that consumed at least
+//	@Description	10 kWh and `max_total_time_seconds.walltime=3600` returns compute units that ran
+//	@Description	for at most one hour. When `key` is not provided, the condition must be satisfied
+//	@Description	by any of the values of the metric.
 //	@Description
 //	@Description	If `to` query parameter is not provided, current time will be used. If `from`
 //	@Description	query parameter is not used, a default query window of 24 hours will be used.
@@ -745,88 +1766,550 @@ queryUnits:
 //	@Description	parameter `timezone` is provided, the unit's created, start and end time strings
 //	@Description	will be presented in that time zone.
 //	@Description
-//	@Description	To limit the number of fields in the response, use `field` query parameter. By default, all
-//	@Description	fields will be included in the response if they are _non-empty_.
+//	@Description	To limit the number of fields in the response, use `field` query parameter. By default, all
+//	@Description	fields will be included in the response if they are _non-empty_.
+//	@Description
+//	@Description	Results can be paginated using `limit` and `offset` query parameters. When `limit`
+//	@Description	is provided, response will include a `pagination` object with total number of units
+//	@Description	and the offset of next page, if any. Maximum allowed `limit` is 10000.
+//	@Description
+//	@Description	For iterating over large number of units, use `cursor` query parameter instead of
+//	@Description	`offset`. The value of `next_cursor` in `pagination` object of the response must be
+//	@Description	used as `cursor` to fetch the next page. Cursor cannot be used along with `offset`.
+//	@Security		BasicAuth
+//	@Tags			units
+//	@Produce		json
+//	@Param			X-Grafana-User	header		string		true	"Current user name"
+//	@Param			cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
+//	@Param			uuid			query		[]string	false	"Unit UUID"		collectionFormat(multi)
+//	@Param			project			query		[]string	false	"Project"		collectionFormat(multi)
+//	@Param			running			query		bool		false	"Whether to fetch running units"
+//	@Param			status			query		string		false	"Status of units"	Enums(active, terminated, all)
+//	@Param			state			query		[]string	false	"State of units"	collectionFormat(multi)
+//	@Param			tag				query		[]string	false	"Tag of units of form key:value"	collectionFormat(multi)
+//	@Param			from			query		string		false	"From timestamp"
+//	@Param			to				query		string		false	"To timestamp"
+//	@Param			timezone		query		string		false	"Time zone in IANA format"
+//	@Param			field			query		[]string	false	"Fields to return in response"	collectionFormat(multi)
+//	@Param			include_units	query		bool		false	"Whether to include units of fields in response"
+//	@Param			limit			query		int			false	"Maximum number of units to return"
+//	@Param			offset			query		int			false	"Number of units to skip"
+//	@Param			cursor			query		string		false	"Cursor returned in the previous page"
+//	@Param			order			query		[]string	false	"Order of units of form column[:asc|desc]"	collectionFormat(multi)
+//	@Success		200				{object}	Response[models.Unit]
+//	@Failure		401				{object}	Response[any]
+//	@Failure		403				{object}	Response[any]
+//	@Failure		500				{object}	Response[any]
+//	@Router			/units [get]
+//
+// GET /units
+// Get unit of dashboard user.
+func (s *CEEMSServer) units(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "units endpoint", s.logger)
+
+	// Get current logged user and dashboard user from headers
+	_, dashboardUser := s.getUser(r)
+
+	// Query for units and write response
+	s.unitsQuerier([]string{dashboardUser}, w, r)
+}
+
+// unitsSearchAdmin    godoc
+//
+//	@Summary		Admin endpoint for searching compute units
+//	@Description	This admin endpoint will fetch compute units of _any_ user, compute units
+//	@Description	identified by query parameters in JSON body of the request. The current
+//	@Description	user is always identified by the header `X-Grafana-User` in the request.
+//	@Description
+//	@Description	The user who is making the request must be in the list of admin users
+//	@Description	configured for the server.
+//	@Description
+//	@Description	This endpoint is meant to fetch a large number of compute units that do not fit
+//	@Description	in the URL as `uuid` query parameters. The body must be a JSON object with query
+//	@Description	parameters of units admin endpoint as keys and list of their values as values, for
+//	@Description	instance, `{"uuid": ["1000", "1001"], "cluster_id": ["slurm-0"]}`. Query parameters
+//	@Description	in the body are merged with the ones in the URL and they have the same effect as
+//	@Description	in units admin endpoint. A maximum of 10000 `uuid` values can be provided in the body.
+//	@Description
+//	@Security		BasicAuth
+//	@Tags			units
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Grafana-User	header		string				true	"Current user name"
+//	@Param			body			body		map[string][]string	true	"Query parameters of units"
+//	@Success		200				{object}	Response[models.Unit]
+//	@Failure		400				{object}	Response[any]
+//	@Failure		401				{object}	Response[any]
+//	@Failure		403				{object}	Response[any]
+//	@Failure		500				{object}	Response[any]
+//	@Router			/units/search/admin [post]
+//
+// POST /units/search/admin
+// Search any unit of any user.
+func (s *CEEMSServer) unitsSearchAdmin(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "units search admin endpoint", s.logger)
+
+	// Merge query parameters in body into URL
+	if err := s.setSearchQueryParams(w, r); err != nil {
+		s.setHeaders(w)
+		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
+
+		return
+	}
+
+	// Query for units and write response
+	s.unitsQuerier(r.URL.Query()["user"], w, r)
+}
+
+// unitsSearch         godoc
+//
+//	@Summary		User endpoint for searching compute units
+//	@Description	This user endpoint will fetch compute units of the current user identified
+//	@Description	by query parameters in JSON body of the request. The current user is always
+//	@Description	identified by the header `X-Grafana-User` in the request.
+//	@Description
+//	@Description	This endpoint is meant to fetch a large number of compute units that do not fit
+//	@Description	in the URL as `uuid` query parameters. The body must be a JSON object with query
+//	@Description	parameters of units endpoint as keys and list of their values as values, for
+//	@Description	instance, `{"uuid": ["1000", "1001"], "project": ["foo"]}`. Query parameters
+//	@Description	in the body are merged with the ones in the URL and they have the same effect as
+//	@Description	in units endpoint. Only compute units of the current user are returned. A maximum
+//	@Description	of 10000 `uuid` values can be provided in the body.
+//	@Description
+//	@Security		BasicAuth
+//	@Tags			units
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Grafana-User	header		string				true	"Current user name"
+//	@Param			body			body		map[string][]string	true	"Query parameters of units"
+//	@Success		200				{object}	Response[models.Unit]
+//	@Failure		400				{object}	Response[any]
+//	@Failure		401				{object}	Response[any]
+//	@Failure		500				{object}	Response[any]
+//	@Router			/units/search [post]
+//
+// POST /units/search
+// Search units of the current user.
+func (s *CEEMSServer) unitsSearch(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "units search endpoint", s.logger)
+
+	// Get current logged user and dashboard user from headers
+	_, dashboardUser := s.getUser(r)
+
+	// Merge query parameters in body into URL
+	if err := s.setSearchQueryParams(w, r); err != nil {
+		s.setHeaders(w)
+		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
+
+		return
+	}
+
+	// Query for units and write response
+	s.unitsQuerier([]string{dashboardUser}, w, r)
+}
+
+// setSearchQueryParams decodes query parameters in JSON body of units search
+// request and merges them into URL query parameters of the request so
+// that the filters are built the same way as in GET requests.
+func (s *CEEMSServer) setSearchQueryParams(w http.ResponseWriter, r *http.Request) error {
+	var body map[string][]string
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSearchBodyBytes)).Decode(&body); err != nil {
+		s.logger.Error("Failed to decode units search body", "err", err)
+
+		return fmt.Errorf("%w: %w", errInvalidSearchBody, err)
+	}
+
+	if len(body["uuid"]) > maxSearchUUIDs {
+		return fmt.Errorf("%w: maximum allowed is %d", errTooManyUUIDs, maxSearchUUIDs)
+	}
+
+	urlValues := r.URL.Query()
+
+	for key, values := range body {
+		// Logged user is set by middleware and must not be set by clients
+		if key == "logged_user" {
+			continue
+		}
+
+		for _, value := range values {
+			urlValues.Add(key, value)
+		}
+	}
+
+	r.URL.RawQuery = urlValues.Encode()
+
+	return nil
+}
+
+// unitsCountAdmin    godoc
+//
+//	@Summary		Admin endpoint for counting compute units.
+//	@Description	This admin endpoint will return the number of compute units of _any_ user,
+//	@Description	compute unit and/or project that match the query parameters. The current
+//	@Description	user is always identified by the header `X-Grafana-User` in the request.
+//	@Description
+//	@Description	The user who is making the request must be in the list of admin users
+//	@Description	configured for the server.
+//	@Description
+//	@Description	The query parameters are same as the ones of `/units/admin` endpoint and
+//	@Description	the number of compute units is returned as the only element of `data`.
+//	@Security		BasicAuth
+//	@Tags			units
+//	@Produce		json
+//	@Param			X-Grafana-User	header		string		true	"Current user name"
+//	@Param			cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
+//	@Param			uuid			query		[]string	false	"Unit UUID"		collectionFormat(multi)
+//	@Param			project			query		[]string	false	"Project"		collectionFormat(multi)
+//	@Param			user			query		[]string	false	"User name"		collectionFormat(multi)
+//	@Param			running			query		bool		false	"Whether to count running units"
+//	@Param			status			query		string		false	"Status of units"	Enums(active, terminated, all)
+//	@Param			state			query		[]string	false	"State of units"	collectionFormat(multi)
+//	@Param			tag				query		[]string	false	"Tag of units of form key:value"	collectionFormat(multi)
+//	@Param			from			query		string		false	"From timestamp"
+//	@Param			to				query		string		false	"To timestamp"
+//	@Success		200				{object}	Response[int]
+//	@Failure		401				{object}	Response[any]
+//	@Failure		403				{object}	Response[any]
+//	@Failure		500				{object}	Response[any]
+//	@Router			/units/count/admin [get]
+//
+// GET /units/count/admin
+// Count units of any user.
+func (s *CEEMSServer) unitsCountAdmin(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "units count admin endpoint", s.logger)
+
+	// Count units and write response
+	s.unitsCountQuerier(r.URL.Query()["user"], w, r)
+}
+
+// unitsCount         godoc
+//
+//	@Summary		User endpoint for counting compute units
+//	@Description	This user endpoint will return the number of compute units of the current
+//	@Description	user that match the query parameters. The current user is always identified
+//	@Description	by the header `X-Grafana-User` in the request.
+//	@Description
+//	@Description	The query parameters are same as the ones of `/units` endpoint and
+//	@Description	the number of compute units is returned as the only element of `data`.
+//	@Description	This endpoint avoids fetching all the compute units when only the number
+//	@Description	of compute units is needed.
+//	@Security		BasicAuth
+//	@Tags			units
+//	@Produce		json
+//	@Param			X-Grafana-User	header		string		true	"Current user name"
+//	@Param			cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
+//	@Param			uuid			query		[]string	false	"Unit UUID"		collectionFormat(multi)
+//	@Param			project			query		[]string	false	"Project"		collectionFormat(multi)
+//	@Param			running			query		bool		false	"Whether to count running units"
+//	@Param			status			query		string		false	"Status of units"	Enums(active, terminated, all)
+//	@Param			state			query		[]string	false	"State of units"	collectionFormat(multi)
+//	@Param			tag				query		[]string	false	"Tag of units of form key:value"	collectionFormat(multi)
+//	@Param			from			query		string		false	"From timestamp"
+//	@Param			to				query		string		false	"To timestamp"
+//	@Success		200				{object}	Response[int]
+//	@Failure		401				{object}	Response[any]
+//	@Failure		403				{object}	Response[any]
+//	@Failure		500				{object}	Response[any]
+//	@Router			/units/count [get]
+//
+// GET /units/count
+// Count units of dashboard user.
+func (s *CEEMSServer) unitsCount(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "units count endpoint", s.logger)
+
+	// Get current logged user and dashboard user from headers
+	_, dashboardUser := s.getUser(r)
+
+	// Count units and write response
+	s.unitsCountQuerier([]string{dashboardUser}, w, r)
+}
+
+// unitsExportAdmin    godoc
+//
+//	@Summary		Admin endpoint for exporting compute units as CSV.
+//	@Description	This admin endpoint will stream compute units of _any_ user, compute unit
+//	@Description	and/or project that match the query parameters as CSV. The current user is
+//	@Description	always identified by the header `X-Grafana-User` in the request.
+//	@Description
+//	@Description	The user who is making the request must be in the list of admin users
+//	@Description	configured for the server.
+//	@Description
+//	@Description	The query parameters are same as the ones of `/units/admin` endpoint. The
+//	@Description	first record of the response contains the names of the fields and the
+//	@Description	compute units are streamed as they are read from the DB. Thus, this endpoint
+//	@Description	is suitable for exporting large number of compute units, for instance, for
+//	@Description	annual reports.
+//	@Security		BasicAuth
+//	@Tags			units
+//	@Produce		text/csv
+//	@Param			X-Grafana-User	header		string		true	"Current user name"
+//	@Param			cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
+//	@Param			uuid			query		[]string	false	"Unit UUID"		collectionFormat(multi)
+//	@Param			project			query		[]string	false	"Project"		collectionFormat(multi)
+//	@Param			user			query		[]string	false	"User name"		collectionFormat(multi)
+//	@Param			running			query		bool		false	"Whether to export running units"
+//	@Param			status			query		string		false	"Status of units"	Enums(active, terminated, all)
+//	@Param			state			query		[]string	false	"State of units"	collectionFormat(multi)
+//	@Param			tag				query		[]string	false	"Tag of units of form key:value"	collectionFormat(multi)
+//	@Param			from			query		string		false	"From timestamp"
+//	@Param			to				query		string		false	"To timestamp"
+//	@Param			field			query		[]string	false	"Fields to export"	collectionFormat(multi)
+//	@Param			order			query		[]string	false	"Order of units of form column[:asc|desc]"	collectionFormat(multi)
+//	@Success		200				{string}	string
+//	@Failure		401				{object}	Response[any]
+//	@Failure		403				{object}	Response[any]
+//	@Failure		500				{object}	Response[any]
+//	@Router			/units/export/admin [get]
+//
+// GET /units/export/admin
+// Export units of any user.
+func (s *CEEMSServer) unitsExportAdmin(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "units export admin endpoint", s.logger)
+
+	// Export units and write response
+	s.unitsExportQuerier(r.URL.Query()["user"], w, r)
+}
+
+// unitsExport         godoc
+//
+//	@Summary		User endpoint for exporting compute units as CSV
+//	@Description	This user endpoint will stream compute units of the current user that
+//	@Description	match the query parameters as CSV. The current user is always identified
+//	@Description	by the header `X-Grafana-User` in the request.
+//	@Description
+//	@Description	The query parameters are same as the ones of `/units` endpoint. The
+//	@Description	first record of the response contains the names of the fields and the
+//	@Description	compute units are streamed as they are read from the DB. Thus, this endpoint
+//	@Description	is suitable for exporting large number of compute units.
 //	@Security		BasicAuth
 //	@Tags			units
-//	@Produce		json
+//	@Produce		text/csv
 //	@Param			X-Grafana-User	header		string		true	"Current user name"
 //	@Param			cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
 //	@Param			uuid			query		[]string	false	"Unit UUID"		collectionFormat(multi)
 //	@Param			project			query		[]string	false	"Project"		collectionFormat(multi)
-//	@Param			user			query		[]string	false	"User name"		collectionFormat(multi)
-//	@Param			running			query		bool		false	"Whether to fetch running units"
+//	@Param			running			query		bool		false	"Whether to export running units"
+//	@Param			status			query		string		false	"Status of units"	Enums(active, terminated, all)
+//	@Param			state			query		[]string	false	"State of units"	collectionFormat(multi)
+//	@Param			tag				query		[]string	false	"Tag of units of form key:value"	collectionFormat(multi)
 //	@Param			from			query		string		false	"From timestamp"
 //	@Param			to				query		string		false	"To timestamp"
-//	@Param			timezone		query		string		false	"Time zone in IANA format"
-//	@Param			field			query		[]string	false	"Fields to return in response"	collectionFormat(multi)
-//	@Success		200				{object}	Response[models.Unit]
+//	@Param			field			query		[]string	false	"Fields to export"	collectionFormat(multi)
+//	@Param			order			query		[]string	false	"Order of units of form column[:asc|desc]"	collectionFormat(multi)
+//	@Success		200				{string}	string
 //	@Failure		401				{object}	Response[any]
 //	@Failure		403				{object}	Response[any]
 //	@Failure		500				{object}	Response[any]
-//	@Router			/units/admin [get]
+//	@Router			/units/export [get]
 //
-// GET /units/admin
-// Get any unit of any user.
-func (s *CEEMSServer) unitsAdmin(w http.ResponseWriter, r *http.Request) {
+// GET /units/export
+// Export units of dashboard user.
+func (s *CEEMSServer) unitsExport(w http.ResponseWriter, r *http.Request) {
 	// Measure elapsed time
-	defer common.TimeTrack(time.Now(), "units admin endpoint", s.logger)
+	defer common.TimeTrack(time.Now(), "units export endpoint", s.logger)
 
-	// Query for units and write response
-	s.unitsQuerier(r.URL.Query()["user"], w, r)
+	// Get current logged user and dashboard user from headers
+	_, dashboardUser := s.getUser(r)
+
+	// Export units and write response
+	s.unitsExportQuerier([]string{dashboardUser}, w, r)
 }
 
-// units         godoc
+// unitTimeseries         godoc
 //
-//	@Summary		User endpoint for fetching compute units
-//	@Description	This user endpoint will fetch compute units of the current user. The
-//	@Description	current user is always identified by the header `X-Grafana-User` in
-//	@Description	the request.
-//	@Description
-//	@Description	If multiple query parameters are passed, for instance, `?uuid=<uuid>&project=<project>`,
-//	@Description	the intersection of query parameters are used to fetch compute units rather than
-//	@Description	the union. That means if the compute unit's `uuid` does not belong to the queried
-//	@Description	project, null response will be returned.
+//	@Summary		Endpoint for fetching time series of a compute unit
+//	@Description	This endpoint will fetch time series of the queried metrics of the compute
+//	@Description	unit from the TSDB of the cluster between start and end times of the
+//	@Description	compute unit. The current user is always identified by the header
+//	@Description	`X-Grafana-User` in the request.
 //	@Description
-//	@Description	In order to return the running compute units as well, use the query parameter `running`.
+//	@Description	The current user must pass the same ownership check as the one of
+//	@Description	`/units/verify` endpoint for the queried compute unit. For running compute
+//	@Description	units, current time is used as end time.
 //	@Description
-//	@Description	If `to` query parameter is not provided, current time will be used. If `from`
-//	@Description	query parameter is not used, a default query window of 24 hours will be used.
-//	@Description	It means if `to` is provided, `from` will be calculated as `to` - 24hrs. If query
-//	@Description	parameter `timezone` is provided, the unit's created, start and end time strings
-//	@Description	will be presented in that time zone.
+//	@Description	The query parameter `metric` must be used to specify the names of metrics
+//	@Description	to fetch. The resolution of time series can be set using `step` query
+//	@Description	parameter which defaults to `1m`. When the step results in too many points
+//	@Description	for the life time of the compute unit, it will be increased accordingly.
+//	@Description	Each series is returned with its labels as a compute unit can have several series
+//	@Description	of the same metric, for instance, one for each node or GPU.
 //	@Description
-//	@Description	To limit the number of fields in the response, use `field` query parameter. By default, all
-//	@Description	fields will be included in the response if they are _non-empty_.
+//	@Description	If the TSDB is not configured for the cluster, a response 503 will be returned.
 //	@Security		BasicAuth
 //	@Tags			units
 //	@Produce		json
 //	@Param			X-Grafana-User	header		string		true	"Current user name"
-//	@Param			cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
-//	@Param			uuid			query		[]string	false	"Unit UUID"		collectionFormat(multi)
-//	@Param			project			query		[]string	false	"Project"		collectionFormat(multi)
-//	@Param			running			query		bool		false	"Whether to fetch running units"
-//	@Param			from			query		string		false	"From timestamp"
-//	@Param			to				query		string		false	"To timestamp"
-//	@Param			timezone		query		string		false	"Time zone in IANA format"
-//	@Param			field			query		[]string	false	"Fields to return in response"	collectionFormat(multi)
-//	@Success		200				{object}	Response[models.Unit]
+//	@Param			uuid			path		string		true	"Unit UUID"
+//	@Param			cluster_id		query		string		true	"Cluster ID"
+//	@Param			metric			query		[]string	true	"Metric names"	collectionFormat(multi)
+//	@Param			step			query		string		false	"Query resolution step"
+//	@Success		200				{object}	Response[models.Timeseries]
+//	@Failure		400				{object}	Response[any]
 //	@Failure		401				{object}	Response[any]
 //	@Failure		403				{object}	Response[any]
+//	@Failure		404				{object}	Response[any]
 //	@Failure		500				{object}	Response[any]
-//	@Router			/units [get]
+//	@Failure		503				{object}	Response[any]
+//	@Router			/units/{uuid}/timeseries [get]
 //
-// GET /units
-// Get unit of dashboard user.
-func (s *CEEMSServer) units(w http.ResponseWriter, r *http.Request) {
+// GET /units/{uuid}/timeseries
+// Get time series of a unit from TSDB.
+func (s *CEEMSServer) unitTimeseries(w http.ResponseWriter, r *http.Request) {
 	// Measure elapsed time
-	defer common.TimeTrack(time.Now(), "units endpoint", s.logger)
+	defer common.TimeTrack(time.Now(), "unit timeseries endpoint", s.logger)
+
+	// Set headers
+	s.setHeaders(w)
 
 	// Get current logged user and dashboard user from headers
-	_, dashboardUser := s.getUser(r)
+	loggedUser, dashboardUser := s.getUser(r)
 
-	// Query for units and write response
-	s.unitsQuerier([]string{dashboardUser}, w, r)
+	uuid := mux.Vars(r)["uuid"]
+	clusterID := r.URL.Query().Get("cluster_id")
+
+	if uuid == "" || clusterID == "" {
+		errorResponse[any](w, &apiError{errorBadData, errMissingUUIDs}, s.logger, nil)
+
+		return
+	}
+
+	// Get queried metrics
+	metrics := r.URL.Query()["metric"]
+	if len(metrics) == 0 {
+		errorResponse[any](w, &apiError{errorBadData, errMissingMetrics}, s.logger, nil)
+
+		return
+	}
+
+	for _, metric := range metrics {
+		if !metricNameRegex.MatchString(metric) {
+			s.logger.Error("Invalid metric name", "loggedUser", loggedUser, "metric", metric)
+			errorResponse[any](w, &apiError{errorBadData, errInvalidMetric}, s.logger, nil)
+
+			return
+		}
+	}
+
+	// Get query step
+	step := defaultTimeseriesStep
+
+	if v := r.URL.Query().Get("step"); v != "" {
+		d, err := model.ParseDuration(v)
+		if err != nil || d <= 0 {
+			errorResponse[any](w, &apiError{errorBadData, errInvalidStep}, s.logger, nil)
+
+			return
+		}
+
+		step = time.Duration(d)
+	}
+
+	// Check if user is owner of the queried unit
+	if !VerifyOwnership(r.Context(), dashboardUser, []string{clusterID}, []string{uuid}, nil, s.db, s.logger) {
+		errorResponse[any](w, &apiError{errorForbidden, errNoAuth}, s.logger, nil)
+
+		return
+	}
+
+	// Get TSDB of the cluster
+	client, ok := s.tsdbs[clusterID]
+	if !ok || !client.Available() {
+		errorResponse[any](w, &apiError{errorUnavailable, errNoTSDB}, s.logger, nil)
+
+		return
+	}
+
+	// Get start and end times of the unit
+	q := Query{}
+	q.query("SELECT started_at_ts,ended_at_ts FROM " + base.UnitsDBTableName)
+	q.query(" WHERE cluster_id IN ")
+	q.param([]string{clusterID})
+	q.query(" AND uuid IN ")
+	q.param([]string{uuid})
+
+	units, err := s.queriers.unit(r.Context(), s.db, q, s.logger)
+	if err != nil {
+		s.logger.Error("Failed to fetch unit", "loggedUser", loggedUser, "uuid", uuid, "err", err)
+		errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
+
+		return
+	}
+
+	if len(units) == 0 || units[0].StartedAtTS == 0 {
+		errorResponse[any](w, &apiError{errorNotFound, errUnitNotFound}, s.logger, nil)
+
+		return
+	}
+
+	start := time.UnixMilli(units[0].StartedAtTS)
+
+	// For running units, use current time as end time
+	end := time.Now()
+	if units[0].EndedAtTS > 0 {
+		end = time.UnixMilli(units[0].EndedAtTS)
+	}
+
+	// Increase step to respect the limit on number of points per series
+	if minStep := end.Sub(start) / maxTimeseriesPoints; step < minStep {
+		step = minStep.Truncate(time.Second) + time.Second
+	}
+
+	query := fmt.Sprintf(`{__name__=~"%s",uuid=%s}`, strings.Join(metrics, "|"), strconv.Quote(uuid))
+
+	result, err := client.RangeQuerySeries(r.Context(), query, start, end, model.Duration(step).String())
+	if err != nil {
+		s.logger.Error("Failed to fetch unit time series", "loggedUser", loggedUser, "uuid", uuid, "err", err)
+		errorResponse[any](w, &apiError{errorExec, err}, s.logger, nil)
+
+		return
+	}
+
+	// Return each series with its labels as a unit can have several series of
+	// same metric, for instance, one for each node or GPU
+	timeseries := make([]models.Timeseries, 0, len(result.Series))
+
+	for _, series := range result.Series {
+		labels := maps.Clone(series.Labels)
+		delete(labels, "__name__")
+
+		values := make([]interface{}, len(series.Samples))
+		for i, sample := range series.Samples {
+			values[i] = []interface{}{
+				float64(sample.Timestamp.UnixMilli()) / 1000,
+				strconv.FormatFloat(sample.Value, 'f', -1, 64),
+			}
+		}
+
+		timeseries = append(timeseries, models.Timeseries{Metric: series.Labels["__name__"], Labels: labels, Values: values})
+	}
+
+	// Sort time series by metric names and labels to have a stable response
+	slices.SortFunc(timeseries, func(a, b models.Timeseries) int {
+		if c := strings.Compare(a.Metric, b.Metric); c != 0 {
+			return c
+		}
+
+		// Maps are printed with sorted keys
+		return strings.Compare(fmt.Sprint(a.Labels), fmt.Sprint(b.Labels))
+	})
+
+	// Write response
+	w.WriteHeader(http.StatusOK)
+
+	response := Response[models.Timeseries]{
+		Status: "success",
+		Data:   timeseries,
+	}
+	if err := json.NewEncoder(w).Encode(&response); err != nil {
+		s.logger.Error("Failed to encode response", "err", err)
+		w.Write([]byte("KO"))
+	}
 }
 
 // verifyUnitsOwnership         godoc
@@ -912,6 +2395,98 @@ func (s *CEEMSServer) verifyUnitsOwnership(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// InvalidateUsageCache removes usage query results from cache. When prefixes
+// are provided, only the results whose cache key, i.e., query URL, starts with one
+// of the prefixes are removed. Else entire cache is flushed. It returns
+// number of removed entries.
+func (s *CEEMSServer) InvalidateUsageCache(prefixes ...string) int {
+	if s.usageCache == nil {
+		return 0
+	}
+
+	if len(prefixes) == 0 {
+		numEntries := s.usageCache.Len()
+		s.usageCache.DeleteAll()
+
+		return numEntries
+	}
+
+	var numEntries int
+
+	for _, key := range s.usageCache.Keys() {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				s.usageCache.Delete(key)
+
+				numEntries++
+
+				break
+			}
+		}
+	}
+
+	return numEntries
+}
+
+// usageCacheAdmin         godoc
+//
+//	@Summary		Admin endpoint to invalidate usage cache
+//	@Description	This admin endpoint will remove cached results of usage queries. The
+//	@Description	current user is always identified by the header `X-Grafana-User` in
+//	@Description	the request.
+//	@Description
+//	@Description	The user who is making the request must be in the list of admin users
+//	@Description	configured for the server.
+//	@Description
+//	@Description	Results of usage queries are cached using the query URL, _e.g.,_
+//	@Description	`/api/v1/usage/current?cluster_id=slurm-0&from=1735686000` as key. If
+//	@Description	one or more `prefix` query parameters are provided, only cached results
+//	@Description	whose key starts with one of the prefixes are removed. Else entire
+//	@Description	cache is flushed. Query parameters in the keys are always sorted by
+//	@Description	their names.
+//	@Description
+//	@Security	BasicAuth
+//	@Tags		usage
+//	@Produce	json
+//	@Param		X-Grafana-User	header		string		true	"Current user name"
+//	@Param		prefix			query		[]string	false	"Cache key prefix"	collectionFormat(multi)
+//	@Success	200				{object}	Response[any]
+//	@Failure	401				{object}	Response[any]
+//	@Failure	403				{object}	Response[any]
+//	@Failure	500				{object}	Response[any]
+//	@Router		/usage/cache/admin [delete]
+//
+// DELETE /usage/cache/admin
+// Invalidate usage cache.
+func (s *CEEMSServer) usageCacheAdmin(w http.ResponseWriter, r *http.Request) {
+	// Set headers
+	s.setHeaders(w)
+
+	// Get current user from header
+	loggedUser, _ := s.getUser(r)
+
+	// Invalidate cache
+	prefixes := r.URL.Query()["prefix"]
+	numEntries := s.InvalidateUsageCache(prefixes...)
+
+	s.logger.Info(
+		"Usage cache invalidated", "loggedUser", loggedUser,
+		"prefixes", strings.Join(prefixes, ","), "num_entries", numEntries,
+	)
+
+	// Write response
+	w.WriteHeader(http.StatusOK)
+
+	response := Response[string]{
+		Status: "success",
+		Data:   []string{strconv.Itoa(numEntries)},
+	}
+	if err := json.NewEncoder(w).Encode(&response); err != nil {
+		s.logger.Error("Failed to encode response", "err", err)
+		w.Write([]byte("KO"))
+	}
+}
+
 // clusters         godoc
 //
 //	@Summary		List clusters
@@ -1039,6 +2614,7 @@ func (s *CEEMSServer) usersQuerier(users []string, w http.ResponseWriter, r *htt
 //	@Description	the request.
 //	@Description
 //	@Description	The details include list of projects that user is currently a part of.
+//	@Description	The role of the user in each project is included in `roles`, when available.
 //	@Description
 //	@Security	BasicAuth
 //	@Tags		users
@@ -1056,47 +2632,265 @@ func (s *CEEMSServer) users(w http.ResponseWriter, r *http.Request) {
 	// Measure elapsed time
 	defer common.TimeTrack(time.Now(), "users endpoint", s.logger)
 
-	// Get current user from header
-	_, dashboardUser := s.getUser(r)
+	// Get current user from header
+	_, dashboardUser := s.getUser(r)
+
+	// Query for users and write response
+	s.usersQuerier([]string{dashboardUser}, w, r)
+}
+
+// usersAdmin         godoc
+//
+//	@Summary		Admin endpoint for fetching user details of _any_ user.
+//	@Description	This endpoint will show details of the queried user(s). The
+//	@Description	current user is always identified by the header `X-Grafana-User` in
+//	@Description	the request.
+//	@Description
+//	@Description	The user who is making the request must be in the list of admin users
+//	@Description	configured for the server.
+//	@Description
+//	@Description	When the query parameter `user` is empty, all users will be returned
+//	@Description	in the response.
+//	@Description
+//	@Description	The details include list of projects that user is currently a part of.
+//	@Description	The role of the user in each project is included in `roles`, when available.
+//	@Description
+//	@Security	BasicAuth
+//	@Tags		users
+//	@Produce	json
+//	@Param		X-Grafana-User	header		string		true	"Current user name"
+//	@Param		user			query		[]string	false	"User name"		collectionFormat(multi)
+//	@Param		cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
+//	@Success	200				{object}	Response[models.User]
+//	@Failure	401				{object}	Response[any]
+//	@Failure	500				{object}	Response[any]
+//	@Router		/users/admin [get]
+//
+// GET /users/admin
+// Get users details.
+func (s *CEEMSServer) usersAdmin(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "users admin endpoint", s.logger)
+
+	// Query for users and write response
+	s.usersQuerier(r.URL.Query()["user"], w, r)
+}
+
+// purgeUserAdmin         godoc
+//
+//	@Summary		Admin endpoint to purge data of a user
+//	@Description	This admin endpoint will delete or anonymize all the data of the user
+//	@Description	in the path across all clusters. The current user is always identified
+//	@Description	by the header `X-Grafana-User` in the request.
+//	@Description
+//	@Description	The user who is making the request must be in the list of admin users
+//	@Description	configured for the server.
+//	@Description
+//	@Description	When query parameter `mode` is `delete`, compute units, usage and user
+//	@Description	entries of the user are deleted and the user is removed from projects.
+//	@Description	When `mode` is `anonymize`, user name is replaced by its SHA-256 hash
+//	@Description	in all these entries so that aggregate usage statistics are preserved.
+//	@Description	When one or more `cluster_id` query parameters are provided, only data
+//	@Description	of those clusters is purged.
+//	@Description
+//	@Description	The response contains the number of affected rows in each table. Note
+//	@Description	that data that is still available on the cluster will be fetched again
+//	@Description	by the server in the next update.
+//	@Description
+//	@Security	BasicAuth
+//	@Tags		users
+//	@Produce	json
+//	@Param		X-Grafana-User	header		string		true	"Current user name"
+//	@Param		name			path		string		true	"User name"
+//	@Param		mode			query		string		false	"Purge mode"	Enums(delete, anonymize)	default(delete)
+//	@Param		cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
+//	@Success	200				{object}	Response[models.AffectedRows]
+//	@Failure	400				{object}	Response[any]
+//	@Failure	401				{object}	Response[any]
+//	@Failure	403				{object}	Response[any]
+//	@Failure	500				{object}	Response[any]
+//	@Router		/users/{name}/admin [delete]
+//
+// DELETE /users/{name}/admin
+// Delete or anonymize data of a user.
+func (s *CEEMSServer) purgeUserAdmin(w http.ResponseWriter, r *http.Request) {
+	// Measure elapsed time
+	defer common.TimeTrack(time.Now(), "purge user admin endpoint", s.logger)
+
+	// Set headers
+	s.setHeaders(w)
+
+	// Get current user from header
+	loggedUser, _ := s.getUser(r)
+
+	user := mux.Vars(r)["name"]
+
+	// Get purge mode
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = purgeModeDelete
+	}
+
+	if mode != purgeModeDelete && mode != purgeModeAnonymize {
+		errorResponse[any](w, &apiError{errorBadData, errInvalidPurgeMode}, s.logger, nil)
+
+		return
+	}
+
+	// Queries are made on a read only connection and hence, open a new connection
+	// to primary DB to purge data
+	db, err := s.primaryDB()
+	if err != nil {
+		s.logger.Error("Failed to open DB", "err", err)
+		errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
+
+		return
+	}
+	defer db.Close()
+
+	clusterIDs := r.URL.Query()["cluster_id"]
 
-	// Query for users and write response
-	s.usersQuerier([]string{dashboardUser}, w, r)
+	affectedRows, err := purgeUser(r.Context(), db, user, mode, clusterIDs)
+	if err != nil {
+		s.logger.Error("Failed to purge user data", "user", user, "mode", mode, "err", err)
+		errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
+
+		return
+	}
+
+	// Cached usage results might contain purged data
+	s.InvalidateUsageCache()
+
+	s.logger.Info(
+		"User data purged", "loggedUser", loggedUser, "user", user, "mode", mode,
+		"cluster_ids", strings.Join(clusterIDs, ","),
+	)
+
+	// Write response
+	w.WriteHeader(http.StatusOK)
+
+	response := Response[models.AffectedRows]{
+		Status: "success",
+		Data:   affectedRows,
+	}
+	if err := json.NewEncoder(w).Encode(&response); err != nil {
+		s.logger.Error("Failed to encode response", "err", err)
+		w.Write([]byte("KO"))
+	}
 }
 
-// usersAdmin         godoc
+// ignoreUnitsAdmin         godoc
 //
-//	@Summary		Admin endpoint for fetching user details of _any_ user.
-//	@Description	This endpoint will show details of the queried user(s). The
-//	@Description	current user is always identified by the header `X-Grafana-User` in
-//	@Description	the request.
+//	@Summary		Admin endpoint to mark compute units as ignored
+//	@Description	This admin endpoint will set or unset the ignore flag of compute units
+//	@Description	identified by `uuid` query parameters. The current user is always identified
+//	@Description	by the header `X-Grafana-User` in the request.
 //	@Description
 //	@Description	The user who is making the request must be in the list of admin users
 //	@Description	configured for the server.
 //	@Description
-//	@Description	When the query parameter `user` is empty, all users will be returned
-//	@Description	in the response.
+//	@Description	Ignored units are not returned by the units endpoints. When query parameter
+//	@Description	`ignore` is `false`, the ignore flag of the units is unset. When one or more
+//	@Description	`cluster_id` query parameters are provided, only units of those clusters
+//	@Description	are updated.
 //	@Description
-//	@Description	The details include list of projects that user is currently a part of.
+//	@Description	Usage of ignored units is subtracted from usage tables and it is added
+//	@Description	back when the ignore flag is unset. The response contains the number of
+//	@Description	updated rows of units, usage and daily usage tables.
 //	@Description
 //	@Security	BasicAuth
-//	@Tags		users
+//	@Tags		units
 //	@Produce	json
 //	@Param		X-Grafana-User	header		string		true	"Current user name"
-//	@Param		user			query		[]string	false	"User name"		collectionFormat(multi)
+//	@Param		uuid			query		[]string	true	"Unit UUID"	collectionFormat(multi)
 //	@Param		cluster_id		query		[]string	false	"Cluster ID"	collectionFormat(multi)
-//	@Success	200				{object}	Response[models.User]
+//	@Param		ignore			query		bool		false	"Ignore flag"	default(true)
+//	@Success	200				{object}	Response[models.AffectedRows]
+//	@Failure	400				{object}	Response[any]
 //	@Failure	401				{object}	Response[any]
+//	@Failure	403				{object}	Response[any]
 //	@Failure	500				{object}	Response[any]
-//	@Router		/users/admin [get]
+//	@Router		/units/ignore/admin [put]
 //
-// GET /users/admin
-// Get users details.
-func (s *CEEMSServer) usersAdmin(w http.ResponseWriter, r *http.Request) {
+// PUT /units/ignore/admin
+// Set or unset ignore flag of units.
+func (s *CEEMSServer) ignoreUnitsAdmin(w http.ResponseWriter, r *http.Request) {
 	// Measure elapsed time
-	defer common.TimeTrack(time.Now(), "users admin endpoint", s.logger)
+	defer common.TimeTrack(time.Now(), "ignore units admin endpoint", s.logger)
 
-	// Query for users and write response
-	s.usersQuerier(r.URL.Query()["user"], w, r)
+	// Set headers
+	s.setHeaders(w)
+
+	// Get current user from header
+	loggedUser, _ := s.getUser(r)
+
+	uuids := r.URL.Query()["uuid"]
+	if len(uuids) == 0 {
+		errorResponse[any](w, &apiError{errorBadData, errMissingUUIDs}, s.logger, nil)
+
+		return
+	}
+
+	// Get ignore flag
+	ignore := true
+
+	if v := r.URL.Query().Get("ignore"); v != "" {
+		var err error
+		if ignore, err = strconv.ParseBool(v); err != nil {
+			errorResponse[any](w, &apiError{errorBadData, errInvalidIgnore}, s.logger, nil)
+
+			return
+		}
+	}
+
+	// Queries are made on a read only connection and hence, open a new connection
+	// to primary DB to update units
+	db, err := s.primaryDB()
+	if err != nil {
+		s.logger.Error("Failed to open DB", "err", err)
+		errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
+
+		return
+	}
+	defer db.Close()
+
+	clusterIDs := r.URL.Query()["cluster_id"]
+
+	affectedRows, err := ignoreUnits(r.Context(), db, uuids, clusterIDs, ignore, s.dbConfig.Data.Timezone.Location)
+	if err != nil {
+		s.logger.Error("Failed to update ignore flag of units", "uuids", strings.Join(uuids, ","), "err", err)
+		errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
+
+		return
+	}
+
+	s.logger.Info(
+		"Ignore flag of units updated", "loggedUser", loggedUser, "uuids", strings.Join(uuids, ","),
+		"cluster_ids", strings.Join(clusterIDs, ","), "ignore", ignore, "rows", affectedRows[0].Rows,
+	)
+
+	// Write response
+	w.WriteHeader(http.StatusOK)
+
+	response := Response[models.AffectedRows]{
+		Status: "success",
+		Data:   affectedRows,
+	}
+	if err := json.NewEncoder(w).Encode(&response); err != nil {
+		s.logger.Error("Failed to encode response", "err", err)
+		w.Write([]byte("KO"))
+	}
+}
+
+// primaryDB opens a new read write connection to primary DB.
+func (s *CEEMSServer) primaryDB() (*sql.DB, error) {
+	dsn := fmt.Sprintf(
+		"file:%s?%s",
+		filepath.Join(s.dbConfig.Data.Path, base.CEEMSDBName),
+		"_mutex=no&_busy_timeout=5000",
+	)
+
+	return sql.Open(sqlite3.DriverName, dsn)
 }
 
 // Get project details.
@@ -1280,61 +3074,38 @@ func (s *CEEMSServer) aggQueryBuilder(
 	return query.String()
 }
 
-// GET /usage/current
-// Get current usage statistics.
-func (s *CEEMSServer) currentUsage(users []string, fields []string, w http.ResponseWriter, r *http.Request) {
-	var usage []models.Usage
-
-	var groupby []string
-
+// queryCurrentUsage fetches current usage statistics of users from DB. The fields
+// and groupby must be validated before calling this function. Errors when building
+// queries of individual fields and when usage is only partially fetched are returned
+// as warnings.
+func (s *CEEMSServer) queryCurrentUsage(
+	users []string,
+	fields []string,
+	groupby []string,
+	queryWindowTS map[string]string,
+	r *http.Request,
+) ([]models.Usage, []string, error) {
 	var targetTable string
 
-	var queryWindowTS map[string]string
-
-	queryParts := make([]string, len(fields))
-
-	var queries, virtualTables []string
+	var queryParts, queries, virtualTables, warnings []string
 
 	var wg sync.WaitGroup
 
 	var mu sync.RWMutex
 
-	var q Query
-
-	var err, qErrs error
-
-	// Round `to` and `from` query parameters to cacheTTL
-	if err := s.roundQueryWindow(r); err != nil {
-		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
-
-		return
-	}
-
-	// Get query window time stamps
-	queryWindowTS, err = s.getQueryWindow(r)
-	if err != nil {
-		errorResponse[any](w, &apiError{errorBadData, err}, s.logger, nil)
-
-		return
-	}
+	var qErrs error
 
-	// Attempt to retrieve from cache if present
-	// Use URL as cache key
-	// Add Expires header when cached value is being returned
-	cacheKey := common.GenerateKey(r.URL.String())
-	if present := s.usageCache.Has(cacheKey); present {
-		cacheValue := s.usageCache.Get(cacheKey)
-		usage = cacheValue.Value()
-		w.Header().Set("Expires", cacheValue.ExpiresAt().Format(time.RFC1123))
+	// Always return the columns that usage is grouped by so that consumers
+	// know the dimensions of each aggregate
+	fields = slices.Clone(fields)
 
-		goto writer
+	for _, col := range groupby {
+		if !slices.Contains(fields, col) {
+			fields = append(fields, col)
+		}
 	}
 
-	// Set write deadline
-	s.setWriteDeadline(5*time.Minute, w)
-
-	// Start a wait group
-	wg = sync.WaitGroup{}
+	queryParts = make([]string, len(fields))
 
 	// Get aggUsageCols based on queried fields
 	for iField, field := range fields {
@@ -1383,7 +3154,7 @@ func (s *CEEMSServer) currentUsage(users []string, fields []string, w http.Respo
 		}
 	}
 
-	if _, ok := r.URL.Query()["experimental"]; ok {
+	if isDailyUsageQuery(r.URL.Query(), groupby) {
 		targetTable = base.DailyUsageDBTableName
 
 		for iQuery, query := range queries {
@@ -1396,7 +3167,7 @@ func (s *CEEMSServer) currentUsage(users []string, fields []string, w http.Respo
 	}
 
 	// Make query
-	q = Query{}
+	q := Query{}
 	q.query(
 		fmt.Sprintf(
 			"SELECT %s FROM (%s AS u LEFT JOIN %s)",
@@ -1410,6 +3181,12 @@ func (s *CEEMSServer) currentUsage(users []string, fields []string, w http.Respo
 	q.query(" WHERE project IN ")
 	q.subQuery(projectsSubQuery(users)) // Get sub query for projects
 
+	// Exclude ignored units. Daily usage table is aggregated from units and
+	// hence it does not have ignore flag
+	if targetTable == base.UnitsDBTableName {
+		q.query(" AND ignore = 0")
+	}
+
 	// Add common query parameters
 	q = s.getCommonQueryParams(&q, r.URL.Query())
 
@@ -1419,67 +3196,196 @@ func (s *CEEMSServer) currentUsage(users []string, fields []string, w http.Respo
 	q.query(" AND ")
 	q.param([]string{queryWindowTS["to"]})
 
-	// Get only units that have finished. We do not present this
-	// query parameter for end users. **Only used in testing**
-	if _, ok := r.URL.Query()["__terminated"]; ok {
-		q.query(" AND ended_at_ts > 0 ")
-	}
-
-	// Finally add GROUP BY clause. Always group by username,project
-	groupby = []string{"username", "project"}
+	// Add status query parameter. It has been validated already
+	q, _ = s.getStatusQueryParam(&q, r.URL.Query())
 
-	for _, q := range r.URL.Query()["groupby"] {
-		if q != "" {
-			groupby = append(groupby, q)
-		}
+	// Add tag query parameters. They have been validated already and they are
+	// rejected on daily usage table as it does not have tags
+	if targetTable == base.UnitsDBTableName {
+		q, _ = s.getTagQueryParams(&q, r.URL.Query())
 	}
-	// Remove duplicates values
-	slices.Sort(groupby)
-	groupby = slices.Compact(groupby)
+
+	// Finally add GROUP BY clause. Columns have been validated already
 	q.query(" GROUP BY " + strings.Join(groupby, ","))
 
-	// Sort by cluster_id, username and project
-	q.query(" ORDER BY cluster_id ASC, username ASC, project ASC ")
+	// Sort by cluster_id, username and project and by day when usage is
+	// split into daily buckets
+	if slices.Contains(groupby, groupByDay) {
+		q.query(" ORDER BY cluster_id ASC, username ASC, project ASC, day ASC ")
+	} else {
+		q.query(" ORDER BY cluster_id ASC, username ASC, project ASC ")
+	}
 
 	// Make query and check for returned number of rows
-	usage, err = s.queriers.usage(r.Context(), s.db, q, s.logger)
+	usage, err := s.queriers.usage(r.Context(), s.db, q, s.logger)
 	if usage == nil && err != nil {
+		return nil, nil, err
+	}
+
+	// Compute efficiencies from average usages
+	for i := range usage {
+		usage[i].ComputeEfficiency()
+	}
+
+	if qErrs != nil {
+		warnings = append(warnings, qErrs.Error())
+	}
+
+	if err != nil {
+		warnings = append(warnings, err.Error())
+	}
+
+	return usage, warnings, nil
+}
+
+// usageResult contains usage statistics fetched from DB along with the columns
+// used to group them and warnings, if any.
+type usageResult struct {
+	usage      []models.Usage
+	groupby    []string
+	warnings   []string
+	etag       string    // ETag of cached usage
+	expiresAt  time.Time // Expiry time of usage when returned from cache
+	queryStart time.Time // Start time of DB query when not returned from cache
+}
+
+// fetchCurrentUsage returns current usage statistics of users using query
+// parameters of r. Results are served from cache when present.
+func (s *CEEMSServer) fetchCurrentUsage(users []string, fields []string, r *http.Request) (*usageResult, *apiError) {
+	var err error
+
+	result := &usageResult{}
+
+	// Validate status query parameter before making any query
+	if _, err := s.getStatusQueryParam(&Query{}, r.URL.Query()); err != nil {
+		return nil, &apiError{errorBadData, err}
+	}
+
+	// Validate tag query parameters before making any query
+	if _, err := s.getTagQueryParams(&Query{}, r.URL.Query()); err != nil {
+		return nil, &apiError{errorBadData, err}
+	}
+
+	// Validate groupby query parameter before making any query
+	if result.groupby, err = s.getGroupByQueryParams(r.URL.Query()); err != nil {
+		return nil, &apiError{errorBadData, err}
+	}
+
+	// Daily usage table does not have tags and status of units
+	if isDailyUsageQuery(r.URL.Query(), result.groupby) {
+		if len(r.URL.Query()["tag"]) > 0 {
+			return nil, &apiError{errorBadData, errTagNotSupported}
+		}
+
+		if status := r.URL.Query().Get("status"); status != "" && status != unitStatusAll {
+			return nil, &apiError{errorBadData, errStatusNotSupported}
+		}
+	}
+
+	// Round `to` and `from` query parameters to cacheTTL
+	if err := s.roundQueryWindow(r); err != nil {
+		return nil, &apiError{errorBadData, err}
+	}
+
+	// Get query window time stamps
+	queryWindowTS, err := s.getQueryWindow(r)
+	if err != nil {
+		return nil, &apiError{errorBadData, err}
+	}
+
+	// Attempt to retrieve from cache if present
+	// Use URL as cache key
+	cacheKey := r.URL.String()
+	if present := s.usageCache.Has(cacheKey); present {
+		cacheValue := s.usageCache.Get(cacheKey)
+		result.usage = cacheValue.Value()
+		result.etag = usageETag(cacheKey, result.usage)
+		result.expiresAt = cacheValue.ExpiresAt()
+
+		s.cacheHits.Inc()
+
+		return result, nil
+	}
+
+	s.cacheMisses.Inc()
+
+	// Make query
+	result.queryStart = time.Now()
+
+	result.usage, result.warnings, err = s.queryCurrentUsage(users, fields, result.groupby, queryWindowTS, r)
+	if err != nil {
 		s.logger.Error("Failed to fetch current usage statistics", "users", strings.Join(users, ","), "err", err)
-		errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
 
-		return
+		return nil, &apiError{errorInternal, err}
 	}
 
 	// Push to cache
-	if len(usage) > 0 {
-		s.usageCache.Set(cacheKey, usage, ttlcache.DefaultTTL)
+	if len(result.usage) > 0 {
+		s.usageCache.Set(cacheKey, result.usage, ttlcache.DefaultTTL)
+		result.etag = usageETag(cacheKey, result.usage)
 	}
 
-writer:
-	// Write response
-	w.WriteHeader(http.StatusOK)
+	return result, nil
+}
 
-	usageResponse := Response[models.Usage]{
-		Status: "success",
-		Data:   usage,
+// GET /usage/current
+// Get current usage statistics.
+func (s *CEEMSServer) currentUsage(users []string, fields []string, w http.ResponseWriter, r *http.Request) {
+	// Set write deadline
+	s.setWriteDeadline(5*time.Minute, w)
+
+	result, apiErr := s.fetchCurrentUsage(users, fields, r)
+	if apiErr != nil {
+		errorResponse[any](w, apiErr, s.logger, nil)
+
+		return
 	}
-	if qErrs != nil {
-		usageResponse.Warnings = append(usageResponse.Warnings, qErrs.Error())
+
+	// Add Expires header when cached value is being returned
+	if !result.expiresAt.IsZero() {
+		w.Header().Set("Expires", result.expiresAt.Format(time.RFC1123))
 	}
 
-	if err != nil {
-		usageResponse.Warnings = append(usageResponse.Warnings, err.Error())
+	if !result.queryStart.IsZero() {
+		s.setQueryDurationHeader(result.queryStart, w)
+	}
+
+	// Clients can skip downloading the response when they already have the
+	// same cached usage
+	if result.etag != "" {
+		w.Header().Set("ETag", result.etag)
+
+		if etagMatches(r.Header.Get("If-None-Match"), result.etag) {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
 	}
 
-	if err = json.NewEncoder(w).Encode(&usageResponse); err != nil {
+	// Write response
+	w.WriteHeader(http.StatusOK)
+
+	usageResponse := Response[models.Usage]{
+		Status:   "success",
+		Data:     result.usage,
+		Units:    fieldUnits[models.Usage](r),
+		GroupBy:  result.groupby,
+		Warnings: result.warnings,
+	}
+	if err := json.NewEncoder(w).Encode(&usageResponse); err != nil {
 		s.logger.Error("Failed to encode response", "err", err)
 		w.Write([]byte("KO"))
 	}
 }
 
-// GET /usage/global
-// Get global usage statistics.
-func (s *CEEMSServer) globalUsage(users []string, queriedFields []string, w http.ResponseWriter, r *http.Request) {
+// fetchGlobalUsage returns global usage statistics of users using query
+// parameters of r.
+func (s *CEEMSServer) fetchGlobalUsage(users []string, queriedFields []string, r *http.Request) (*usageResult, *apiError) {
+	// Usage table does not have tags
+	if len(r.URL.Query()["tag"]) > 0 {
+		return nil, &apiError{errorBadData, errTagNotSupported}
+	}
+
 	// Get sub query for projects
 	qSub := projectsSubQuery(users)
 
@@ -1498,26 +3404,52 @@ func (s *CEEMSServer) globalUsage(users []string, queriedFields []string, w http
 	q.query(" ORDER BY cluster_id ASC, username ASC, project ASC ")
 
 	// Make query and check for returned number of rows
+	result := &usageResult{queryStart: time.Now()}
+
 	usage, err := s.queriers.usage(r.Context(), s.db, q, s.logger)
 	if usage == nil && err != nil {
 		s.logger.Error("Failed to fetch global usage statistics", "users", strings.Join(users, ","), "err", err)
-		errorResponse[any](w, &apiError{errorInternal, err}, s.logger, nil)
+
+		return nil, &apiError{errorInternal, err}
+	}
+
+	if err != nil {
+		result.warnings = append(result.warnings, err.Error())
+	}
+
+	// Compute efficiencies from average usages
+	for i := range usage {
+		usage[i].ComputeEfficiency()
+	}
+
+	result.usage = usage
+
+	return result, nil
+}
+
+// GET /usage/global
+// Get global usage statistics.
+func (s *CEEMSServer) globalUsage(users []string, queriedFields []string, w http.ResponseWriter, r *http.Request) {
+	result, apiErr := s.fetchGlobalUsage(users, queriedFields, r)
+	if apiErr != nil {
+		errorResponse[any](w, apiErr, s.logger, nil)
 
 		return
 	}
 
+	s.setQueryDurationHeader(result.queryStart, w)
+
 	// Write response
 	w.WriteHeader(http.StatusOK)
 
 	usageResponse := Response[models.Usage]{
-		Status: "success",
-		Data:   usage,
-	}
-	if err != nil {
-		usageResponse.Warnings = append(usageResponse.Warnings, err.Error())
+		Status:   "success",
+		Data:     result.usage,
+		Units:    fieldUnits[models.Usage](r),
+		Warnings: result.warnings,
 	}
 
-	if err = json.NewEncoder(w).Encode(&usageResponse); err != nil {
+	if err := json.NewEncoder(w).Encode(&usageResponse); err != nil {
 		s.logger.Error("Failed to encode response", "err", err)
 		w.Write([]byte("KO"))
 	}
@@ -1541,6 +3473,19 @@ func (s *CEEMSServer) globalUsage(users []string, queriedFields []string, w http
 //	@Description	The statistics can be limited to certain projects by passing `project` query,
 //	@Description	parameter.
 //	@Description
+//	@Description	In `current` mode, the statistics can be limited to only running or only finished
+//	@Description	compute units by passing `status` query parameter with `active` or `terminated`
+//	@Description	value, respectively. By default, all units are included.
+//	@Description
+//	@Description	In `current` mode, the statistics are always aggregated by `username` and `project`.
+//	@Description	Additional dimensions can be added using `groupby` query parameter which must be one
+//	@Description	of `cluster_id`, `resource_manager`, `project`, `groupname`, `username` or `day`. The
+//	@Description	columns used for aggregating statistics are returned in `groupby` of the response.
+//	@Description	When grouped by `day`, usage is split into daily buckets over the query window and
+//	@Description	the date of each bucket is returned in `day` field.
+//	@Description	Buckets are computed from daily usage statistics which do not have `tag` and
+//	@Description	`status` of units and hence, these query parameters cannot be used along with it.
+//	@Description
 //	@Description	If `to` query parameter is not provided, current time will be used. If `from`
 //	@Description	query parameter is not used, a default query window of 24 hours will be used.
 //	@Description	It means if `to` is provided, `from` will be calculated as `to` - 24hrs.
@@ -1554,24 +3499,35 @@ func (s *CEEMSServer) globalUsage(users []string, queriedFields []string, w http
 //	@Description	needs to aggregate metrics over these JSON types using custom aggregate
 //	@Description	functions which can be slow.
 //	@Description
-//	@Description	Therefore the query results are cached for 15 min to avoid load on server.
-//	@Description	URL string is used as the cache key. Thus, the query parameters
+//	@Description	Therefore the query results are cached for 15 min, by default, to avoid load on
+//	@Description	server. URL string is used as the cache key. Thus, the query parameters
 //	@Description	`from` and `to` are rounded to the nearest timestamp that are
-//	@Description	multiple of 900 sec (15 min). The first query will make a DB query and
+//	@Description	multiple of cache TTL. The first query will make a DB query and
 //	@Description	cache results and subsequent queries, for a given user and same URL
 //	@Description	query parameters, will return the same cached result until the cache
-//	@Description	is invalidated after 15 min.
+//	@Description	is invalidated after cache TTL. Cache TTL can be configured using
+//	@Description	`web.cache_ttl` in the configuration file.
+//	@Description
+//	@Description	Cached responses of `current` mode include an `ETag` header. When the ETag is
+//	@Description	sent back in `If-None-Match` header and the usage has not changed, 304 response
+//	@Description	is returned without body.
 //	@Security		BasicAuth
 //	@Tags			usage
 //	@Produce		json
 //	@Param			X-Grafana-User	header		string		true	"Current user name"
 //	@Param			mode			path		string		true	"Whether to get usage stats within a period or global"	Enums(current, global)
-//	@Param			cluster_id		query		[]string	false	"cluster ID"											collectionFormat(multi)
-//	@Param			project			query		[]string	false	"Project"												collectionFormat(multi)
+//	@Param			If-None-Match	header		string		false	"ETag of previous response"
+//	@Param			cluster_id		query		[]string	false	"cluster ID"	collectionFormat(multi)
+//	@Param			project			query		[]string	false	"Project"		collectionFormat(multi)
 //	@Param			from			query		string		false	"From timestamp"
 //	@Param			to				query		string		false	"To timestamp"
-//	@Param			field			query		[]string	false	"Fields to return in response"	collectionFormat(multi)
+//	@Param			status			query		string		false	"Status of units"						Enums(active, terminated, all)
+//	@Param			tag				query		[]string	false	"Tag of units of form key:value. Only supported in current mode"			collectionFormat(multi)
+//	@Param			groupby			query		[]string	false	"Additional columns to group usage by"	collectionFormat(multi)
+//	@Param			field			query		[]string	false	"Fields to return in response"			collectionFormat(multi)
+//	@Param			include_units	query		bool		false	"Whether to include units of fields in response"
 //	@Success		200				{object}	Response[models.Usage]
+//	@Success		304				{string}	string
 //	@Failure		401				{object}	Response[any]
 //	@Failure		500				{object}	Response[any]
 //	@Router			/usage/{mode} [get]
@@ -1639,6 +3595,19 @@ func (s *CEEMSServer) usage(w http.ResponseWriter, r *http.Request) {
 //	@Description	The statistics can be limited to certain projects by passing `project` query,
 //	@Description	parameter.
 //	@Description
+//	@Description	In `current` mode, the statistics can be limited to only running or only finished
+//	@Description	compute units by passing `status` query parameter with `active` or `terminated`
+//	@Description	value, respectively. By default, all units are included.
+//	@Description
+//	@Description	In `current` mode, the statistics are always aggregated by `username` and `project`.
+//	@Description	Additional dimensions can be added using `groupby` query parameter which must be one
+//	@Description	of `cluster_id`, `resource_manager`, `project`, `groupname`, `username` or `day`. The
+//	@Description	columns used for aggregating statistics are returned in `groupby` of the response.
+//	@Description	When grouped by `day`, usage is split into daily buckets over the query window and
+//	@Description	the date of each bucket is returned in `day` field.
+//	@Description	Buckets are computed from daily usage statistics which do not have `tag` and
+//	@Description	`status` of units and hence, these query parameters cannot be used along with it.
+//	@Description
 //	@Description	If `to` query parameter is not provided, current time will be used. If `from`
 //	@Description	query parameter is not used, a default query window of 24 hours will be used.
 //	@Description	It means if `to` is provided, `from` will be calculated as `to` - 24hrs.
@@ -1652,25 +3621,36 @@ func (s *CEEMSServer) usage(w http.ResponseWriter, r *http.Request) {
 //	@Description	needs to aggregate metrics over these JSON types using custom aggregate
 //	@Description	functions which can be slow.
 //	@Description
-//	@Description	Therefore the query results are cached for 15 min to avoid load on server.
-//	@Description	URL string is used as the cache key. Thus, the query parameters
+//	@Description	Therefore the query results are cached for 15 min, by default, to avoid load on
+//	@Description	server. URL string is used as the cache key. Thus, the query parameters
 //	@Description	`from` and `to` are rounded to the nearest timestamp that are
-//	@Description	multiple of 900 sec (15 min). The first query will make a DB query and
+//	@Description	multiple of cache TTL. The first query will make a DB query and
 //	@Description	cache results and subsequent queries, for a given user and same URL
 //	@Description	query parameters, will return the same cached result until the cache
-//	@Description	is invalidated after 15 min.
+//	@Description	is invalidated after cache TTL. Cache TTL can be configured using
+//	@Description	`web.cache_ttl` in the configuration file.
+//	@Description
+//	@Description	Cached responses of `current` mode include an `ETag` header. When the ETag is
+//	@Description	sent back in `If-None-Match` header and the usage has not changed, 304 response
+//	@Description	is returned without body.
 //	@Security		BasicAuth
 //	@Tags			usage
 //	@Produce		json
 //	@Param			X-Grafana-User	header		string		true	"Current user name"
 //	@Param			mode			path		string		true	"Whether to get usage stats within a period or global"	Enums(current, global)
-//	@Param			cluster_id		query		[]string	false	"cluster ID"											collectionFormat(multi)
+//	@Param			If-None-Match	header		string		false	"ETag of previous response"
+//	@Param			cluster_id		query		[]string	false	"cluster ID"	collectionFormat(multi)
 //	@Param			project			query		[]string	false	"Project"
 //	@Param			user			query		[]string	false	"Username"	collectionFormat(multi)
 //	@Param			from			query		string		false	"From timestamp"
 //	@Param			to				query		string		false	"To timestamp"
-//	@Param			field			query		[]string	false	"Fields to return in response"	collectionFormat(multi)
+//	@Param			status			query		string		false	"Status of units"						Enums(active, terminated, all)
+//	@Param			tag				query		[]string	false	"Tag of units of form key:value. Only supported in current mode"			collectionFormat(multi)
+//	@Param			groupby			query		[]string	false	"Additional columns to group usage by"	collectionFormat(multi)
+//	@Param			field			query		[]string	false	"Fields to return in response"			collectionFormat(multi)
+//	@Param			include_units	query		bool		false	"Whether to include units of fields in response"
 //	@Success		200				{object}	Response[models.Usage]
+//	@Success		304				{string}	string
 //	@Failure		401				{object}	Response[any]
 //	@Failure		403				{object}	Response[any]
 //	@Failure		500				{object}	Response[any]
@@ -1734,7 +3714,7 @@ func (s *CEEMSServer) currentStats(users []string, w http.ResponseWriter, r *htt
 
 	// Make query
 	q = Query{}
-	q.query(fmt.Sprintf("SELECT %s FROM %s WHERE 1=1", statsQuery, base.UnitsDBTableName))
+	q.query(fmt.Sprintf("SELECT %s FROM %s WHERE ignore = 0", statsQuery, base.UnitsDBTableName))
 
 	// Get query window time stamps
 	queryWindowTS, err = s.getQueryWindow(r)
@@ -1764,6 +3744,9 @@ func (s *CEEMSServer) currentStats(users []string, w http.ResponseWriter, r *htt
 		q.param(clusterIDs)
 	}
 
+	// Exclude units of system users
+	s.excludeSystemUsers(&q)
+
 	// Finally add GROUP BY clause. Always group by cluster_id
 	q.query(" GROUP BY cluster_id")
 
@@ -1796,6 +3779,17 @@ func (s *CEEMSServer) currentStats(users []string, w http.ResponseWriter, r *htt
 	}
 }
 
+// excludeSystemUsers adds a clause to query to exclude units of system users
+// so that stats reflect only real users.
+func (s *CEEMSServer) excludeSystemUsers(q *Query) {
+	if len(s.systemUsers) == 0 {
+		return
+	}
+
+	q.query(" AND username NOT IN ")
+	q.param(s.systemUsers)
+}
+
 // GET /stats/global
 // Get global usage statistics.
 func (s *CEEMSServer) globalStats(users []string, w http.ResponseWriter, r *http.Request) {
@@ -1810,7 +3804,7 @@ func (s *CEEMSServer) globalStats(users []string, w http.ResponseWriter, r *http
 
 	// Make query
 	q = Query{}
-	q.query(fmt.Sprintf("SELECT %s FROM %s WHERE 1=1", statsQuery, base.UnitsDBTableName))
+	q.query(fmt.Sprintf("SELECT %s FROM %s WHERE ignore = 0", statsQuery, base.UnitsDBTableName))
 
 	// Get cluster_id query parameters if any
 	if clusterIDs := r.URL.Query()["cluster_id"]; len(clusterIDs) > 0 {
@@ -1818,6 +3812,9 @@ func (s *CEEMSServer) globalStats(users []string, w http.ResponseWriter, r *http
 		q.param(clusterIDs)
 	}
 
+	// Exclude units of system users
+	s.excludeSystemUsers(&q)
+
 	// Finally add GROUP BY clause. Always group by cluster_id
 	q.query(" GROUP BY cluster_id")
 
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -15,14 +16,22 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/jellydator/ttlcache/v3"
 	"github.com/mahendrapaipuri/ceems/pkg/api/base"
 	"github.com/mahendrapaipuri/ceems/pkg/api/db"
+	db_migrator "github.com/mahendrapaipuri/ceems/pkg/api/db/migrator"
 	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	"github.com/mahendrapaipuri/ceems/pkg/sqlite3"
+	"github.com/mahendrapaipuri/ceems/pkg/tsdb"
+	config_util "github.com/prometheus/common/config"
+	"github.com/prometheus/common/version"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -93,6 +102,7 @@ func setupServer(d string) *CEEMSServer {
 		cluster: clusterQuerier,
 		stat:    statQuerier,
 		key:     keyQuerier,
+		count:   countQuerier,
 	}
 
 	return server
@@ -126,6 +136,10 @@ func keyQuerier(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) (
 	return mockKeys, nil
 }
 
+func countQuerier(ctx context.Context, db *sql.DB, q Query) (int, error) {
+	return len(mockServerUnits), nil
+}
+
 func keyQuerierErr(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) ([]models.Key, error) {
 	return nil, errors.New("failed query")
 }
@@ -137,6 +151,29 @@ func getMockUnits(
 	return mockServerUnits, nil
 }
 
+func TestQueryDBPath(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tmpDir := t.TempDir()
+	primaryDir := filepath.Join(tmpDir, "primary")
+	replicaDir := filepath.Join(tmpDir, "replica")
+
+	// No replica configured
+	c := db.DataConfig{Path: primaryDir}
+	assert.Equal(t, filepath.Join(primaryDir, base.CEEMSDBName), queryDBPath(c, logger))
+
+	// Replica configured but DB file does not exist
+	c.ReplicaPath = replicaDir
+	assert.Equal(t, filepath.Join(primaryDir, base.CEEMSDBName), queryDBPath(c, logger))
+
+	// Replica DB file exists
+	err := os.MkdirAll(replicaDir, 0o750)
+	require.NoError(t, err)
+	f, err := os.Create(filepath.Join(replicaDir, base.CEEMSDBName))
+	require.NoError(t, err)
+	f.Close()
+	assert.Equal(t, filepath.Join(replicaDir, base.CEEMSDBName), queryDBPath(c, logger))
+}
+
 // Test users and users admin handlers.
 func TestUsersHandlers(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -300,6 +337,22 @@ func TestUnitsHandler(t *testing.T) {
 			handler: server.unitsAdmin,
 			code:    200,
 		},
+		{
+			name:    "units with field units",
+			req:     "/api/" + base.APIVersion + "/units?include_units=true",
+			user:    "foousr",
+			admin:   false,
+			handler: server.units,
+			code:    200,
+		},
+	}
+
+	expectedUnits := map[string]string{
+		"total_time_seconds":         "seconds",
+		"total_cpu_energy_usage_kwh": "kWh",
+		"total_cpu_emissions_gms":    "gCO2",
+		"total_gpu_energy_usage_kwh": "kWh",
+		"total_gpu_emissions_gms":    "gCO2",
 	}
 
 	for _, test := range tests {
@@ -330,94 +383,355 @@ func TestUnitsHandler(t *testing.T) {
 		assert.Equal(t, test.code, w.Code)
 		assert.Equal(t, "success", response.Status)
 		assert.Equal(t, mockServerUnits, response.Data)
+
+		if request.URL.Query().Get("include_units") == "true" {
+			assert.Equal(t, expectedUnits, response.Units, test.name)
+		} else {
+			assert.Empty(t, response.Units, test.name)
+		}
 	}
 }
 
-// Test usage and usage admin handlers.
-func TestUsageHandlers(t *testing.T) {
+func TestUnitsHandlerMaxResultRows(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	f, err := os.Create(filepath.Join(tmpDir, base.CEEMSDBName))
-	if err != nil {
-		require.NoError(t, err)
-	}
+	require.NoError(t, err)
 
 	defer f.Close()
 
 	server := setupServer(tmpDir)
 	defer server.Shutdown(context.Background())
 
+	server.maxResultRows = 1
+
+	var gotQuery string
+
+	server.queriers.unit = func(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) ([]models.Unit, error) {
+		gotQuery, _ = q.get()
+
+		return mockServerUnits, nil
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/api/"+base.APIVersion+"/units", nil)
+	request.Header.Set("X-Grafana-User", "foousr")
+
+	w := httptest.NewRecorder()
+	server.units(w, request)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var response Response[models.Unit]
+
+	require.NoError(t, json.Unmarshal(data, &response))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, gotQuery, " LIMIT 2 ")
+	assert.Equal(t, mockServerUnits[:1], response.Data)
+	assert.Equal(t, []string{"results truncated to 1 units. Use pagination to fetch all units"}, response.Warnings)
+}
+
+func TestUnitsCountHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	// Capture the query executed by the server
+	var queries []string
+
+	server.queriers.count = func(ctx context.Context, db *sql.DB, q Query) (int, error) {
+		query, _ := q.get()
+		queries = append(queries, query)
+
+		return len(mockServerUnits), nil
+	}
+
 	// Test cases
 	tests := []testCase{
 		{
-			name:    "current usage",
-			req:     "/api/" + base.APIVersion + "/usage/current",
+			name:    "units count",
+			req:     "/api/" + base.APIVersion + "/units/count?project=foo",
 			user:    "foousr",
-			admin:   false,
-			handler: server.usage,
+			handler: server.unitsCount,
 			code:    200,
 		},
 		{
-			name:    "current usage cached",
-			req:     "/api/" + base.APIVersion + "/usage/current",
+			name:    "units count admin",
+			req:     "/api/" + base.APIVersion + "/units/count/admin?user=foousr&user=barusr",
 			user:    "foousr",
-			admin:   false,
-			handler: server.usage,
+			admin:   true,
+			handler: server.unitsCountAdmin,
 			code:    200,
 		},
 		{
-			name:    "global usage",
-			req:     "/api/" + base.APIVersion + "/usage/global",
+			name:    "units count with invalid status",
+			req:     "/api/" + base.APIVersion + "/units/count?status=unknown",
 			user:    "foousr",
-			admin:   false,
-			handler: server.usage,
-			code:    200,
+			handler: server.unitsCount,
+			code:    400,
 		},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, test.req, nil)
+		request.Header.Set("X-Grafana-User", test.user)
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		test.handler(w, request)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		// Get body
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		// Unmarshal byte into structs.
+		var response Response[int]
+
+		json.Unmarshal(data, &response)
+		assert.Equal(t, test.code, w.Code, test.name)
+
+		if test.code == 200 {
+			assert.Equal(t, "success", response.Status, test.name)
+			assert.Equal(t, []int{len(mockServerUnits)}, response.Data, test.name)
+		}
+	}
+
+	// Check that count queries use same conditions as units queries
+	require.Len(t, queries, 2)
+	assert.True(t, strings.HasPrefix(queries[0], "SELECT COUNT(*) FROM units WHERE ignore = 0  AND username IN (?)"), queries[0])
+	assert.Contains(t, queries[0], "project IN (?)")
+	assert.True(t, strings.HasPrefix(queries[1], "SELECT COUNT(*) FROM units WHERE ignore = 0  AND username IN (?,?)"), queries[1])
+}
+
+func TestUnitsExportHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	// Capture the queries executed by the server
+	var query string
+
+	var params []string
+
+	server.queriers.export = func(ctx context.Context, db *sql.DB, q Query, w io.Writer, logger *slog.Logger) (int, error) {
+		query, params = q.get()
+
+		_, err := w.Write([]byte("uuid,project\n1000,foo\n"))
+
+		return 1, err
+	}
+
+	// Test cases
+	tests := []struct {
+		name    string
+		req     string
+		user    string
+		handler func(http.ResponseWriter, *http.Request)
+		code    int
+		users   []string
+	}{
 		{
-			name:    "current usage admin",
-			req:     "/api/" + base.APIVersion + "/usage/current/admin",
-			user:    "adm1",
-			admin:   true,
-			handler: server.usageAdmin,
+			name:    "export units",
+			req:     "/api/" + base.APIVersion + "/units/export?field=uuid&field=project",
+			user:    "foousr",
+			handler: server.unitsExport,
 			code:    200,
+			users:   []string{"foousr"},
 		},
 		{
-			name:    "current usage admin cached",
-			req:     "/api/" + base.APIVersion + "/usage/current/admin",
+			name:    "export units admin",
+			req:     "/api/" + base.APIVersion + "/units/export/admin?field=uuid&field=project&user=barusr",
 			user:    "adm1",
-			admin:   true,
-			handler: server.usageAdmin,
+			handler: server.unitsExportAdmin,
 			code:    200,
+			users:   []string{"barusr"},
 		},
 		{
-			name:    "global usage admin",
-			req:     "/api/" + base.APIVersion + "/usage/global/admin",
-			user:    "adm1",
-			admin:   true,
-			handler: server.usageAdmin,
-			code:    200,
+			name:    "export units with malformed query",
+			req:     "/api/" + base.APIVersion + "/units/export?from=foo",
+			user:    "foousr",
+			handler: server.unitsExport,
+			code:    400,
 		},
 	}
 
 	for _, test := range tests {
+		query = ""
+
 		request := httptest.NewRequest(http.MethodGet, test.req, nil)
-		request.Header.Set("X-Grafana-User", test.user)
+		request.Header.Set(loggedUserHeader, test.user)
+		request.Header.Set(dashboardUserHeader, test.user)
 
-		if test.admin {
-			q := url.Values{}
-			q.Add("user", "foousr")
-			request.URL.RawQuery = q.Encode()
-		}
+		// Start recorder
+		w := httptest.NewRecorder()
+		test.handler(w, request)
 
-		if strings.Contains(test.name, "current") {
-			request = mux.SetURLVars(request, map[string]string{"mode": "current"})
-		} else {
-			request = mux.SetURLVars(request, map[string]string{"mode": "global"})
+		res := w.Result()
+		defer res.Body.Close()
+
+		// Get body
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, test.code, w.Code, test.name)
+
+		if test.code != 200 {
+			assert.Empty(t, query, test.name)
+
+			continue
 		}
 
+		assert.Equal(t, "text/csv", res.Header.Get("Content-Type"), test.name)
+		assert.Equal(t, `attachment; filename="units.csv"`, res.Header.Get("Content-Disposition"), test.name)
+		assert.Equal(t, "uuid,project\n1000,foo\n", string(data), test.name)
+
+		// Ownership and query window filters must be applied
+		assert.Contains(t, query, "SELECT uuid,project FROM units WHERE ignore = 0  AND username IN (?)", test.name)
+		assert.Contains(t, query, "AND ended_at BETWEEN (?) AND (?) ORDER BY cluster_id ASC, uuid ASC", test.name)
+		assert.Equal(t, test.users, params[:1], test.name)
+	}
+}
+
+func TestUnitsHandlerPagination(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	// Capture the queries executed by the server
+	var query string
+
+	server.queriers.unit = func(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) ([]models.Unit, error) {
+		query, _ = q.get()
+
+		return mockServerUnits, nil
+	}
+	server.queriers.count = func(ctx context.Context, db *sql.DB, q Query) (int, error) {
+		return 5, nil
+	}
+
+	nextOffset := 3
+	cursor := encodeUnitsCursor("slurm-0", "1000")
+	nextCursor := encodeUnitsCursor("os-0", "10001")
+
+	// Test cases
+	tests := []struct {
+		name       string
+		req        string
+		code       int
+		limit      string
+		order      string
+		keyset     bool
+		pagination *Pagination
+	}{
+		{
+			name:       "first page",
+			req:        "/api/" + base.APIVersion + "/units?limit=2",
+			code:       200,
+			limit:      "LIMIT 2 OFFSET 0",
+			pagination: &Pagination{Total: 5, Limit: 2, Offset: 0, NextOffset: &[]int{2}[0], NextCursor: nextCursor},
+		},
+		{
+			name:       "intermediate page",
+			req:        "/api/" + base.APIVersion + "/units?limit=2&offset=1",
+			code:       200,
+			limit:      "LIMIT 2 OFFSET 1",
+			pagination: &Pagination{Total: 5, Limit: 2, Offset: 1, NextOffset: &nextOffset, NextCursor: nextCursor},
+		},
+		{
+			name:       "last page",
+			req:        "/api/" + base.APIVersion + "/units?limit=2&offset=3",
+			code:       200,
+			limit:      "LIMIT 2 OFFSET 3",
+			pagination: &Pagination{Total: 5, Limit: 2, Offset: 3},
+		},
+		{
+			name:       "limit capped",
+			req:        "/api/" + base.APIVersion + "/units?limit=100000",
+			code:       200,
+			limit:      fmt.Sprintf("LIMIT %d OFFSET 0", maxUnitsPageLimit),
+			pagination: &Pagination{Total: 5, Limit: maxUnitsPageLimit, Offset: 0, NextOffset: &[]int{2}[0], NextCursor: nextCursor},
+		},
+		{
+			name:       "cursor with full page",
+			req:        "/api/" + base.APIVersion + "/units?limit=2&cursor=" + cursor,
+			code:       200,
+			limit:      "LIMIT 2 OFFSET 0",
+			keyset:     true,
+			pagination: &Pagination{Total: 5, Limit: 2, Offset: 0, Cursor: cursor, NextCursor: nextCursor},
+		},
+		{
+			name:       "cursor with last page",
+			req:        "/api/" + base.APIVersion + "/units?limit=3&cursor=" + cursor,
+			code:       200,
+			limit:      "LIMIT 3 OFFSET 0",
+			keyset:     true,
+			pagination: &Pagination{Total: 5, Limit: 3, Offset: 0, Cursor: cursor},
+		},
+		{
+			name:       "cursor without limit",
+			req:        "/api/" + base.APIVersion + "/units?cursor=" + cursor,
+			code:       200,
+			limit:      fmt.Sprintf("LIMIT %d OFFSET 0", maxUnitsPageLimit),
+			keyset:     true,
+			pagination: &Pagination{Total: 5, Limit: maxUnitsPageLimit, Offset: 0, Cursor: cursor},
+		},
+		{
+			name: "invalid cursor",
+			req:  "/api/" + base.APIVersion + "/units?limit=2&cursor=foo",
+			code: 400,
+		},
+		{
+			name: "cursor with offset",
+			req:  "/api/" + base.APIVersion + "/units?limit=2&offset=2&cursor=" + cursor,
+			code: 400,
+		},
+		{
+			name: "cursor with order",
+			req:  "/api/" + base.APIVersion + "/units?limit=2&order=started_at:desc&cursor=" + cursor,
+			code: 400,
+		},
+		{
+			name:       "first page with order",
+			req:        "/api/" + base.APIVersion + "/units?limit=2&order=started_at:desc",
+			code:       200,
+			limit:      "LIMIT 2 OFFSET 0",
+			order:      "started_at_ts DESC, cluster_id ASC, uuid ASC",
+			pagination: &Pagination{Total: 5, Limit: 2, Offset: 0, NextOffset: &[]int{2}[0]},
+		},
+		{
+			name: "no pagination",
+			req:  "/api/" + base.APIVersion + "/units",
+			code: 200,
+		},
+		{
+			name: "invalid limit",
+			req:  "/api/" + base.APIVersion + "/units?limit=-1",
+			code: 400,
+		},
+		{
+			name: "invalid offset",
+			req:  "/api/" + base.APIVersion + "/units?limit=10&offset=foo",
+			code: 400,
+		},
+	}
+
+	for _, test := range tests {
+		query = ""
+
+		request := httptest.NewRequest(http.MethodGet, test.req, nil)
+		request.Header.Set("X-Grafana-User", "foousr")
+
 		// Start recorder
 		w := httptest.NewRecorder()
-		test.handler(w, request)
+		server.units(w, request)
 
 		res := w.Result()
 		defer res.Body.Close()
@@ -427,23 +741,33 @@ func TestUsageHandlers(t *testing.T) {
 		require.NoError(t, err)
 
 		// Unmarshal byte into structs.
-		var response Response[models.Usage]
+		var response Response[models.Unit]
 
 		json.Unmarshal(data, &response)
-		assert.Equal(t, test.code, w.Code)
-		assert.Equal(t, "success", response.Status)
-		assert.Equal(t, mockServerUsage, response.Data)
+		assert.Equal(t, test.code, w.Code, test.name)
+		assert.Equal(t, test.pagination, response.Pagination, test.name)
 
-		if strings.Contains(test.name, "cached") {
-			assert.NotEmpty(t, res.Header["Expires"])
+		order := "cluster_id ASC, uuid ASC"
+		if test.order != "" {
+			order = test.order
+		}
+
+		if test.limit != "" {
+			assert.Contains(t, query, "ORDER BY "+order+"  "+test.limit, test.name)
 		} else {
-			assert.Empty(t, res.Header["Expires"])
+			assert.NotContains(t, query, "LIMIT", test.name)
+		}
+
+		if test.keyset {
+			assert.Contains(t, query, ") WHERE (cluster_id, uuid) > (?,?) ORDER BY", test.name)
+		} else {
+			assert.NotContains(t, query, "(cluster_id, uuid) >", test.name)
 		}
 	}
 }
 
 // Test usage and usage admin handlers.
-func TestUsageErrorHandlers(t *testing.T) {
+func TestUsageHandlers(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	f, err := os.Create(filepath.Join(tmpDir, base.CEEMSDBName))
@@ -456,9 +780,6 @@ func TestUsageErrorHandlers(t *testing.T) {
 	server := setupServer(tmpDir)
 	defer server.Shutdown(context.Background())
 
-	// Return errors for intermediate queries
-	server.queriers.key = keyQuerierErr
-
 	// Test cases
 	tests := []testCase{
 		{
@@ -469,6 +790,22 @@ func TestUsageErrorHandlers(t *testing.T) {
 			handler: server.usage,
 			code:    200,
 		},
+		{
+			name:    "current usage cached",
+			req:     "/api/" + base.APIVersion + "/usage/current",
+			user:    "foousr",
+			admin:   false,
+			handler: server.usage,
+			code:    200,
+		},
+		{
+			name:    "global usage",
+			req:     "/api/" + base.APIVersion + "/usage/global",
+			user:    "foousr",
+			admin:   false,
+			handler: server.usage,
+			code:    200,
+		},
 		{
 			name:    "current usage admin",
 			req:     "/api/" + base.APIVersion + "/usage/current/admin",
@@ -477,6 +814,22 @@ func TestUsageErrorHandlers(t *testing.T) {
 			handler: server.usageAdmin,
 			code:    200,
 		},
+		{
+			name:    "current usage admin cached",
+			req:     "/api/" + base.APIVersion + "/usage/current/admin",
+			user:    "adm1",
+			admin:   true,
+			handler: server.usageAdmin,
+			code:    200,
+		},
+		{
+			name:    "global usage admin",
+			req:     "/api/" + base.APIVersion + "/usage/global/admin",
+			user:    "adm1",
+			admin:   true,
+			handler: server.usageAdmin,
+			code:    200,
+		},
 	}
 
 	for _, test := range tests {
@@ -513,7 +866,415 @@ func TestUsageErrorHandlers(t *testing.T) {
 		assert.Equal(t, test.code, w.Code)
 		assert.Equal(t, "success", response.Status)
 		assert.Equal(t, mockServerUsage, response.Data)
-		assert.NotEmpty(t, response.Warnings)
+
+		if strings.Contains(test.name, "cached") {
+			assert.NotEmpty(t, res.Header["Expires"])
+		} else {
+			assert.Empty(t, res.Header["Expires"])
+		}
+	}
+}
+
+// Test usage and usage admin handlers.
+func TestUsageCacheMetrics(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	// Make same query twice so that second one is served from cache
+	for range 2 {
+		request := httptest.NewRequest(http.MethodGet, "/api/"+base.APIVersion+"/usage/current?from=1000&to=4000", nil)
+		request.Header.Set("X-Grafana-User", "foousr")
+		request = mux.SetURLVars(request, map[string]string{"mode": "current"})
+
+		w := httptest.NewRecorder()
+		server.usage(w, request)
+		assert.Equal(t, 200, w.Code)
+	}
+
+	// Check metrics
+	request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(w, request)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 200, w.Code)
+	assert.Contains(t, string(data), "ceems_api_usage_cache_hits_total 1")
+	assert.Contains(t, string(data), "ceems_api_usage_cache_misses_total 1")
+	assert.Contains(t, string(data), "ceems_api_usage_cache_entries 1")
+}
+
+func TestUsageQueryDurationHeader(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	tests := []struct {
+		name    string
+		mode    string
+		enabled bool
+		header  bool
+	}{
+		{name: "disabled", mode: "current", enabled: false, header: false},
+		{name: "current usage", mode: "current", enabled: true, header: true},
+		{name: "current usage cached", mode: "current", enabled: true, header: false},
+		{name: "global usage", mode: "global", enabled: true, header: true},
+	}
+
+	for _, test := range tests {
+		server.queryDuration = test.enabled
+
+		// Disabled request uses a different window so that the next one is not cached
+		from := "1000"
+		if !test.enabled {
+			from = "2000"
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "/api/"+base.APIVersion+"/usage/"+test.mode+"?from="+from+"&to=4000", nil)
+		request.Header.Set("X-Grafana-User", "foousr")
+		request = mux.SetURLVars(request, map[string]string{"mode": test.mode})
+
+		w := httptest.NewRecorder()
+		server.usage(w, request)
+		assert.Equal(t, 200, w.Code, test.name)
+
+		if test.header {
+			duration, err := strconv.ParseFloat(w.Header().Get(queryDurationHeader), 64)
+			require.NoError(t, err, test.name)
+			assert.GreaterOrEqual(t, duration, 0.0, test.name)
+		} else {
+			assert.Empty(t, w.Header().Get(queryDurationHeader), test.name)
+		}
+	}
+}
+
+func TestUsageGroupBy(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	// Capture the queries executed by the server
+	var query string
+
+	server.queriers.usage = func(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) ([]models.Usage, error) {
+		query, _ = q.get()
+
+		return mockServerUsage, nil
+	}
+
+	// Test cases
+	tests := []struct {
+		name    string
+		req     string
+		code    int
+		groupby []string
+	}{
+		{
+			name:    "default groupby",
+			req:     "/api/" + base.APIVersion + "/usage/current?field=num_units",
+			code:    200,
+			groupby: []string{"project", "username"},
+		},
+		{
+			name:    "additional groupby",
+			req:     "/api/" + base.APIVersion + "/usage/current?field=num_units&groupby=cluster_id&groupby=groupname&groupby=project",
+			code:    200,
+			groupby: []string{"cluster_id", "groupname", "project", "username"},
+		},
+		{
+			name:    "daily groupby",
+			req:     "/api/" + base.APIVersion + "/usage/current?field=num_units&groupby=day",
+			code:    200,
+			groupby: []string{"day", "project", "username"},
+		},
+		{
+			name: "daily groupby with tag",
+			req:  "/api/" + base.APIVersion + "/usage/current?groupby=day&tag=qos:long",
+			code: 400,
+		},
+		{
+			name: "daily groupby with status",
+			req:  "/api/" + base.APIVersion + "/usage/current?groupby=day&status=active",
+			code: 400,
+		},
+		{
+			name: "aggregated column in groupby",
+			req:  "/api/" + base.APIVersion + "/usage/current?groupby=num_units",
+			code: 400,
+		},
+		{
+			name: "unknown column in groupby",
+			req:  "/api/" + base.APIVersion + "/usage/current?groupby=partition",
+			code: 400,
+		},
+		{
+			name: "malicious groupby",
+			req:  "/api/" + base.APIVersion + "/usage/current?groupby=username%20ORDER%20BY%201--",
+			code: 400,
+		},
+	}
+
+	for _, test := range tests {
+		query = ""
+
+		request := httptest.NewRequest(http.MethodGet, test.req, nil)
+		request.Header.Set("X-Grafana-User", "foousr")
+		request = mux.SetURLVars(request, map[string]string{"mode": "current"})
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		server.usage(w, request)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		// Get body
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		// Unmarshal byte into structs.
+		var response Response[models.Usage]
+
+		json.Unmarshal(data, &response)
+		assert.Equal(t, test.code, w.Code, test.name)
+		assert.Equal(t, test.groupby, response.GroupBy, test.name)
+
+		if test.code != 200 {
+			assert.Empty(t, query, test.name)
+
+			continue
+		}
+
+		// Grouped columns must be selected and used in GROUP BY clause
+		for _, col := range test.groupby {
+			assert.Contains(t, query, ","+aggUsageQueries[col], test.name)
+		}
+
+		assert.Contains(t, query, " GROUP BY "+strings.Join(test.groupby, ",")+" ORDER BY", test.name)
+
+		// Daily buckets must be queried from daily usage table and sorted by day
+		// and ignored units must be excluded when querying units table
+		if slices.Contains(test.groupby, groupByDay) {
+			assert.Contains(t, query, "FROM (daily_usage AS u", test.name)
+			assert.Contains(t, query, "project ASC, day ASC", test.name)
+		} else {
+			assert.Contains(t, query, "FROM (units AS u", test.name)
+			assert.Contains(t, query, " AND ignore = 0", test.name)
+		}
+	}
+}
+
+func TestUsageGroupByDayValues(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Prepare DB with a unit that ran during two days. Units table has the
+	// usage of unit over its lifetime whereas daily usage table has the usage
+	// of each day
+	sqlDB, err := sql.Open(sqlite3.DriverName, filepath.Join(tmpDir, base.CEEMSDBName))
+	require.NoError(t, err)
+
+	defer sqlDB.Close()
+
+	migrator, err := db_migrator.New(db.MigrationsFS, "migrations", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.NoError(t, migrator.ApplyMigrations(sqlDB))
+
+	_, err = sqlDB.Exec(
+		`INSERT INTO units (cluster_id, uuid, project, username, total_time_seconds, last_updated_at) VALUES ('slurm-0', '1000', 'prj', 'foousr', '{"alloc_cputime":300}', '2025-01-02T12:00:00')`,
+	)
+	require.NoError(t, err)
+
+	for day, cpuTime := range map[string]int{"2025-01-01": 100, "2025-01-02": 200} {
+		_, err = sqlDB.Exec(
+			`INSERT INTO daily_usage (cluster_id, project, username, num_units, total_time_seconds, last_updated_at) VALUES ('slurm-0', 'prj', 'foousr', 1, ?, ?)`,
+			fmt.Sprintf(`{"alloc_cputime":%d}`, cpuTime), day+"T00:00:00",
+		)
+		require.NoError(t, err)
+	}
+
+	_, err = sqlDB.Exec(`INSERT INTO projects (cluster_id, name, users) VALUES ('slurm-0', 'prj', '["foousr"]')`)
+	require.NoError(t, err)
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	server.queriers.usage = Querier[models.Usage]
+	server.queriers.key = Querier[models.Key]
+
+	request := httptest.NewRequest(
+		http.MethodGet,
+		"/api/"+base.APIVersion+"/usage/current?field=total_time_seconds&groupby=day&from=1735689600&to=1735862400",
+		nil,
+	)
+	request.Header.Set(dashboardUserHeader, "foousr")
+	request = mux.SetURLVars(request, map[string]string{"mode": "current"})
+
+	// Start recorder
+	w := httptest.NewRecorder()
+	server.usage(w, request)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	// Get body
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	// Unmarshal byte into structs.
+	var response Response[models.Usage]
+
+	require.NoError(t, json.Unmarshal(data, &response))
+	require.Equal(t, 200, w.Code)
+
+	// Usage of each day must be split and not attributed to the day at which
+	// unit was last updated
+	expected := []models.Usage{
+		{Project: "prj", User: "foousr", Day: "2025-01-01", TotalTime: models.MetricMap{"alloc_cputime": 100}},
+		{Project: "prj", User: "foousr", Day: "2025-01-02", TotalTime: models.MetricMap{"alloc_cputime": 200}},
+	}
+	assert.Equal(t, expected, response.Data)
+}
+
+func TestUsageETag(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	makeRequest := func(ifNoneMatch string) *http.Response {
+		request := httptest.NewRequest(http.MethodGet, "/api/"+base.APIVersion+"/usage/current?from=1000&to=4000", nil)
+		request.Header.Set("X-Grafana-User", "foousr")
+
+		if ifNoneMatch != "" {
+			request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+
+		request = mux.SetURLVars(request, map[string]string{"mode": "current"})
+
+		w := httptest.NewRecorder()
+		server.usage(w, request)
+
+		return w.Result()
+	}
+
+	// First request must return ETag
+	res := makeRequest("")
+	defer res.Body.Close()
+
+	etag := res.Header.Get("ETag")
+	assert.Equal(t, 200, res.StatusCode)
+	assert.NotEmpty(t, etag)
+
+	// Cached response with matching ETag must return 304 without body
+	res = makeRequest(etag)
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 304, res.StatusCode)
+	assert.Equal(t, etag, res.Header.Get("ETag"))
+	assert.Empty(t, data)
+
+	// Weak and multiple ETags must match as well
+	res = makeRequest(`"foo", W/` + etag)
+	defer res.Body.Close()
+	assert.Equal(t, 304, res.StatusCode)
+
+	// Non matching ETag must return full response
+	res = makeRequest(`"foo"`)
+	defer res.Body.Close()
+	assert.Equal(t, 200, res.StatusCode)
+
+	// When usage changes after cache expiry, ETag must change
+	server.usageCache.DeleteAll()
+	server.queriers.usage = func(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) ([]models.Usage, error) {
+		return mockServerUsage[:1], nil
+	}
+
+	res = makeRequest(etag)
+	defer res.Body.Close()
+	assert.Equal(t, 200, res.StatusCode)
+	assert.NotEqual(t, etag, res.Header.Get("ETag"))
+}
+
+func TestUsageErrorHandlers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	f, err := os.Create(filepath.Join(tmpDir, base.CEEMSDBName))
+	if err != nil {
+		require.NoError(t, err)
+	}
+
+	defer f.Close()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	// Return errors for intermediate queries
+	server.queriers.key = keyQuerierErr
+
+	// Test cases
+	tests := []testCase{
+		{
+			name:    "current usage",
+			req:     "/api/" + base.APIVersion + "/usage/current",
+			user:    "foousr",
+			admin:   false,
+			handler: server.usage,
+			code:    200,
+		},
+		{
+			name:    "current usage admin",
+			req:     "/api/" + base.APIVersion + "/usage/current/admin",
+			user:    "adm1",
+			admin:   true,
+			handler: server.usageAdmin,
+			code:    200,
+		},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, test.req, nil)
+		request.Header.Set("X-Grafana-User", test.user)
+
+		if test.admin {
+			q := url.Values{}
+			q.Add("user", "foousr")
+			request.URL.RawQuery = q.Encode()
+		}
+
+		if strings.Contains(test.name, "current") {
+			request = mux.SetURLVars(request, map[string]string{"mode": "current"})
+		} else {
+			request = mux.SetURLVars(request, map[string]string{"mode": "global"})
+		}
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		test.handler(w, request)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		// Get body
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		// Unmarshal byte into structs.
+		var response Response[models.Usage]
+
+		json.Unmarshal(data, &response)
+		assert.Equal(t, test.code, w.Code)
+		assert.Equal(t, "success", response.Status)
+		assert.Equal(t, mockServerUsage, response.Data)
+		assert.NotEmpty(t, response.Warnings)
 	}
 }
 
@@ -588,6 +1349,533 @@ func TestStatsHandlers(t *testing.T) {
 	}
 }
 
+func TestStatsHandlersWithSystemUsers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	server.systemUsers = []string{"root", "slurm"}
+
+	// Capture stats query
+	var query string
+
+	var params []string
+
+	server.queriers.stat = func(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) ([]models.Stat, error) {
+		query, params = q.get()
+
+		return mockStats, nil
+	}
+
+	for _, mode := range []string{"current", "global"} {
+		query, params = "", nil
+
+		request := httptest.NewRequest(http.MethodGet, "/api/"+base.APIVersion+"/stats/"+mode+"/admin?cluster_id=slurm-0", nil)
+		request.Header.Set("X-Grafana-User", "adm1")
+		request = mux.SetURLVars(request, map[string]string{"mode": mode})
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		server.statsAdmin(w, request)
+
+		assert.Equal(t, 200, w.Code, mode)
+
+		// Ignored units and units of system users must be excluded before grouping
+		assert.Contains(t, query, " WHERE ignore = 0", mode)
+		assert.Contains(t, query, " AND username NOT IN (?,?) GROUP BY cluster_id", mode)
+		assert.Equal(t, []string{"slurm-0", "root", "slurm"}, params[len(params)-3:], mode)
+	}
+}
+
+// Test usage cache invalidation.
+func TestUsageCacheAdminHandler(t *testing.T) {
+	server := setupServer(t.TempDir())
+	defer server.Shutdown(context.Background())
+
+	keys := []string{
+		"/api/" + base.APIVersion + "/usage/current?cluster_id=slurm-0&logged_user=foo",
+		"/api/" + base.APIVersion + "/usage/current?cluster_id=slurm-1&logged_user=foo",
+		"/api/" + base.APIVersion + "/usage/global?cluster_id=slurm-0&logged_user=foo",
+	}
+
+	// Test cases
+	tests := []struct {
+		name      string
+		prefixes  []string
+		remaining []string
+	}{
+		{
+			name:      "scoped invalidation",
+			prefixes:  []string{"/api/" + base.APIVersion + "/usage/current?cluster_id=slurm-0"},
+			remaining: keys[1:],
+		},
+		{
+			name:      "multiple prefixes",
+			prefixes:  []string{"/api/" + base.APIVersion + "/usage/global", "/api/" + base.APIVersion + "/usage/current?cluster_id=slurm-1"},
+			remaining: keys[:1],
+		},
+		{
+			name: "full flush",
+		},
+	}
+
+	for _, test := range tests {
+		for _, key := range keys {
+			server.usageCache.Set(key, mockServerUsage, ttlcache.DefaultTTL)
+		}
+
+		q := url.Values{}
+		for _, prefix := range test.prefixes {
+			q.Add("prefix", prefix)
+		}
+
+		request := httptest.NewRequest(http.MethodDelete, "/api/"+base.APIVersion+"/usage/cache/admin", nil)
+		request.Header.Set("X-Grafana-User", "foo")
+		request.URL.RawQuery = q.Encode()
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		server.usageCacheAdmin(w, request)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		// Get body
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		// Unmarshal byte into structs.
+		var response Response[string]
+
+		json.Unmarshal(data, &response)
+		assert.Equal(t, 200, w.Code, test.name)
+		assert.Equal(t, "success", response.Status, test.name)
+		assert.Equal(t, []string{strconv.Itoa(len(keys) - len(test.remaining))}, response.Data, test.name)
+		assert.ElementsMatch(t, test.remaining, server.usageCache.Keys(), test.name)
+	}
+}
+
+func TestPurgeUserAdminHandler(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name       string
+		mode       string
+		clusterIDs []string
+		code       int
+		expected   []models.AffectedRows
+		users      []string
+		projects   []string
+		roles      []string
+	}{
+		{
+			name: "delete",
+			code: 200,
+			expected: []models.AffectedRows{
+				{Table: base.UnitsDBTableName, Rows: 2},
+				{Table: base.UsageDBTableName, Rows: 2},
+				{Table: base.DailyUsageDBTableName, Rows: 2},
+				{Table: base.UsersDBTableName, Rows: 2},
+				{Table: base.ProjectsDBTableName, Rows: 2},
+			},
+			users:    []string{"barusr", "barusr"},
+			projects: []string{`["barusr"]`, `["barusr"]`},
+			roles:    []string{`{}`, `{}`},
+		},
+		{
+			name:       "delete on cluster",
+			clusterIDs: []string{"slurm-0"},
+			code:       200,
+			expected: []models.AffectedRows{
+				{Table: base.UnitsDBTableName, Rows: 1},
+				{Table: base.UsageDBTableName, Rows: 1},
+				{Table: base.DailyUsageDBTableName, Rows: 1},
+				{Table: base.UsersDBTableName, Rows: 1},
+				{Table: base.ProjectsDBTableName, Rows: 1},
+			},
+			users:    []string{"barusr", "barusr", "foousr"},
+			projects: []string{`["barusr"]`, `["foousr","barusr"]`},
+			roles:    []string{`{}`, `{"foousr":["coordinator"]}`},
+		},
+		{
+			name: "anonymize",
+			mode: "anonymize",
+			code: 200,
+			expected: []models.AffectedRows{
+				{Table: base.UnitsDBTableName, Rows: 2},
+				{Table: base.UsageDBTableName, Rows: 2},
+				{Table: base.DailyUsageDBTableName, Rows: 2},
+				{Table: base.UsersDBTableName, Rows: 2},
+				{Table: base.ProjectsDBTableName, Rows: 2},
+			},
+			users: []string{anonymizeUser("foousr"), anonymizeUser("foousr"), "barusr", "barusr"},
+			projects: []string{
+				`["` + anonymizeUser("foousr") + `","barusr"]`,
+				`["` + anonymizeUser("foousr") + `","barusr"]`,
+			},
+			roles: []string{
+				`{"` + anonymizeUser("foousr") + `":["coordinator"]}`,
+				`{"` + anonymizeUser("foousr") + `":["coordinator"]}`,
+			},
+		},
+		{
+			name: "invalid mode",
+			mode: "drop",
+			code: 400,
+		},
+	}
+
+	for _, test := range tests {
+		tmpDir := t.TempDir()
+
+		// Prepare DB with data of two users on two clusters
+		sqlDB, err := sql.Open(sqlite3.DriverName, filepath.Join(tmpDir, base.CEEMSDBName))
+		require.NoError(t, err)
+
+		migrator, err := db_migrator.New(db.MigrationsFS, "migrations", slog.New(slog.NewTextHandler(io.Discard, nil)))
+		require.NoError(t, err)
+		require.NoError(t, migrator.ApplyMigrations(sqlDB))
+
+		for _, clusterID := range []string{"slurm-0", "slurm-1"} {
+			for _, user := range []string{"foousr", "barusr"} {
+				_, err = sqlDB.Exec("INSERT INTO units (cluster_id, uuid, username) VALUES (?, ?, ?)", clusterID, user, user)
+				require.NoError(t, err)
+				_, err = sqlDB.Exec("INSERT INTO usage (cluster_id, project, username) VALUES (?, 'prj', ?)", clusterID, user)
+				require.NoError(t, err)
+				_, err = sqlDB.Exec("INSERT INTO daily_usage (cluster_id, project, username) VALUES (?, 'prj', ?)", clusterID, user)
+				require.NoError(t, err)
+				_, err = sqlDB.Exec("INSERT INTO users (cluster_id, name) VALUES (?, ?)", clusterID, user)
+				require.NoError(t, err)
+			}
+
+			_, err = sqlDB.Exec(
+				`INSERT INTO projects (cluster_id, name, users, roles) VALUES (?, 'prj', '["foousr","barusr"]', '{"foousr":["coordinator"]}')`,
+				clusterID,
+			)
+			require.NoError(t, err)
+		}
+
+		server := setupServer(tmpDir)
+		defer server.Shutdown(context.Background())
+
+		q := url.Values{}
+		if test.mode != "" {
+			q.Set("mode", test.mode)
+		}
+
+		for _, clusterID := range test.clusterIDs {
+			q.Add("cluster_id", clusterID)
+		}
+
+		request := httptest.NewRequest(http.MethodDelete, "/api/"+base.APIVersion+"/users/foousr/admin", nil)
+		request.Header.Set("X-Grafana-User", "adm1")
+		request.URL.RawQuery = q.Encode()
+		request = mux.SetURLVars(request, map[string]string{"name": "foousr"})
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		server.purgeUserAdmin(w, request)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		// Get body
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		// Unmarshal byte into structs.
+		var response Response[models.AffectedRows]
+
+		json.Unmarshal(data, &response)
+		assert.Equal(t, test.code, w.Code, test.name)
+
+		if test.code != 200 {
+			continue
+		}
+
+		assert.Equal(t, test.expected, response.Data, test.name)
+
+		// Check remaining users in units table
+		var users []string
+
+		rows, err := sqlDB.Query("SELECT username FROM units ORDER BY id")
+		require.NoError(t, err)
+
+		for rows.Next() {
+			var user string
+			require.NoError(t, rows.Scan(&user))
+			users = append(users, user)
+		}
+
+		rows.Close()
+		assert.ElementsMatch(t, test.users, users, test.name)
+
+		// Check users and roles of projects
+		var projects, roles []string
+
+		rows, err = sqlDB.Query("SELECT users, roles FROM projects ORDER BY id")
+		require.NoError(t, err)
+
+		for rows.Next() {
+			var users, role string
+			require.NoError(t, rows.Scan(&users, &role))
+			projects = append(projects, users)
+			roles = append(roles, role)
+		}
+
+		rows.Close()
+		assert.Equal(t, test.projects, projects, test.name)
+		assert.Equal(t, test.roles, roles, test.name)
+
+		sqlDB.Close()
+	}
+}
+
+func TestIgnoreUnitsAdminHandler(t *testing.T) {
+	// Usage of a cluster in usage and daily usage tables
+	type clusterUsage struct {
+		numUnits int64
+		energy   models.MetricMap
+		avgCPU   models.MetricMap
+	}
+
+	// Test cases
+	tests := []struct {
+		name       string
+		uuids      []string
+		clusterIDs []string
+		ignore     string
+		code       int
+		expected   []models.AffectedRows
+		ignored    []string
+		usage      map[string]clusterUsage
+		dailyUsage map[string]clusterUsage
+	}{
+		{
+			name:  "ignore units",
+			uuids: []string{"1000", "1001"},
+			code:  200,
+			expected: []models.AffectedRows{
+				{Table: base.UnitsDBTableName, Rows: 4},
+				{Table: base.UsageDBTableName, Rows: 4},
+				{Table: base.DailyUsageDBTableName, Rows: 8},
+			},
+			ignored: []string{"slurm-0/1000", "slurm-0/1001", "slurm-0/1002", "slurm-1/1000", "slurm-1/1001"},
+			usage: map[string]clusterUsage{
+				"slurm-0": {0, models.MetricMap{"host": 0}, models.MetricMap{"usage": 0}},
+				"slurm-1": {1, models.MetricMap{"host": 10}, models.MetricMap{"usage": 80}},
+			},
+			dailyUsage: map[string]clusterUsage{
+				"slurm-0": {0, models.MetricMap{"host": 0}, models.MetricMap{"usage": 0}},
+				"slurm-1": {1, models.MetricMap{"host": 5}, models.MetricMap{"usage": 80}},
+			},
+		},
+		{
+			name:       "ignore units on cluster",
+			uuids:      []string{"1000"},
+			clusterIDs: []string{"slurm-1"},
+			ignore:     "true",
+			code:       200,
+			expected: []models.AffectedRows{
+				{Table: base.UnitsDBTableName, Rows: 1},
+				{Table: base.UsageDBTableName, Rows: 1},
+				{Table: base.DailyUsageDBTableName, Rows: 2},
+			},
+			ignored: []string{"slurm-0/1002", "slurm-1/1000"},
+			usage: map[string]clusterUsage{
+				"slurm-0": {2, models.MetricMap{"host": 20}, models.MetricMap{"usage": 35}},
+				"slurm-1": {2, models.MetricMap{"host": 20}, models.MetricMap{"usage": 65}},
+			},
+			dailyUsage: map[string]clusterUsage{
+				"slurm-0": {2, models.MetricMap{"host": 10}, models.MetricMap{"usage": 35}},
+				"slurm-1": {2, models.MetricMap{"host": 10}, models.MetricMap{"usage": 65}},
+			},
+		},
+		{
+			name:   "unset ignore flag",
+			uuids:  []string{"1002"},
+			ignore: "false",
+			code:   200,
+			expected: []models.AffectedRows{
+				{Table: base.UnitsDBTableName, Rows: 2},
+				{Table: base.UsageDBTableName, Rows: 1},
+				{Table: base.DailyUsageDBTableName, Rows: 2},
+			},
+			usage: map[string]clusterUsage{
+				"slurm-0": {3, models.MetricMap{"host": 30}, models.MetricMap{"usage": 50}},
+				"slurm-1": {3, models.MetricMap{"host": 30}, models.MetricMap{"usage": 50}},
+			},
+			dailyUsage: map[string]clusterUsage{
+				"slurm-0": {3, models.MetricMap{"host": 15}, models.MetricMap{"usage": 50}},
+				"slurm-1": {3, models.MetricMap{"host": 15}, models.MetricMap{"usage": 50}},
+			},
+		},
+		{
+			name: "missing uuids",
+			code: 400,
+		},
+		{
+			name:   "invalid ignore",
+			uuids:  []string{"1000"},
+			ignore: "maybe",
+			code:   400,
+		},
+	}
+
+	// All units run for a day starting at noon so that their usage is split
+	// equally between two days in daily usage table
+	startedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(24 * time.Hour)
+	days := []string{
+		startedAt.Truncate(24 * time.Hour).Format(base.DatetimeLayout),
+		endedAt.Truncate(24 * time.Hour).Format(base.DatetimeLayout),
+	}
+
+	avgCPUUsages := map[string]float64{"1000": 20, "1001": 50, "1002": 80}
+
+	for _, test := range tests {
+		tmpDir := t.TempDir()
+
+		// Prepare DB with units on two clusters
+		sqlDB, err := sql.Open(sqlite3.DriverName, filepath.Join(tmpDir, base.CEEMSDBName))
+		require.NoError(t, err)
+
+		migrator, err := db_migrator.New(db.MigrationsFS, "migrations", slog.New(slog.NewTextHandler(io.Discard, nil)))
+		require.NoError(t, err)
+		require.NoError(t, migrator.ApplyMigrations(sqlDB))
+
+		for _, clusterID := range []string{"slurm-0", "slurm-1"} {
+			var numUnits, weight, avgCPUUsage float64
+
+			for _, uuid := range []string{"1000", "1001", "1002"} {
+				// On slurm-0, unit 1001 is ignored by updater and unit 1002 is
+				// ignored by admin and hence, not aggregated into usage
+				ignore := 0
+
+				if clusterID == "slurm-0" {
+					switch uuid {
+					case "1001":
+						ignore = models.UnitIgnoredByUpdater
+					case "1002":
+						ignore = models.UnitIgnoredByAdmin
+					}
+				}
+
+				_, err = sqlDB.Exec(
+					`INSERT INTO units (cluster_id, uuid, username, project, started_at_ts, ended_at_ts, total_time_seconds, total_cpu_energy_usage_kwh, avg_cpu_usage, ignore)
+					VALUES (?, ?, 'foousr', 'fooprj', ?, ?, '{"alloc_cputime":100,"alloc_cpumemtime":0,"alloc_gputime":0,"alloc_gpumemtime":0}', '{"host":10}', json_object('usage', ?), ?)`,
+					clusterID, uuid, startedAt.UnixMilli(), endedAt.UnixMilli(), avgCPUUsages[uuid], ignore,
+				)
+				require.NoError(t, err)
+
+				if ignore != models.UnitIgnoredByAdmin {
+					numUnits++
+					weight += 100
+					avgCPUUsage += avgCPUUsages[uuid] * 100
+				}
+			}
+
+			_, err = sqlDB.Exec(
+				`INSERT INTO usage (cluster_id, username, project, num_units, total_time_seconds, total_cpu_energy_usage_kwh, avg_cpu_usage)
+				VALUES (?, 'foousr', 'fooprj', ?, json_object('alloc_cputime', ?, 'alloc_cpumemtime', 0, 'alloc_gputime', 0, 'alloc_gpumemtime', 0), json_object('host', ?), json_object('usage', ?))`,
+				clusterID, numUnits, weight, numUnits*10, avgCPUUsage/weight,
+			)
+			require.NoError(t, err)
+
+			// Units are counted on the day they started
+			for iday, day := range days {
+				_, err = sqlDB.Exec(
+					`INSERT INTO daily_usage (cluster_id, username, project, num_units, total_time_seconds, total_cpu_energy_usage_kwh, avg_cpu_usage, last_updated_at)
+					VALUES (?, 'foousr', 'fooprj', ?, json_object('alloc_cputime', ?, 'alloc_cpumemtime', 0, 'alloc_gputime', 0, 'alloc_gpumemtime', 0), json_object('host', ?), json_object('usage', ?), ?)`,
+					clusterID, numUnits*float64(1-iday), weight/2, numUnits*5, avgCPUUsage/weight, day,
+				)
+				require.NoError(t, err)
+			}
+		}
+
+		server := setupServer(tmpDir)
+		defer server.Shutdown(context.Background())
+
+		q := url.Values{}
+		for _, uuid := range test.uuids {
+			q.Add("uuid", uuid)
+		}
+
+		for _, clusterID := range test.clusterIDs {
+			q.Add("cluster_id", clusterID)
+		}
+
+		if test.ignore != "" {
+			q.Set("ignore", test.ignore)
+		}
+
+		request := httptest.NewRequest(http.MethodPut, "/api/"+base.APIVersion+"/units/ignore/admin", nil)
+		request.Header.Set("X-Grafana-User", "adm1")
+		request.URL.RawQuery = q.Encode()
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		server.ignoreUnitsAdmin(w, request)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		// Get body
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		// Unmarshal byte into structs.
+		var response Response[models.AffectedRows]
+
+		json.Unmarshal(data, &response)
+		assert.Equal(t, test.code, w.Code, test.name)
+
+		if test.code != 200 {
+			sqlDB.Close()
+
+			continue
+		}
+
+		assert.Equal(t, test.expected, response.Data, test.name)
+
+		// Check ignored units
+		var ignored []string
+
+		rows, err := sqlDB.Query("SELECT cluster_id, uuid FROM units WHERE ignore = ? ORDER BY id", models.UnitIgnoredByAdmin)
+		require.NoError(t, err)
+
+		for rows.Next() {
+			var clusterID, uuid string
+			require.NoError(t, rows.Scan(&clusterID, &uuid))
+			ignored = append(ignored, clusterID+"/"+uuid)
+		}
+
+		rows.Close()
+		assert.ElementsMatch(t, test.ignored, ignored, test.name)
+
+		// Check usage and daily usage of first day
+		for _, table := range []string{base.UsageDBTableName, base.DailyUsageDBTableName} {
+			expected := test.usage
+			if table == base.DailyUsageDBTableName {
+				expected = test.dailyUsage
+			}
+
+			for clusterID, usage := range expected {
+				var got clusterUsage
+
+				err := sqlDB.QueryRow(
+					fmt.Sprintf("SELECT num_units, total_cpu_energy_usage_kwh, avg_cpu_usage FROM %s WHERE cluster_id = ? ORDER BY last_updated_at LIMIT 1", table),
+					clusterID,
+				).Scan(&got.numUnits, &got.energy, &got.avgCPU)
+				require.NoError(t, err)
+				assert.Equal(t, usage, got, "%s: %s %s", test.name, table, clusterID)
+			}
+		}
+
+		sqlDB.Close()
+	}
+}
+
 // Test verify handler.
 func TestVerifyHandler(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -604,36 +1892,186 @@ func TestVerifyHandler(t *testing.T) {
 
 	tests := []testCase{
 		{
-			name:    "verify bad data",
-			req:     "/api/" + base.APIVersion + "/units/verify",
-			user:    "foousr",
-			admin:   false,
-			handler: server.verifyUnitsOwnership,
-			code:    400,
+			name:    "verify bad data",
+			req:     "/api/" + base.APIVersion + "/units/verify",
+			user:    "foousr",
+			admin:   false,
+			handler: server.verifyUnitsOwnership,
+			code:    400,
+		},
+		{
+			name:    "verify forbidden",
+			req:     "/api/" + base.APIVersion + "/units/verify?uuid=1234",
+			user:    "foousr",
+			admin:   false,
+			handler: server.verifyUnitsOwnership,
+			code:    403,
+		},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, test.req, nil)
+		request.Header.Set("X-Grafana-User", test.user)
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		test.handler(w, request)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, test.code, w.Code)
+	}
+}
+
+func TestUnitTimeseriesHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	f, err := os.Create(filepath.Join(tmpDir, base.CEEMSDBName))
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	// Mock TSDB server
+	var tsdbQuery url.Values
+
+	tsdbServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		tsdbQuery = r.PostForm
+
+		w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[` +
+			`{"metric":{"__name__":"ceems_memory_used_bytes","uuid":"1000"},"values":[[1735045414,"2000"]]},` +
+			`{"metric":{"__name__":"ceems_cpu_usage_seconds_total","uuid":"1000","mode":"user"},"values":[[1735045414,"10"]]},` +
+			`{"metric":{"__name__":"ceems_cpu_usage_seconds_total","uuid":"1000","mode":"system"},"values":[[1735045414,"2.5"]]}]}}`))
+	}))
+	defer tsdbServer.Close()
+
+	client, err := tsdb.New(tsdbServer.URL, config_util.HTTPClientConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	server.tsdbs = map[string]*tsdb.TSDB{"slurm-0": client}
+
+	tests := []struct {
+		name  string
+		req   string
+		noDB  bool
+		units []models.Unit
+		code  int
+	}{
+		{
+			name: "missing cluster ID",
+			req:  "/api/" + base.APIVersion + "/units/1000/timeseries?metric=ceems_memory_used_bytes",
+			code: 400,
+		},
+		{
+			name: "missing metrics",
+			req:  "/api/" + base.APIVersion + "/units/1000/timeseries?cluster_id=slurm-0",
+			code: 400,
 		},
 		{
-			name:    "verify forbidden",
-			req:     "/api/" + base.APIVersion + "/units/verify?uuid=1234",
-			user:    "foousr",
-			admin:   false,
-			handler: server.verifyUnitsOwnership,
-			code:    403,
+			name: "invalid metric",
+			req:  "/api/" + base.APIVersion + "/units/1000/timeseries?cluster_id=slurm-0&metric=foo%22%7D",
+			code: 400,
+		},
+		{
+			name: "invalid step",
+			req:  "/api/" + base.APIVersion + "/units/1000/timeseries?cluster_id=slurm-0&metric=foo&step=bar",
+			code: 400,
+		},
+		{
+			name: "forbidden",
+			req:  "/api/" + base.APIVersion + "/units/1000/timeseries?cluster_id=slurm-0&metric=foo",
+			code: 403,
+		},
+		{
+			name: "no TSDB",
+			req:  "/api/" + base.APIVersion + "/units/10001/timeseries?cluster_id=os-0&metric=foo",
+			noDB: true,
+			code: 503,
+		},
+		{
+			name: "unit not found",
+			req:  "/api/" + base.APIVersion + "/units/1000/timeseries?cluster_id=slurm-0&metric=foo",
+			noDB: true,
+			code: 404,
+		},
+		{
+			name:  "success",
+			req:   "/api/" + base.APIVersion + "/units/1000/timeseries?cluster_id=slurm-0&metric=ceems_memory_used_bytes&metric=ceems_cpu_usage_seconds_total",
+			noDB:  true,
+			units: []models.Unit{{StartedAtTS: 1735045414000, EndedAtTS: 1735049014000}},
+			code:  200,
 		},
 	}
 
+	db := server.db
+
 	for _, test := range tests {
+		// Ownership check is skipped when there is no DB
+		server.db = db
+		if test.noDB {
+			server.db = nil
+		}
+
+		server.queriers.unit = func(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) ([]models.Unit, error) {
+			return test.units, nil
+		}
+
 		request := httptest.NewRequest(http.MethodGet, test.req, nil)
-		request.Header.Set("X-Grafana-User", test.user)
+		request = mux.SetURLVars(request, map[string]string{"uuid": strings.Split(test.req, "/")[4]})
+		request.Header.Set("X-Grafana-User", "foousr")
 
 		// Start recorder
 		w := httptest.NewRecorder()
-		test.handler(w, request)
+		server.unitTimeseries(w, request)
 
 		res := w.Result()
 		defer res.Body.Close()
 
-		assert.Equal(t, test.code, w.Code)
+		assert.Equal(t, test.code, w.Code, test.name)
+
+		if test.code != 200 {
+			continue
+		}
+
+		var response Response[models.Timeseries]
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+
+		// Series of same metric must be returned separately with their labels
+		assert.Equal(t, "success", response.Status)
+		assert.Equal(
+			t,
+			[]models.Timeseries{
+				{
+					Metric: "ceems_cpu_usage_seconds_total",
+					Labels: map[string]string{"uuid": "1000", "mode": "system"},
+					Values: []interface{}{[]interface{}{1735045414.0, "2.5"}},
+				},
+				{
+					Metric: "ceems_cpu_usage_seconds_total",
+					Labels: map[string]string{"uuid": "1000", "mode": "user"},
+					Values: []interface{}{[]interface{}{1735045414.0, "10"}},
+				},
+				{
+					Metric: "ceems_memory_used_bytes",
+					Labels: map[string]string{"uuid": "1000"},
+					Values: []interface{}{[]interface{}{1735045414.0, "2000"}},
+				},
+			},
+			response.Data,
+		)
+
+		// Check query made to TSDB
+		assert.Equal(t, `{__name__=~"ceems_memory_used_bytes|ceems_cpu_usage_seconds_total",uuid="1000"}`, tsdbQuery.Get("query"))
+		assert.Equal(t, "2024-12-24T13:03:34Z", tsdbQuery.Get("start"))
+		assert.Equal(t, "2024-12-24T14:03:34Z", tsdbQuery.Get("end"))
+		assert.Equal(t, "1m", tsdbQuery.Get("step"))
 	}
+
+	server.db = db
 }
 
 // Test demo handlers.
@@ -871,6 +2309,425 @@ func TestUnitsHandlerWithUnituuidsQueryParams(t *testing.T) {
 	assert.Equal(t, expectedUnits, response.Data)
 }
 
+func TestUnitsSearchHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	f, err := os.Create(filepath.Join(tmpDir, base.CEEMSDBName))
+	if err != nil {
+		require.NoError(t, err)
+	}
+
+	defer f.Close()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	// Capture params of units query
+	var params []string
+
+	server.queriers.unit = func(ctx context.Context, db *sql.DB, q Query, logger *slog.Logger) ([]models.Unit, error) {
+		params = q.params
+
+		return mockServerUnits, nil
+	}
+
+	tooManyUUIDs := make([]string, maxSearchUUIDs+1)
+	for i := range tooManyUUIDs {
+		tooManyUUIDs[i] = strconv.Itoa(i)
+	}
+
+	tooManyBody, err := json.Marshal(map[string][]string{"uuid": tooManyUUIDs})
+	require.NoError(t, err)
+
+	// Test cases
+	tests := []struct {
+		name    string
+		req     string
+		body    string
+		admin   bool
+		code    int
+		params  []string
+		errCode string
+	}{
+		{
+			name:   "units search",
+			req:    "/api/" + base.APIVersion + "/units/search?from=1672527600&to=1685570400",
+			body:   `{"uuid":["1000","1001"],"project":["prj1"],"user":["bar"]}`,
+			code:   200,
+			params: []string{"foousr", "1000", "1001", "prj1"},
+		},
+		{
+			name:   "units search admin",
+			req:    "/api/" + base.APIVersion + "/units/search/admin?from=1672527600&to=1685570400",
+			body:   `{"uuid":["1000","1001"],"user":["bar"]}`,
+			admin:  true,
+			code:   200,
+			params: []string{"bar", "1000", "1001"},
+		},
+		{
+			name:    "units search with invalid body",
+			req:     "/api/" + base.APIVersion + "/units/search",
+			body:    `{"uuid":"1000"}`,
+			code:    400,
+			errCode: "CEEMS-40022",
+		},
+		{
+			name:    "units search with too many uuids",
+			req:     "/api/" + base.APIVersion + "/units/search",
+			body:    string(tooManyBody),
+			code:    400,
+			errCode: "CEEMS-40023",
+		},
+	}
+
+	for _, test := range tests {
+		params = nil
+
+		request := httptest.NewRequest(http.MethodPost, test.req, strings.NewReader(test.body))
+		request.Header.Set("X-Grafana-User", "foousr")
+		request.Header.Set(dashboardUserHeader, "foousr")
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		if test.admin {
+			server.unitsSearchAdmin(w, request)
+		} else {
+			server.unitsSearch(w, request)
+		}
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, test.code, w.Code, test.name)
+
+		var response Response[models.Unit]
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&response), test.name)
+
+		if test.code != 200 {
+			assert.Equal(t, test.errCode, response.Code, test.name)
+
+			continue
+		}
+
+		assert.Equal(t, mockServerUnits, response.Data, test.name)
+
+		// Users and uuids in body must be in the query
+		for _, param := range test.params {
+			assert.Contains(t, params, param, test.name)
+		}
+
+		// User endpoint must ignore users in body
+		if !test.admin {
+			assert.NotContains(t, params, "bar", test.name)
+		}
+	}
+}
+
+func TestRoundQueryWindow(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	// Default TTL
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/usage/current?from=1000&to=4000", nil)
+	require.NoError(t, server.roundQueryWindow(request))
+	assert.Equal(t, "900", request.URL.Query().Get("from"))
+	assert.Equal(t, "3600", request.URL.Query().Get("to"))
+
+	// Configured TTL
+	server.cacheTTL = 5 * time.Minute
+
+	request = httptest.NewRequest(http.MethodGet, "/api/v1/usage/current?from=1000&to=4000", nil)
+	require.NoError(t, server.roundQueryWindow(request))
+	assert.Equal(t, "900", request.URL.Query().Get("from"))
+	assert.Equal(t, "3900", request.URL.Query().Get("to"))
+
+	// Malformed timestamp
+	request = httptest.NewRequest(http.MethodGet, "/api/v1/usage/current?from=foo", nil)
+	require.Error(t, server.roundQueryWindow(request))
+}
+
+// Test /units and /usage with status query parameter.
+func TestStatusQueryParams(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	f, err := os.Create(filepath.Join(tmpDir, base.CEEMSDBName))
+	if err != nil {
+		require.NoError(t, err)
+	}
+
+	defer f.Close()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	tests := []struct {
+		name    string
+		req     string
+		mode    string
+		handler func(http.ResponseWriter, *http.Request)
+		status  string
+		errType errorType
+	}{
+		{
+			name:    "active units with exceeded query window",
+			req:     "/api/v1/units?status=active&from=1672527600&to=1685570400",
+			handler: server.units,
+			status:  "success",
+		},
+		{
+			name:    "terminated units",
+			req:     "/api/v1/units?status=terminated",
+			handler: server.units,
+			status:  "success",
+		},
+		{
+			name:    "invalid units status",
+			req:     "/api/v1/units?status=foo",
+			handler: server.units,
+			status:  "error",
+			errType: errorBadData,
+		},
+		{
+			name:    "failed units",
+			req:     "/api/v1/units?state=FAILED&state=timeout&running",
+			handler: server.units,
+			status:  "success",
+		},
+		{
+			name:    "invalid units state",
+			req:     "/api/v1/units?state=foo",
+			handler: server.units,
+			status:  "error",
+			errType: errorBadData,
+		},
+		{
+			name:    "terminated usage",
+			req:     "/api/v1/usage/current?status=terminated",
+			mode:    "current",
+			handler: server.usage,
+			status:  "success",
+		},
+		{
+			name:    "invalid usage status",
+			req:     "/api/v1/usage/current?status=foo",
+			mode:    "current",
+			handler: server.usage,
+			status:  "error",
+			errType: errorBadData,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, test.req, nil)
+		if test.mode != "" {
+			req = mux.SetURLVars(req, map[string]string{"mode": test.mode})
+		}
+
+		req.Header.Set("X-Grafana-User", "foousr")
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		test.handler(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		// Get body
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err, test.name)
+
+		// Unmarshal byte into structs.
+		var response Response[any]
+
+		json.Unmarshal(data, &response)
+
+		assert.Equal(t, test.status, response.Status, test.name)
+		assert.Equal(t, test.errType, response.ErrorType, test.name)
+	}
+
+	// Check status clauses
+	for status, expected := range map[string]string{
+		"":           "",
+		"all":        "",
+		"active":     " AND ended_at_ts = 0 ",
+		"terminated": " AND ended_at_ts > 0 ",
+	} {
+		q, err := server.getStatusQueryParam(&Query{}, url.Values{"status": []string{status}})
+		require.NoError(t, err)
+
+		query, _ := q.get()
+		assert.Equal(t, expected, query, status)
+	}
+}
+
+func TestMetricThresholdQueryParams(t *testing.T) {
+	server := &CEEMSServer{}
+
+	q, err := server.getMetricThresholdQueryParams(&Query{}, url.Values{
+		"min_total_cpu_energy_usage_kwh":  []string{"10"},
+		"max_total_time_seconds.walltime": []string{"3600.5"},
+		"project":                         []string{"foo"},
+	})
+	require.NoError(t, err)
+
+	query, params := q.get()
+	assert.Equal(
+		t,
+		" AND CAST(json_extract(total_time_seconds, (?)) AS REAL) <= (?)"+
+			" AND EXISTS (SELECT 1 FROM json_each(total_cpu_energy_usage_kwh) WHERE CAST(value AS REAL) >= (?))",
+		query,
+	)
+	assert.Equal(t, []string{"$.walltime", "3600.5", "10"}, params)
+
+	// Invalid parameters
+	for _, values := range []url.Values{
+		{"min_project": []string{"10"}},
+		{"min_foo": []string{"10"}},
+		{"max_avg_cpu_usage.global')": []string{"10"}},
+		{"max_avg_cpu_usage": []string{"foo"}},
+		{"max_avg_cpu_usage": []string{"NaN"}},
+	} {
+		_, err := server.getMetricThresholdQueryParams(&Query{}, values)
+		require.ErrorIs(t, err, errInvalidThreshold, values)
+	}
+}
+
+func TestStateQueryParams(t *testing.T) {
+	server := &CEEMSServer{}
+
+	// No state
+	q, err := server.getStateQueryParam(&Query{}, url.Values{})
+	require.NoError(t, err)
+
+	query, params := q.get()
+	assert.Empty(t, query)
+	assert.Empty(t, params)
+
+	// States are case insensitive and prefixes are matched
+	q, err = server.getStateQueryParam(&Query{}, url.Values{"state": []string{"cancelled", "FAILED"}})
+	require.NoError(t, err)
+
+	query, params = q.get()
+	assert.Equal(t, " AND (state IN (?,?) OR state LIKE (?) OR state LIKE (?))", query)
+	assert.Equal(t, []string{"CANCELLED", "FAILED", "CANCELLED %", "FAILED %"}, params)
+
+	// Unknown state
+	_, err = server.getStateQueryParam(&Query{}, url.Values{"state": []string{"RUNNING", "foo"}})
+	require.ErrorIs(t, err, errInvalidState)
+}
+
+func TestTagQueryParams(t *testing.T) {
+	server := &CEEMSServer{}
+
+	// No tag
+	q, err := server.getTagQueryParams(&Query{}, url.Values{})
+	require.NoError(t, err)
+
+	query, params := q.get()
+	assert.Empty(t, query)
+	assert.Empty(t, params)
+
+	// Values can contain separator
+	q, err = server.getTagQueryParams(&Query{}, url.Values{"tag": []string{"costcenter:1234", "grant:abc:1"}})
+	require.NoError(t, err)
+
+	query, params = q.get()
+	assert.Equal(
+		t,
+		" AND CAST(json_extract(tags, (?)) AS TEXT) = (?) AND CAST(json_extract(tags, (?)) AS TEXT) = (?)",
+		query,
+	)
+	assert.Equal(t, []string{"$.costcenter", "1234", "$.grant", "abc:1"}, params)
+
+	// Invalid tags
+	for _, tag := range []string{"costcenter", "cost.center:1234", ":1234"} {
+		_, err = server.getTagQueryParams(&Query{}, url.Values{"tag": []string{tag}})
+		require.ErrorIs(t, err, errInvalidTag, tag)
+	}
+}
+
+func TestUsageTagQueryParams(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	tests := []struct {
+		name    string
+		mode    string
+		req     string
+		code    int
+		errCode string
+	}{
+		{
+			name: "current usage with tag",
+			mode: "current",
+			req:  "/api/" + base.APIVersion + "/usage/current?tag=qos:long",
+			code: 200,
+		},
+		{
+			name:    "experimental current usage with tag",
+			mode:    "current",
+			req:     "/api/" + base.APIVersion + "/usage/current?experimental&tag=qos:long",
+			code:    400,
+			errCode: "CEEMS-40024",
+		},
+		{
+			name:    "global usage with tag",
+			mode:    "global",
+			req:     "/api/" + base.APIVersion + "/usage/global?tag=qos:long",
+			code:    400,
+			errCode: "CEEMS-40024",
+		},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, test.req, nil)
+		request.Header.Set("X-Grafana-User", "foousr")
+		request = mux.SetURLVars(request, map[string]string{"mode": test.mode})
+
+		// Start recorder
+		w := httptest.NewRecorder()
+		server.usage(w, request)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, test.code, w.Code, test.name)
+
+		var response Response[models.Usage]
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&response), test.name)
+		assert.Equal(t, test.errCode, response.Code, test.name)
+	}
+}
+
+func TestUnitsOrderQueryParam(t *testing.T) {
+	server := &CEEMSServer{}
+
+	// No order
+	order, err := server.getUnitsOrderQueryParam(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultUnitsOrder, order)
+
+	// Time columns are ordered by timestamps and default order is appended
+	order, err = server.getUnitsOrderQueryParam(url.Values{"order": []string{"started_at:desc", "project"}})
+	require.NoError(t, err)
+	assert.Equal(t, " ORDER BY started_at_ts DESC, project ASC, cluster_id ASC, uuid ASC ", order)
+
+	// Default order columns are not repeated
+	order, err = server.getUnitsOrderQueryParam(url.Values{"order": []string{"uuid:DESC"}})
+	require.NoError(t, err)
+	assert.Equal(t, " ORDER BY uuid DESC, cluster_id ASC ", order)
+
+	// Invalid orders
+	for _, o := range []string{"foo", "started_at:up", "total_time_seconds:desc", ""} {
+		_, err = server.getUnitsOrderQueryParam(url.Values{"order": []string{o}})
+		require.ErrorIs(t, err, errInvalidOrder, o)
+	}
+}
+
 // // Test /usage
 // func TestUsageHandler(t *testing.T) {
 // 	server := setupServer()
@@ -912,3 +2769,39 @@ func TestUnitsHandlerWithUnituuidsQueryParams(t *testing.T) {
 // 		t.Errorf("expected usage %#v usage, got %#v", expectedUsage, response.Data)
 // 	}
 // }
+
+func TestBuildInfoHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	server := setupServer(tmpDir)
+	defer server.Shutdown(context.Background())
+
+	version.Version = "0.1.0"
+	version.Revision = "abcd"
+
+	// Create request without any user header as endpoint is unauthenticated
+	req := httptest.NewRequest(http.MethodGet, "/api/"+base.APIVersion+"/status/buildinfo", nil)
+
+	// Start recorder
+	w := httptest.NewRecorder()
+	server.buildInfo(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	// Get body
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	// Unmarshal byte into structs
+	var response Response[models.BuildInfo]
+
+	require.NoError(t, json.Unmarshal(data, &response))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "success", response.Status)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "0.1.0", response.Data[0].Version)
+	assert.Equal(t, "abcd", response.Data[0].Revision)
+	assert.NotEmpty(t, response.Data[0].GoVersion)
+}
@@ -2,6 +2,8 @@
 package models
 
 import (
+	"strings"
+
 	"github.com/mahendrapaipuri/ceems/internal/structset"
 )
 
@@ -14,6 +16,28 @@ const (
 	adminUsersTableName = "admin_users"
 )
 
+// globalMetricKey is the key of metric maps that contains the value of metric
+// aggregated over all resources of compute unit.
+const globalMetricKey = "global"
+
+// UsageComputedColNames are the JSON field names of usage that are not stored in
+// DB and computed at query time.
+var UsageComputedColNames = []string{"efficiency", "day"}
+
+// Values of ignore flag of units. Units ignored by updaters, for instance, when
+// they ran for less than cutoff duration, are still aggregated into usage tables
+// whereas units ignored by admin users are excluded from usage tables.
+const (
+	UnitIgnoredByUpdater = 1
+	UnitIgnoredByAdmin   = 2
+)
+
+// Roles of users in projects.
+const (
+	RoleMember      = "member"      // Regular member of the project
+	RoleCoordinator = "coordinator" // User who can administer the project
+)
+
 // Unit is an abstract compute unit that can mean Job (batchjobs), VM (cloud) or Pod (k8s).
 type Unit struct {
 	ID                  int64      `json:"-"                                    sql:"id"                         sqlitetype:"integer not null primary key"`
@@ -50,6 +74,7 @@ type Unit struct {
 	Ignore              int        `json:"-"                                    sql:"ignore"                     sqlitetype:"integer"` // Whether to ignore unit
 	NumUpdates          int64      `json:"-"                                    sql:"num_updates"                sqlitetype:"integer"` // Number of updates. This is used internally to update aggregate metrics
 	LastUpdatedAt       string     `json:"-"                                    sql:"last_updated_at"            sqlitetype:"text"`    // Last updated time. It can be used to clean up DB
+	UpdaterTags         Tag        `json:"-"                                    sql:"updater_tags"               sqlitetype:"text"`    // Tags set by updaters for internal use, for instance, to flag units for backfill. They are preserved across updates
 }
 
 // TableName returns the table which units are stored into.
@@ -91,6 +116,8 @@ type Usage struct {
 	TotalIOReadStats    MetricMap `json:"total_io_read_stats,omitempty"        sql:"total_io_read_stats"        sqlitetype:"text"`    // Total IO read statistics GB during lifetime of unit
 	TotalIngressStats   MetricMap `json:"total_ingress_stats,omitempty"        sql:"total_ingress_stats"        sqlitetype:"text"`    // Total Ingress statistics of unit
 	TotalOutgressStats  MetricMap `json:"total_outgress_stats,omitempty"       sql:"total_outgress_stats"       sqlitetype:"text"`    // Total Outgress statistics of unit
+	Efficiency          MetricMap `json:"efficiency,omitempty"                 sql:"-"`                                               // CPU and GPU efficiencies computed from average usages at query time. It is not stored in DB
+	Day                 string    `json:"day,omitempty"                        sql:"day"`                                             // Day of usage when usage is grouped by day. It is computed at query time and not stored in DB
 	NumUpdates          int64     `json:"-"                                    sql:"num_updates"                sqlitetype:"text"`    // Number of updates. This is used internally to update aggregate metrics
 }
 
@@ -110,6 +137,27 @@ func (u Usage) TagMap(keyTag string, valueTag string) map[string]string {
 	return structset.StructFieldTagMap(u, keyTag, valueTag)
 }
 
+// ComputeEfficiency sets CPU and GPU efficiencies of usage. Efficiency is the
+// ratio of average usage to the allocated resources and it is computed only when
+// both average usage and allocated time of the resource are available.
+func (u *Usage) ComputeEfficiency() {
+	efficiency := make(MetricMap)
+
+	for resource, avgUsage := range map[string]MetricMap{"cpu": u.AveCPUUsage, "gpu": u.AveGPUUsage} {
+		usage, ok := avgUsage[globalMetricKey]
+		if !ok || u.TotalTime["alloc_"+resource+"time"] <= 0 {
+			continue
+		}
+
+		// Average usages are percentages of allocated resources
+		efficiency[resource] = usage / 100
+	}
+
+	if len(efficiency) > 0 {
+		u.Efficiency = efficiency
+	}
+}
+
 // DailyUsage statistics of each project/tenant/namespace.
 type DailyUsage struct {
 	Usage
@@ -150,6 +198,7 @@ type Project struct {
 	ResourceManager string `json:"resource_manager" sql:"resource_manager" sqlitetype:"text"` // Name of the resource manager that owns project. Eg slurm, openstack, kubernetes, etc
 	Name            string `json:"name"             sql:"name"             sqlitetype:"text"` // Name of the project
 	Users           List   `json:"users"            sql:"users"            sqlitetype:"text"` // List of users of the project
+	Roles           Roles  `json:"roles,omitempty"  sql:"roles"            sqlitetype:"text"` // Map of users of the project to their roles in the project
 	Tags            List   `json:"tags,omitempty"   sql:"tags"             sqlitetype:"text"` // List of meta data tags of the project
 	LastUpdatedAt   string `json:"-"                sql:"last_updated_at"  sqlitetype:"text"` // Last Updated time
 }
@@ -178,6 +227,7 @@ type User struct {
 	ResourceManager string `json:"resource_manager" sql:"resource_manager" sqlitetype:"text"` // Name of the resource manager that owns user. Eg slurm, openstack, kubernetes, etc
 	Name            string `json:"name"             sql:"name"             sqlitetype:"text"` // Name of the user
 	Projects        List   `json:"projects"         sql:"projects"         sqlitetype:"text"` // List of projects of the user
+	Roles           Roles  `json:"roles,omitempty"  sql:"roles"            sqlitetype:"text"` // Map of projects of the user to user's role in the project
 	Tags            List   `json:"tags,omitempty"   sql:"tags"             sqlitetype:"text"` // List of meta data tags of the user
 	LastUpdatedAt   string `json:"-"                sql:"last_updated_at"  sqlitetype:"text"` // Last Updated time
 }
@@ -222,6 +272,32 @@ func (a AdminUsers) TagMap(keyTag string, valueTag string) map[string]string {
 	return structset.StructFieldTagMap(a, keyTag, valueTag)
 }
 
+// fieldUnitSuffixes maps the suffix of JSON field names of numeric
+// fields to their units.
+var fieldUnitSuffixes = map[string]string{
+	"_seconds": "seconds",
+	"_kwh":     "kWh",
+	"_gms":     "gCO2",
+	"_watts":   "watts",
+}
+
+// FieldUnits returns a map of JSON field names to their units for a given
+// model. Units are derived from the suffix of field names and fields without
+// a known unit are omitted.
+func FieldUnits(s interface{}) map[string]string {
+	units := make(map[string]string)
+
+	for _, field := range structset.StructFieldTagValues(s, "json") {
+		for suffix, unit := range fieldUnitSuffixes {
+			if strings.HasSuffix(field, suffix) {
+				units[field] = unit
+			}
+		}
+	}
+
+	return units
+}
+
 // Key represents arbritrary keys used in metric maps.
 type Key struct {
 	Name string `json:"name" sql:"name" sqlitetype:"text"` // Name of the metric key
@@ -238,6 +314,30 @@ func (k Key) TagMap(keyTag string, valueTag string) map[string]string {
 	return structset.StructFieldTagMap(k, keyTag, valueTag)
 }
 
+// AffectedRows contains the number of rows of a DB table affected by an
+// admin operation like purging data of a user.
+type AffectedRows struct {
+	Table string `json:"table"` // Name of the DB table
+	Rows  int64  `json:"rows"`  // Number of affected rows
+}
+
+// BuildInfo contains the build information of CEEMS API server.
+type BuildInfo struct {
+	Version   string `json:"version"`   // Version of the build
+	Revision  string `json:"revision"`  // Git commit of the build
+	Branch    string `json:"branch"`    // Git branch of the build
+	BuildUser string `json:"buildUser"` // User who made the build
+	BuildDate string `json:"buildDate"` // Date of the build
+	GoVersion string `json:"goVersion"` // Go version used for the build
+}
+
+// Timeseries contains the values of a series of a compute unit fetched from TSDB.
+type Timeseries struct {
+	Metric string            `json:"metric"` // Name of the metric
+	Labels map[string]string `json:"labels"` // Labels of the series except metric name
+	Values []interface{}     `json:"values"` // Pairs of timestamp and value of the series
+}
+
 // // Ownership mode for a given compute unit
 // type Ownership struct {
 // 	UUID string `json:"uuid"` // UUID of the compute unit
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageComputeEfficiency(t *testing.T) {
+	tests := []struct {
+		name     string
+		usage    Usage
+		expected MetricMap
+	}{
+		{
+			name: "cpu and gpu efficiencies",
+			usage: Usage{
+				TotalTime:   MetricMap{"alloc_cputime": 100, "alloc_gputime": 10},
+				AveCPUUsage: MetricMap{"global": 50},
+				AveGPUUsage: MetricMap{"global": 25},
+			},
+			expected: MetricMap{"cpu": 0.5, "gpu": 0.25},
+		},
+		{
+			name: "only cpu efficiency when no gpus are allocated",
+			usage: Usage{
+				TotalTime:   MetricMap{"alloc_cputime": 100, "alloc_gputime": 0},
+				AveCPUUsage: MetricMap{"global": 50},
+				AveGPUUsage: MetricMap{"global": 0},
+			},
+			expected: MetricMap{"cpu": 0.5},
+		},
+		{
+			name: "no efficiency without allocated times",
+			usage: Usage{
+				AveCPUUsage: MetricMap{"global": 50},
+			},
+		},
+		{
+			name: "no efficiency without average usages",
+			usage: Usage{
+				TotalTime: MetricMap{"alloc_cputime": 100},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test.usage.ComputeEfficiency()
+		assert.Equal(t, test.expected, test.usage.Efficiency, test.name)
+	}
+}
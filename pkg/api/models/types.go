@@ -92,6 +92,9 @@ type Tag = Generic
 // Allocation is a type alias to Generic that stores allocation data of compute units.
 type Allocation = Generic
 
+// Roles is a type alias to Generic that stores roles of users in projects.
+type Roles = Generic
+
 // MetricMap is a type alias to Generic that stores arbritrary metrics as a map.
 type MetricMap map[string]JSONFloat
 
@@ -216,17 +216,6 @@ func (o *openstackManager) activeInstances(ctx context.Context, start time.Time,
 			"extra_specs": server.Flavor.ExtraSpecs,
 		}
 
-		// Tags
-		tags := models.Tag{
-			"metadata":       server.Metadata,
-			"tags":           server.Tags,
-			"server_groups":  strings.Join(server.ServerGroups, ","),
-			"hypervisor":     server.HypervisorHostname,
-			"reservation_id": server.ReservationID,
-			"power_state":    server.PowerState.String(),
-			"az":             server.AvailabilityZone,
-		}
-
 		units[iServer] = models.Unit{
 			ResourceManager: openstackVMManager,
 			UUID:            server.ID,
@@ -243,7 +232,7 @@ func (o *openstackManager) activeInstances(ctx context.Context, start time.Time,
 			State:           server.Status,
 			TotalTime:       totalTime,
 			Allocation:      allocation,
-			Tags:            tags,
+			Tags:            serverTags(server),
 		}
 
 		iServer++
@@ -254,6 +243,32 @@ func (o *openstackManager) activeInstances(ctx context.Context, start time.Time,
 	return units, nil
 }
 
+// serverTags returns the tags of the unit of server.
+func serverTags(server Server) models.Tag {
+	tags := models.Tag{
+		"metadata":       server.Metadata,
+		"tags":           server.Tags,
+		"server_groups":  strings.Join(server.ServerGroups, ","),
+		"hypervisor":     server.HypervisorHostname,
+		"reservation_id": server.ReservationID,
+		"power_state":    server.PowerState.String(),
+		"az":             server.AvailabilityZone,
+	}
+
+	// Depending on the compute API microversion, servers have either
+	// flavor name (>= 2.47) or flavor ID (< 2.47). Add only the ones
+	// that are available so that units can be grouped by flavor
+	if server.Flavor.Name != "" {
+		tags["flavor"] = server.Flavor.Name
+	}
+
+	if server.Flavor.ID != "" {
+		tags["flavor_id"] = server.Flavor.ID
+	}
+
+	return tags
+}
+
 // fetchInstances fetches a list of active/deleted compute instances from Openstack cluster.
 func (o *openstackManager) fetchInstances(ctx context.Context, start time.Time, end time.Time, deleted bool) ([]Server, error) {
 	// Create a new GET request
@@ -77,6 +77,13 @@ func (o *openstackManager) fetchUsers(ctx context.Context) ([]User, error) {
 		return nil, fmt.Errorf("failed to rotate api token for openstack cluster: %w", err)
 	}
 
+	// List only users of configured domain
+	if o.domainID != "" {
+		q := req.URL.Query()
+		q.Add("domain_id", o.domainID)
+		req.URL.RawQuery = q.Encode()
+	}
+
 	// Get response
 	resp, err := apiRequest[UsersResponse](req, o.client)
 	if err != nil {
@@ -86,6 +93,45 @@ func (o *openstackManager) fetchUsers(ctx context.Context) ([]User, error) {
 	return resp.Users, nil
 }
 
+// fetchDomainID fetches the ID of domain with given name from Openstack cluster.
+func (o *openstackManager) fetchDomainID(ctx context.Context, name string) (string, error) {
+	// Create a new GET request
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		o.domains().String(),
+		nil,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request to fetch domains for openstack cluster: %w", err)
+	}
+
+	// Add token to request headers
+	req, err = o.addTokenHeader(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate api token for openstack cluster: %w", err)
+	}
+
+	// Add query parameters
+	q := req.URL.Query()
+	q.Add("name", name)
+	req.URL.RawQuery = q.Encode()
+
+	// Get response
+	resp, err := apiRequest[DomainsResponse](req, o.client)
+	if err != nil {
+		return "", fmt.Errorf("failed to complete request to fetch domains for openstack cluster: %w", err)
+	}
+
+	for _, domain := range resp.Domains {
+		if domain.Name == name {
+			return domain.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("domain %s not found in openstack cluster", name)
+}
+
 // fetchUserProjects fetches a list of projects of a specific user from Openstack cluster.
 func (o *openstackManager) fetchUserProjects(ctx context.Context, userID string) ([]Project, error) {
 	// Create a new GET request
@@ -187,6 +233,12 @@ func (o *openstackManager) usersProjectsAssoc(ctx context.Context, current time.
 
 	for userID, projects := range userProjects {
 		for _, project := range projects {
+			// Users can have role assignments on projects of other domains.
+			// Ignore them as those projects do not belong to current cluster
+			if o.domainID != "" && project.DomainID != o.domainID {
+				continue
+			}
+
 			userProjectsList[userID] = append(userProjectsList[userID], project.Name)
 			projectUsersList[project.ID] = append(projectUsersList[project.ID], usersMap[userID].Name)
 			projectIDs = append(projectIDs, project.ID)
@@ -48,6 +48,7 @@ type openstackManager struct {
 	auth                       []byte
 	client                     *http.Client
 	apiToken                   string
+	domainID                   string
 	apiTokenExpiry             time.Time
 	userProjectsCache          userProjectsCache
 	userProjectsCacheTTL       time.Duration
@@ -60,6 +61,36 @@ type openstackConfig struct {
 		Identity string `yaml:"identity"`
 	} `yaml:"api_service_endpoints"`
 	AuthConfig interface{} `yaml:"auth"`
+	DomainID   string      `yaml:"domain_id"`
+	DomainName string      `yaml:"domain_name"`
+}
+
+// addDomainScope scopes the token to the configured domain when auth
+// config does not have an explicit scope.
+func (c *openstackConfig) addDomainScope() {
+	if c.DomainID == "" && c.DomainName == "" {
+		return
+	}
+
+	auth, ok := c.AuthConfig.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if _, ok := auth["scope"]; ok {
+		return
+	}
+
+	// Domain ID takes precedence over name as names are unique only
+	// within a given cloud
+	var domain map[string]interface{}
+	if c.DomainID != "" {
+		domain = map[string]interface{}{"id": c.DomainID}
+	} else {
+		domain = map[string]interface{}{"name": c.DomainName}
+	}
+
+	auth["scope"] = map[string]interface{}{"domain": domain}
 }
 
 // addAuthKey embeds AuthConfig as value under `auth` key.
@@ -143,6 +174,8 @@ func New(cluster models.Cluster, logger *slog.Logger) (resource.Fetcher, error)
 	}
 
 	// Convert auth to bytes to embed into requests later
+	osConfig.AuthConfig = common.ConvertMapI2MapS(osConfig.AuthConfig)
+	osConfig.addDomainScope()
 	osConfig.addAuthKey()
 
 	if openstackManager.auth, err = json.Marshal(common.ConvertMapI2MapS(osConfig.AuthConfig)); err != nil {
@@ -158,6 +191,16 @@ func New(cluster models.Cluster, logger *slog.Logger) (resource.Fetcher, error)
 		return nil, errors.Unwrap(err)
 	}
 
+	// Resolve domain ID when only domain name is configured
+	openstackManager.domainID = osConfig.DomainID
+	if openstackManager.domainID == "" && osConfig.DomainName != "" {
+		if openstackManager.domainID, err = openstackManager.fetchDomainID(context.Background(), osConfig.DomainName); err != nil {
+			logger.Error("Failed to fetch domain ID for Openstack cluster", "id", cluster.ID, "domain", osConfig.DomainName, "err", err)
+
+			return nil, err
+		}
+	}
+
 	// Get initial users and projects
 	if err = openstackManager.updateUsersProjects(context.Background(), time.Now()); err != nil {
 		logger.Error("Failed to update users and projects for Openstack cluster", "id", cluster.ID, "err", err)
@@ -226,6 +269,11 @@ func (o *openstackManager) users() *url.URL {
 	return o.apiURLs["identity"].JoinPath("/v3/users")
 }
 
+// domains endpoint.
+func (o *openstackManager) domains() *url.URL {
+	return o.apiURLs["identity"].JoinPath("/v3/domains")
+}
+
 // user details endpoint.
 func (o *openstackManager) userProjects(id string) *url.URL {
 	return o.apiURLs["identity"].JoinPath(fmt.Sprintf("/v3/users/%s/projects", id))
@@ -64,6 +64,7 @@ var (
 				"metadata":       map[string]string{},
 				"tags":           []string{},
 				"server_groups":  "",
+				"flavor":         "m10.vgpu",
 			},
 		},
 		"0687859c-b7b8-47ea-aa4c-74162f52fbfc": {
@@ -103,6 +104,7 @@ var (
 				"metadata":       map[string]string{},
 				"tags":           []string{},
 				"server_groups":  "",
+				"flavor":         "cirros256",
 			},
 		},
 		"66c3eff0-52eb-45e2-a5da-5fe21c0ef3f3": {
@@ -142,6 +144,7 @@ var (
 				"metadata":       map[string]string{},
 				"tags":           []string{},
 				"server_groups":  "",
+				"flavor":         "m1.xl",
 			},
 		},
 	}
@@ -218,6 +221,13 @@ func mockOSIdentityAPIServer() *httptest.Server {
 				return
 			}
 
+			// All users in fixtures belong to default domain
+			if domainID := r.URL.Query().Get("domain_id"); domainID != "" && domainID != "default" {
+				w.Write([]byte(`{"users": []}`))
+
+				return
+			}
+
 			if data, err := os.ReadFile("../../testdata/openstack/identity/users.json"); err == nil {
 				w.Write(data)
 
@@ -238,6 +248,20 @@ func mockOSIdentityAPIServer() *httptest.Server {
 
 				return
 			}
+		} else if strings.HasSuffix(r.URL.Path, "domains") {
+			if tokens := r.Header[tokenHeaderName]; len(tokens) == 0 {
+				w.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+
+			if r.URL.Query().Get("name") == "Default" {
+				w.Write([]byte(`{"domains": [{"id": "default", "name": "Default"}]}`))
+			} else {
+				w.Write([]byte(`{"domains": []}`))
+			}
+
+			return
 		} else if strings.HasSuffix(r.URL.Path, "tokens") {
 			decoder := json.NewDecoder(r.Body)
 
@@ -261,9 +285,10 @@ func mockOSIdentityAPIServer() *httptest.Server {
 	return server
 }
 
-func mockConfig(computeAPIURL, identityAPIURL string) (yaml.Node, error) {
+func mockConfig(computeAPIURL, identityAPIURL string, extra ...string) (yaml.Node, error) {
 	config := `
 ---
+%s
 api_service_endpoints:
   compute: %s
   identity: %s
@@ -276,7 +301,7 @@ auth:
         name: admin
         password: supersecret`
 
-	cfg := fmt.Sprintf(config, computeAPIURL, identityAPIURL)
+	cfg := fmt.Sprintf(config, strings.Join(extra, "\n"), computeAPIURL, identityAPIURL)
 
 	var extraConfig yaml.Node
 
@@ -349,6 +374,71 @@ func TestOpenstackFetcher(t *testing.T) {
 	}
 }
 
+func TestOpenstackFetcherDomainScope(t *testing.T) {
+	// Setup mock API servers
+	computeAPIServer := mockOSComputeAPIServer()
+	defer computeAPIServer.Close()
+
+	identityAPIServer := mockOSIdentityAPIServer()
+	defer identityAPIServer.Close()
+
+	tests := []struct {
+		name     string
+		config   []string
+		domainID string
+		numUsers int
+		fail     bool
+	}{
+		{
+			name:     "domain name",
+			config:   []string{"domain_name: Default"},
+			domainID: "default",
+			numUsers: len(expectedUsers),
+		},
+		{
+			name:     "domain id",
+			config:   []string{"domain_id: other"},
+			domainID: "other",
+			numUsers: 0,
+		},
+		{
+			name:   "unknown domain name",
+			config: []string{"domain_name: unknown"},
+			fail:   true,
+		},
+	}
+
+	for _, test := range tests {
+		extraConfig, err := mockConfig(computeAPIServer.URL, identityAPIServer.URL, test.config...)
+		require.NoError(t, err, test.name)
+
+		cluster := models.Cluster{
+			ID:      "os-0",
+			Manager: "openstack",
+			Extra:   extraConfig,
+		}
+
+		os, err := New(cluster, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		if test.fail {
+			require.Error(t, err, test.name)
+
+			continue
+		}
+
+		require.NoError(t, err, test.name)
+
+		// Token must be scoped to domain
+		osManager, ok := os.(*openstackManager)
+		require.True(t, ok)
+		assert.Equal(t, test.domainID, osManager.domainID, test.name)
+		assert.Contains(t, string(osManager.auth), `"scope":{"domain":`, test.name)
+
+		users, _, err := os.FetchUsersProjects(context.Background(), current)
+		require.NoError(t, err, test.name)
+		assert.Len(t, users[0].Users, test.numUsers, test.name)
+	}
+}
+
 func TestOpenstackFetcherFail(t *testing.T) {
 	// Setup mock API servers
 	computeAPIServer := mockOSComputeAPIServer()
@@ -415,3 +505,15 @@ func TestOpenstackServiceError(t *testing.T) {
 	_, err = os.FetchUnits(ctx, time.Now(), time.Now())
 	require.Error(t, err)
 }
+
+func TestServerTags(t *testing.T) {
+	// Servers from older compute API microversions have only flavor ID
+	// and AZ might be absent
+	var server Server
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "1", "status": "DELETED", "flavor": {"id": "42", "links": []}}`), &server))
+
+	tags := serverTags(server)
+	assert.Equal(t, "42", tags["flavor_id"])
+	assert.Empty(t, tags["az"])
+	assert.NotContains(t, tags, "flavor")
+}
@@ -358,3 +358,16 @@ type Project struct {
 type ProjectsResponse struct {
 	Projects []Project `json:"projects"`
 }
+
+// Domain represents a Domain in the OpenStack Identity Service.
+type Domain struct {
+	// ID is the unique ID of the domain.
+	ID string `json:"id"`
+
+	// Name is the name of the domain.
+	Name string `json:"name"`
+}
+
+type DomainsResponse struct {
+	Domains []Domain `json:"domains"`
+}
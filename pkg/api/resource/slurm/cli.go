@@ -153,227 +153,307 @@ func parseSacctCmdOutput(sacctOutput string, start time.Time, end time.Time) ([]
 
 	for iline, line := range sacctOutputLines {
 		go func(i int, l string) {
-			var jobStat models.Unit
-
-			components := strings.Split(l, "|")
-			jobid := components[sacctFieldMap["jobidraw"]]
-
-			// Ignore if we cannot get all components
-			if len(components) < len(sacctFields) {
+			jobStat, ok := parseSacctComponents(strings.Split(l, "|"), intStartTS, intEndTS, loc)
+			if !ok {
 				wg.Done()
 
 				return
 			}
 
-			// Ignore job steps
-			if strings.Contains(jobid, ".") {
-				wg.Done()
+			jobLock.Lock()
+			jobs[i] = jobStat
+			numJobs += 1
+			jobLock.Unlock()
+			wg.Done()
+		}(iline, line)
+	}
 
-				return
-			}
+	wg.Wait()
 
-			// Ignore jobs that never ran
-			if components[sacctFieldMap["nodelist"]] == "None assigned" {
-				wg.Done()
+	return jobs, numJobs
+}
 
-				return
-			}
+// parseSacctComponents converts components of a sacct output line into a unit.
+// Boundaries of the current interval, intStartTS and intEndTS, are used to
+// estimate elapsed time of the unit in the interval. If the line does not
+// correspond to a valid job, false is returned.
+func parseSacctComponents(components []string, intStartTS int64, intEndTS int64, loc *time.Location) (models.Unit, bool) {
+	jobid := components[sacctFieldMap["jobidraw"]]
 
-			// Attempt to convert strings to int and ignore any errors in conversion
-			var gidInt, uidInt int64
-			gidInt, _ = strconv.ParseInt(components[sacctFieldMap["gid"]], 10, 64)
-			uidInt, _ = strconv.ParseInt(components[sacctFieldMap["uid"]], 10, 64)
-			// elapsedSeconds, _ = strconv.ParseInt(components[sacctFieldMap["elapsedraw"]], 10, 64)
+	// Ignore if we cannot get all components
+	if len(components) < len(sacctFields) {
+		return models.Unit{}, false
+	}
 
-			// Convert time strings to configured time location
-			eventTS := make(map[string]int64, 3)
+	// Ignore job steps
+	if strings.Contains(jobid, ".") {
+		return models.Unit{}, false
+	}
 
-			for _, c := range []string{"submit", "start", "end"} {
-				if t, err := time.Parse(base.DatetimezoneLayout, components[sacctFieldMap[c]]); err == nil {
-					components[sacctFieldMap[c]] = t.In(loc).Format(base.DatetimezoneLayout)
-				}
+	// Ignore jobs that never ran
+	if components[sacctFieldMap["nodelist"]] == "None assigned" {
+		return models.Unit{}, false
+	}
 
-				eventTS[c] = helper.TimeToTimestamp(base.DatetimezoneLayout, components[sacctFieldMap[c]])
-			}
+	// Attempt to convert strings to int and ignore any errors in conversion
+	var gidInt, uidInt int64
+	gidInt, _ = strconv.ParseInt(components[sacctFieldMap["gid"]], 10, 64)
+	uidInt, _ = strconv.ParseInt(components[sacctFieldMap["uid"]], 10, 64)
+	// elapsedSeconds, _ = strconv.ParseInt(components[sacctFieldMap["elapsedraw"]], 10, 64)
 
-			// Parse alloctres to get billing, nnodes, ncpus, ngpus and mem
-			var billing, nnodes, ncpus, ngpus int64
+	// Convert time strings to configured time location
+	eventTS := make(map[string]int64, 3)
 
-			var memString string
+	for _, c := range []string{"submit", "start", "end"} {
+		if t, err := time.Parse(base.DatetimezoneLayout, components[sacctFieldMap[c]]); err == nil {
+			components[sacctFieldMap[c]] = t.In(loc).Format(base.DatetimezoneLayout)
+		}
 
-			for _, elem := range strings.Split(components[sacctFieldMap["alloctres"]], ",") {
-				tresKV := strings.Split(elem, "=")
-				if tresKV[0] == "billing" {
-					billing, _ = strconv.ParseInt(tresKV[1], 10, 64)
-				}
+		eventTS[c] = helper.TimeToTimestamp(base.DatetimezoneLayout, components[sacctFieldMap[c]])
+	}
 
-				if tresKV[0] == "node" {
-					nnodes, _ = strconv.ParseInt(tresKV[1], 10, 64)
-				}
+	// Parse allocated and requested TRES of job
+	alloc := parseTRES(components[sacctFieldMap["alloctres"]])
+	ncpus, ngpus, mem := alloc.cpus, alloc.gpus, alloc.mem
 
-				if tresKV[0] == "cpu" {
-					ncpus, _ = strconv.ParseInt(tresKV[1], 10, 64)
-				}
-				// For MIG devices, it can be gres/gpu:<MIG ID>
-				// https://github.com/SchedMD/slurm/blob/db91ac3046b3b7b845cce4a99127db8c6f14a8e8/testsuite/expect/test39.19#L70
-				// Use a regex gres\/gpu:([^=]+)=(\d+) for identifying number of instances
-				// For the moment, use strings.HasPrefix to identify GPU
-				if strings.HasPrefix(tresKV[0], "gres/gpu") {
-					ngpus, _ = strconv.ParseInt(tresKV[1], 10, 64)
-				}
+	// Assume job's elapsed time during this interval overlaps with interval's
+	// boundaries
+	startMark := intStartTS
+	endMark := intEndTS
 
-				if tresKV[0] == "mem" {
-					memString = tresKV[1]
-				}
-			}
+	// If job has not started between interval's start and end time,
+	// elapsedTime should be zero. This can happen when job is in pending state
+	// after submission
+	if eventTS["start"] == 0 {
+		endMark = startMark
 
-			// If mem is not empty string, convert the units [K|M|G|T] into numeric bytes
-			// The following logic covers the cases when memory is of form 200M, 250.5G
-			// and also without unit eg 20000, 40000. When there is no unit we assume
-			// it is already in bytes
-			matches := memRegex.FindStringSubmatch(memString)
-
-			var mem int64
-
-			if len(matches) >= 2 {
-				if memFloat, err := strconv.ParseFloat(matches[1], 64); err == nil {
-					if len(matches) == 3 {
-						if unitConv, ok := toBytes[matches[2]]; ok {
-							mem = int64(memFloat) * unitConv
-						}
-					}
-				}
-			}
+		goto elapsed
+	}
 
-			// Assume job's elapsed time during this interval overlaps with interval's
-			// boundaries
-			startMark := intStartTS
-			endMark := intEndTS
+	// If job has already finished in the past we need to get boundaries from
+	// job's start and end time. This case should not arrive in production as
+	// there is no reason SLURM gives us the jobs that have finished in the past
+	// that do not overlap with interval boundaries
+	if eventTS["end"] > 0 && eventTS["end"] < intStartTS {
+		startMark = eventTS["start"]
+		endMark = eventTS["end"]
 
-			// If job has not started between interval's start and end time,
-			// elapsedTime should be zero. This can happen when job is in pending state
-			// after submission
-			if eventTS["start"] == 0 {
-				endMark = startMark
+		goto elapsed
+	}
 
-				goto elapsed
-			}
+	// If job has started **after** start of interval, we should mark job's start
+	// time as start of elapsed time
+	if eventTS["start"] > intStartTS {
+		startMark = eventTS["start"]
+	}
 
-			// If job has already finished in the past we need to get boundaries from
-			// job's start and end time. This case should not arrive in production as
-			// there is no reason SLURM gives us the jobs that have finished in the past
-			// that do not overlap with interval boundaries
-			if eventTS["end"] > 0 && eventTS["end"] < intStartTS {
-				startMark = eventTS["start"]
-				endMark = eventTS["end"]
+	// If job has ended before end of interval, we should mark job's end time
+	// as elapsed end time.
+	if eventTS["end"] > 0 && eventTS["end"] < intEndTS {
+		endMark = eventTS["end"]
+	}
 
-				goto elapsed
-			}
+elapsed:
+	// Get elapsed time of job in this interval in seconds
+	elapsedSeconds := (endMark - startMark) / 1000
 
-			// If job has started **after** start of interval, we should mark job's start
-			// time as start of elapsed time
-			if eventTS["start"] > intStartTS {
-				startMark = eventTS["start"]
-			}
+	// Get cpuSeconds and gpuSeconds of the current interval
+	var cpuSeconds, gpuSeconds int64
+	cpuSeconds = ncpus * elapsedSeconds
+	gpuSeconds = ngpus * elapsedSeconds
 
-			// If job has ended before end of interval, we should mark job's end time
-			// as elapsed end time.
-			if eventTS["end"] > 0 && eventTS["end"] < intEndTS {
-				endMark = eventTS["end"]
-			}
+	// Get cpuMemSeconds and gpuMemSeconds of current interval in MB
+	var cpuMemSeconds, gpuMemSeconds int64
+	if mem > 0 {
+		cpuMemSeconds = mem * elapsedSeconds / toBytes["M"]
+	} else {
+		cpuMemSeconds = elapsedSeconds
+	}
 
-		elapsed:
-			// Get elapsed time of job in this interval in seconds
-			elapsedSeconds := (endMark - startMark) / 1000
-
-			// Get cpuSeconds and gpuSeconds of the current interval
-			var cpuSeconds, gpuSeconds int64
-			cpuSeconds = ncpus * elapsedSeconds
-			gpuSeconds = ngpus * elapsedSeconds
-
-			// Get cpuMemSeconds and gpuMemSeconds of current interval in MB
-			var cpuMemSeconds, gpuMemSeconds int64
-			if mem > 0 {
-				cpuMemSeconds = mem * elapsedSeconds / toBytes["M"]
-			} else {
-				cpuMemSeconds = elapsedSeconds
-			}
+	// Currently we use walltime as GPU mem time. This wont be a correct proxy
+	// if MIG is enabled in GPUs where different portions of memory can be
+	// allocated
+	// NOTE: Not sure how SLURM outputs the gres/gpu when MIG is activated.
+	// We need to check it and update this part to take GPU memory into account
+	if ngpus > 0 {
+		gpuMemSeconds = elapsedSeconds
+	}
 
-			// Currently we use walltime as GPU mem time. This wont be a correct proxy
-			// if MIG is enabled in GPUs where different portions of memory can be
-			// allocated
-			// NOTE: Not sure how SLURM outputs the gres/gpu when MIG is activated.
-			// We need to check it and update this part to take GPU memory into account
-			if ngpus > 0 {
-				gpuMemSeconds = elapsedSeconds
-			}
+	// Expand nodelist range expressions
+	allNodes := helper.NodelistParser(components[sacctFieldMap["nodelist"]])
+	nodelistExp := strings.Join(allNodes, "|")
 
-			// Expand nodelist range expressions
-			allNodes := helper.NodelistParser(components[sacctFieldMap["nodelist"]])
-			nodelistExp := strings.Join(allNodes, "|")
-
-			// Allocation
-			allocation := models.Allocation{
-				"nodes":   nnodes,
-				"cpus":    ncpus,
-				"mem":     mem,
-				"gpus":    ngpus,
-				"billing": billing,
-			}
+	// Allocation
+	allocation := alloc.allocation()
+	if reqTRES := components[sacctFieldMap["reqtres"]]; reqTRES != "" {
+		allocation["requested"] = parseTRES(reqTRES).allocation()
+	}
+
+	// Tags
+	tags := models.Tag{
+		"uid":         uidInt,
+		"gid":         gidInt,
+		"partition":   components[sacctFieldMap["partition"]],
+		"qos":         components[sacctFieldMap["qos"]],
+		"exit_code":   components[sacctFieldMap["exitcode"]],
+		"nodelist":    components[sacctFieldMap["nodelist"]],
+		"nodelistexp": nodelistExp,
+		"workdir":     components[sacctFieldMap["workdir"]],
+	}
+
+	// Comments are free text and they are added to tags only when set.
+	// As admin comment is the last field, any delimiters in it will split
+	// it into several components and hence, join them back.
+	if comment := components[sacctFieldMap["comment"]]; comment != "" {
+		tags["comment"] = comment
+	}
+
+	if adminComment := strings.Join(components[sacctFieldMap["admincomment"]:], "|"); adminComment != "" {
+		tags["admin_comment"] = adminComment
+	}
+
+	// Make jobStats struct for each job and put it in jobs slice
+	jobStat := models.Unit{
+		ResourceManager: "slurm",
+		UUID:            jobid,
+		Name:            components[sacctFieldMap["jobname"]],
+		Project:         components[sacctFieldMap["account"]],
+		Group:           components[sacctFieldMap["group"]],
+		User:            components[sacctFieldMap["user"]],
+		CreatedAt:       components[sacctFieldMap["submit"]],
+		StartedAt:       components[sacctFieldMap["start"]],
+		EndedAt:         components[sacctFieldMap["end"]],
+		CreatedAtTS:     eventTS["submit"],
+		StartedAtTS:     eventTS["start"],
+		EndedAtTS:       eventTS["end"],
+		Elapsed:         components[sacctFieldMap["elapsed"]],
+		State:           components[sacctFieldMap["state"]],
+		Allocation:      allocation,
+		TotalTime: models.MetricMap{
+			"walltime":         models.JSONFloat(elapsedSeconds),
+			"alloc_cputime":    models.JSONFloat(cpuSeconds),
+			"alloc_cpumemtime": models.JSONFloat(cpuMemSeconds),
+			"alloc_gputime":    models.JSONFloat(gpuSeconds),
+			"alloc_gpumemtime": models.JSONFloat(gpuMemSeconds),
+		},
+		Tags: tags,
+	}
+
+	return jobStat, true
+}
+
+// jobTRES contains trackable resources (TRES) of a job.
+type jobTRES struct {
+	billing  int64
+	nodes    int64
+	cpus     int64
+	gpus     int64
+	mem      int64
+	gres     map[string]int64 // Generic resources like gpu, gpu:a100, shard
+	licenses map[string]int64
+}
+
+// allocation returns TRES as allocation of compute unit. GRES and licenses are
+// included only when job has them.
+func (t jobTRES) allocation() models.Allocation {
+	allocation := models.Allocation{
+		"nodes":   t.nodes,
+		"cpus":    t.cpus,
+		"mem":     t.mem,
+		"gpus":    t.gpus,
+		"billing": t.billing,
+	}
+
+	if len(t.gres) > 0 {
+		allocation["gres"] = t.gres
+	}
+
+	if len(t.licenses) > 0 {
+		allocation["licenses"] = t.licenses
+	}
+
+	return allocation
+}
+
+// parseTRES parses TRES string of form billing=80,cpu=160,gres/gpu=8,mem=320G,node=2.
+// GRES are of form gres/<name>[:<type>]=<count> and licenses are of form
+// license/<name>=<count>. Entries that cannot be parsed and GRES and licenses
+// with zero count are ignored.
+func parseTRES(tres string) jobTRES {
+	var t jobTRES
+
+	var memString string
+
+	for _, elem := range strings.Split(tres, ",") {
+		key, value, ok := strings.Cut(elem, "=")
+		if !ok {
+			continue
+		}
 
-			// Tags
-			tags := models.Tag{
-				"uid":         uidInt,
-				"gid":         gidInt,
-				"partition":   components[sacctFieldMap["partition"]],
-				"qos":         components[sacctFieldMap["qos"]],
-				"exit_code":   components[sacctFieldMap["exitcode"]],
-				"nodelist":    components[sacctFieldMap["nodelist"]],
-				"nodelistexp": nodelistExp,
-				"workdir":     components[sacctFieldMap["workdir"]],
+		switch {
+		case key == "billing":
+			t.billing, _ = strconv.ParseInt(value, 10, 64)
+		case key == "node":
+			t.nodes, _ = strconv.ParseInt(value, 10, 64)
+		case key == "cpu":
+			t.cpus, _ = strconv.ParseInt(value, 10, 64)
+		case key == "mem":
+			memString = value
+		case strings.HasPrefix(key, "gres/"):
+			if count, err := strconv.ParseInt(value, 10, 64); err == nil && count > 0 {
+				if t.gres == nil {
+					t.gres = make(map[string]int64)
+				}
+
+				t.gres[strings.TrimPrefix(key, "gres/")] = count
 			}
+		case strings.HasPrefix(key, "license/"):
+			if count, err := strconv.ParseInt(value, 10, 64); err == nil && count > 0 {
+				if t.licenses == nil {
+					t.licenses = make(map[string]int64)
+				}
 
-			// Make jobStats struct for each job and put it in jobs slice
-			jobStat = models.Unit{
-				ResourceManager: "slurm",
-				UUID:            jobid,
-				Name:            components[sacctFieldMap["jobname"]],
-				Project:         components[sacctFieldMap["account"]],
-				Group:           components[sacctFieldMap["group"]],
-				User:            components[sacctFieldMap["user"]],
-				CreatedAt:       components[sacctFieldMap["submit"]],
-				StartedAt:       components[sacctFieldMap["start"]],
-				EndedAt:         components[sacctFieldMap["end"]],
-				CreatedAtTS:     eventTS["submit"],
-				StartedAtTS:     eventTS["start"],
-				EndedAtTS:       eventTS["end"],
-				Elapsed:         components[sacctFieldMap["elapsed"]],
-				State:           components[sacctFieldMap["state"]],
-				Allocation:      allocation,
-				TotalTime: models.MetricMap{
-					"walltime":         models.JSONFloat(elapsedSeconds),
-					"alloc_cputime":    models.JSONFloat(cpuSeconds),
-					"alloc_cpumemtime": models.JSONFloat(cpuMemSeconds),
-					"alloc_gputime":    models.JSONFloat(gpuSeconds),
-					"alloc_gpumemtime": models.JSONFloat(gpuMemSeconds),
-				},
-				Tags: tags,
+				t.licenses[strings.TrimPrefix(key, "license/")] = count
 			}
+		}
+	}
 
-			jobLock.Lock()
-			jobs[i] = jobStat
-			numJobs += 1
-			jobLock.Unlock()
-			wg.Done()
-		}(iline, line)
+	// SLURM reports both gres/gpu and gres/gpu:<type> when GPUs have types. For
+	// MIG devices, type is the MIG profile. Use untyped count when available
+	// and sum of typed counts otherwise
+	// https://github.com/SchedMD/slurm/blob/db91ac3046b3b7b845cce4a99127db8c6f14a8e8/testsuite/expect/test39.19#L70
+	if count, ok := t.gres["gpu"]; ok {
+		t.gpus = count
+	} else {
+		for name, count := range t.gres {
+			if strings.HasPrefix(name, "gpu:") {
+				t.gpus += count
+			}
+		}
 	}
 
-	wg.Wait()
+	// If mem is not empty string, convert the units [K|M|G|T] into numeric bytes
+	// The following logic covers the cases when memory is of form 200M, 250.5G
+	// and also without unit eg 20000, 40000. When there is no unit we assume
+	// it is already in bytes
+	matches := memRegex.FindStringSubmatch(memString)
+
+	if len(matches) >= 2 {
+		if memFloat, err := strconv.ParseFloat(matches[1], 64); err == nil {
+			if len(matches) == 3 {
+				if unitConv, ok := toBytes[matches[2]]; ok {
+					t.mem = int64(memFloat) * unitConv
+				}
+			}
+		}
+	}
 
-	return jobs, numJobs
+	return t
 }
 
 // Parse sacctmgr command output and return association.
+// Each line of output has account, user and comma separated list of accounts
+// that the user is coordinator of. The last component is optional.
 func parseSacctMgrCmdOutput(sacctMgrOutput string, currentTime string) ([]models.User, []models.Project) {
 	// No header in output
 	sacctMgrOutputLines := strings.Split(sacctMgrOutput, "\n")
@@ -385,6 +465,9 @@ func parseSacctMgrCmdOutput(sacctMgrOutput string, currentTime string) ([]models
 
 	userProjectMap := make(map[string][]string)
 
+	// Map of user to accounts that user is coordinator of
+	userCoordMap := make(map[string][]string)
+
 	var users []string
 
 	var projects []string
@@ -420,6 +503,10 @@ func parseSacctMgrCmdOutput(sacctMgrOutput string, currentTime string) ([]models
 			projectUserMap[components[0]] = append(projectUserMap[components[0]], components[1])
 			users = append(users, components[1])
 			projects = append(projects, components[0])
+
+			if len(components) > 2 && components[2] != "" {
+				userCoordMap[components[1]] = append(userCoordMap[components[1]], strings.Split(components[2], ",")...)
+			}
 			assocLock.Unlock()
 			wg.Done()
 		}(line)
@@ -448,14 +535,19 @@ func parseSacctMgrCmdOutput(sacctMgrOutput string, currentTime string) ([]models
 		slices.Sort(projectUsers)
 
 		var usersList models.List
+
+		usersRoles := make(models.Roles)
+
 		for _, u := range slices.Compact(projectUsers) {
 			usersList = append(usersList, u)
+			usersRoles[u] = sacctMgrRole(userCoordMap[u], projects[i])
 		}
 
 		// Make Association
 		projectModels[i] = models.Project{
 			Name:          projects[i],
 			Users:         usersList,
+			Roles:         usersRoles,
 			LastUpdatedAt: currentTime,
 		}
 	}
@@ -470,14 +562,19 @@ func parseSacctMgrCmdOutput(sacctMgrOutput string, currentTime string) ([]models
 		slices.Sort(userProjects)
 
 		var projectsList models.List
+
+		projectsRoles := make(models.Roles)
+
 		for _, p := range slices.Compact(userProjects) {
 			projectsList = append(projectsList, p)
+			projectsRoles[p] = sacctMgrRole(userCoordMap[users[i]], p)
 		}
 
 		// Make Association
 		userModels[i] = models.User{
 			Name:          users[i],
 			Projects:      projectsList,
+			Roles:         projectsRoles,
 			LastUpdatedAt: currentTime,
 		}
 	}
@@ -485,23 +582,36 @@ func parseSacctMgrCmdOutput(sacctMgrOutput string, currentTime string) ([]models
 	return userModels, projectModels
 }
 
-// runSacctCmd executes sacct command and return output.
-func (s *slurmScheduler) runSacctCmd(ctx context.Context, start, end time.Time) ([]byte, error) {
+// sacctMgrRole returns role of the user in the account based on the
+// list of accounts that user is coordinator of.
+func sacctMgrRole(coordAccounts []string, account string) string {
+	if slices.Contains(coordAccounts, account) {
+		return models.RoleCoordinator
+	}
+
+	return models.RoleMember
+}
+
+// jobStates returns the states of jobs to fetch for the interval ending at end.
+func jobStates(end time.Time) []string {
 	// If we are fetching historical data, do not use RUNNING state as it can report
 	// same job twice once when it was still in running state and once it is in completed
 	// state.
-	// endTimeParsed, _ := time.Parse(base.DatetimeLayout, endTime)
-	var states []string
 	// When fetching current jobs, endTime should be very close to current time. Here we
 	// assume that if current time is more than 5 sec than end time, we are fetching
 	// historical data
 	if time.Now().In(end.Location()).Sub(end) > 5*time.Second {
 		// Strip RUNNING state from slice
-		states = slurmStates[:len(slurmStates)-1]
-	} else {
-		states = slurmStates
+		return slurmStates[:len(slurmStates)-1]
 	}
 
+	return slurmStates
+}
+
+// runSacctCmd executes sacct command and return output.
+func (s *slurmScheduler) runSacctCmd(ctx context.Context, start, end time.Time) ([]byte, error) {
+	states := jobStates(end)
+
 	// sacct path
 	sacctPath := filepath.Join(s.cluster.CLI.Path, "sacct")
 
@@ -557,8 +667,12 @@ func (s *slurmScheduler) runSacctCmd(ctx context.Context, start, end time.Time)
 
 // Run sacctmgr command and return output.
 func (s *slurmScheduler) runSacctMgrCmd(ctx context.Context) ([]byte, error) {
-	// Use jobIDRaw that outputs the array jobs as regular job IDs instead of id_array format
-	args := []string{"--parsable2", "--noheader", "list", "associations", "format=Account,User"}
+	// List users with their associations and coordinator accounts to get roles of
+	// users in each account
+	args := []string{
+		"--parsable2", "--noheader", "list", "users", "withassoc", "withcoord",
+		"format=Account,User,Coordinators",
+	}
 
 	// sacct path
 	sacctMgrPath := filepath.Join(s.cluster.CLI.Path, "sacctmgr")
@@ -619,9 +733,10 @@ func executeInSecurityContext(
 
 // Run preflight checks on provided config.
 func preflightChecks(s *slurmScheduler) error {
-	// // Always prefer REST API mode if configured
-	// if clusterConfig.Web.URL != "" {
-	// 	return checkRESTAPI(clusterConfig, logger)
-	// }
+	// Always prefer REST API mode if configured
+	if s.cluster.Web.URL != "" {
+		return preflightsREST(s)
+	}
+
 	return preflightsCLI(s)
 }
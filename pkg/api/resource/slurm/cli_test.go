@@ -35,36 +35,68 @@ func TestParseSacctCmdOutput(t *testing.T) {
 	require.Equal(t, 2, numUnits)
 
 	// Job finished in past
-	sacctCmdOutput1 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-20T14:37:02+0100|2023-02-20T14:37:07+0100|2023-02-20T15:37:07+0100|01:49:22|3000|0:0|RUNNING|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2|compute-0|test_script1|/home/usr`
+	sacctCmdOutput1 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-20T14:37:02+0100|2023-02-20T14:37:07+0100|2023-02-20T15:37:07+0100|01:49:22|3000|0:0|RUNNING|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2||compute-0|test_script1|/home/usr||`
 	units, _ = parseSacctCmdOutput(sacctCmdOutput1, start, end)
 	// Check if elapsed time corresponds to real elapsed time of job
 	assert.InEpsilon(t, 3600, float64(units[0].TotalTime["walltime"]), 0)
 
 	// Job created but not started
-	sacctCmdOutput2 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T14:37:02+0100|NA|NA|01:49:22|3000|0:0|PENDING|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2|compute-0|test_script1|/home/usr`
+	sacctCmdOutput2 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T14:37:02+0100|NA|NA|01:49:22|3000|0:0|PENDING|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2||compute-0|test_script1|/home/usr||`
 	units, _ = parseSacctCmdOutput(sacctCmdOutput2, start, end)
 	// Check if elapsed time corresponds to real elapsed time of job
 	assert.Equal(t, 0, int(units[0].TotalTime["walltime"]))
 
 	// Job started inside current interval
-	sacctCmdOutput3 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T15:10:00+0100|2023-02-21T15:10:00+0100|NA|01:49:22|3000|0:0|RUNNING|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2|compute-0|test_script1|/home/usr`
+	sacctCmdOutput3 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T15:10:00+0100|2023-02-21T15:10:00+0100|NA|01:49:22|3000|0:0|RUNNING|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2||compute-0|test_script1|/home/usr||`
 	units, _ = parseSacctCmdOutput(sacctCmdOutput3, start, end)
 	// Check if elapsed time corresponds to real elapsed time of job
 	assert.InEpsilon(t, 300, float64(units[0].TotalTime["walltime"]), 0)
 
 	// Job ended inside current interval
-	sacctCmdOutput4 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T14:10:00+0100|2023-02-21T14:10:00+0100|2023-02-21T15:10:00+0100|01:49:22|3000|0:0|COMPLETED|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2|compute-0|test_script1|/home/usr`
+	sacctCmdOutput4 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T14:10:00+0100|2023-02-21T14:10:00+0100|2023-02-21T15:10:00+0100|01:49:22|3000|0:0|COMPLETED|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2||compute-0|test_script1|/home/usr||`
 	units, _ = parseSacctCmdOutput(sacctCmdOutput4, start, end)
 	// Check if elapsed time corresponds to real elapsed time of job
 	assert.InEpsilon(t, 600, float64(units[0].TotalTime["walltime"]), 0)
 
 	// Job started and ended inside current interval
-	sacctCmdOutput5 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T15:10:00+0100|2023-02-21T15:10:00+0100|2023-02-21T15:12:00+0100|01:49:22|3000|0:0|COMPLETED|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2|compute-0|test_script1|/home/usr`
+	sacctCmdOutput5 := `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T15:10:00+0100|2023-02-21T15:10:00+0100|2023-02-21T15:12:00+0100|01:49:22|3000|0:0|COMPLETED|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320G,node=2||compute-0|test_script1|/home/usr||`
 	units, _ = parseSacctCmdOutput(sacctCmdOutput5, start, end)
 	// Check if elapsed time corresponds to real elapsed time of job
 	assert.InEpsilon(t, 120, float64(units[0].TotalTime["walltime"]), 0)
 }
 
+func TestParseTRES(t *testing.T) {
+	tests := []struct {
+		name     string
+		tres     string
+		expected jobTRES
+	}{
+		{
+			name:     "gpus with types",
+			tres:     "billing=10,cpu=4,gres/gpu=2,gres/gpu:a100=2,mem=8G,node=1",
+			expected: jobTRES{billing: 10, cpus: 4, gpus: 2, mem: 8 * toBytes["G"], nodes: 1, gres: map[string]int64{"gpu": 2, "gpu:a100": 2}},
+		},
+		{
+			name:     "mig instances without untyped count",
+			tres:     "cpu=4,gres/gpu:1g.5gb=2,gres/gpu:2g.10gb=1,node=1",
+			expected: jobTRES{cpus: 4, gpus: 3, nodes: 1, gres: map[string]int64{"gpu:1g.5gb": 2, "gpu:2g.10gb": 1}},
+		},
+		{
+			name:     "licenses and other gres",
+			tres:     "cpu=2,gres/gpu=0,gres/shard=4,license/matlab=2,license/ansys=0,mem=200M",
+			expected: jobTRES{cpus: 2, mem: 200 * toBytes["M"], gres: map[string]int64{"shard": 4}, licenses: map[string]int64{"matlab": 2}},
+		},
+		{
+			name: "empty tres",
+			tres: "",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, parseTRES(test.tres), test.name)
+	}
+}
+
 func TestParseSacctMgrCmdOutput(t *testing.T) {
 	users, projects := parseSacctMgrCmdOutput(sacctMgrCmdOutput, current.Format(base.DatetimezoneLayout))
 	require.ElementsMatch(t, expectedUsers, users)
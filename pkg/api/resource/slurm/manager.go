@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
@@ -23,7 +25,8 @@ const (
 
 // Fetch modes.
 const (
-	cliMode = "cli"
+	cliMode  = "cli"
+	restMode = "rest"
 )
 
 // Security contexts.
@@ -38,6 +41,9 @@ type slurmScheduler struct {
 	fetchMode        string // Whether to fetch from REST API or CLI commands
 	cmdExecMode      string // If sacct mode is chosen, the mode of executing command, ie, sudo or cap or native
 	securityContexts map[string]*security.SecurityContext
+	client           *http.Client // If REST API mode is chosen, HTTP client to query slurmrestd
+	apiURL           *url.URL     // If REST API mode is chosen, base URL of slurmrestd
+	apiVersion       string       // If REST API mode is chosen, version of slurmrestd API
 }
 
 const slurmBatchScheduler = "slurm"
@@ -48,7 +54,7 @@ var (
 	sacctFields = []string{
 		"jobidraw", "partition", "qos", "account", "group", "gid", "user", "uid",
 		"submit", "start", "end", "elapsed", "elapsedraw", "exitcode", "state",
-		"alloctres", "nodelist", "jobname", "workdir",
+		"alloctres", "reqtres", "nodelist", "jobname", "workdir", "comment", "admincomment",
 	}
 	slurmStates = []string{
 		"CANCELLED", "COMPLETED", "FAILED", "NODE_FAIL", "PREEMPTED", "TIMEOUT",
@@ -105,6 +111,16 @@ func (s *slurmScheduler) FetchUnits(
 		return []models.ClusterUnits{{Cluster: s.cluster, Units: jobs}}, nil
 	}
 
+	if s.fetchMode == restMode {
+		if jobs, err = s.fetchFromSlurmrestd(ctx, start, end); err != nil {
+			s.logger.Error("Failed to fetch jobs from slurmrestd", "cluster_id", s.cluster.ID, "err", err)
+
+			return nil, err
+		}
+
+		return []models.ClusterUnits{{Cluster: s.cluster, Units: jobs}}, nil
+	}
+
 	return nil, fmt.Errorf("unknown fetch mode for compute units SLURM cluster %s", s.cluster.ID)
 }
 
@@ -133,6 +149,20 @@ func (s *slurmScheduler) FetchUsersProjects(
 			}, nil
 	}
 
+	if s.fetchMode == restMode {
+		if users, projects, err = s.fetchAssociationsFromSlurmrestd(ctx, current); err != nil {
+			s.logger.Error("Failed to fetch associations from slurmrestd", "cluster_id", s.cluster.ID, "err", err)
+
+			return nil, nil, err
+		}
+
+		return []models.ClusterUsers{
+				{Cluster: s.cluster, Users: users},
+			}, []models.ClusterProjects{
+				{Cluster: s.cluster, Projects: projects},
+			}, nil
+	}
+
 	return nil, nil, fmt.Errorf("unknown fetch mode for projects for SLURM cluster %s", s.cluster.ID)
 }
 
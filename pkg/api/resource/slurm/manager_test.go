@@ -19,18 +19,18 @@ var (
 	start, _       = time.Parse(base.DatetimezoneLayout, "2023-02-21T15:00:00+0100")
 	end, _         = time.Parse(base.DatetimezoneLayout, "2023-02-21T15:15:00+0100")
 	current, _     = time.Parse(base.DatetimezoneLayout, "2023-02-21T15:15:00+0100")
-	sacctCmdOutput = `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T14:37:02+0100|2023-02-21T14:37:07+0100|NA|01:49:22|3000|0:0|RUNNING|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320.5G,node=2|compute-0|test_script1|/home/usr
-1481508|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T13:49:20+0100|2023-02-21T13:49:06+0100|2023-02-21T15:10:23+0100|00:08:17|4920|0:0|COMPLETED|billing=1,cpu=2,mem=4M,node=1|compute-[0-2]|test_script2|/home/usr`
+	sacctCmdOutput = `1479763|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T14:37:02+0100|2023-02-21T14:37:07+0100|NA|01:49:22|3000|0:0|RUNNING|billing=80,cpu=160,energy=1439089,gres/gpu=8,mem=320.5G,node=2|billing=80,cpu=160,gres/gpu:a100=8,license/matlab=2,mem=320G,node=2|compute-0|test_script1|/home/usr|project=prj1|cost_center=cc1|internal
+1481508|part1|qos1|acc1|grp|1000|usr|1000|2023-02-21T13:49:20+0100|2023-02-21T13:49:06+0100|2023-02-21T15:10:23+0100|00:08:17|4920|0:0|COMPLETED|billing=1,cpu=2,mem=4M,node=1||compute-[0-2]|test_script2|/home/usr||`
 	sacctMgrCmdOutput = `root|
 root|root
 prj1|
 prj2|
 prj3|
-prj3|usr1
-prj3|usr2
+prj3|usr1|
+prj3|usr2|prj4
 prj4|
-prj4|usr2
-prj4|usr3`
+prj4|usr2|prj4
+prj4|usr3|`
 	expectedBatchJobs = []models.Unit{
 		{
 			ID:              0,
@@ -61,16 +61,28 @@ prj4|usr3`
 				"mem":     int64(343597383680),
 				"nodes":   int64(2),
 				"billing": int64(80),
+				"gres":    map[string]int64{"gpu": 8},
+				"requested": models.Allocation{
+					"cpus":     int64(160),
+					"gpus":     int64(8),
+					"mem":      int64(343597383680),
+					"nodes":    int64(2),
+					"billing":  int64(80),
+					"gres":     map[string]int64{"gpu:a100": 8},
+					"licenses": map[string]int64{"matlab": 2},
+				},
 			},
 			Tags: models.Generic{
-				"gid":         int64(1000),
-				"nodelist":    "compute-0",
-				"nodelistexp": "compute-0",
-				"exit_code":   "0:0",
-				"partition":   "part1",
-				"qos":         "qos1",
-				"uid":         int64(1000),
-				"workdir":     "/home/usr",
+				"gid":           int64(1000),
+				"nodelist":      "compute-0",
+				"nodelistexp":   "compute-0",
+				"exit_code":     "0:0",
+				"partition":     "part1",
+				"qos":           "qos1",
+				"uid":           int64(1000),
+				"workdir":       "/home/usr",
+				"comment":       "project=prj1",
+				"admin_comment": "cost_center=cc1|internal",
 			},
 			Ignore: 0,
 		},
@@ -121,11 +133,13 @@ prj4|usr3`
 		{
 			Name:          "prj3",
 			Users:         models.List{"usr1", "usr2"},
+			Roles:         models.Roles{"usr1": "member", "usr2": "member"},
 			LastUpdatedAt: "2023-02-21T15:15:00+0100",
 		},
 		{
 			Name:          "prj4",
 			Users:         models.List{"usr2", "usr3"},
+			Roles:         models.Roles{"usr2": "coordinator", "usr3": "member"},
 			LastUpdatedAt: "2023-02-21T15:15:00+0100",
 		},
 	}
@@ -133,16 +147,19 @@ prj4|usr3`
 		{
 			Name:          "usr1",
 			Projects:      models.List{"prj3"},
+			Roles:         models.Roles{"prj3": "member"},
 			LastUpdatedAt: "2023-02-21T15:15:00+0100",
 		},
 		{
 			Name:          "usr2",
 			Projects:      models.List{"prj3", "prj4"},
+			Roles:         models.Roles{"prj3": "member", "prj4": "coordinator"},
 			LastUpdatedAt: "2023-02-21T15:15:00+0100",
 		},
 		{
 			Name:          "usr3",
 			Projects:      models.List{"prj4"},
+			Roles:         models.Roles{"prj4": "member"},
 			LastUpdatedAt: "2023-02-21T15:15:00+0100",
 		},
 	}
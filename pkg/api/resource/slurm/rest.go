@@ -0,0 +1,404 @@
+package slurm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/pkg/api/base"
+	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	config_util "github.com/prometheus/common/config"
+)
+
+// Default version of slurmrestd API.
+const defaultAPIVersion = "v0.0.40"
+
+// slurmConfig contains the extra configuration of SLURM cluster.
+type slurmConfig struct {
+	APIVersion string `yaml:"api_version"`
+}
+
+// slurmNumber is a number returned by slurmrestd. Depending on API version, numbers
+// are returned either as plain integers or as objects with set, infinite and number keys.
+type slurmNumber int64
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (n *slurmNumber) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var obj struct {
+			Set    bool  `json:"set"`
+			Number int64 `json:"number"`
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+
+		if obj.Set {
+			*n = slurmNumber(obj.Number)
+		}
+
+		return nil
+	}
+
+	var number int64
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+
+	*n = slurmNumber(number)
+
+	return nil
+}
+
+// slurmError is the error returned by slurmrestd.
+type slurmError struct {
+	Description string `json:"description"`
+	Error       string `json:"error"`
+}
+
+// slurmJob is the job object returned by slurmdb jobs endpoint of slurmrestd.
+type slurmJob struct {
+	JobID     slurmNumber `json:"job_id"`
+	Name      string      `json:"name"`
+	Account   string      `json:"account"`
+	Partition string      `json:"partition"`
+	QoS       string      `json:"qos"`
+	Group     string      `json:"group"`
+	User      string      `json:"user"`
+	Nodes     string      `json:"nodes"`
+	WorkDir   string      `json:"working_directory"`
+	Time      struct {
+		Elapsed    slurmNumber `json:"elapsed"`
+		Submission slurmNumber `json:"submission"`
+		Start      slurmNumber `json:"start"`
+		End        slurmNumber `json:"end"`
+	} `json:"time"`
+	State struct {
+		Current []string `json:"current"`
+	} `json:"state"`
+	ExitCode struct {
+		ReturnCode slurmNumber `json:"return_code"`
+		Signal     struct {
+			ID slurmNumber `json:"id"`
+		} `json:"signal"`
+	} `json:"exit_code"`
+	TRES struct {
+		Allocated []slurmTRES `json:"allocated"`
+		Requested []slurmTRES `json:"requested"`
+	} `json:"tres"`
+	Comment struct {
+		Administrator string `json:"administrator"`
+		Job           string `json:"job"`
+	} `json:"comment"`
+}
+
+// slurmTRES is a trackable resource of a job in slurmrestd responses.
+type slurmTRES struct {
+	Type  string      `json:"type"`
+	Name  string      `json:"name"`
+	Count slurmNumber `json:"count"`
+}
+
+// slurmJobsResponse is the response of slurmdb jobs endpoint of slurmrestd.
+type slurmJobsResponse struct {
+	Jobs   []slurmJob   `json:"jobs"`
+	Errors []slurmError `json:"errors"`
+}
+
+// slurmAssociationsResponse is the response of slurmdb associations endpoint of slurmrestd.
+type slurmAssociationsResponse struct {
+	Associations []struct {
+		Account string `json:"account"`
+		User    string `json:"user"`
+	} `json:"associations"`
+	Errors []slurmError `json:"errors"`
+}
+
+// slurmAccountsResponse is the response of slurmdb accounts endpoint of slurmrestd.
+type slurmAccountsResponse struct {
+	Accounts []struct {
+		Name         string `json:"name"`
+		Coordinators []struct {
+			Name string `json:"name"`
+		} `json:"coordinators"`
+	} `json:"accounts"`
+	Errors []slurmError `json:"errors"`
+}
+
+// slurmErrors returns a joined error from errors returned by slurmrestd.
+func slurmErrors(errs []slurmError) error {
+	var err error
+	for _, e := range errs {
+		err = errors.Join(err, fmt.Errorf("%s: %s", e.Error, e.Description))
+	}
+
+	return err
+}
+
+// Run preflights for REST API mode.
+func preflightsREST(slurm *slurmScheduler) error {
+	slurm.fetchMode = restMode
+	slurm.logger.Debug("Using SLURM REST API")
+
+	var err error
+
+	// Ensure we have a valid URL for slurmrestd
+	if slurm.apiURL, err = url.Parse(slurm.cluster.Web.URL); err != nil {
+		slurm.logger.Error("Failed to parse slurmrestd URL", "url", slurm.cluster.Web.URL, "err", err)
+
+		return err
+	}
+
+	// Get API version from extra_config, if provided
+	slurm.apiVersion = defaultAPIVersion
+
+	if !slurm.cluster.Extra.IsZero() {
+		cfg := &slurmConfig{}
+		if err := slurm.cluster.Extra.Decode(cfg); err != nil {
+			slurm.logger.Error("Failed to decode extra_config for SLURM cluster", "id", slurm.cluster.ID, "err", err)
+
+			return err
+		}
+
+		if cfg.APIVersion != "" {
+			slurm.apiVersion = cfg.APIVersion
+		}
+	}
+
+	// Make a HTTP client for slurmrestd from client config. Authentication
+	// headers X-SLURM-USER-NAME and X-SLURM-USER-TOKEN must be configured
+	// using http_headers section of web config.
+	if slurm.client, err = config_util.NewClientFromConfig(slurm.cluster.Web.HTTPClientConfig, "slurm"); err != nil {
+		slurm.logger.Error("Failed to create HTTP client for slurmrestd", "id", slurm.cluster.ID, "err", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// slurmdbURL returns URL of the given slurmdb endpoint of slurmrestd.
+func (s *slurmScheduler) slurmdbURL(endpoint string) *url.URL {
+	return s.apiURL.JoinPath("slurmdb", s.apiVersion, endpoint)
+}
+
+// apiRequest makes GET request to slurmrestd and unpacks the response into T.
+func apiRequest[T any](ctx context.Context, client *http.Client, reqURL *url.URL) (T, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return *new(T), err
+	}
+
+	req.Header.Add("Accept", "application/json")
+
+	// Make request
+	resp, err := client.Do(req)
+	if err != nil {
+		return *new(T), err
+	}
+	defer resp.Body.Close()
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return *new(T), fmt.Errorf("request failed with status: %d", resp.StatusCode)
+	}
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return *new(T), err
+	}
+
+	// Unpack into data
+	var data T
+	if err = json.Unmarshal(body, &data); err != nil {
+		return *new(T), err
+	}
+
+	return data, nil
+}
+
+// Get jobs from slurmrestd.
+func (s *slurmScheduler) fetchFromSlurmrestd(ctx context.Context, start time.Time, end time.Time) ([]models.Unit, error) {
+	reqURL := s.slurmdbURL("jobs")
+
+	// Query job allocations only between start and end times
+	q := url.Values{}
+	q.Add("start_time", strconv.FormatInt(start.Unix(), 10))
+	q.Add("end_time", strconv.FormatInt(end.Unix(), 10))
+	q.Add("state", strings.Join(jobStates(end), ","))
+	q.Add("skip_steps", "true")
+	reqURL.RawQuery = q.Encode()
+
+	resp, err := apiRequest[slurmJobsResponse](ctx, s.client, reqURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Errors) > 0 {
+		return nil, slurmErrors(resp.Errors)
+	}
+
+	jobs, numJobs := parseSlurmrestdJobs(resp.Jobs, start, end)
+	s.logger.Info("SLURM jobs fetched", "cluster_id", s.cluster.ID, "start", start, "end", end, "num_jobs", numJobs)
+
+	return jobs, nil
+}
+
+// Get user project associations from slurmrestd.
+func (s *slurmScheduler) fetchAssociationsFromSlurmrestd(
+	ctx context.Context,
+	current time.Time,
+) ([]models.User, []models.Project, error) {
+	assocs, err := apiRequest[slurmAssociationsResponse](ctx, s.client, s.slurmdbURL("associations"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(assocs.Errors) > 0 {
+		return nil, nil, slurmErrors(assocs.Errors)
+	}
+
+	accounts, err := apiRequest[slurmAccountsResponse](ctx, s.client, s.slurmdbURL("accounts"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(accounts.Errors) > 0 {
+		return nil, nil, slurmErrors(accounts.Errors)
+	}
+
+	// Map of user to accounts that user is coordinator of
+	userCoordMap := make(map[string][]string)
+
+	for _, account := range accounts.Accounts {
+		for _, coord := range account.Coordinators {
+			userCoordMap[coord.Name] = append(userCoordMap[coord.Name], account.Name)
+		}
+	}
+
+	// Convert associations to sacctmgr output format to reuse its parser
+	lines := make([]string, len(assocs.Associations))
+
+	for i, assoc := range assocs.Associations {
+		coordAccounts := userCoordMap[assoc.User]
+		slices.Sort(coordAccounts)
+
+		lines[i] = strings.Join([]string{assoc.Account, assoc.User, strings.Join(coordAccounts, ",")}, "|")
+	}
+
+	users, projects := parseSacctMgrCmdOutput(strings.Join(lines, "\n"), current.Format(base.DatetimeLayout))
+	s.logger.Info("SLURM user account data fetched", "cluster_id", s.cluster.ID, "num_users", len(users), "num_accounts", len(projects))
+
+	return users, projects, nil
+}
+
+// parseSlurmrestdJobs converts jobs returned by slurmrestd into units. Each job is
+// converted into the components of equivalent sacct output line and hence, units
+// are estimated exactly the same way as in CLI mode.
+func parseSlurmrestdJobs(slurmJobs []slurmJob, start time.Time, end time.Time) ([]models.Unit, int) {
+	// Update period
+	intStartTS := start.UnixMilli()
+	intEndTS := end.UnixMilli()
+
+	// Get current location
+	loc := end.Location()
+
+	jobs := make([]models.Unit, 0, len(slurmJobs))
+
+	for _, job := range slurmJobs {
+		components := make([]string, len(sacctFields))
+
+		// Times are in epoch and zero means they are not set
+		for field, ts := range map[string]slurmNumber{
+			"submit": job.Time.Submission,
+			"start":  job.Time.Start,
+			"end":    job.Time.End,
+		} {
+			if ts > 0 {
+				components[sacctFieldMap[field]] = time.Unix(int64(ts), 0).In(loc).Format(base.DatetimezoneLayout)
+			} else {
+				components[sacctFieldMap[field]] = "Unknown"
+			}
+		}
+
+		nodes := job.Nodes
+		if nodes == "" {
+			nodes = "None assigned"
+		}
+
+		components[sacctFieldMap["jobidraw"]] = strconv.FormatInt(int64(job.JobID), 10)
+		components[sacctFieldMap["partition"]] = job.Partition
+		components[sacctFieldMap["qos"]] = job.QoS
+		components[sacctFieldMap["account"]] = job.Account
+		components[sacctFieldMap["group"]] = job.Group
+		components[sacctFieldMap["user"]] = job.User
+		components[sacctFieldMap["elapsed"]] = formatElapsed(int64(job.Time.Elapsed))
+		components[sacctFieldMap["elapsedraw"]] = strconv.FormatInt(int64(job.Time.Elapsed), 10)
+		components[sacctFieldMap["exitcode"]] = fmt.Sprintf("%d:%d", job.ExitCode.ReturnCode, job.ExitCode.Signal.ID)
+		components[sacctFieldMap["state"]] = strings.Join(job.State.Current, ",")
+		components[sacctFieldMap["alloctres"]] = formatTRES(job.TRES.Allocated)
+		components[sacctFieldMap["reqtres"]] = formatTRES(job.TRES.Requested)
+		components[sacctFieldMap["nodelist"]] = nodes
+		components[sacctFieldMap["jobname"]] = job.Name
+		components[sacctFieldMap["workdir"]] = job.WorkDir
+		components[sacctFieldMap["comment"]] = job.Comment.Job
+		components[sacctFieldMap["admincomment"]] = job.Comment.Administrator
+
+		jobStat, ok := parseSacctComponents(components, intStartTS, intEndTS, loc)
+		if !ok {
+			continue
+		}
+
+		// slurmrestd does not return UID and GID of jobs
+		delete(jobStat.Tags, "uid")
+		delete(jobStat.Tags, "gid")
+
+		jobs = append(jobs, jobStat)
+	}
+
+	return jobs, len(jobs)
+}
+
+// formatElapsed formats elapsed seconds in the same format as sacct, ie,
+// [DD-]HH:MM:SS.
+func formatElapsed(seconds int64) string {
+	days := seconds / 86400
+	hours := (seconds % 86400) / 3600
+	minutes := (seconds % 3600) / 60
+	secs := seconds % 60
+
+	if days > 0 {
+		return fmt.Sprintf("%d-%02d:%02d:%02d", days, hours, minutes, secs)
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, secs)
+}
+
+// formatTRES formats TRES of slurmrestd response in the same format as sacct.
+// Memory in TRES is in MB.
+func formatTRES(tres []slurmTRES) string {
+	elems := make([]string, 0, len(tres))
+
+	for _, t := range tres {
+		switch {
+		case (t.Type == "gres" || t.Type == "license") && t.Name != "":
+			elems = append(elems, fmt.Sprintf("%s/%s=%d", t.Type, t.Name, t.Count))
+		case t.Type == "mem":
+			elems = append(elems, fmt.Sprintf("mem=%dM", t.Count))
+		default:
+			elems = append(elems, fmt.Sprintf("%s=%d", t.Type, t.Count))
+		}
+	}
+
+	return strings.Join(elems, ",")
+}
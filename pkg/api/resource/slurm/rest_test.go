@@ -0,0 +1,220 @@
+package slurm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	slurmrestdJobs = `{
+  "jobs": [
+    {
+      "job_id": 1479763,
+      "name": "test_script1",
+      "account": "acc1",
+      "partition": "part1",
+      "qos": "qos1",
+      "group": "grp",
+      "user": "usr",
+      "nodes": "compute-0",
+      "working_directory": "/home/usr",
+      "time": {"elapsed": 6562, "submission": 1676986622, "start": 1676986627, "end": 0},
+      "state": {"current": ["RUNNING"]},
+      "exit_code": {"return_code": {"set": true, "infinite": false, "number": 0}, "signal": {"id": {"set": false, "infinite": false, "number": 0}}},
+      "tres": {"allocated": [
+        {"type": "billing", "name": "", "count": 80},
+        {"type": "cpu", "name": "", "count": 160},
+        {"type": "gres", "name": "gpu", "count": 8},
+        {"type": "mem", "name": "", "count": 327680},
+        {"type": "node", "name": "", "count": 2}
+      ], "requested": [
+        {"type": "billing", "name": "", "count": 80},
+        {"type": "cpu", "name": "", "count": 160},
+        {"type": "gres", "name": "gpu:a100", "count": 8},
+        {"type": "license", "name": "matlab", "count": 2},
+        {"type": "mem", "name": "", "count": 327680},
+        {"type": "node", "name": "", "count": 2}
+      ]},
+      "comment": {"administrator": "cost_center=cc1|internal", "job": "project=prj1", "system": ""}
+    },
+    {
+      "job_id": 1481508,
+      "name": "test_script2",
+      "account": "acc1",
+      "partition": "part1",
+      "qos": "qos1",
+      "group": "grp",
+      "user": "usr",
+      "nodes": "compute-[0-2]",
+      "working_directory": "/home/usr",
+      "time": {"elapsed": 497, "submission": 1676983760, "start": 1676983746, "end": 1676988623},
+      "state": {"current": ["COMPLETED"]},
+      "exit_code": {"return_code": 0, "signal": {"id": 0}},
+      "tres": {"allocated": [
+        {"type": "billing", "name": "", "count": 1},
+        {"type": "cpu", "name": "", "count": 2},
+        {"type": "mem", "name": "", "count": 4},
+        {"type": "node", "name": "", "count": 1}
+      ]},
+      "comment": {"administrator": "", "job": "", "system": ""}
+    },
+    {
+      "job_id": 1481510,
+      "name": "test_script3",
+      "account": "acc1",
+      "partition": "part1",
+      "qos": "qos1",
+      "group": "grp",
+      "user": "usr",
+      "nodes": "None assigned",
+      "working_directory": "/home/usr",
+      "time": {"elapsed": 0, "submission": 1676983760, "start": 0, "end": 0},
+      "state": {"current": ["PENDING"]},
+      "exit_code": {"return_code": 0, "signal": {"id": 0}},
+      "tres": {"allocated": []},
+      "comment": {"administrator": "", "job": "", "system": ""}
+    }
+  ],
+  "errors": []
+}`
+	slurmrestdAssociations = `{
+  "associations": [
+    {"account": "root", "user": ""},
+    {"account": "root", "user": "root"},
+    {"account": "prj1", "user": ""},
+    {"account": "prj3", "user": "usr1"},
+    {"account": "prj3", "user": "usr2"},
+    {"account": "prj4", "user": "usr2"},
+    {"account": "prj4", "user": "usr3"}
+  ],
+  "errors": []
+}`
+	slurmrestdAccounts = `{
+  "accounts": [
+    {"name": "prj3", "coordinators": []},
+    {"name": "prj4", "coordinators": [{"name": "usr2", "direct": true}]}
+  ],
+  "errors": []
+}`
+)
+
+func mockSlurmrestdServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Slurm-User-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/slurmdb/v0.0.41/jobs"):
+			w.Write([]byte(slurmrestdJobs))
+		case strings.HasSuffix(r.URL.Path, "/slurmdb/v0.0.41/associations"):
+			w.Write([]byte(slurmrestdAssociations))
+		case strings.HasSuffix(r.URL.Path, "/slurmdb/v0.0.41/accounts"):
+			w.Write([]byte(slurmrestdAccounts))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestParseSlurmrestdJobs(t *testing.T) {
+	var resp slurmJobsResponse
+	require.NoError(t, json.Unmarshal([]byte(slurmrestdJobs), &resp))
+
+	units, numUnits := parseSlurmrestdJobs(resp.Jobs, start, end)
+	require.Equal(t, 2, numUnits)
+
+	// Units must be same as the ones from sacct except UID and GID
+	var expectedUnits []models.Unit
+
+	for _, unit := range expectedBatchJobs {
+		tags := make(models.Generic)
+
+		for k, v := range unit.Tags {
+			if k != "uid" && k != "gid" {
+				tags[k] = v
+			}
+		}
+
+		unit.Tags = tags
+		expectedUnits = append(expectedUnits, unit)
+	}
+
+	// Unset times are reported as Unknown
+	expectedUnits[0].EndedAt = "Unknown"
+
+	assert.ElementsMatch(t, expectedUnits, units)
+}
+
+func TestFormatElapsed(t *testing.T) {
+	assert.Equal(t, "00:08:17", formatElapsed(497))
+	assert.Equal(t, "2-01:00:01", formatElapsed(2*86400+3601))
+}
+
+func TestSLURMFetcherREST(t *testing.T) {
+	server := mockSlurmrestdServer()
+	defer server.Close()
+
+	var extra yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("api_version: v0.0.41"), &extra))
+
+	cluster := models.Cluster{
+		ID:      "slurm-0",
+		Manager: "slurm",
+		Web:     models.WebConfig{URL: server.URL},
+		Extra:   extra,
+	}
+
+	// Configure authentication headers
+	require.NoError(t, yaml.Unmarshal([]byte(`
+url: `+server.URL+`
+http_headers:
+  X-SLURM-USER-NAME:
+    values: [slurm]
+  X-SLURM-USER-TOKEN:
+    secrets: [secret]`), &cluster.Web))
+
+	slurm, err := New(cluster, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	units, err := slurm.FetchUnits(context.Background(), start, end)
+	require.NoError(t, err)
+	require.Len(t, units[0].Units, 2)
+
+	users, projects, err := slurm.FetchUsersProjects(context.Background(), current)
+	require.NoError(t, err)
+	require.Len(t, users[0].Users, len(expectedUsers))
+	require.Len(t, projects[0].Projects, len(expectedProjects))
+
+	for i, user := range expectedUsers {
+		assert.Equal(t, user.Name, users[0].Users[i].Name)
+		assert.Equal(t, user.Roles, users[0].Users[i].Roles)
+	}
+
+	for i, project := range expectedProjects {
+		assert.Equal(t, project.Name, projects[0].Projects[i].Name)
+		assert.Equal(t, project.Roles, projects[0].Projects[i].Roles)
+	}
+
+	// Requests without token must fail
+	cluster.Web.HTTPClientConfig.HTTPHeaders = nil
+
+	slurm, err = New(cluster, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	_, err = slurm.FetchUnits(context.Background(), start, end)
+	require.Error(t, err)
+}
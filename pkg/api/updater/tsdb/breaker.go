@@ -0,0 +1,132 @@
+package tsdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Default circuit breaker settings.
+const (
+	defaultFailureThreshold = 3
+	defaultCooldown         = 30 * time.Minute
+)
+
+// breakerState is the state of circuit breaker.
+type breakerState int
+
+// Circuit breaker states.
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// String implements fmt.Stringer interface.
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// breakerStateGauge exports the state of circuit breaker of each TSDB backend.
+var breakerStateGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "ceems_api",
+		Subsystem: "tsdb_updater",
+		Name:      "circuit_breaker_state",
+		Help:      "State of circuit breaker of TSDB backend. 0: closed, 1: open, 2: half open",
+	},
+	[]string{"id", "backend"},
+)
+
+// circuitBreaker stops making requests to an unreachable TSDB backend after
+// threshold consecutive failures. Once cooldown has elapsed, a single request
+// is allowed to probe if backend has recovered.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	state     breakerState
+	gauge     prometheus.Gauge
+	mu        sync.Mutex
+}
+
+// newCircuitBreaker returns a new instance of circuitBreaker. A non-positive
+// threshold disables the circuit breaker.
+func newCircuitBreaker(threshold int, cooldown time.Duration, gauge prometheus.Gauge) *circuitBreaker {
+	gauge.Set(float64(breakerClosed))
+
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		gauge:     gauge,
+	}
+}
+
+// allow returns true if request to backend can be made.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != breakerOpen {
+		return true
+	}
+
+	// Probe backend once cooldown has elapsed
+	if time.Since(b.openedAt) >= b.cooldown {
+		b.setState(breakerHalfOpen)
+
+		return true
+	}
+
+	return false
+}
+
+// open returns true if breaker is open.
+func (b *circuitBreaker) open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state == breakerOpen
+}
+
+// success records a successful request and closes the breaker.
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.setState(breakerClosed)
+}
+
+// failure records a failed request. Breaker is opened when number of consecutive
+// failures reaches threshold or when probe request fails.
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	if b.threshold <= 0 {
+		return
+	}
+
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = time.Now()
+		b.setState(breakerOpen)
+	}
+}
+
+// setState sets the state of breaker. Caller must hold the lock.
+func (b *circuitBreaker) setState(state breakerState) {
+	b.state = state
+	b.gauge.Set(float64(state))
+}
@@ -0,0 +1,47 @@
+package tsdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := newCircuitBreaker(2, time.Hour, prometheus.NewGauge(prometheus.GaugeOpts{Name: "state"}))
+
+	// Breaker must be opened only after threshold failures
+	breaker.failure()
+	assert.True(t, breaker.allow())
+	breaker.failure()
+	assert.False(t, breaker.allow())
+	assert.True(t, breaker.open())
+	assert.Equal(t, breakerOpen, breaker.state)
+
+	// After cool down, breaker must allow a probe request
+	breaker.openedAt = time.Now().Add(-2 * time.Hour)
+	assert.True(t, breaker.allow())
+	assert.Equal(t, breakerHalfOpen, breaker.state)
+
+	// Failed probe must open breaker again
+	breaker.failure()
+	assert.False(t, breaker.allow())
+
+	// Successful probe must close breaker
+	breaker.openedAt = time.Now().Add(-2 * time.Hour)
+	assert.True(t, breaker.allow())
+	breaker.success()
+	assert.False(t, breaker.open())
+	assert.Equal(t, breakerClosed, breaker.state)
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	breaker := newCircuitBreaker(0, time.Hour, prometheus.NewGauge(prometheus.GaugeOpts{Name: "state"}))
+
+	for range 10 {
+		breaker.failure()
+	}
+
+	assert.True(t, breaker.allow())
+}
@@ -3,15 +3,19 @@ package tsdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"html/template"
 	"log/slog"
 	"maps"
 	"math"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/mahendrapaipuri/ceems/pkg/api/base"
 	"github.com/mahendrapaipuri/ceems/pkg/api/helper"
 	"github.com/mahendrapaipuri/ceems/pkg/api/models"
 	"github.com/mahendrapaipuri/ceems/pkg/api/updater"
@@ -29,17 +33,122 @@ const (
 	defaultQueryMaxSeries = 50
 )
 
+// backendConfig is the configuration of an additional TSDB backend.
+type backendConfig struct {
+	Web        models.WebConfig `yaml:"web"`
+	ClusterIDs []string         `yaml:"cluster_ids"`
+}
+
+// circuitBreakerConfig is the configuration of circuit breaker of TSDB backends.
+type circuitBreakerConfig struct {
+	FailureThreshold int            `yaml:"failure_threshold"`
+	Cooldown         model.Duration `yaml:"cooldown"`
+}
+
 // config is the container for the configuration of a given TSDB instance.
 type tsdbConfig struct {
 	QueryMaxSeries int                          `yaml:"query_max_series"`
 	CutoffDuration model.Duration               `yaml:"cutoff_duration"`
 	Queries        map[string]map[string]string `yaml:"queries"`
+	QueryWindows   map[string]model.Duration    `yaml:"query_windows"`
 	LabelsToDrop   []string                     `yaml:"labels_to_drop"`
+	Backends       []backendConfig              `yaml:"backends"`
+	CircuitBreaker circuitBreakerConfig         `yaml:"circuit_breaker"`
+	Compression    string                       `yaml:"compression"`
 }
 
-// Embed TSDB struct into our TSDBUpdater struct.
+// validate checks that query windows are configured only for known average
+// metrics and all query templates can be rendered. When TSDB is reachable, the syntax
+// of rendered queries is validated as well.
+func (c *tsdbConfig) validate(ctx context.Context, t *tsdb.TSDB, logger *slog.Logger) error {
+	for metricName, window := range c.QueryWindows {
+		if _, ok := c.Queries[metricName]; !ok {
+			return fmt.Errorf("query window configured for unknown metric %s", metricName)
+		}
+
+		// Total metrics are summed over update intervals and hence a window
+		// different from update interval will over count them
+		if !strings.HasPrefix(metricName, "avg_") {
+			return fmt.Errorf("query window can only be configured for avg_* metrics, found %s", metricName)
+		}
+
+		if window <= 0 {
+			return fmt.Errorf("query window of metric %s must be positive", metricName)
+		}
+	}
+
+	// Sample template data to render queries
+	tmplData := map[string]interface{}{
+		"UUIDs":                   "1|2",
+		"ScrapeInterval":          defaultSettings.ScrapeInterval,
+		"ScrapeIntervalMilli":     defaultSettings.ScrapeInterval.Milliseconds(),
+		"EvaluationInterval":      defaultSettings.EvaluationInterval,
+		"EvaluationIntervalMilli": defaultSettings.EvaluationInterval.Milliseconds(),
+		"RateInterval":            defaultSettings.RateInterval,
+		"Range":                   15 * time.Minute,
+	}
+
+	// Check TSDB only once to validate syntax of queries
+	checkSyntax := t.Available() && t.Ping() == nil
+
+	for metricName, queries := range c.Queries {
+		for subMetricName, queryTemplate := range queries {
+			name := fmt.Sprintf("%s_%s", metricName, subMetricName)
+
+			tmpl, err := template.New(name).Parse(queryTemplate)
+			if err != nil {
+				return fmt.Errorf("invalid query template for metric %s: %w", name, err)
+			}
+
+			builder := &strings.Builder{}
+			if err := tmpl.Execute(builder, tmplData); err != nil {
+				return fmt.Errorf("failed to render query template for metric %s: %w", name, err)
+			}
+
+			if !checkSyntax {
+				continue
+			}
+
+			if _, err := t.FormatQuery(ctx, builder.String()); err != nil {
+				if errors.Is(err, tsdb.ErrBadQuery) {
+					return fmt.Errorf("invalid query for metric %s: %w", name, err)
+				}
+
+				// Older TSDB versions might not support formatting queries
+				logger.Warn("Skipping syntax validation of queries", "err", err)
+
+				checkSyntax = false
+			}
+		}
+	}
+
+	return nil
+}
+
+// backend is a TSDB client that serves units of clusters in clusterIDs. If
+// clusterIDs is empty, backend serves units of all clusters.
+type backend struct {
+	clusterIDs []string
+	breaker    *circuitBreaker
+	*tsdb.TSDB
+}
+
+// name returns the host of backend.
+func (b *backend) name() string {
+	return b.URL.Host
+}
+
+// serves returns true if backend serves units of cluster clusterID.
+func (b *backend) serves(clusterID string) bool {
+	return len(b.clusterIDs) == 0 || slices.Contains(b.clusterIDs, clusterID)
+}
+
+// Embed TSDB struct into our TSDBUpdater struct. TSDB embedded is the primary
+// backend configured in web section of the updater and backends include the
+// primary and all additional backends.
 type tsdbUpdater struct {
-	config *tsdbConfig
+	config   *tsdbConfig
+	backends []*backend
 	*tsdb.TSDB
 }
 
@@ -48,11 +157,30 @@ var (
 	metricLock = sync.RWMutex{}
 )
 
+// Default TSDB settings used to render query templates during validation.
+var defaultSettings = tsdb.Settings{
+	ScrapeInterval:     time.Minute,
+	EvaluationInterval: time.Minute,
+	RateInterval:       4 * time.Minute,
+}
+
+// available returns true if at least one backend that serves cluster is available.
+func (t *tsdbUpdater) available(clusterID string) bool {
+	for _, backend := range t.backends {
+		if backend.Available() && backend.serves(clusterID) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Register TSDB updater
 // tsdb will estimate time averaged metrics and update units struct
 // It will also remove ignored units time series.
 func init() {
 	updater.Register(tsdbUpdaterID, New)
+	updater.RegisterCollector(breakerStateGauge)
 }
 
 // New create a new TSDB updater.
@@ -60,6 +188,10 @@ func New(instance updater.Instance, logger *slog.Logger) (updater.Updater, error
 	// Make TSDB config from instances extra config
 	config := tsdbConfig{
 		QueryMaxSeries: defaultQueryMaxSeries,
+		CircuitBreaker: circuitBreakerConfig{
+			FailureThreshold: defaultFailureThreshold,
+			Cooldown:         model.Duration(defaultCooldown),
+		},
 	}
 	if err := instance.Extra.Decode(&config); err != nil {
 		logger.Error("Failed to setup TSDB updater", "id", instance.ID, "err", err)
@@ -68,7 +200,7 @@ func New(instance updater.Instance, logger *slog.Logger) (updater.Updater, error
 	}
 
 	// Create instances of TSDB
-	tsdb, err := tsdb.New(
+	client, err := tsdb.New(
 		instance.Web.URL,
 		instance.Web.HTTPClientConfig,
 		logger.With("id", instance.ID),
@@ -79,11 +211,65 @@ func New(instance updater.Instance, logger *slog.Logger) (updater.Updater, error
 		return nil, err
 	}
 
-	logger.Info("TSDB updater setup successful", "id", instance.ID)
+	if err := client.SetCompression(config.Compression); err != nil {
+		logger.Error("Failed to setup TSDB updater", "instance_id", instance.ID, "err", err)
+
+		return nil, err
+	}
+
+	// Validate queries
+	if err := config.validate(context.Background(), client, logger); err != nil {
+		logger.Error("Invalid queries in TSDB updater config", "id", instance.ID, "err", err)
+
+		return nil, err
+	}
+
+	// Primary backend serves units of all clusters
+	backends := []*backend{{TSDB: client}}
+
+	// Setup additional backends
+	for i, backendConfig := range config.Backends {
+		backendConfig.Web.SetDirectory(filepath.Dir(base.ConfigFilePath))
+
+		backendClient, err := tsdb.New(
+			backendConfig.Web.URL,
+			backendConfig.Web.HTTPClientConfig,
+			logger.With("id", instance.ID, "backend", i),
+		)
+		if err != nil {
+			logger.Error("Failed to setup TSDB backend", "id", instance.ID, "backend", i, "err", err)
+
+			return nil, err
+		}
+
+		if err := backendClient.SetCompression(config.Compression); err != nil {
+			logger.Error("Failed to setup TSDB backend", "id", instance.ID, "backend", i, "err", err)
+
+			return nil, err
+		}
+
+		backends = append(backends, &backend{clusterIDs: backendConfig.ClusterIDs, TSDB: backendClient})
+	}
+
+	// Setup circuit breakers of available backends
+	for _, backend := range backends {
+		if !backend.Available() {
+			continue
+		}
+
+		backend.breaker = newCircuitBreaker(
+			config.CircuitBreaker.FailureThreshold,
+			time.Duration(config.CircuitBreaker.Cooldown),
+			breakerStateGauge.WithLabelValues(instance.ID, backend.name()),
+		)
+	}
+
+	logger.Info("TSDB updater setup successful", "id", instance.ID, "num_backends", len(backends))
 
 	return &tsdbUpdater{
 		&config,
-		tsdb,
+		backends,
+		client,
 	}, nil
 }
 
@@ -95,7 +281,7 @@ func (t *tsdbUpdater) Update(
 	units []models.ClusterUnits,
 ) []models.ClusterUnits {
 	for _, clusterUnit := range units {
-		clusterUnit.Units = t.update(ctx, startTime, endTime, clusterUnit.Units)
+		clusterUnit.Units = t.update(ctx, startTime, endTime, clusterUnit.Cluster.ID, clusterUnit.Units)
 	}
 
 	return units
@@ -113,19 +299,21 @@ func (t *tsdbUpdater) queryBuilder(name string, queryTemplate string, data map[s
 	return builder.String(), nil
 }
 
-// Get time averaged value of each metric identified by label uuid.
+// Get time averaged value of each metric identified by label uuid from backend.
+// Returned error joins errors of all failed queries.
 func (t *tsdbUpdater) fetchAggMetrics(
 	ctx context.Context,
+	backend *backend,
 	queryTime time.Time,
 	duration time.Duration,
 	uuids []string,
 	settings *tsdb.Settings,
-) map[string]map[string]tsdb.Metric {
+) (map[string]map[string]tsdb.Metric, error) {
 	aggMetrics := make(map[string]map[string]tsdb.Metric, len(t.config.Queries))
 
 	// If duration is less than rateInterval bail
 	if duration < settings.RateInterval {
-		return aggMetrics
+		return aggMetrics, nil
 	}
 
 	// UPDATE 20250110: Not necessary anymore as we estimate the batch size dynamically
@@ -137,6 +325,9 @@ func (t *tsdbUpdater) fetchAggMetrics(
 	// 	rateInterval = 2 * rateInterval
 	// }
 
+	// Errors of failed queries
+	var errs error
+
 	// Start a wait group
 	var wg sync.WaitGroup
 	for _, queries := range t.config.Queries {
@@ -156,6 +347,13 @@ func (t *tsdbUpdater) fetchAggMetrics(
 
 	// Loop over t.config.queries map and make queries
 	for metricName, queries := range t.config.Queries {
+		// If a query window is configured for average metric, use it as range
+		metricTmplData := tmplData
+		if window, ok := t.config.QueryWindows[metricName]; ok && strings.HasPrefix(metricName, "avg_") {
+			metricTmplData = maps.Clone(tmplData)
+			metricTmplData["Range"] = time.Duration(window)
+		}
+
 		for subMetricName, query := range queries {
 			go func(n string, sn string, q string) {
 				defer wg.Done()
@@ -164,7 +362,7 @@ func (t *tsdbUpdater) fetchAggMetrics(
 
 				var err error
 
-				tsdbQuery, err := t.queryBuilder(fmt.Sprintf("%s_%s", n, sn), q, tmplData)
+				tsdbQuery, err := t.queryBuilder(fmt.Sprintf("%s_%s", n, sn), q, metricTmplData)
 				if err != nil {
 					t.Logger.Error(
 						"Failed to build query from template", "metric", n,
@@ -174,12 +372,16 @@ func (t *tsdbUpdater) fetchAggMetrics(
 					return
 				}
 
-				if aggMetric, err = t.Query(ctx, tsdbQuery, queryTime); err != nil {
-					t.Logger.Error(
+				if aggMetric, err = backend.Query(ctx, tsdbQuery, queryTime); err != nil {
+					backend.Logger.Error(
 						"Failed to fetch metrics from TSDB", "metric", n, "duration",
 						duration, "scrape_int", settings.ScrapeInterval,
 						"rate_int", settings.RateInterval, "err", err,
 					)
+
+					metricLock.Lock()
+					errs = errors.Join(errs, fmt.Errorf("metric %s_%s: %w", n, sn, err))
+					metricLock.Unlock()
 				} else {
 					metricLock.Lock()
 					if aggMetrics[n] == nil {
@@ -196,7 +398,57 @@ func (t *tsdbUpdater) fetchAggMetrics(
 	// Wait for all go routines
 	wg.Wait()
 
-	return aggMetrics
+	return aggMetrics, errs
+}
+
+// fetchMetrics fetches aggregate metrics of units from backend in batches.
+func (t *tsdbUpdater) fetchMetrics(
+	ctx context.Context,
+	backend *backend,
+	endTime time.Time,
+	duration time.Duration,
+	uuids []string,
+) (map[string]map[string]tsdb.Metric, error) {
+	// Get current TSDB settings
+	// Get rate and scrape intervals
+	settings := backend.Settings(ctx)
+
+	// Estimate a batch size based on scrape interval, duration, query max samples and total time series
+	samplesPerSeries := max(uint64(duration.Seconds()/settings.ScrapeInterval.Seconds()), 1)
+	maxLabels := settings.QueryMaxSamples / (uint64(t.config.QueryMaxSeries) * samplesPerSeries)
+	batchSize := min(max(int(0.8*float64(maxLabels)), 10), len(uuids)) // Just to ensure we ALWAYS stay in limit
+
+	// Batch UUIDs into slices of 1000 so that we make TSDB requests for each 1000 units
+	// This is to safeguard against OOM errors due to a very large number of units
+	// that can spread across big time interval
+	uuidBatches := helper.ChunkBy(uuids, batchSize)
+	numBatches := len(uuidBatches)
+
+	aggMetrics := make(map[string]map[string]tsdb.Metric)
+
+	var errs error
+
+	// Loop over each chunk
+	for iBatch, batchUUIDs := range uuidBatches {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			// Get aggregate metrics of present chunk
+			batchedAggMetrics, err := t.fetchAggMetrics(ctx, backend, endTime, duration, batchUUIDs, settings)
+			if err != nil {
+				errs = errors.Join(errs, err)
+			}
+
+			mergeMetrics(aggMetrics, batchedAggMetrics)
+
+			backend.Logger.Debug(
+				"progress", "batch_id", iBatch, "total_batches", numBatches, "batch_size", batchSize,
+			)
+		}
+	}
+
+	return aggMetrics, errs
 }
 
 // Fetch unit metrics from TSDB and update UnitStat struct for each unit.
@@ -204,10 +456,11 @@ func (t *tsdbUpdater) update(
 	ctx context.Context,
 	startTime time.Time,
 	endTime time.Time,
+	clusterID string,
 	units []models.Unit,
 ) []models.Unit {
-	// Bail if TSDB is unavailable or there are no units to update
-	if !t.Available() || len(units) == 0 {
+	// Bail if none of TSDB backends of cluster are available or there are no units to update
+	if !t.available(clusterID) || len(units) == 0 {
 		return units
 	}
 
@@ -245,7 +498,7 @@ func (t *tsdbUpdater) update(
 		if units[i].EndedAtTS > 0 {
 			if units[i].EndedAtTS-units[i].StartedAtTS < time.Duration(t.config.CutoffDuration).Milliseconds() {
 				ignoredUnits = append(ignoredUnits, uuid)
-				units[i].Ignore = 1
+				units[i].Ignore = models.UnitIgnoredByUpdater
 			}
 		}
 
@@ -253,57 +506,61 @@ func (t *tsdbUpdater) update(
 		j++
 	}
 
-	// Get current TSDB settings
-	// Get rate and scrape intervals
-	settings := t.Settings(ctx)
+	uuids := allUnitUUIDs[:j]
 
-	// Estimate a batch size based on scrape interval, duration, query max samples and total time series
-	samplesPerSeries := max(uint64(duration.Seconds()/settings.ScrapeInterval.Seconds()), 1)
-	maxLabels := settings.QueryMaxSamples / (uint64(t.config.QueryMaxSeries) * samplesPerSeries)
-	batchSize := min(max(int(0.8*float64(maxLabels)), 10), len(allUnitUUIDs[:j])) // Just to ensure we ALWAYS stay in limit
+	aggMetrics := make(map[string]map[string]tsdb.Metric)
 
-	// Batch UUIDs into slices of 1000 so that we make TSDB requests for each 1000 units
-	// This is to safeguard against OOM errors due to a very large number of units
-	// that can spread across big time interval
-	uuidBatches := helper.ChunkBy(allUnitUUIDs[:j], batchSize)
-	numBatches := len(uuidBatches)
+	// Hosts of backends where one or more queries failed
+	var failedBackends []string
 
-	aggMetrics := make(map[string]map[string]tsdb.Metric)
+	// Query each backend that serves the cluster and merge results. Metrics are
+	// keyed by UUID and hence, when units are sharded across backends, merging
+	// results gives metrics of all units.
+	for _, backend := range t.backends {
+		if !backend.Available() || !backend.serves(clusterID) {
+			continue
+		}
 
-	// Loop over each chunk
-	for iBatch, batchUUIDs := range uuidBatches {
-		select {
-		case <-ctx.Done():
+		// When circuit breaker is open, skip backend until cooldown period has
+		// elapsed to avoid delaying updates of units
+		if !backend.breaker.allow() {
+			backend.Logger.Debug("Circuit breaker open. Skipping TSDB backend", "cluster_id", clusterID)
+
+			failedBackends = append(failedBackends, backend.name())
+
+			continue
+		}
+
+		if err := backend.Ping(); err != nil {
+			backend.Logger.Error("TSDB backend unreachable", "cluster_id", clusterID, "err", err)
+			backend.breaker.failure()
+
+			failedBackends = append(failedBackends, backend.name())
+
+			continue
+		}
+
+		metrics, err := t.fetchMetrics(ctx, backend, endTime, duration, uuids)
+		if ctx.Err() != nil {
 			t.Logger.Error("Aborting units update", "err", ctx.Err())
 
 			return units
-		default:
-			// Get aggregate metrics of present chunk
-			batchedAggMetrics := t.fetchAggMetrics(ctx, endTime, duration, batchUUIDs, settings)
-
-			// Merge metrics map of each metric type. Metric map has uuid as key and hence
-			// merging is safe as UUID is "unique" during the given update interval
-			for metricName, metrics := range batchedAggMetrics {
-				// If inner map has not been initialized yet, do it
-				// These are parent metrics like avg_cpu_usage, avg_gpu_usage
-				if aggMetrics[metricName] == nil {
-					aggMetrics[metricName] = make(map[string]tsdb.Metric, len(metrics))
-				}
-				// Each parent metric has sub metrics that operator chooses and we loop
-				// over them here
-				for subMetricName, subMetrics := range metrics {
-					if aggMetrics[metricName][subMetricName] == nil {
-						aggMetrics[metricName][subMetricName] = make(tsdb.Metric, len(subMetrics))
-					}
+		}
 
-					maps.Copy(aggMetrics[metricName][subMetricName], subMetrics)
-				}
-			}
+		if err != nil {
+			backend.Logger.Warn("Failed to fetch some metrics from TSDB backend", "cluster_id", clusterID, "err", err)
 
-			t.Logger.Debug(
-				"progress", "batch_id", iBatch, "total_batches", numBatches, "batch_size", batchSize,
-			)
+			failedBackends = append(failedBackends, backend.name())
 		}
+
+		// Consider backend as failed only when none of the queries succeeded
+		if err != nil && len(metrics) == 0 {
+			backend.breaker.failure()
+		} else {
+			backend.breaker.success()
+		}
+
+		mergeMetrics(aggMetrics, metrics)
 	}
 
 	// Update all units
@@ -438,19 +695,40 @@ func (t *tsdbUpdater) update(
 		}
 	}
 
+	// When queries to backends failed, record a warning on units for which
+	// no metrics were found in any of the backends rather than failing the
+	// whole update. These units are flagged for backfilling metrics later.
+	if len(failedBackends) > 0 {
+		warning := "failed to fetch metrics from TSDB backend(s): " + strings.Join(failedBackends, ",")
+
+		for i := range len(units) {
+			if units[i].UUID == "" || hasMetrics(aggMetrics, units[i].UUID) {
+				continue
+			}
+
+			if units[i].UpdaterTags == nil {
+				units[i].UpdaterTags = make(models.Tag)
+			}
+
+			units[i].UpdaterTags[updater.WarningTagKey] = warning
+			units[i].UpdaterTags[updater.BackfillTagKey] = updater.BackfillPending
+		}
+	}
+
 	// Finally delete time series
-	if err := t.deleteTimeSeries(ctx, startTime, endTime, ignoredUnits); err != nil {
+	if err := t.deleteTimeSeries(ctx, startTime, endTime, clusterID, ignoredUnits); err != nil {
 		t.Logger.Error("Failed to delete time series in TSDB", "err", err)
 	}
 
 	return units
 }
 
-// Delete time series data of ignored units.
+// Delete time series data of ignored units from all backends that serve cluster.
 func (t *tsdbUpdater) deleteTimeSeries(
 	ctx context.Context,
 	startTime time.Time,
 	endTime time.Time,
+	clusterID string,
 	unitUUIDs []string,
 ) error {
 	// Check if there are any units to ignore. If there aren't return immediately
@@ -490,7 +768,58 @@ func (t *tsdbUpdater) deleteTimeSeries(
 	matchers = append(matchers, fmt.Sprintf("{uuid=~\"%s\"}", allUUIDs))
 
 	// Make a API request to delete data of ignored units
-	return t.Delete(ctx, start, end, matchers)
+	var errs error
+
+	for _, backend := range t.backends {
+		if !backend.Available() || !backend.serves(clusterID) || backend.breaker.open() {
+			continue
+		}
+
+		if err := backend.Delete(ctx, start, end, matchers); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// mergeMetrics merges metrics from src into dst. Metric maps have uuid as key
+// and hence merging is safe as UUID is "unique" during the given update interval.
+// If a unit is found in multiple sources, first found value is retained.
+func mergeMetrics(dst, src map[string]map[string]tsdb.Metric) {
+	for metricName, metrics := range src {
+		// If inner map has not been initialized yet, do it
+		// These are parent metrics like avg_cpu_usage, avg_gpu_usage
+		if dst[metricName] == nil {
+			dst[metricName] = make(map[string]tsdb.Metric, len(metrics))
+		}
+		// Each parent metric has sub metrics that operator chooses and we loop
+		// over them here
+		for subMetricName, subMetrics := range metrics {
+			if dst[metricName][subMetricName] == nil {
+				dst[metricName][subMetricName] = make(tsdb.Metric, len(subMetrics))
+			}
+
+			for uuid, value := range subMetrics {
+				if _, ok := dst[metricName][subMetricName][uuid]; !ok {
+					dst[metricName][subMetricName][uuid] = value
+				}
+			}
+		}
+	}
+}
+
+// hasMetrics returns true if any metric of unit uuid is found in metrics.
+func hasMetrics(metrics map[string]map[string]tsdb.Metric, uuid string) bool {
+	for _, subMetrics := range metrics {
+		for _, metric := range subMetrics {
+			if _, ok := metric[uuid]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 // sanitizeValue verifies if value is either NaN/Inf/-Inf.
@@ -8,6 +8,8 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -458,3 +460,228 @@ func TestTSDBUpdateFailNoTSDB(t *testing.T) {
 	updatedUnits := tsdb.Update(context.Background(), time.Now().Add(-5*time.Minute), time.Now(), units)
 	assert.Equal(t, expectedUnits, updatedUnits)
 }
+
+func TestTSDBUpdaterQueryWindows(t *testing.T) {
+	var queries []string
+
+	var queriesLock sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		queriesLock.Lock()
+		queries = append(queries, r.Form.Get("query"))
+		queriesLock.Unlock()
+
+		w.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": []}}`))
+	}))
+	defer server.Close()
+
+	config := `
+---
+queries:
+  avg_cpu_usage:
+    usage: avg_over_time(foo[{{.Range}}])
+  avg_gpu_usage:
+    usage: avg_over_time(bar[{{.Range}}])
+query_windows:
+  avg_gpu_usage: 1h`
+
+	var extraConfig yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(config), &extraConfig))
+
+	tsdb, err := New(
+		updater.Instance{ID: "default", Updater: "tsdb", Web: models.WebConfig{URL: server.URL}, Extra: extraConfig},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	require.NoError(t, err)
+
+	// Reset queries made during validation
+	queries = nil
+
+	tsdbUpdater, ok := tsdb.(*tsdbUpdater)
+	require.True(t, ok)
+
+	_, err = tsdbUpdater.fetchAggMetrics(context.Background(), tsdbUpdater.backends[0], time.Now(), 15*time.Minute, []string{"1"}, &defaultSettings)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"avg_over_time(foo[15m0s])", "avg_over_time(bar[1h0m0s])"}, queries)
+}
+
+func TestTSDBUpdaterInvalidConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		if strings.HasSuffix(r.URL.Path, "format_query") && strings.Contains(r.Form.Get("query"), "((") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status": "error", "errorType": "bad_data", "error": "parse error"}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"status": "success", "data": "foo"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{
+			name: "window of unknown metric",
+			config: `
+queries:
+  avg_cpu_usage:
+    usage: foo
+query_windows:
+  avg_gpu_usage: 1h`,
+		},
+		{
+			name: "window of total metric",
+			config: `
+queries:
+  total_cpu_energy_usage_kwh:
+    total: foo[{{.Range}}]
+query_windows:
+  total_cpu_energy_usage_kwh: 1h`,
+		},
+		{
+			name: "invalid template",
+			config: `
+queries:
+  avg_cpu_usage:
+    usage: foo[{{.Range]`,
+		},
+		{
+			name: "invalid promql",
+			config: `
+queries:
+  avg_cpu_usage:
+    usage: sum((foo)`,
+		},
+	}
+
+	for _, test := range tests {
+		var extraConfig yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(test.config), &extraConfig), test.name)
+
+		_, err := New(
+			updater.Instance{ID: "default", Updater: "tsdb", Web: models.WebConfig{URL: server.URL}, Extra: extraConfig},
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+		require.Error(t, err, test.name)
+	}
+}
+
+func TestTSDBUpdaterMultipleBackends(t *testing.T) {
+	// Primary server returns metrics of units 1 and 2
+	server := mockTSDBServer()
+	defer server.Close()
+
+	// Shard server returns metrics of unit 3
+	shardServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": [{"metric": {"uuid": "3"}, "value": [12345, "3.3"]}]}}`))
+	}))
+	defer shardServer.Close()
+
+	// Failing server serves only slurm-1 cluster
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	config := fmt.Sprintf(`
+---
+queries:
+  avg_cpu_usage:
+    usage: foo
+backends:
+  - web:
+      url: %s
+  - web:
+      url: %s
+    cluster_ids:
+      - slurm-1`, shardServer.URL, failServer.URL)
+
+	var extraConfig yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(config), &extraConfig))
+
+	tsdb, err := New(
+		updater.Instance{ID: "default", Updater: "tsdb", Web: models.WebConfig{URL: server.URL}, Extra: extraConfig},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	require.NoError(t, err)
+
+	currTime := time.Now()
+
+	units := []models.ClusterUnits{
+		{
+			Cluster: models.Cluster{ID: "slurm-0"},
+			Units:   []models.Unit{{UUID: "1"}, {UUID: "2"}, {UUID: "3"}},
+		},
+		{
+			Cluster: models.Cluster{ID: "slurm-1"},
+			Units:   []models.Unit{{UUID: "1"}, {UUID: "4"}},
+		},
+	}
+
+	updatedUnits := tsdb.Update(context.Background(), currTime.Add(-15*time.Minute), currTime, units)
+
+	// Metrics from all backends must be merged
+	for i, value := range []models.JSONFloat{1.1, 2.2, 3.3} {
+		assert.Equal(t, models.MetricMap{"usage": value}, updatedUnits[0].Units[i].AveCPUUsage)
+		assert.Empty(t, updatedUnits[0].Units[i].Tags)
+	}
+
+	// Units without metrics must be flagged when a backend failed
+	assert.Equal(t, models.MetricMap{"usage": 1.1}, updatedUnits[1].Units[0].AveCPUUsage)
+	assert.Empty(t, updatedUnits[1].Units[0].Tags)
+	assert.Empty(t, updatedUnits[1].Units[1].AveCPUUsage)
+	assert.Contains(t, updatedUnits[1].Units[1].UpdaterTags[updater.WarningTagKey], "failed to fetch metrics from TSDB backend(s)")
+}
+
+func TestTSDBUpdaterCircuitBreaker(t *testing.T) {
+	server := mockTSDBServer()
+
+	config := `
+---
+queries:
+  avg_cpu_usage:
+    usage: foo
+circuit_breaker:
+  failure_threshold: 1
+  cooldown: 1h`
+
+	var extraConfig yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(config), &extraConfig))
+
+	tsdb, err := New(
+		updater.Instance{ID: "breaker", Updater: "tsdb", Web: models.WebConfig{URL: server.URL}, Extra: extraConfig},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	require.NoError(t, err)
+
+	// Stop TSDB server
+	server.Close()
+
+	currTime := time.Now()
+
+	for range 2 {
+		units := []models.ClusterUnits{
+			{
+				Cluster: models.Cluster{ID: "slurm-0"},
+				Units:   []models.Unit{{UUID: "1"}},
+			},
+		}
+
+		updatedUnits := tsdb.Update(context.Background(), currTime.Add(-15*time.Minute), currTime, units)
+
+		// Units must be flagged for backfill
+		assert.Empty(t, updatedUnits[0].Units[0].AveCPUUsage)
+		assert.Equal(t, updater.BackfillPending, updatedUnits[0].Units[0].UpdaterTags[updater.BackfillTagKey])
+	}
+
+	// Breaker must be open after first failure
+	tsdbUpdater, ok := tsdb.(*tsdbUpdater)
+	require.True(t, ok)
+	assert.True(t, tsdbUpdater.backends[0].breaker.open())
+}
@@ -17,9 +17,24 @@ import (
 	"github.com/mahendrapaipuri/ceems/internal/common"
 	"github.com/mahendrapaipuri/ceems/pkg/api/base"
 	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	"github.com/mahendrapaipuri/ceems/pkg/tsdb"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/yaml.v3"
 )
 
+// Name of TSDB updater.
+const tsdbUpdaterName = "tsdb"
+
+// Updater tag keys and values set on units by updaters. Units whose metrics could
+// not be fetched are tagged with a warning and flagged for backfilling metrics later.
+const (
+	WarningTagKey   = "tsdb_warning"
+	BackfillTagKey  = "tsdb_backfill"
+	BackfillPending = "pending"
+	BackfillDone    = "done"
+	BackfillNoData  = "no_data"
+)
+
 // Custom errors.
 var (
 	ErrDuplID         = errors.New("duplicate ID found in updaters config")
@@ -62,6 +77,22 @@ var (
 	updaterFactories = make(map[string]func(instance Instance, logger *slog.Logger) (Updater, error))
 )
 
+// Collectors of updaters' metrics.
+var (
+	collectors []prometheus.Collector
+)
+
+// RegisterCollector registers a collector of updater metrics which will be
+// exported by API server.
+func RegisterCollector(collector prometheus.Collector) {
+	collectors = append(collectors, collector)
+}
+
+// Collectors returns collectors of all registered updaters.
+func Collectors() []prometheus.Collector {
+	return collectors
+}
+
 // Register registers updater struct into factories.
 func Register(
 	name string,
@@ -114,6 +145,58 @@ func updaterConfig() (*Config[Instance], error) {
 	return config, nil
 }
 
+// clustersConfig contains the configuration of clusters and updaters.
+type clustersConfig struct {
+	Clusters  []models.Cluster `yaml:"clusters"`
+	Instances []Instance       `yaml:"updaters"`
+}
+
+// TSDBs returns TSDB clients of clusters keyed by cluster ID. TSDB of a cluster
+// is the one configured in the first TSDB updater of the cluster. Clusters without
+// any TSDB updater are not included in the returned map.
+func TSDBs(logger *slog.Logger) (map[string]*tsdb.TSDB, error) {
+	config, err := common.MakeConfig[clustersConfig](base.ConfigFilePath)
+	if err != nil {
+		logger.Error("Failed to parse clusters config", "err", err)
+
+		return nil, err
+	}
+
+	// Get TSDB updater instances
+	instances := make(map[string]Instance)
+
+	for _, instance := range config.Instances {
+		if instance.Updater == tsdbUpdaterName {
+			instance.Web.HTTPClientConfig.SetDirectory(filepath.Dir(base.ConfigFilePath))
+			instances[instance.ID] = instance
+		}
+	}
+
+	tsdbs := make(map[string]*tsdb.TSDB)
+
+	for _, cluster := range config.Clusters {
+		for _, id := range cluster.Updaters {
+			instance, ok := instances[id]
+			if !ok {
+				continue
+			}
+
+			client, err := tsdb.New(instance.Web.URL, instance.Web.HTTPClientConfig, logger.With("id", instance.ID))
+			if err != nil {
+				logger.Error("Failed to setup TSDB client", "cluster_id", cluster.ID, "id", instance.ID, "err", err)
+
+				return nil, err
+			}
+
+			tsdbs[cluster.ID] = client
+
+			break
+		}
+	}
+
+	return tsdbs, nil
+}
+
 // New creates a new UnitUpdater.
 func New(logger *slog.Logger) (*UnitUpdater, error) {
 	var updater Updater
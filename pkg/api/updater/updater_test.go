@@ -2,6 +2,8 @@ package updater
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"testing"
@@ -50,6 +52,32 @@ updaters:
       queries:
         avg_cpu_usage: foo
         avg_cpu_mem_usage: foo`
+	case "clusters":
+		configFileTmpl = `
+---
+clusters:
+  - id: slurm-0
+    manager: slurm
+    updaters:
+      - default-0
+      - default-1
+  - id: slurm-1
+    manager: slurm
+    updaters:
+      - default-1
+  - id: slurm-2
+    manager: slurm
+updaters:
+  - id: default-0
+    updater: tsdb
+    web:
+      url: %[1]s
+    extra_config:
+      cutoff_duration: %[2]s
+  - id: default-1
+    updater: tsdb
+    web:
+      url: %[1]s/other`
 	case "malformed_1":
 		// Missing s in tsbd_instances
 		configFileTmpl = `
@@ -162,3 +190,17 @@ func TestOneInstanceConfig(t *testing.T) {
 	_, err = checkConfig([]string{"tsdb"}, cfg)
 	assert.NoError(t, err)
 }
+
+func TestTSDBs(t *testing.T) {
+	// Make mock config
+	base.ConfigFilePath = mockConfig(t.TempDir(), "clusters", "http://localhost:9090")
+
+	tsdbs, err := TSDBs(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.Len(t, tsdbs, 2)
+
+	// First TSDB updater of cluster must be used
+	assert.Equal(t, "http://localhost:9090", tsdbs["slurm-0"].URL.String())
+	assert.Equal(t, "http://localhost:9090/other", tsdbs["slurm-1"].URL.String())
+	assert.NotContains(t, tsdbs, "slurm-2")
+}
@@ -12,9 +12,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/containerd/cgroups/v3"
 	"github.com/containerd/cgroups/v3/cgroup1"
@@ -30,6 +33,8 @@ const (
 	// For further documentation check BPF part.
 	cgroupSubSysCount = 15
 	genericSubsystem  = "compute"
+	// Number of clock ticks per second of process times in procfs.
+	userHZ = 100
 )
 
 // Resource Managers.
@@ -38,6 +43,14 @@ const (
 	libvirt = "libvirt"
 )
 
+// Reasons of cgroup discovery errors.
+const (
+	discoveryErrWalk        = "walk_error"
+	discoveryErrInvalidPath = "invalid_path"
+	discoveryErrEmptyID     = "empty_id"
+	discoveryErrRegexMiss   = "regex_miss"
+)
+
 // Block IO Op names.
 const (
 	readOp  = "Read"
@@ -55,6 +68,7 @@ const (
 */
 var (
 	slurmCgroupPathRegex  = regexp.MustCompile("^.*/slurm(?:.*?)/job_([0-9]+)(?:.*$)")
+	slurmCustomPathRegex  = regexp.MustCompile("^.*/job_([0-9]+)(?:.*$)")
 	slurmIgnoreProcsRegex = regexp.MustCompile("slurmstepd:(.*)|sleep ([0-9]+)|/bin/bash (.*)/slurm_script")
 )
 
@@ -78,13 +92,29 @@ var (
 		"Active cgroup subsystem for cgroups v1.",
 	).Default("cpuacct").String()
 
+	procsCacheTTL = CEEMSExporterApp.Flag(
+		"collector.cgroups.procs-cache-ttl",
+		"Duration for which processes of cgroups are cached. Ideally set it to the scrape interval. Cache is always invalidated when cgroups appear or disappear. Use 0s to disable cache.",
+	).Default("15s").Duration()
+	slurmCgroupPrefix = CEEMSExporterApp.Flag(
+		"collector.cgroups.slurm-cgroup-prefix",
+		"Path under which SLURM creates job cgroups relative to cgroup root on cgroups v2 (default: system.slice/slurmstepd.scope) or relative to active subsystem on cgroups v1 (default: slurm). Use it only when SLURM uses a non-standard cgroup layout.",
+	).Default("").String()
+	ignoreProcsRegexes = CEEMSExporterApp.Flag(
+		"collector.cgroups.ignore-procs",
+		"Regular expression of command lines of processes that must be ignored in compute units in addition to the built-in ones. Repeat the flag to add multiple expressions.",
+	).Strings()
+
 	// Hidden opts for e2e and unit tests.
 	forceCgroupsVersion = CEEMSExporterApp.Flag(
 		"collector.cgroups.force-version",
 		"Set cgroups version manually. Used only for testing.",
-	).Hidden().Enum("v1", "v2")
+	).Hidden().Enum("v1", "v2", "hybrid")
 )
 
+// extraIgnoreProcs are the compiled regexes of --collector.cgroups.ignore-procs.
+var extraIgnoreProcs atomic.Pointer[[]*regexp.Regexp]
+
 type cgroupPath struct {
 	abs, rel string
 }
@@ -113,6 +143,41 @@ func (c *cgroup) String() string {
 	)
 }
 
+// procsCache caches the processes of cgroups to reduce reads on procfs.
+type procsCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	lastUpdate time.Time
+	paths      []string                 // Paths of cgroups found in last update
+	procs      map[string][]procfs.Proc // Map of cgroup ID to its processes
+}
+
+// discoveryErrors keeps the number of errors during cgroups discovery by reason.
+type discoveryErrors struct {
+	mu     sync.Mutex
+	counts map[string]float64
+}
+
+// inc increments the number of errors of reason.
+func (e *discoveryErrors) inc(reason string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.counts == nil {
+		e.counts = make(map[string]float64)
+	}
+
+	e.counts[reason]++
+}
+
+// get returns the number of errors of reason.
+func (e *discoveryErrors) get(reason string) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.counts[reason]
+}
+
 // cgroupManager is the container that have cgroup information of resource manager.
 type cgroupManager struct {
 	logger           *slog.Logger
@@ -122,11 +187,16 @@ type cgroupManager struct {
 	slice            string            // Slice under which cgroups are managed eg system.slice, machine.slice
 	scope            string            // Scope under which cgroups are managed eg slurmstepd.scope, machine-qemu\x2d1\x2dvm1.scope
 	activeController string            // Active controller for cgroups v1
+	unifiedCtrls     []string          // Controllers bound to unified hierarchy in hybrid mode
 	mountPoint       string            // Path under which resource manager creates cgroups
 	manager          string            // cgroup manager
 	idRegex          *regexp.Regexp    // Regular expression to capture cgroup ID set by resource manager
 	isChild          func(string) bool // Function to identify child cgroup paths. Function must return true if cgroup is a child to root cgroup
+	isNonUnit        func(string) bool // Function to identify cgroups created by resource manager that are not units. Function must return true if cgroup must not be counted as discovery error
 	ignoreProc       func(string) bool // Function to filter processes in cgroup based on cmdline. Function must return true if process must be ignored
+	procsCache       *procsCache       // Cache of processes in cgroups. Caching is disabled when nil
+	watcher          *cgroupWatcher    // Watcher of cgroup creation and removal events. Disabled when nil
+	discoveryErrs    discoveryErrors   // Number of errors during cgroups discovery
 }
 
 // String implements stringer interface of the struct.
@@ -142,6 +212,31 @@ func (c *cgroupManager) String() string {
 	)
 }
 
+// setUnifiedControllers sets the controllers that are bound to unified hierarchy
+// when cgroups are in hybrid mode. Metrics of these controllers are read from
+// cgroups v2 and metrics of the rest of controllers from cgroups v1.
+func (c *cgroupManager) setUnifiedControllers() {
+	if c.mode != cgroups.Hybrid {
+		return
+	}
+
+	// Unified hierarchy is mounted at /sys/fs/cgroup/unified in hybrid mode
+	if data, err := os.ReadFile(filepath.Join(c.root, "unified", "cgroup.controllers")); err == nil {
+		c.unifiedCtrls = strings.Fields(string(data))
+	} else {
+		c.logger.Debug("Failed to read controllers of unified hierarchy", "err", err)
+	}
+
+	for _, ctrl := range []string{"cpu", "cpuset", "memory", "io", "pids", "rdma"} {
+		version := "v1"
+		if slices.Contains(c.unifiedCtrls, ctrl) {
+			version = "v2"
+		}
+
+		c.logger.Info("cgroups in hybrid mode", "controller", ctrl, "version", version)
+	}
+}
+
 // setMountPoint sets mountPoint for thc cgroupManager struct.
 func (c *cgroupManager) setMountPoint() {
 	switch c.manager {
@@ -152,7 +247,7 @@ func (c *cgroupManager) setMountPoint() {
 			c.mountPoint = filepath.Join(c.root, c.slice, c.scope)
 		default:
 			// /sys/fs/cgroup/cpuacct/slurm
-			c.mountPoint = filepath.Join(c.root, c.activeController, c.manager)
+			c.mountPoint = filepath.Join(c.root, c.activeController, c.slice)
 
 			// For cgroups v1 we need to shift root to /sys/fs/cgroup/cpuacct
 			c.root = filepath.Join(c.root, c.activeController)
@@ -178,7 +273,9 @@ func (c *cgroupManager) setMountPoint() {
 func (c *cgroupManager) discover() ([]cgroup, error) {
 	var cgroups []cgroup
 
-	cgroupProcs := make(map[string][]procfs.Proc)
+	var cgroupPaths []string
+
+	cgroupDirs := make(map[string][]string)
 
 	cgroupChildren := make(map[string][]cgroupPath)
 
@@ -198,6 +295,7 @@ func (c *cgroupManager) discover() ([]cgroup, error) {
 		rel, err := filepath.Rel(c.root, p)
 		if err != nil {
 			c.logger.Error("Failed to resolve relative path for cgroup", "path", p, "err", err)
+			c.discoveryErrs.inc(discoveryErrInvalidPath)
 
 			return nil
 		}
@@ -206,6 +304,7 @@ func (c *cgroupManager) discover() ([]cgroup, error) {
 		sanitizedPath, err := unescapeString(p)
 		if err != nil {
 			c.logger.Error("Failed to sanitize cgroup path", "path", p, "err", err)
+			c.discoveryErrs.inc(discoveryErrInvalidPath)
 
 			return nil
 		}
@@ -213,27 +312,28 @@ func (c *cgroupManager) discover() ([]cgroup, error) {
 		// Get cgroup ID which is instance ID
 		cgroupIDMatches := c.idRegex.FindStringSubmatch(sanitizedPath)
 		if len(cgroupIDMatches) <= 1 {
+			// Count only direct children of mount point as deeper cgroups of
+			// unmatched ones are unmatched as well. Cgroups that resource manager
+			// creates for its own purposes are not counted either
+			if filepath.Dir(p) == filepath.Clean(c.mountPoint) && !c.isNonUnit(p) {
+				c.logger.Debug("cgroup path does not match ID regex", "path", p)
+				c.discoveryErrs.inc(discoveryErrRegexMiss)
+			}
+
 			return nil
 		}
 
 		id := strings.TrimSpace(cgroupIDMatches[1])
 		if id == "" {
 			c.logger.Error("Empty cgroup ID", "path", p)
+			c.discoveryErrs.inc(discoveryErrEmptyID)
 
 			return nil
 		}
 
-		// Find procs in this cgroup
-		if data, err := os.ReadFile(filepath.Join(p, "cgroup.procs")); err == nil {
-			scanner := bufio.NewScanner(bytes.NewReader(data))
-			for scanner.Scan() {
-				if pid, err := strconv.ParseInt(scanner.Text(), 10, 0); err == nil {
-					if proc, err := c.fs.Proc(int(pid)); err == nil {
-						cgroupProcs[id] = append(cgroupProcs[id], proc)
-					}
-				}
-			}
-		}
+		// Keep track of cgroup directories to read procs later
+		cgroupPaths = append(cgroupPaths, p)
+		cgroupDirs[id] = append(cgroupDirs[id], p)
 
 		// Ignore child cgroups. We are only interested in root cgroup
 		if c.isChild(p) {
@@ -257,10 +357,14 @@ func (c *cgroupManager) discover() ([]cgroup, error) {
 		return nil
 	}); err != nil {
 		c.logger.Error("Error walking cgroup subsystem", "path", c.mountPoint, "err", err)
+		c.discoveryErrs.inc(discoveryErrWalk)
 
 		return nil, err
 	}
 
+	// Find procs in cgroups
+	cgroupProcs := c.cgroupProcs(cgroupPaths, cgroupDirs)
+
 	// Merge cgroupProcs and cgroupChildren with cgroups slice
 	for icgrp := range cgroups {
 		if procs, ok := cgroupProcs[cgroups[icgrp].id]; ok {
@@ -275,6 +379,56 @@ func (c *cgroupManager) discover() ([]cgroup, error) {
 	return cgroups, nil
 }
 
+// cgroupProcs returns a map of cgroup ID to its processes. When cache is enabled,
+// processes are read from cgroup.procs files only when cache has expired or when
+// cgroups have appeared/disappeared since last update.
+func (c *cgroupManager) cgroupProcs(paths []string, dirs map[string][]string) map[string][]procfs.Proc {
+	if c.procsCache == nil {
+		return c.readCgroupProcs(dirs)
+	}
+
+	c.procsCache.mu.Lock()
+	defer c.procsCache.mu.Unlock()
+
+	if time.Since(c.procsCache.lastUpdate) < c.procsCache.ttl && slices.Equal(paths, c.procsCache.paths) {
+		c.logger.Debug("Using cached processes of cgroups", "num_cgroups", len(c.procsCache.procs))
+
+		return c.procsCache.procs
+	}
+
+	c.procsCache.procs = c.readCgroupProcs(dirs)
+	c.procsCache.paths = paths
+	c.procsCache.lastUpdate = time.Now()
+
+	return c.procsCache.procs
+}
+
+// readCgroupProcs reads cgroup.procs files of all cgroup directories and returns a
+// map of cgroup ID to its processes.
+func (c *cgroupManager) readCgroupProcs(dirs map[string][]string) map[string][]procfs.Proc {
+	cgroupProcs := make(map[string][]procfs.Proc)
+
+	for id, paths := range dirs {
+		for _, p := range paths {
+			data, err := os.ReadFile(filepath.Join(p, "cgroup.procs"))
+			if err != nil {
+				continue
+			}
+
+			scanner := bufio.NewScanner(bytes.NewReader(data))
+			for scanner.Scan() {
+				if pid, err := strconv.ParseInt(scanner.Text(), 10, 0); err == nil {
+					if proc, err := c.fs.Proc(int(pid)); err == nil {
+						cgroupProcs[id] = append(cgroupProcs[id], proc)
+					}
+				}
+			}
+		}
+	}
+
+	return cgroupProcs
+}
+
 // NewCgroupManager returns an instance of cgroupManager based on resource manager.
 func NewCgroupManager(name string, logger *slog.Logger) (*cgroupManager, error) {
 	// Instantiate a new Proc FS
@@ -285,6 +439,14 @@ func NewCgroupManager(name string, logger *slog.Logger) (*cgroupManager, error)
 		return nil, err
 	}
 
+	// Compile additional regexes of processes to ignore
+	ignoreRegexes, err := compileIgnoreProcs(*ignoreProcsRegexes)
+	if err != nil {
+		return nil, err
+	}
+
+	extraIgnoreProcs.Store(&ignoreRegexes)
+
 	var manager *cgroupManager
 
 	switch name {
@@ -300,9 +462,13 @@ func NewCgroupManager(name string, logger *slog.Logger) (*cgroupManager, error)
 			}
 		} else {
 			var mode cgroups.CGMode
-			if *forceCgroupsVersion == "v1" {
+
+			switch *forceCgroupsVersion {
+			case "v1":
 				mode = cgroups.Legacy
-			} else {
+			case "hybrid":
+				mode = cgroups.Hybrid
+			default:
 				mode = cgroups.Mode()
 			}
 
@@ -322,17 +488,51 @@ func NewCgroupManager(name string, logger *slog.Logger) (*cgroupManager, error)
 		// Add path regex
 		manager.idRegex = slurmCgroupPathRegex
 
+		// When a custom prefix is configured, cgroups are created under it
+		// instead of default slice and scope. As the prefix might not contain
+		// slurm in its path, use a regex that only relies on job directories
+		if *slurmCgroupPrefix != "" {
+			manager.slice = strings.Trim(*slurmCgroupPrefix, "/")
+			manager.scope = ""
+			manager.idRegex = slurmCustomPathRegex
+		}
+
 		// Identify child cgroup
 		manager.isChild = func(p string) bool {
 			return strings.Contains(p, "/step_")
 		}
-		manager.ignoreProc = func(p string) bool {
+
+		// SLURM creates system cgroup for slurmstepd processes in cgroups v2
+		// and uid_* cgroups that contain job cgroups in cgroups v1
+		manager.isNonUnit = func(p string) bool {
+			base := filepath.Base(p)
+
+			return base == "system" || strings.HasPrefix(base, "uid_")
+		}
+		manager.ignoreProc = withIgnoreProcs(func(p string) bool {
 			return slurmIgnoreProcsRegex.MatchString(p)
+		})
+
+		// Setup procs cache
+		if *procsCacheTTL > 0 {
+			manager.procsCache = &procsCache{ttl: *procsCacheTTL}
 		}
 
+		// Find controllers of unified hierarchy in hybrid mode
+		manager.setUnifiedControllers()
+
 		// Set mountpoint
 		manager.setMountPoint()
 
+		// Ensure that custom prefix exists
+		if *slurmCgroupPrefix != "" {
+			if _, err := os.Stat(manager.mountPoint); err != nil {
+				return nil, fmt.Errorf("cgroup path %s of SLURM cgroup prefix %s not found: %w", manager.mountPoint, *slurmCgroupPrefix, err)
+			}
+
+			logger.Info("Using custom SLURM cgroup prefix", "prefix", *slurmCgroupPrefix, "mount", manager.mountPoint)
+		}
+
 		return manager, nil
 
 	case libvirt:
@@ -346,9 +546,13 @@ func NewCgroupManager(name string, logger *slog.Logger) (*cgroupManager, error)
 			}
 		} else {
 			var mode cgroups.CGMode
-			if *forceCgroupsVersion == "v1" {
+
+			switch *forceCgroupsVersion {
+			case "v1":
 				mode = cgroups.Legacy
-			} else {
+			case "hybrid":
+				mode = cgroups.Hybrid
+			default:
 				mode = cgroups.Mode()
 			}
 
@@ -374,10 +578,21 @@ func NewCgroupManager(name string, logger *slog.Logger) (*cgroupManager, error)
 		manager.isChild = func(p string) bool {
 			return strings.Contains(p, "/libvirt") || strings.Contains(p, "/emulator") || strings.Contains(p, "/vcpu")
 		}
-		manager.ignoreProc = func(p string) bool {
+		manager.isNonUnit = func(p string) bool {
+			return false
+		}
+		manager.ignoreProc = withIgnoreProcs(func(p string) bool {
 			return false
+		})
+
+		// Setup procs cache
+		if *procsCacheTTL > 0 {
+			manager.procsCache = &procsCache{ttl: *procsCacheTTL}
 		}
 
+		// Find controllers of unified hierarchy in hybrid mode
+		manager.setUnifiedControllers()
+
 		// Set mountpoint
 		manager.setMountPoint()
 
@@ -388,6 +603,82 @@ func NewCgroupManager(name string, logger *slog.Logger) (*cgroupManager, error)
 	}
 }
 
+// compileIgnoreProcs compiles regular expressions of processes to ignore.
+func compileIgnoreProcs(exprs []string) ([]*regexp.Regexp, error) {
+	regexes := make([]*regexp.Regexp, len(exprs))
+
+	for i, expr := range exprs {
+		var err error
+		if regexes[i], err = regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("invalid regular expression %s in --collector.cgroups.ignore-procs: %w", expr, err)
+		}
+	}
+
+	return regexes, nil
+}
+
+// withIgnoreProcs returns a function that returns true when the process must be
+// ignored either by the built-in ignore function or by any of the additional
+// regexes. Additional regexes are looked up on each call so that they can be
+// updated when exporter configuration is reloaded.
+func withIgnoreProcs(ignore func(string) bool) func(string) bool {
+	return func(p string) bool {
+		if ignore(p) {
+			return true
+		}
+
+		if regexes := extraIgnoreProcs.Load(); regexes != nil {
+			for _, regex := range *regexes {
+				if regex.MatchString(p) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+}
+
+// bootTime returns the boot time of the node in seconds since epoch. Zero is
+// returned when it cannot be read.
+func (c *cgroupManager) bootTime() uint64 {
+	stat, err := c.fs.Stat()
+	if err != nil {
+		c.logger.Debug("Failed to read boot time", "err", err)
+
+		return 0
+	}
+
+	return stat.BootTime
+}
+
+// procsStartTime returns the start time of the oldest process among procs in
+// seconds since epoch. Boot time must be read once per scrape by the caller to
+// avoid parsing /proc/stat for each process. Zero is returned when start time
+// cannot be read for any of the processes.
+func procsStartTime(procs []procfs.Proc, bootTime uint64) float64 {
+	if bootTime == 0 {
+		return 0
+	}
+
+	var startTime float64
+
+	for _, proc := range procs {
+		stat, err := proc.Stat()
+		if err != nil {
+			continue
+		}
+
+		t := float64(bootTime) + float64(stat.Starttime)/userHZ
+
+		if startTime == 0 || t < startTime {
+			startTime = t
+		}
+	}
+
+	return startTime
+}
+
 // cgMetric contains metrics returned by cgroup.
 type cgMetric struct {
 	path            string
@@ -396,6 +687,8 @@ type cgMetric struct {
 	cpuTotal        float64
 	cpus            int
 	cpuPressure     float64
+	cpuThrottled    float64
+	cpuThrottledSec float64
 	memoryRSS       float64
 	memoryCache     float64
 	memoryUsed      float64
@@ -412,7 +705,15 @@ type cgMetric struct {
 	blkioPressure   float64
 	rdmaHCAHandles  map[string]float64
 	rdmaHCAObjects  map[string]float64
+	netTxBytes      float64
+	netTxPackets    float64
+	numProcs        int
+	numGPUs         int
+	startTime       float64
+	numTasks        float64
+	maxTasks        float64
 	uuid            string
+	step            string // Name of the step when metrics belong to a step of SLURM job
 	err             bool
 }
 
@@ -429,6 +730,8 @@ type cgroupCollector struct {
 	cgCPUSystem       *prometheus.Desc
 	cgCPUs            *prometheus.Desc
 	cgCPUPressure     *prometheus.Desc
+	cgCPUThrottled    *prometheus.Desc
+	cgCPUThrottledSec *prometheus.Desc
 	cgMemoryRSS       *prometheus.Desc
 	cgMemoryCache     *prometheus.Desc
 	cgMemoryUsed      *prometheus.Desc
@@ -445,13 +748,32 @@ type cgroupCollector struct {
 	cgBlkioPressure   *prometheus.Desc
 	cgRDMAHCAHandles  *prometheus.Desc
 	cgRDMAHCAObjects  *prometheus.Desc
+	cgNetTxBytes      *prometheus.Desc
+	cgNetTxPackets    *prometheus.Desc
+	cgProcs           *prometheus.Desc
+	cgStartTime       *prometheus.Desc
+	cgTasks           *prometheus.Desc
+	cgTasksMax        *prometheus.Desc
+	cgStepCPUUser     *prometheus.Desc
+	cgStepCPUSystem   *prometheus.Desc
+	cgStepMemoryRSS   *prometheus.Desc
+	cgStepMemoryUsed  *prometheus.Desc
+	cgDuplicateID     *prometheus.Desc
+	duplicatesMu      sync.Mutex
+	duplicateIDs      map[string]struct{} // cgroup UUIDs that were found at multiple paths in last scrape
+	cgPower           *prometheus.Desc
+	cgCreatedTime     *prometheus.Desc
+	cgExitedTime      *prometheus.Desc
+	powerAttributor   *powerAttributor
 	collectError      *prometheus.Desc
+	discoveryErrors   *prometheus.Desc
 }
 
 type cgroupOpts struct {
 	collectSwapMemStats bool
 	collectBlockIOStats bool
 	collectPSIStats     bool
+	collectNetStats     bool
 }
 
 // NewCgroupCollector returns a new cgroupCollector exposing a summary of cgroups.
@@ -486,13 +808,31 @@ func NewCgroupCollector(logger *slog.Logger, cgManager *cgroupManager, opts cgro
 		logger.Error("Failed to get list of block devices on the host", "err", err)
 	}
 
+	// Network stats are read from net_cls controller which is only available
+	// on cgroups v1
+	if opts.collectNetStats && cgManager.mode == cgroups.Unified {
+		logger.Warn("Network metrics are only supported on cgroups v1. Disabling them")
+
+		opts.collectNetStats = false
+	}
+
+	// Setup power attributor when enabled
+	var attributor *powerAttributor
+
+	if *powerAttributionModel != "" {
+		if attributor, err = newPowerAttributor(cgManager.manager, logger); err != nil {
+			logger.Error("Failed to setup power attribution of compute units", "err", err)
+		}
+	}
+
 	return &cgroupCollector{
-		logger:        logger,
-		cgroupManager: cgManager,
-		opts:          opts,
-		hostMemInfo:   hostMemInfo,
-		hostname:      hostname,
-		blockDevices:  blockDevices,
+		logger:          logger,
+		cgroupManager:   cgManager,
+		opts:            opts,
+		hostMemInfo:     hostMemInfo,
+		hostname:        hostname,
+		blockDevices:    blockDevices,
+		powerAttributor: attributor,
 		numCgs: prometheus.NewDesc(
 			prometheus.BuildFQName(Namespace, genericSubsystem, "units"),
 			"Total number of jobs",
@@ -523,6 +863,18 @@ func NewCgroupCollector(logger *slog.Logger, cgManager *cgroupManager, opts cgro
 			[]string{"manager", "hostname", "uuid"},
 			nil,
 		),
+		cgCPUThrottled: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_cpu_throttled_periods_total"),
+			"Total number of periods in which job CPU usage is throttled",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgCPUThrottledSec: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_cpu_throttled_seconds_total"),
+			"Total job CPU throttled time in seconds",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
 		cgMemoryRSS: prometheus.NewDesc(
 			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_memory_rss_bytes"),
 			"Memory RSS used in bytes",
@@ -619,23 +971,155 @@ func NewCgroupCollector(logger *slog.Logger, cgManager *cgroupManager, opts cgro
 			[]string{"manager", "hostname", "uuid", "device"},
 			nil,
 		),
+		cgNetTxBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_net_tx_bytes_total"),
+			"Total number of bytes transmitted by the job",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgNetTxPackets: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_net_tx_packets_total"),
+			"Total number of packets transmitted by the job",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgProcs: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_pids"),
+			"Current number of processes in the job",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgStartTime: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_start_time_seconds"),
+			"Start time of the oldest process in cgroup of compute unit in seconds since epoch",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgTasks: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_tasks"),
+			"Current number of tasks in the job as reported by pids controller",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgTasksMax: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_tasks_max"),
+			"Maximum number of tasks allowed in the job as reported by pids controller",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgStepCPUUser: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_step_cpu_user_seconds_total"),
+			"Total job step CPU user seconds",
+			[]string{"manager", "hostname", "uuid", "step"},
+			nil,
+		),
+		cgStepCPUSystem: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_step_cpu_system_seconds_total"),
+			"Total job step CPU system seconds",
+			[]string{"manager", "hostname", "uuid", "step"},
+			nil,
+		),
+		cgStepMemoryRSS: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_step_memory_rss_bytes"),
+			"Job step memory RSS used in bytes",
+			[]string{"manager", "hostname", "uuid", "step"},
+			nil,
+		),
+		cgStepMemoryUsed: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_step_memory_used_bytes"),
+			"Job step memory used in bytes",
+			[]string{"manager", "hostname", "uuid", "step"},
+			nil,
+		),
+		cgDuplicateID: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "exporter", "cgroup_duplicate_id"),
+			"Number of cgroups found with same ID. Value more than 1 indicates cgroups of other hosts are visible",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgCreatedTime: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_created_timestamp_seconds"),
+			"Time at which cgroup of compute unit has been created in seconds since epoch",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgExitedTime: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_exited_timestamp_seconds"),
+			"Time at which cgroup of compute unit has been removed in seconds since epoch",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
+		cgPower: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, genericSubsystem, "unit_power_watts"),
+			"Estimated share of node power of the compute unit in watts",
+			[]string{"manager", "hostname", "uuid"},
+			nil,
+		),
 		collectError: prometheus.NewDesc(
 			prometheus.BuildFQName(Namespace, genericSubsystem, "collect_error"),
 			"Indicates collection error, 0=no error, 1=error",
 			[]string{"manager", "hostname", "uuid"},
 			nil,
 		),
+		discoveryErrors: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "collector", "cgroup_discovery_errors_total"),
+			"Total number of errors during discovery of cgroups by reason",
+			[]string{"manager", "hostname", "reason"},
+			nil,
+		),
 	}, nil
 }
 
+// updateDiscoveryErrors sends number of cgroup discovery errors on given channel.
+// It must be called even when discovery fails so that walk errors are exported.
+func (c *cgroupCollector) updateDiscoveryErrors(ch chan<- prometheus.Metric) {
+	for _, reason := range []string{discoveryErrWalk, discoveryErrInvalidPath, discoveryErrEmptyID, discoveryErrRegexMiss} {
+		ch <- prometheus.MustNewConstMetric(
+			c.discoveryErrors, prometheus.CounterValue, c.cgroupManager.discoveryErrs.get(reason),
+			c.cgroupManager.manager, c.hostname, reason,
+		)
+	}
+}
+
 // Update updates cgroup metrics on given channel.
 func (c *cgroupCollector) Update(ch chan<- prometheus.Metric, metrics []cgMetric) error {
 	// Fetch metrics
 	metrics = c.doUpdate(metrics)
 
+	// Fetch network stats of all cgroups at once
+	if c.opts.collectNetStats {
+		c.netStats(metrics)
+	}
+
+	// Separate metrics of steps from the ones of root cgroups
+	var stepMetrics []cgMetric
+
+	metrics = slices.DeleteFunc(metrics, func(m cgMetric) bool {
+		if m.step != "" {
+			stepMetrics = append(stepMetrics, m)
+
+			return true
+		}
+
+		return false
+	})
+
 	// First send num jobs on the current host
 	ch <- prometheus.MustNewConstMetric(c.numCgs, prometheus.GaugeValue, float64(len(metrics)), c.cgroupManager.manager, c.hostname)
 
+	// Send cgroups that have been found more than once. This happens when cgroups
+	// of other hosts are visible on current host, eg, multiple slurmd daemons or
+	// shared cgroup file system, which results in double counting of usage
+	duplicates := duplicateCgroups(metrics)
+	c.logDuplicates(duplicates)
+
+	for uuid, paths := range duplicates {
+		ch <- prometheus.MustNewConstMetric(c.cgDuplicateID, prometheus.GaugeValue, float64(len(paths)), c.cgroupManager.manager, c.hostname, uuid)
+	}
+
+	// Send errors during discovery of cgroups
+	c.updateDiscoveryErrors(ch)
+
 	// Send metrics of each cgroup
 	for _, m := range metrics {
 		if m.err {
@@ -646,6 +1130,8 @@ func (c *cgroupCollector) Update(ch chan<- prometheus.Metric, metrics []cgMetric
 		ch <- prometheus.MustNewConstMetric(c.cgCPUUser, prometheus.CounterValue, m.cpuUser, c.cgroupManager.manager, c.hostname, m.uuid)
 		ch <- prometheus.MustNewConstMetric(c.cgCPUSystem, prometheus.CounterValue, m.cpuSystem, c.cgroupManager.manager, c.hostname, m.uuid)
 		ch <- prometheus.MustNewConstMetric(c.cgCPUs, prometheus.GaugeValue, float64(m.cpus), c.cgroupManager.manager, c.hostname, m.uuid)
+		ch <- prometheus.MustNewConstMetric(c.cgCPUThrottled, prometheus.CounterValue, m.cpuThrottled, c.cgroupManager.manager, c.hostname, m.uuid)
+		ch <- prometheus.MustNewConstMetric(c.cgCPUThrottledSec, prometheus.CounterValue, m.cpuThrottledSec, c.cgroupManager.manager, c.hostname, m.uuid)
 
 		// Memory stats
 		ch <- prometheus.MustNewConstMetric(c.cgMemoryRSS, prometheus.GaugeValue, m.memoryRSS, c.cgroupManager.manager, c.hostname, m.uuid)
@@ -700,13 +1186,114 @@ func (c *cgroupCollector) Update(ch chan<- prometheus.Metric, metrics []cgMetric
 				ch <- prometheus.MustNewConstMetric(c.cgRDMAHCAObjects, prometheus.GaugeValue, objects, c.cgroupManager.manager, c.hostname, m.uuid, device)
 			}
 		}
+
+		// Process and task stats
+		ch <- prometheus.MustNewConstMetric(c.cgProcs, prometheus.GaugeValue, float64(m.numProcs), c.cgroupManager.manager, c.hostname, m.uuid)
+
+		// Omit start time when none of the processes of cgroup are readable
+		if m.startTime > 0 {
+			ch <- prometheus.MustNewConstMetric(c.cgStartTime, prometheus.GaugeValue, m.startTime, c.cgroupManager.manager, c.hostname, m.uuid)
+		}
+
+		if m.numTasks > 0 {
+			ch <- prometheus.MustNewConstMetric(c.cgTasks, prometheus.GaugeValue, m.numTasks, c.cgroupManager.manager, c.hostname, m.uuid)
+		}
+
+		if m.maxTasks > 0 {
+			ch <- prometheus.MustNewConstMetric(c.cgTasksMax, prometheus.GaugeValue, m.maxTasks, c.cgroupManager.manager, c.hostname, m.uuid)
+		}
+
+		// Network stats
+		if c.opts.collectNetStats && m.netTxPackets > 0 {
+			ch <- prometheus.MustNewConstMetric(c.cgNetTxBytes, prometheus.CounterValue, m.netTxBytes, c.cgroupManager.manager, c.hostname, m.uuid)
+			ch <- prometheus.MustNewConstMetric(c.cgNetTxPackets, prometheus.CounterValue, m.netTxPackets, c.cgroupManager.manager, c.hostname, m.uuid)
+		}
+	}
+
+	// Send estimated power of each cgroup
+	if c.powerAttributor != nil {
+		for uuid, watts := range c.powerAttributor.attribute(metrics) {
+			ch <- prometheus.MustNewConstMetric(c.cgPower, prometheus.GaugeValue, watts, c.cgroupManager.manager, c.hostname, uuid)
+		}
+	}
+
+	// Send cgroup creation and removal events
+	if c.cgroupManager.watcher != nil {
+		for uuid, event := range c.cgroupManager.watcher.cgroupEvents() {
+			if !event.created.IsZero() {
+				ch <- prometheus.MustNewConstMetric(c.cgCreatedTime, prometheus.GaugeValue, float64(event.created.UnixMilli())/1000, c.cgroupManager.manager, c.hostname, uuid)
+			}
+
+			if !event.exited.IsZero() {
+				ch <- prometheus.MustNewConstMetric(c.cgExitedTime, prometheus.GaugeValue, float64(event.exited.UnixMilli())/1000, c.cgroupManager.manager, c.hostname, uuid)
+			}
+		}
+	}
+
+	// Send metrics of steps
+	for _, m := range stepMetrics {
+		if m.err {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.cgStepCPUUser, prometheus.CounterValue, m.cpuUser, c.cgroupManager.manager, c.hostname, m.uuid, m.step)
+		ch <- prometheus.MustNewConstMetric(c.cgStepCPUSystem, prometheus.CounterValue, m.cpuSystem, c.cgroupManager.manager, c.hostname, m.uuid, m.step)
+		ch <- prometheus.MustNewConstMetric(c.cgStepMemoryRSS, prometheus.GaugeValue, m.memoryRSS, c.cgroupManager.manager, c.hostname, m.uuid, m.step)
+		ch <- prometheus.MustNewConstMetric(c.cgStepMemoryUsed, prometheus.GaugeValue, m.memoryUsed, c.cgroupManager.manager, c.hostname, m.uuid, m.step)
 	}
 
 	return nil
 }
 
+// logDuplicates logs cgroups that are found at multiple paths. A warning is logged
+// only when a cgroup becomes duplicate and it is logged at debug level in the
+// subsequent scrapes to avoid flooding logs.
+func (c *cgroupCollector) logDuplicates(duplicates map[string][]string) {
+	c.duplicatesMu.Lock()
+	defer c.duplicatesMu.Unlock()
+
+	seen := make(map[string]struct{}, len(duplicates))
+
+	for uuid, paths := range duplicates {
+		if _, ok := c.duplicateIDs[uuid]; ok {
+			c.logger.Debug("Cgroup with same ID found at multiple paths", "uuid", uuid, "paths", strings.Join(paths, ","))
+		} else {
+			c.logger.Warn("Cgroup with same ID found at multiple paths", "uuid", uuid, "paths", strings.Join(paths, ","))
+		}
+
+		seen[uuid] = struct{}{}
+	}
+
+	// Forget cgroups that are not duplicate anymore
+	c.duplicateIDs = seen
+}
+
+// duplicateCgroups returns a map of cgroup UUIDs that are found at more than one
+// path to their paths.
+func duplicateCgroups(metrics []cgMetric) map[string][]string {
+	paths := make(map[string][]string)
+	for _, m := range metrics {
+		paths[m.uuid] = append(paths[m.uuid], m.path)
+	}
+
+	duplicates := make(map[string][]string)
+
+	for uuid, p := range paths {
+		if len(p) > 1 {
+			duplicates[uuid] = p
+		}
+	}
+
+	return duplicates
+}
+
 // Stop releases any system resources held by collector.
 func (c *cgroupCollector) Stop(_ context.Context) error {
+	// Stop watching cgroup events
+	if c.cgroupManager.watcher != nil {
+		return c.cgroupManager.watcher.stop()
+	}
+
 	return nil
 }
 
@@ -734,9 +1321,12 @@ func (c *cgroupCollector) doUpdate(metrics []cgMetric) []cgMetric {
 
 // update get metrics of a given cgroup path.
 func (c *cgroupCollector) update(m *cgMetric) {
-	if c.cgroupManager.mode == cgroups.Unified {
-		c.statsV2(m)
-	} else {
+	switch c.cgroupManager.mode { //nolint:exhaustive
+	case cgroups.Unified:
+		c.statsV2(m, *cgroupfsPath)
+	case cgroups.Hybrid:
+		c.statsHybrid(m)
+	default:
 		c.statsV1(m)
 	}
 }
@@ -789,6 +1379,8 @@ func (c *cgroupCollector) getCPUs(path string) ([]string, error) {
 	var cpusPath string
 	if c.cgroupManager.mode == cgroups.Unified {
 		cpusPath = fmt.Sprintf("%s%s/cpuset.cpus.effective", *cgroupfsPath, path)
+	} else if c.cgroupManager.mode == cgroups.Hybrid && slices.Contains(c.cgroupManager.unifiedCtrls, "cpuset") {
+		cpusPath = fmt.Sprintf("%s/unified%s/cpuset.cpus.effective", *cgroupfsPath, path)
 	} else {
 		cpusPath = fmt.Sprintf("%s/cpuset%s/cpuset.cpus", *cgroupfsPath, path)
 	}
@@ -854,6 +1446,12 @@ func (c *cgroupCollector) statsV1(metric *cgMetric) {
 			metric.cpuSystem = float64(stats.GetCPU().GetUsage().GetKernel()) / 1000000000.0
 			metric.cpuTotal = float64(stats.GetCPU().GetUsage().GetTotal()) / 1000000000.0
 		}
+
+		// Throttling stats are available only when cpu controller is mounted
+		if stats.GetCPU().GetThrottling() != nil {
+			metric.cpuThrottled = float64(stats.GetCPU().GetThrottling().GetThrottledPeriods())
+			metric.cpuThrottledSec = float64(stats.GetCPU().GetThrottling().GetThrottledTime()) / 1000000000.0
+		}
 	}
 
 	if cpus, err := c.getCPUs(path); err == nil {
@@ -937,16 +1535,76 @@ func (c *cgroupCollector) statsV1(metric *cgMetric) {
 			metric.rdmaHCAObjects[device.GetDevice()] = float64(device.GetHcaObjects())
 		}
 	}
+
+	// Get pids stats. Limit will be 0 when it is set to "max"
+	if stats.GetPids() != nil {
+		metric.numTasks = float64(stats.GetPids().GetCurrent())
+		metric.maxTasks = float64(stats.GetPids().GetLimit())
+	}
 }
 
-// statsV2 fetches metrics from cgroups v2.
-func (c *cgroupCollector) statsV2(metric *cgMetric) {
+// statsHybrid fetches metrics from cgroups in hybrid mode. Metrics of controllers
+// bound to unified hierarchy are read from cgroups v2 and rest of them from
+// cgroups v1.
+func (c *cgroupCollector) statsHybrid(metric *cgMetric) {
+	c.statsV1(metric)
+
+	if len(c.cgroupManager.unifiedCtrls) == 0 {
+		return
+	}
+
+	unified := cgMetric{path: metric.path}
+	c.statsV2(&unified, filepath.Join(*cgroupfsPath, "unified"))
+
+	if unified.err {
+		metric.err = true
+
+		return
+	}
+
+	for _, ctrl := range c.cgroupManager.unifiedCtrls {
+		switch ctrl {
+		case "cpu":
+			metric.cpuUser = unified.cpuUser
+			metric.cpuSystem = unified.cpuSystem
+			metric.cpuTotal = unified.cpuTotal
+			metric.cpuThrottled = unified.cpuThrottled
+			metric.cpuThrottledSec = unified.cpuThrottledSec
+			metric.cpuPressure = unified.cpuPressure
+		case "memory":
+			metric.memoryRSS = unified.memoryRSS
+			metric.memoryCache = unified.memoryCache
+			metric.memoryUsed = unified.memoryUsed
+			metric.memoryTotal = unified.memoryTotal
+			metric.memoryFailCount = unified.memoryFailCount
+			metric.memswUsed = unified.memswUsed
+			metric.memswTotal = unified.memswTotal
+			metric.memswFailCount = unified.memswFailCount
+			metric.memoryPressure = unified.memoryPressure
+		case "io":
+			metric.blkioReadBytes = unified.blkioReadBytes
+			metric.blkioWriteBytes = unified.blkioWriteBytes
+			metric.blkioReadReqs = unified.blkioReadReqs
+			metric.blkioWriteReqs = unified.blkioWriteReqs
+			metric.blkioPressure = unified.blkioPressure
+		case "rdma":
+			metric.rdmaHCAHandles = unified.rdmaHCAHandles
+			metric.rdmaHCAObjects = unified.rdmaHCAObjects
+		case "pids":
+			metric.numTasks = unified.numTasks
+			metric.maxTasks = unified.maxTasks
+		}
+	}
+}
+
+// statsV2 fetches metrics from cgroups v2 mounted at mountpoint.
+func (c *cgroupCollector) statsV2(metric *cgMetric, mountpoint string) {
 	path := metric.path
 
-	c.logger.Debug("Loading cgroup v2", "path", path)
+	c.logger.Debug("Loading cgroup v2", "path", path, "mountpoint", mountpoint)
 
 	// Load cgroups
-	ctrl, err := cgroup2.Load(path, cgroup2.WithMountpoint(*cgroupfsPath))
+	ctrl, err := cgroup2.Load(path, cgroup2.WithMountpoint(mountpoint))
 	if err != nil {
 		metric.err = true
 
@@ -978,6 +1636,8 @@ func (c *cgroupCollector) statsV2(metric *cgMetric) {
 		metric.cpuUser = float64(stats.GetCPU().GetUserUsec()) / 1000000.0
 		metric.cpuSystem = float64(stats.GetCPU().GetSystemUsec()) / 1000000.0
 		metric.cpuTotal = float64(stats.GetCPU().GetUsageUsec()) / 1000000.0
+		metric.cpuThrottled = float64(stats.GetCPU().GetNrThrottled())
+		metric.cpuThrottledSec = float64(stats.GetCPU().GetThrottledUsec()) / 1000000.0
 
 		if stats.GetCPU().GetPSI() != nil {
 			metric.cpuPressure = float64(stats.GetCPU().GetPSI().GetFull().GetTotal()) / 1000000.0
@@ -1057,12 +1717,22 @@ func (c *cgroupCollector) statsV2(metric *cgMetric) {
 			metric.rdmaHCAObjects[device.GetDevice()] = float64(device.GetHcaObjects())
 		}
 	}
+
+	// Get pids stats. Limit will be math.MaxUint64 when it is set to "max"
+	if stats.GetPids() != nil {
+		metric.numTasks = float64(stats.GetPids().GetCurrent())
+
+		if stats.GetPids().GetLimit() != math.MaxUint64 {
+			metric.maxTasks = float64(stats.GetPids().GetLimit())
+		}
+	}
 }
 
 // subsystem returns cgroups v1 subsystems.
 func subsystem() ([]cgroup1.Subsystem, error) {
 	s := []cgroup1.Subsystem{
 		cgroup1.NewCpuacct(*cgroupfsPath),
+		cgroup1.NewCpu(*cgroupfsPath),
 		cgroup1.NewMemory(*cgroupfsPath),
 		cgroup1.NewRdma(*cgroupfsPath),
 		cgroup1.NewPids(*cgroupfsPath),
@@ -0,0 +1,312 @@
+package collector
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Duration for which events of exited cgroups are retained. Events are
+// reported in every scrape during this duration which ensures that they
+// are captured even when there are several scrapers with different intervals.
+const cgroupEventsRetention = 10 * time.Minute
+
+// cgroupEvent stores the time at which cgroup of a compute unit has been
+// created and/or removed.
+type cgroupEvent struct {
+	created time.Time
+	exited  time.Time
+}
+
+// cgroupWatcher watches the cgroup file system using inotify for creation
+// and removal of cgroups of compute units. Only the directories up to cgroups
+// of compute units are watched, i.e., the cgroups of compute units themselves
+// are not watched.
+type cgroupWatcher struct {
+	logger     *slog.Logger
+	fd         int
+	maxWatches int
+	idRegex    *regexp.Regexp
+	isChild    func(string) bool
+	mu         sync.Mutex
+	watches    map[int]string          // Map of watch descriptors to watched directories
+	events     map[string]*cgroupEvent // Map of cgroup IDs to their events
+	limitHit   bool                    // Whether maximum number of watches has been reached
+	done       chan struct{}
+	stopped    chan struct{}
+}
+
+// newCgroupWatcher returns a new cgroupWatcher that watches mountPoint and all
+// its sub directories that are not cgroups of compute units. Number of watches
+// is bounded by maxWatches.
+func newCgroupWatcher(
+	mountPoint string,
+	idRegex *regexp.Regexp,
+	isChild func(string) bool,
+	maxWatches int,
+	logger *slog.Logger,
+) (*cgroupWatcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialise inotify: %w", err)
+	}
+
+	w := &cgroupWatcher{
+		logger:     logger,
+		fd:         fd,
+		maxWatches: maxWatches,
+		idRegex:    idRegex,
+		isChild:    isChild,
+		watches:    make(map[int]string),
+		events:     make(map[string]*cgroupEvent),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+
+	// Add watches on mount point and its sub directories. Cgroups that exist
+	// already have been created before the watcher and hence, they are not
+	// recorded
+	if err := w.watchDir(mountPoint, time.Time{}); err != nil {
+		unix.Close(fd)
+
+		return nil, fmt.Errorf("failed to add watches on cgroups: %w", err)
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+// watchDir adds watches on directory root and all its sub directories that are
+// not cgroups of compute units. When ts is not zero, cgroups of compute units
+// found in root are recorded as created at ts.
+func (w *cgroupWatcher) watchDir(root string, ts time.Time) error {
+	return filepath.WalkDir(root, func(p string, info fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		// Do not descend into cgroups of compute units
+		if id, ok := w.cgroupID(p); ok {
+			if !ts.IsZero() {
+				w.mu.Lock()
+				if _, ok := w.events[id]; !ok {
+					w.events[id] = &cgroupEvent{created: ts}
+				}
+				w.mu.Unlock()
+			}
+
+			return filepath.SkipDir
+		}
+
+		return w.addWatch(p)
+	})
+}
+
+// cgroupID returns the ID of the root cgroup of compute unit at path p.
+func (w *cgroupWatcher) cgroupID(p string) (string, bool) {
+	// Unescape UTF-8 characters in cgroup path
+	sanitizedPath, err := unescapeString(p)
+	if err != nil {
+		return "", false
+	}
+
+	matches := w.idRegex.FindStringSubmatch(sanitizedPath)
+	if len(matches) <= 1 || w.isChild(p) {
+		return "", false
+	}
+
+	if id := strings.TrimSpace(matches[1]); id != "" {
+		return id, true
+	}
+
+	return "", false
+}
+
+// addWatch adds a new watch on directory p if maximum number of watches is not
+// reached yet.
+func (w *cgroupWatcher) addWatch(p string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Warn only once until watches are available again
+	if len(w.watches) >= w.maxWatches {
+		if !w.limitHit {
+			w.logger.Warn("Maximum number of cgroup watches reached", "max_watches", w.maxWatches, "path", p)
+		} else {
+			w.logger.Debug("Maximum number of cgroup watches reached", "max_watches", w.maxWatches, "path", p)
+		}
+
+		w.limitHit = true
+
+		return filepath.SkipDir
+	}
+
+	wd, err := unix.InotifyAddWatch(w.fd, p, unix.IN_CREATE|unix.IN_DELETE|unix.IN_ONLYDIR)
+	if err != nil {
+		return err
+	}
+
+	w.watches[wd] = p
+	w.limitHit = false
+
+	return nil
+}
+
+// run reads inotify events until watcher is stopped.
+func (w *cgroupWatcher) run() {
+	defer close(w.stopped)
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	fds := []unix.PollFd{{Fd: int32(w.fd), Events: unix.POLLIN}} //nolint:gosec
+
+	for {
+		select {
+		case <-w.done:
+			return
+		default:
+		}
+
+		// Poll with a timeout so that we can check if watcher is stopped
+		n, err := unix.Poll(fds, 500)
+		if err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+
+			w.logger.Error("Failed to poll cgroup events", "err", err)
+
+			return
+		}
+
+		if n == 0 {
+			continue
+		}
+
+		n, err = unix.Read(w.fd, buf)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+
+			w.logger.Error("Failed to read cgroup events", "err", err)
+
+			return
+		}
+
+		w.handleEvents(buf[:n], time.Now())
+	}
+}
+
+// handleEvents parses raw inotify events and updates cgroup events.
+func (w *cgroupWatcher) handleEvents(buf []byte, ts time.Time) {
+	for offset := 0; offset+unix.SizeofInotifyEvent <= len(buf); {
+		wd := int(int32(binary.NativeEndian.Uint32(buf[offset:]))) //nolint:gosec
+		mask := binary.NativeEndian.Uint32(buf[offset+4:])
+		nameLen := int(binary.NativeEndian.Uint32(buf[offset+12:]))
+
+		start := offset + unix.SizeofInotifyEvent
+		if start+nameLen > len(buf) {
+			return
+		}
+
+		name := strings.TrimRight(string(buf[start:start+nameLen]), "\x00")
+		offset = start + nameLen
+
+		w.handleEvent(wd, mask, name, ts)
+	}
+}
+
+// handleEvent updates cgroup events based on a single inotify event.
+func (w *cgroupWatcher) handleEvent(wd int, mask uint32, name string, ts time.Time) {
+	if mask&unix.IN_Q_OVERFLOW != 0 {
+		w.logger.Warn("cgroup events queue overflowed. Some events might be lost")
+
+		return
+	}
+
+	w.mu.Lock()
+	dir, ok := w.watches[wd]
+
+	// Watched directory has been removed
+	if mask&unix.IN_IGNORED != 0 {
+		delete(w.watches, wd)
+	}
+	w.mu.Unlock()
+
+	if !ok || name == "" || mask&unix.IN_ISDIR == 0 {
+		return
+	}
+
+	p := filepath.Join(dir, name)
+
+	id, ok := w.cgroupID(p)
+	if !ok {
+		// Watch new intermediate directories, eg, uid_1000 on cgroups v1.
+		// Cgroups of compute units can be created in the directory before
+		// the watch is added and they are found by scanning the directory
+		if mask&unix.IN_CREATE != 0 {
+			if err := w.watchDir(p, ts); err != nil {
+				w.logger.Debug("Failed to add watch on cgroup directory", "path", p, "err", err)
+			}
+		}
+
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch {
+	case mask&unix.IN_CREATE != 0:
+		w.events[id] = &cgroupEvent{created: ts}
+	case mask&unix.IN_DELETE != 0:
+		if event, ok := w.events[id]; ok {
+			event.exited = ts
+		} else {
+			w.events[id] = &cgroupEvent{exited: ts}
+		}
+	}
+}
+
+// cgroupEvents returns a copy of current cgroup events. Events of cgroups that
+// exited more than retention period ago are removed.
+func (w *cgroupWatcher) cgroupEvents() map[string]cgroupEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	events := make(map[string]cgroupEvent, len(w.events))
+
+	for id, event := range w.events {
+		if !event.exited.IsZero() && time.Since(event.exited) > cgroupEventsRetention {
+			delete(w.events, id)
+
+			continue
+		}
+
+		events[id] = *event
+	}
+
+	return events
+}
+
+// stop stops watching cgroup events and releases inotify instance.
+func (w *cgroupWatcher) stop() error {
+	close(w.done)
+	<-w.stopped
+
+	return unix.Close(w.fd)
+}
@@ -0,0 +1,109 @@
+package collector
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCgroupWatcher(t *testing.T) {
+	mountPoint := filepath.Join(t.TempDir(), "slurm")
+	require.NoError(t, os.MkdirAll(filepath.Join(mountPoint, "uid_1000", "job_1", "step_0"), 0o755))
+
+	isChild := func(p string) bool {
+		return strings.Contains(p, "/step_")
+	}
+
+	w, err := newCgroupWatcher(mountPoint, slurmCgroupPathRegex, isChild, 10, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	defer w.stop()
+
+	// Cgroups of jobs must not be watched
+	w.mu.Lock()
+	assert.ElementsMatch(t, []string{mountPoint, filepath.Join(mountPoint, "uid_1000")}, slices.Collect(maps.Values(w.watches)))
+	w.mu.Unlock()
+
+	// Create a new job in existing and new uid directories
+	require.NoError(t, os.Mkdir(filepath.Join(mountPoint, "uid_1000", "job_2"), 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(mountPoint, "uid_2000"), 0o755))
+
+	// Wait until new uid directory is watched
+	require.Eventually(t, func() bool {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		return len(w.watches) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.Mkdir(filepath.Join(mountPoint, "uid_2000", "job_3"), 0o755))
+
+	// Jobs created in a new uid directory before it is watched must be found
+	// as well
+	require.NoError(t, os.MkdirAll(filepath.Join(mountPoint, "uid_3000", "job_4"), 0o755))
+
+	// Remove an existing and a new job
+	require.NoError(t, os.RemoveAll(filepath.Join(mountPoint, "uid_1000", "job_1")))
+	require.NoError(t, os.RemoveAll(filepath.Join(mountPoint, "uid_1000", "job_2")))
+
+	require.Eventually(t, func() bool {
+		events := w.cgroupEvents()
+
+		return len(events) == 4 && !events["2"].exited.IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	events := w.cgroupEvents()
+
+	// Job 1 existed before watcher started
+	assert.True(t, events["1"].created.IsZero())
+	assert.False(t, events["1"].exited.IsZero())
+
+	// Job 2 started and exited
+	assert.False(t, events["2"].created.IsZero())
+	assert.False(t, events["2"].exited.IsZero())
+
+	// Job 3 is still running
+	assert.False(t, events["3"].created.IsZero())
+	assert.True(t, events["3"].exited.IsZero())
+
+	// Job 4 has been found by scanning new uid directory
+	assert.False(t, events["4"].created.IsZero())
+	assert.True(t, events["4"].exited.IsZero())
+}
+
+func TestCgroupWatcherEvents(t *testing.T) {
+	var logs bytes.Buffer
+
+	w, err := newCgroupWatcher(
+		t.TempDir(), slurmCgroupPathRegex, func(string) bool { return false }, 1,
+		slog.New(slog.NewTextHandler(&logs, nil)),
+	)
+	require.NoError(t, err)
+
+	defer w.stop()
+
+	// Maximum number of watches must not be exceeded and it must be
+	// warned only once
+	require.ErrorIs(t, w.addWatch(t.TempDir()), filepath.SkipDir)
+	require.ErrorIs(t, w.addWatch(t.TempDir()), filepath.SkipDir)
+	assert.Equal(t, 1, strings.Count(logs.String(), "Maximum number of cgroup watches reached"))
+
+	// Exited events older than retention period must be removed
+	w.events["1"] = &cgroupEvent{exited: time.Now().Add(-2 * cgroupEventsRetention)}
+	w.events["2"] = &cgroupEvent{exited: time.Now()}
+
+	events := w.cgroupEvents()
+	assert.Len(t, events, 1)
+	assert.Contains(t, events, "2")
+	assert.Len(t, w.events, 1)
+}
@@ -0,0 +1,169 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mahendrapaipuri/ceems/internal/osexec"
+)
+
+// CLI options.
+var (
+	tcPath = CEEMSExporterApp.Flag(
+		"collector.cgroups.tc-path",
+		"Path to tc binary used to fetch statistics of traffic control classes for network metrics of cgroups.",
+	).Default("tc").String()
+)
+
+// netClsClassIDFile is the file in net_cls controller of cgroups v1
+// that contains the class ID of the cgroup.
+const netClsClassIDFile = "net_cls.classid"
+
+// tcClass is the JSON representation of a traffic control class
+// returned by tc -s -j class show.
+type tcClass struct {
+	Handle string `json:"handle"`
+	Stats  struct {
+		Bytes   uint64 `json:"bytes"`
+		Packets uint64 `json:"packets"`
+	} `json:"stats"`
+}
+
+// netClassStats contains network statistics of a traffic control class.
+type netClassStats struct {
+	txBytes   float64
+	txPackets float64
+}
+
+// parseTCClassID converts the handle of traffic control class of format
+// major:minor in hex to class ID as found in net_cls.classid.
+func parseTCClassID(handle string) (uint32, error) {
+	major, minor, found := strings.Cut(handle, ":")
+	if !found {
+		return 0, fmt.Errorf("invalid tc class handle %s", handle)
+	}
+
+	majorID, err := strconv.ParseUint(major, 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid major in tc class handle %s: %w", handle, err)
+	}
+
+	var minorID uint64
+
+	if minor != "" {
+		if minorID, err = strconv.ParseUint(minor, 16, 16); err != nil {
+			return 0, fmt.Errorf("invalid minor in tc class handle %s: %w", handle, err)
+		}
+	}
+
+	return uint32(majorID<<16 | minorID), nil
+}
+
+// parseTCClassStats parses output of tc -s -j class show and adds stats of
+// each class to the given map of class ID to stats.
+func parseTCClassStats(out []byte, stats map[uint32]netClassStats) error {
+	var classes []tcClass
+	if err := json.Unmarshal(out, &classes); err != nil {
+		return err
+	}
+
+	for _, class := range classes {
+		classID, err := parseTCClassID(class.Handle)
+		if err != nil {
+			continue
+		}
+
+		s := stats[classID]
+		s.txBytes += float64(class.Stats.Bytes)
+		s.txPackets += float64(class.Stats.Packets)
+		stats[classID] = s
+	}
+
+	return nil
+}
+
+// tcClassStats returns stats of traffic control classes of all network
+// interfaces on the host, except loopback, keyed by class ID. Stats of
+// classes with same ID on different interfaces are summed up.
+func tcClassStats() (map[uint32]netClassStats, error) {
+	ifaces, err := os.ReadDir(sysFilePath("class/net"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	stats := make(map[uint32]netClassStats)
+
+	for _, iface := range ifaces {
+		if iface.Name() == "lo" {
+			continue
+		}
+
+		out, err := osexec.Execute(*tcPath, []string{"-s", "-j", "class", "show", "dev", iface.Name()}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tc class stats of interface %s: %w", iface.Name(), err)
+		}
+
+		// tc returns empty output when there are no classes on interface
+		if len(strings.TrimSpace(string(out))) == 0 {
+			continue
+		}
+
+		if err := parseTCClassStats(out, stats); err != nil {
+			return nil, fmt.Errorf("failed to parse tc class stats of interface %s: %w", iface.Name(), err)
+		}
+	}
+
+	return stats, nil
+}
+
+// netClassID returns the class ID of cgroup at path in net_cls controller
+// of cgroups v1. Path must be relative to root of cgroup hierarchy.
+func netClassID(path string) (uint32, error) {
+	content, err := os.ReadFile(cgroupFilePath(filepath.Join("net_cls", path, netClsClassIDFile)))
+	if err != nil {
+		return 0, err
+	}
+
+	classID, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(classID), nil
+}
+
+// netStats sets network stats of cgroups based on class ID of cgroups in
+// net_cls controller and stats of traffic control classes. Only egress traffic
+// can be accounted this way as classes of net_cls controller are applied
+// only to outgoing packets.
+func (c *cgroupCollector) netStats(metrics []cgMetric) {
+	stats, err := tcClassStats()
+	if err != nil {
+		c.logger.Error("Failed to fetch network stats of traffic control classes", "err", err)
+
+		return
+	}
+
+	for i := range metrics {
+		classID, err := netClassID(metrics[i].path)
+		if err != nil {
+			c.logger.Debug("Failed to read net_cls class ID of cgroup", "path", metrics[i].path, "err", err)
+
+			continue
+		}
+
+		// Class ID 0 means class ID is not set for the cgroup
+		if classID == 0 {
+			continue
+		}
+
+		if s, ok := stats[classID]; ok {
+			metrics[i].netTxBytes = s.txBytes
+			metrics[i].netTxPackets = s.txPackets
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTCClassID(t *testing.T) {
+	classID, err := parseTCClassID("10:1")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x100001), classID)
+
+	classID, err = parseTCClassID("1:")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x10000), classID)
+
+	_, err = parseTCClassID("root")
+	require.Error(t, err)
+}
+
+func TestCgroupNetStats(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Mock network interfaces
+	sysDir := filepath.Join(tmpDir, "sys")
+	for _, iface := range []string{"lo", "eth0", "ib0"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(sysDir, "class", "net", iface), 0o755))
+	}
+
+	// Mock class IDs of cgroups
+	cgroupDir := filepath.Join(tmpDir, "cgroup")
+	for path, classID := range map[string]string{
+		"slurm/uid_1000/job_1": "1048577\n",
+		"slurm/uid_1000/job_2": "1048578\n",
+		"slurm/uid_1000/job_3": "0\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(cgroupDir, "net_cls", path), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(cgroupDir, "net_cls", path, netClsClassIDFile), []byte(classID), 0o600))
+	}
+
+	// Mock tc command
+	tcScript := `#!/bin/bash
+case "$6" in
+  eth0) echo '[{"class":"htb","handle":"10:","stats":{"bytes":300,"packets":3}},{"class":"htb","handle":"10:1","stats":{"bytes":100,"packets":1}},{"class":"htb","handle":"10:2","stats":{"bytes":200,"packets":2}}]' ;;
+  ib0) echo '[{"class":"htb","handle":"10:1","stats":{"bytes":1000,"packets":10}}]' ;;
+  *) exit 1 ;;
+esac
+`
+	tcFile := filepath.Join(tmpDir, "tc")
+	require.NoError(t, os.WriteFile(tcFile, []byte(tcScript), 0o700)) //nolint:gosec
+
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.sysfs", sysDir,
+			"--path.cgroupfs", cgroupDir,
+			"--collector.cgroups.tc-path", tcFile,
+		},
+	)
+	require.NoError(t, err)
+
+	c := cgroupCollector{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		opts:   cgroupOpts{collectNetStats: true},
+	}
+
+	metrics := []cgMetric{
+		{uuid: "1", path: "/slurm/uid_1000/job_1"},
+		{uuid: "2", path: "/slurm/uid_1000/job_2"},
+		{uuid: "3", path: "/slurm/uid_1000/job_3"},
+		{uuid: "4", path: "/slurm/uid_1000/job_4"},
+	}
+
+	c.netStats(metrics)
+
+	expected := map[string][]float64{
+		"1": {1100, 11},
+		"2": {200, 2},
+		"3": {0, 0},
+		"4": {0, 0},
+	}
+
+	for _, m := range metrics {
+		assert.Equal(t, expected[m.uuid], []float64{m.netTxBytes, m.netTxPackets}, m.uuid)
+	}
+}
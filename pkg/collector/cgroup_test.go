@@ -4,13 +4,21 @@
 package collector
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/containerd/cgroups/v3"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -168,6 +176,82 @@ func TestCgroupsV1Metrics(t *testing.T) {
 	assert.Equal(t, expectedMetrics, metric[0])
 }
 
+func TestCgroupsHybridMetrics(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Use cgroups v1 controllers of test data except memory and pids which are
+	// bound to unified hierarchy
+	for _, ctrl := range []string{"cpuacct", "blkio", "rdma"} {
+		src, err := filepath.Abs(filepath.Join("testdata/sys/fs/cgroup", ctrl))
+		require.NoError(t, err)
+		require.NoError(t, os.Symlink(src, filepath.Join(tmpDir, ctrl)))
+	}
+
+	unifiedPath := filepath.Join(tmpDir, "unified/slurm/uid_1000/job_1009248")
+	require.NoError(t, os.MkdirAll(unifiedPath, 0o755))
+
+	files := map[string]string{
+		"unified/cgroup.controllers":                            "memory pids\n",
+		"unified/slurm/uid_1000/job_1009248/cgroup.controllers": "memory pids\n",
+		"unified/slurm/uid_1000/job_1009248/memory.stat":        "anon 1000\nfile 2000\n",
+		"unified/slurm/uid_1000/job_1009248/memory.current":     "5000\n",
+		"unified/slurm/uid_1000/job_1009248/memory.max":         "max\n",
+		"unified/slurm/uid_1000/job_1009248/pids.current":       "3\n",
+		"unified/slurm/uid_1000/job_1009248/pids.max":           "max\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600))
+	}
+
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", tmpDir,
+		},
+	)
+	require.NoError(t, err)
+
+	// cgroup Manager
+	cgManager := &cgroupManager{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		mode:   cgroups.Hybrid,
+		root:   tmpDir,
+	}
+	cgManager.setUnifiedControllers()
+	assert.Equal(t, []string{"memory", "pids"}, cgManager.unifiedCtrls)
+
+	// opts
+	opts := cgroupOpts{
+		collectSwapMemStats: true,
+		collectPSIStats:     true,
+	}
+
+	c := cgroupCollector{
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		cgroupManager: cgManager,
+		opts:          opts,
+		hostMemInfo:   map[string]float64{"MemTotal_bytes": float64(123456)},
+	}
+
+	// CPU and RDMA metrics must be read from v1 and memory and pids from v2
+	expectedMetrics := cgMetric{
+		path:           "/slurm/uid_1000/job_1009248",
+		cpuUser:        0.39,
+		cpuSystem:      0.45,
+		cpuTotal:       1.012410966,
+		memoryRSS:      1000,
+		memoryCache:    2000,
+		memoryUsed:     5000,
+		memoryTotal:    123456,
+		numTasks:       3,
+		rdmaHCAHandles: map[string]float64{"hfi1_0": 479, "hfi1_1": 1479, "hfi1_2": 2479},
+		rdmaHCAObjects: map[string]float64{"hfi1_0": 340, "hfi1_1": 1340, "hfi1_2": 2340},
+		err:            false,
+	}
+
+	metric := c.doUpdate([]cgMetric{{path: expectedMetrics.path}})
+	assert.Equal(t, expectedMetrics, metric[0])
+}
+
 func TestNewCgroupManagerV2(t *testing.T) {
 	_, err := CEEMSExporterApp.Parse(
 		[]string{
@@ -202,6 +286,45 @@ func TestNewCgroupManagerV2(t *testing.T) {
 	assert.Len(t, cgroups, 4)
 }
 
+func TestCgroupDiscoveryErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// One job cgroup, cgroups created by SLURM that are not jobs and one
+	// cgroup that is not managed by SLURM
+	mountPoint := filepath.Join(tmpDir, "system.slice", "slurmstepd.scope")
+	for _, dir := range []string{"job_1/step_0", "system", "uid_1000", "unknown"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(mountPoint, dir), 0o750))
+	}
+
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", tmpDir,
+			"--collector.cgroups.force-version", "v2",
+		},
+	)
+	require.NoError(t, err)
+
+	manager, err := NewCgroupManager("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	cgroups, err := manager.discover()
+	require.NoError(t, err)
+	assert.Len(t, cgroups, 1)
+
+	// Only direct children of mount point that do not match and are not
+	// created by SLURM must be counted
+	assert.InDelta(t, 1, manager.discoveryErrs.get(discoveryErrRegexMiss), 0)
+	assert.InDelta(t, 0, manager.discoveryErrs.get(discoveryErrWalk), 0)
+
+	// Errors must be counted across discoveries
+	require.NoError(t, os.RemoveAll(mountPoint))
+
+	_, err = manager.discover()
+	require.Error(t, err)
+	assert.InDelta(t, 1, manager.discoveryErrs.get(discoveryErrRegexMiss), 0)
+	assert.InDelta(t, 1, manager.discoveryErrs.get(discoveryErrWalk), 0)
+}
+
 func TestNewCgroupManagerV1(t *testing.T) {
 	_, err := CEEMSExporterApp.Parse(
 		[]string{
@@ -240,6 +363,103 @@ func TestNewCgroupManagerV1(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestNewCgroupManagerSlurmPrefix(t *testing.T) {
+	// Custom prefix on cgroups v2
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", "testdata/sys/fs/cgroup",
+			"--collector.cgroups.force-version", "v2",
+			"--collector.cgroups.slurm-cgroup-prefix", "/system.slice/slurmstepd.scope/",
+		},
+	)
+	require.NoError(t, err)
+
+	manager, err := NewCgroupManager("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "testdata/sys/fs/cgroup/system.slice/slurmstepd.scope", manager.mountPoint)
+
+	cgroups, err := manager.discover()
+	require.NoError(t, err)
+	assert.Len(t, cgroups, 3)
+
+	// Custom prefix on cgroups v1
+	_, err = CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", "testdata/sys/fs/cgroup",
+			"--collector.cgroups.force-version", "v1",
+			"--collector.cgroups.slurm-cgroup-prefix", "slurm/uid_1000",
+		},
+	)
+	require.NoError(t, err)
+
+	manager, err = NewCgroupManager("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "testdata/sys/fs/cgroup/cpuacct/slurm/uid_1000", manager.mountPoint)
+
+	cgroups, err = manager.discover()
+	require.NoError(t, err)
+	assert.Len(t, cgroups, 3)
+
+	// Non existent prefix must return error
+	_, err = CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", "testdata/sys/fs/cgroup",
+			"--collector.cgroups.force-version", "v2",
+			"--collector.cgroups.slurm-cgroup-prefix", "custom.slice",
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = NewCgroupManager("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.Error(t, err)
+}
+
+func TestNewCgroupManagerIgnoreProcs(t *testing.T) {
+	// Repeatable flags accumulate values between parses. Reset them so that
+	// other tests are not affected
+	t.Cleanup(func() { *ignoreProcsRegexes = nil })
+
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", "testdata/sys/fs/cgroup",
+			"--collector.cgroups.force-version", "v2",
+			"--collector.cgroups.ignore-procs", "^/usr/bin/wrapper",
+			"--collector.cgroups.ignore-procs", "prolog.sh$",
+		},
+	)
+	require.NoError(t, err)
+
+	// Slurm case must retain built-in ignore regex
+	manager, err := NewCgroupManager("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	assert.True(t, manager.ignoreProc("slurmstepd: [1009248.batch]"))
+	assert.True(t, manager.ignoreProc("/usr/bin/wrapper --job 1"))
+	assert.True(t, manager.ignoreProc("/bin/bash /etc/slurm/prolog.sh"))
+	assert.False(t, manager.ignoreProc("/home/usr/bin/app"))
+
+	// libvirt case
+	manager, err = NewCgroupManager("libvirt", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	assert.True(t, manager.ignoreProc("/usr/bin/wrapper --vm 1"))
+	assert.False(t, manager.ignoreProc("/usr/bin/qemu-system-x86_64"))
+
+	// Invalid regex must return error
+	_, err = CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", "testdata/sys/fs/cgroup",
+			"--collector.cgroups.ignore-procs", "wrapper(",
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = NewCgroupManager("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.ErrorContains(t, err, "invalid regular expression wrapper(")
+}
+
 func TestParseCgroupSubSysIds(t *testing.T) {
 	_, err := CEEMSExporterApp.Parse(
 		[]string{
@@ -334,3 +554,238 @@ func TestParseCgroupSubSysIds(t *testing.T) {
 
 	assert.ElementsMatch(t, expectedControllers, controllers)
 }
+
+func TestCgroupManagerProcsCache(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", "testdata/sys/fs/cgroup",
+			"--path.procfs", "testdata/proc",
+			"--collector.cgroups.force-version", "v2",
+			"--collector.cgroups.procs-cache-ttl", "1h",
+		},
+	)
+	require.NoError(t, err)
+
+	manager, err := NewCgroupManager("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.NotNil(t, manager.procsCache)
+
+	cgroups, err := manager.discover()
+	require.NoError(t, err)
+
+	lastUpdate := manager.procsCache.lastUpdate
+	assert.NotEmpty(t, manager.procsCache.paths)
+
+	// Second discovery must be served from cache
+	cachedCgroups, err := manager.discover()
+	require.NoError(t, err)
+	assert.Equal(t, lastUpdate, manager.procsCache.lastUpdate)
+	assert.Equal(t, cgroups, cachedCgroups)
+
+	// Simulate a cgroup disappearing which must invalidate cache
+	manager.procsCache.paths = manager.procsCache.paths[1:]
+
+	_, err = manager.discover()
+	require.NoError(t, err)
+	assert.True(t, manager.procsCache.lastUpdate.After(lastUpdate))
+
+	// Disable cache
+	_, err = CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", "testdata/sys/fs/cgroup",
+			"--collector.cgroups.force-version", "v2",
+			"--collector.cgroups.procs-cache-ttl", "0s",
+		},
+	)
+	require.NoError(t, err)
+
+	manager, err = NewCgroupManager("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	assert.Nil(t, manager.procsCache)
+}
+
+func TestDuplicateCgroups(t *testing.T) {
+	metrics := []cgMetric{
+		{uuid: "1009248", path: "/system.slice/slurmstepd.scope/job_1009248"},
+		{uuid: "1009249", path: "/system.slice/slurmstepd.scope/job_1009249"},
+		{uuid: "1009248", path: "/system.slice/compute-1_slurmstepd.scope/job_1009248"},
+	}
+
+	expected := map[string][]string{
+		"1009248": {
+			"/system.slice/slurmstepd.scope/job_1009248",
+			"/system.slice/compute-1_slurmstepd.scope/job_1009248",
+		},
+	}
+	assert.Equal(t, expected, duplicateCgroups(metrics))
+
+	// No duplicates
+	assert.Empty(t, duplicateCgroups(metrics[:2]))
+
+	// Duplicates must be warned only when they are found first
+	var logs bytes.Buffer
+
+	c := cgroupCollector{logger: slog.New(slog.NewTextHandler(&logs, nil))}
+
+	c.logDuplicates(expected)
+	c.logDuplicates(expected)
+	assert.Equal(t, 1, strings.Count(logs.String(), "level=WARN"))
+
+	// Cgroups that become duplicate again must be warned again
+	c.logDuplicates(nil)
+	c.logDuplicates(expected)
+	assert.Equal(t, 2, strings.Count(logs.String(), "level=WARN"))
+}
+
+func TestProcsStartTime(t *testing.T) {
+	fs, err := procfs.NewFS("testdata/proc")
+	require.NoError(t, err)
+
+	var procs []procfs.Proc
+
+	for _, pid := range []int{26231, 26232} {
+		proc, err := fs.Proc(pid)
+		require.NoError(t, err)
+
+		procs = append(procs, proc)
+	}
+
+	stat, err := fs.Stat()
+	require.NoError(t, err)
+
+	// Start time of oldest process must be returned
+	assert.InDelta(t, 1418183276.05, procsStartTime(procs, stat.BootTime), 1e-6)
+
+	// No processes
+	assert.Zero(t, procsStartTime(nil, stat.BootTime))
+
+	// Unknown boot time
+	assert.Zero(t, procsStartTime(procs, 0))
+}
+
+func TestCgroupsV2PidsMetrics(t *testing.T) {
+	cgroupDir := t.TempDir()
+	jobDir := filepath.Join(cgroupDir, "system.slice", "slurmstepd.scope", "job_1")
+	require.NoError(t, os.MkdirAll(jobDir, 0o755))
+
+	for file, content := range map[string]string{
+		"cgroup.controllers": "pids\n",
+		"pids.current":       "12\n",
+		"pids.max":           "4096\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(jobDir, file), []byte(content), 0o600))
+	}
+
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", cgroupDir,
+		},
+	)
+	require.NoError(t, err)
+
+	c := cgroupCollector{
+		cgroupManager: &cgroupManager{mode: cgroups.Unified},
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	metric := c.doUpdate([]cgMetric{{path: "/system.slice/slurmstepd.scope/job_1", numProcs: 3}})
+	assert.Equal(t, 3, metric[0].numProcs)
+	assert.InDelta(t, 12, metric[0].numTasks, 0)
+	assert.InDelta(t, 4096, metric[0].maxTasks, 0)
+
+	// Unlimited tasks must not set maximum number of tasks
+	require.NoError(t, os.WriteFile(filepath.Join(jobDir, "pids.max"), []byte("max\n"), 0o600))
+
+	metric = c.doUpdate([]cgMetric{{path: "/system.slice/slurmstepd.scope/job_1"}})
+	assert.InDelta(t, 12, metric[0].numTasks, 0)
+	assert.Zero(t, metric[0].maxTasks)
+}
+
+func TestCgroupsV2CPUThrottlingMetrics(t *testing.T) {
+	cgroupDir := t.TempDir()
+	jobDir := filepath.Join(cgroupDir, "system.slice", "slurmstepd.scope", "job_1")
+	require.NoError(t, os.MkdirAll(jobDir, 0o755))
+
+	for file, content := range map[string]string{
+		"cgroup.controllers": "cpu\n",
+		"cpu.stat":           "usage_usec 2000000\nuser_usec 1500000\nsystem_usec 500000\nnr_periods 100\nnr_throttled 25\nthrottled_usec 1250000\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(jobDir, file), []byte(content), 0o600))
+	}
+
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", cgroupDir,
+		},
+	)
+	require.NoError(t, err)
+
+	c := cgroupCollector{
+		cgroupManager: &cgroupManager{mode: cgroups.Unified},
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	metric := c.doUpdate([]cgMetric{{path: "/system.slice/slurmstepd.scope/job_1"}})
+	assert.InDelta(t, 25, metric[0].cpuThrottled, 0)
+	assert.InDelta(t, 1.25, metric[0].cpuThrottledSec, 0)
+}
+
+func BenchmarkCgroupManagerProcs(b *testing.B) {
+	fs, err := procfs.NewFS("testdata/proc")
+	require.NoError(b, err)
+
+	pids, err := fs.AllProcs()
+	require.NoError(b, err)
+
+	var procs strings.Builder
+	for _, p := range pids {
+		fmt.Fprintf(&procs, "%d\n", p.PID)
+	}
+
+	// Synthetic cgroup tree of 1000 jobs with 4 steps each
+	mountPoint := filepath.Join(b.TempDir(), "system.slice", "slurmstepd.scope")
+
+	var paths []string
+
+	dirs := make(map[string][]string)
+
+	for job := range 1000 {
+		id := strconv.Itoa(job)
+		jobDir := filepath.Join(mountPoint, "job_"+id)
+
+		jobDirs := []string{jobDir}
+		for step := range 4 {
+			jobDirs = append(jobDirs, filepath.Join(jobDir, fmt.Sprintf("step_%d", step)))
+		}
+
+		for _, p := range jobDirs {
+			require.NoError(b, os.MkdirAll(p, 0o755))
+			require.NoError(b, os.WriteFile(filepath.Join(p, "cgroup.procs"), []byte(procs.String()), 0o600))
+
+			paths = append(paths, p)
+			dirs[id] = append(dirs[id], p)
+		}
+	}
+
+	for _, ttl := range []time.Duration{0, time.Hour} {
+		manager := &cgroupManager{
+			logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+			fs:         fs,
+			mode:       cgroups.Unified,
+			mountPoint: mountPoint,
+			manager:    slurm,
+			idRegex:    slurmCgroupPathRegex,
+		}
+
+		// Caching is disabled when TTL is zero
+		if ttl > 0 {
+			manager.procsCache = &procsCache{ttl: ttl}
+		}
+
+		b.Run(fmt.Sprintf("ttl=%s", ttl), func(b *testing.B) {
+			for range b.N {
+				manager.cgroupProcs(paths, dirs)
+			}
+		})
+	}
+}
@@ -92,6 +92,14 @@ func (b *CEEMSExporter) Main() error {
 			"web.debug-server",
 			"Enable debug server (default: disabled).",
 		).Default("false").Bool()
+		selfTestMode = b.App.Flag(
+			"self-test",
+			"Run all enabled collectors once, print the metrics and exit. Hostname label is always empty in this mode (default: disabled).",
+		).Default("false").Bool()
+		selfTestGoldenFile = b.App.Flag(
+			"self-test.golden-file",
+			"Path to golden file to compare the metric names and labels of self test against.",
+		).Default("").String()
 
 		// test CLI flags hidden
 		dropPrivs = b.App.Flag(
@@ -142,8 +150,18 @@ func (b *CEEMSExporter) Main() error {
 		"host_details", internal_runtime.Uname(), "fd_limits", internal_runtime.FdLimits(),
 	)
 
-	// Get hostname
-	if !*emptyHostnameLabel {
+	// Get absolute path for golden file if provided
+	var goldenFilePath string
+	if *selfTestGoldenFile != "" {
+		goldenFilePath, err = filepath.Abs(*selfTestGoldenFile)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path of the golden file: %w", err)
+		}
+	}
+
+	// Get hostname. Always use empty hostname in self test mode so that
+	// the output is reproducible across hosts
+	if !*emptyHostnameLabel && !*selfTestMode {
 		hostname, err = os.Hostname()
 		if err != nil {
 			logger.Error("Failed to get hostname", "err", err)
@@ -184,7 +202,7 @@ func (b *CEEMSExporter) Main() error {
 		securityCfg := &security.Config{
 			RunAsUser: "nobody",
 			Caps:      allCollectorCaps,
-			ReadPaths: []string{webConfigFilePath},
+			ReadPaths: []string{webConfigFilePath, goldenFilePath},
 		}
 
 		// Drop all unnecessary privileges
@@ -193,6 +211,11 @@ func (b *CEEMSExporter) Main() error {
 		}
 	}
 
+	// In self test mode, run collectors once and exit
+	if *selfTestMode {
+		return selfTest(collector, goldenFilePath, os.Stdout, logger)
+	}
+
 	// Create web server config
 	config := &Config{
 		Logger:     logger,
@@ -239,6 +262,27 @@ func (b *CEEMSExporter) Main() error {
 		}
 	}()
 
+	// Reload collectors and ignored processes on SIGHUP
+	go func() {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+
+		defer signal.Stop(hup)
+
+		for {
+			select {
+			case <-hup:
+				logger.Info("Reloading exporter configuration")
+
+				if err := reloadConfig(&b.App, os.Args[1:], collector, logger); err != nil {
+					logger.Error("Failed to reload exporter configuration", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	// Listen for the interrupt signal.
 	<-ctx.Done()
 
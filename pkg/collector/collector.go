@@ -99,6 +99,8 @@ func RegisterCollector(
 type CEEMSCollector struct {
 	Collectors map[string]Collector
 	logger     *slog.Logger
+	disabledMu sync.RWMutex
+	disabled   map[string]bool // collectors disabled at runtime
 }
 
 // DisableDefaultCollectors sets the collector state to false for all collectors which
@@ -181,17 +183,28 @@ func NewCEEMSCollector(logger *slog.Logger) (*CEEMSCollector, error) {
 }
 
 // Describe implements the prometheus.Collector interface.
-func (n CEEMSCollector) Describe(ch chan<- *prometheus.Desc) {
+func (n *CEEMSCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- scrapeDurationDesc
 	ch <- scrapeSuccessDesc
 }
 
 // Collect implements the prometheus.Collector interface.
-func (n CEEMSCollector) Collect(ch chan<- prometheus.Metric) {
-	wg := sync.WaitGroup{}
-	wg.Add(len(n.Collectors))
+func (n *CEEMSCollector) Collect(ch chan<- prometheus.Metric) {
+	// Skip collectors disabled at runtime
+	n.disabledMu.RLock()
+	collectors := make(map[string]Collector, len(n.Collectors))
 
 	for name, c := range n.Collectors {
+		if !n.disabled[name] {
+			collectors[name] = c
+		}
+	}
+	n.disabledMu.RUnlock()
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(collectors))
+
+	for name, c := range collectors {
 		go func(name string, c Collector) {
 			execute(name, c, ch, n.logger)
 			wg.Done()
@@ -202,7 +215,7 @@ func (n CEEMSCollector) Collect(ch chan<- prometheus.Metric) {
 }
 
 // Close stops all the collectors and release system resources.
-func (n CEEMSCollector) Close(ctx context.Context) error {
+func (n *CEEMSCollector) Close(ctx context.Context) error {
 	var errs error
 
 	for _, c := range n.Collectors {
@@ -214,6 +227,46 @@ func (n CEEMSCollector) Close(ctx context.Context) error {
 	return errs
 }
 
+// SetEnabled enables or disables collectors at runtime based on states. Only
+// collectors that were enabled when exporter started can be toggled as new
+// collectors might need privileges that have been dropped already. Disabled
+// collectors are not stopped so that they can be enabled again.
+func (n *CEEMSCollector) SetEnabled(states map[string]bool) {
+	n.disabledMu.Lock()
+	defer n.disabledMu.Unlock()
+
+	disabled := make(map[string]bool)
+
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		enabled := states[name]
+
+		if _, ok := n.Collectors[name]; !ok {
+			if enabled {
+				n.logger.Warn("Collector cannot be enabled at runtime. Restart exporter to enable it", "collector", name)
+			}
+
+			continue
+		}
+
+		if !enabled {
+			disabled[name] = true
+		}
+
+		if enabled == n.disabled[name] {
+			n.logger.Info("Collector state changed", "collector", name, "enabled", enabled)
+		}
+	}
+
+	n.disabled = disabled
+}
+
 // execute collects the metrics from each collector.
 func execute(name string, c Collector, ch chan<- prometheus.Metric, logger *slog.Logger) {
 	begin := time.Now()
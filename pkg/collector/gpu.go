@@ -1,25 +1,56 @@
 package collector
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mahendrapaipuri/ceems/internal/osexec"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var gpuPowerDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(Namespace, "gpu", "power_watts"),
+	"Estimated power usage of GPU in Watts",
+	[]string{
+		"manager",
+		"hostname",
+		"index",
+		"gpuuuid",
+		"source",
+	},
+	nil,
+)
+
+// CLI options.
+var (
+	smiCacheTTL = CEEMSExporterApp.Flag(
+		"collector.gpu.smi-cache-ttl",
+		"Duration for which outputs of nvidia-smi commands are cached. Ideally set it to the scrape interval. Cache is always invalidated when MIG instances or mediated devices are reconfigured. Use 0s to disable cache.",
+	).Default("15s").Duration()
+	gpuStaticPower = CEEMSExporterApp.Flag(
+		"collector.gpu.static-power",
+		"Estimated power usage in Watts of GPU models that do not report power usage. Model must be a substring of GPU name as reported by SMI command. Repeat the flag for each model (eg: --collector.gpu.static-power=\"Tesla K80=150\").",
+	).StringMap()
 )
 
 // Used for e2e tests.
 var (
 	gpuType = CEEMSExporterApp.Flag(
 		"collector.gpu.type",
-		"GPU device type. Currently only nvidia and amd devices are supported.",
-	).Hidden().Enum("nvidia", "amd")
+		"GPU device type. Currently only nvidia, amd and intel devices are supported.",
+	).Hidden().Enum("nvidia", "amd", "intel")
 	nvidiaSmiPath = CEEMSExporterApp.Flag(
 		"collector.gpu.nvidia-smi-path",
 		"Absolute path to nvidia-smi binary. Use only for testing.",
@@ -28,6 +59,10 @@ var (
 		"collector.gpu.rocm-smi-path",
 		"Absolute path to rocm-smi binary. Use only for testing.",
 	).Hidden().Default("").String()
+	xpuSmiPath = CEEMSExporterApp.Flag(
+		"collector.gpu.xpu-smi-path",
+		"Absolute path to xpu-smi binary. Use only for testing.",
+	).Hidden().Default("").String()
 )
 
 // Regexes.
@@ -38,8 +73,77 @@ var (
 		"mdevUUID":  regexp.MustCompile(`^\s+MDEV UUID\s+: ([a-zA-Z0-9\-]+)`),
 		"gpuInstID": regexp.MustCompile(`^\s+GPU Instance ID\s+: ([0-9]+|N/A)`),
 	}
+	migProfileRegex = regexp.MustCompile(`^\|\s+([0-9]+)\s+MIG\s+(\S+)\s+[0-9]+\s+[0-9]+/[0-9]+\s+([0-9.]+)\s+\S+\s+([0-9]+)\s+`)
 )
 
+// smiOutputCache caches outputs of SMI commands so that repeated invocations
+// of same command by different collectors reuse a single output.
+var smiOutputCache = &smiCache{entries: make(map[string]smiCacheEntry)}
+
+// smiCacheEntry is the output of SMI command along with the time of execution.
+type smiCacheEntry struct {
+	out     []byte
+	created time.Time
+}
+
+// smiCache caches the outputs of SMI commands.
+type smiCache struct {
+	mu      sync.Mutex
+	state   string // Fingerprint of MIG instances and mdevs when cache was last populated
+	entries map[string]smiCacheEntry
+}
+
+// execute returns the output of the command from cache when it is still valid.
+// Otherwise command is executed and its output is cached. Lock is held while
+// the command is executed so that concurrent callers wait for a single execution.
+func (c *smiCache) execute(cmd string, args []string) ([]byte, error) {
+	if *smiCacheTTL <= 0 {
+		return osexec.Execute(cmd, args, nil)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Invalidate cache when MIG instances or mdevs have been reconfigured
+	if state := gpuPartitionsState(); state != c.state {
+		c.entries = make(map[string]smiCacheEntry)
+		c.state = state
+	}
+
+	key := cmd + " " + strings.Join(args, " ")
+	if entry, ok := c.entries[key]; ok && time.Since(entry.created) < *smiCacheTTL {
+		return entry.out, nil
+	}
+
+	out, err := osexec.Execute(cmd, args, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.entries[key] = smiCacheEntry{out: out, created: time.Now()}
+
+	return out, nil
+}
+
+// gpuPartitionsState returns a fingerprint of MIG GPU instances and mediated
+// devices (vGPUs) on the host. NVIDIA driver creates a capability directory for
+// every GPU instance and kernel creates a sysfs entry for every mdev. Thus, the
+// fingerprint changes whenever MIG instances or mdevs are created or destroyed.
+func gpuPartitionsState() string {
+	var paths []string
+
+	for _, pattern := range []string{
+		procFilePath("driver/nvidia/capabilities/gpu*/mig/gi*"),
+		sysFilePath("bus/mdev/devices/*"),
+	} {
+		if matches, err := filepath.Glob(pattern); err == nil {
+			paths = append(paths, matches...)
+		}
+	}
+
+	return strings.Join(paths, ",")
+}
+
 // BusID is a struct that contains PCI bus address of GPU device.
 type BusID struct {
 	domain   uint64
@@ -113,17 +217,25 @@ type MIGMode struct {
 	CurrentMIG string   `xml:"current_mig"`
 }
 
+// PowerReadings contains power readings of GPU. Older drivers report them
+// under power_readings element and newer ones under gpu_power_readings.
+type PowerReadings struct {
+	PowerDraw string `xml:"power_draw"`
+}
+
 type GPU struct {
-	XMLName      xml.Name   `xml:"gpu"`
-	ID           string     `xml:"id,attr"`
-	ProductName  string     `xml:"product_name"`
-	ProductBrand string     `xml:"product_brand"`
-	ProductArch  string     `xml:"product_architecture"`
-	MIGMode      MIGMode    `xml:"mig_mode"`
-	VirtMode     VirtMode   `xml:"gpu_virtualization_mode"`
-	MIGDevices   MIGDevices `xml:"mig_devices"`
-	UUID         string     `xml:"uuid"`
-	MinorNumber  string     `xml:"minor_number"`
+	XMLName             xml.Name      `xml:"gpu"`
+	ID                  string        `xml:"id,attr"`
+	ProductName         string        `xml:"product_name"`
+	ProductBrand        string        `xml:"product_brand"`
+	ProductArch         string        `xml:"product_architecture"`
+	MIGMode             MIGMode       `xml:"mig_mode"`
+	VirtMode            VirtMode      `xml:"gpu_virtualization_mode"`
+	MIGDevices          MIGDevices    `xml:"mig_devices"`
+	UUID                string        `xml:"uuid"`
+	MinorNumber         string        `xml:"minor_number"`
+	PowerReadings       PowerReadings `xml:"gpu_power_readings"`
+	LegacyPowerReadings PowerReadings `xml:"power_readings"`
 }
 
 type NVIDIASMILog struct {
@@ -137,9 +249,19 @@ type MIGInstance struct {
 	computeInstID uint64
 	gpuInstID     uint64
 	smFraction    float64
+	numSMs        uint64
+	memory        float64 // FB memory in GiB
+	profile       string
 	mdevUUIDs     []string
 }
 
+// migProfile contains the details of MIG GPU instance profile.
+type migProfile struct {
+	name   string
+	numSMs uint64
+	memory float64 // Memory in GiB
+}
+
 // Device contains the details of GPU devices.
 type Device struct {
 	localIndex   string
@@ -151,6 +273,7 @@ type Device struct {
 	migInstances []MIGInstance
 	migEnabled   bool
 	vgpuEnabled  bool
+	staticPower  float64 // Estimated power in Watts when set by --collector.gpu.static-power
 }
 
 // String implements Stringer interface of the Device struct.
@@ -176,15 +299,187 @@ func (d *Device) CompareBusID(id string) bool {
 	return d.busID.Compare(busID)
 }
 
+// gpuPowerEstimate contains estimated power of a physical GPU or a MIG instance.
+type gpuPowerEstimate struct {
+	index  string
+	uuid   string
+	source string
+	power  float64
+}
+
+// estimatedPower returns estimated power of the physical GPU and, when MIG is
+// enabled, of each MIG instance. The configured static power and the measured
+// power of MIG enabled GPUs are split across MIG instances proportional to their
+// SM share. Measured power of GPUs without MIG is not exported as it is already
+// exported by vendor exporters like DCGM exporter.
+func (d *Device) estimatedPower(measuredPower float64) []gpuPowerEstimate {
+	var estimates []gpuPowerEstimate
+
+	// Only GPUs whose static power is configured
+	if d.staticPower > 0 {
+		estimates = append(estimates, d.splitPower(d.staticPower, "static")...)
+	}
+
+	if d.migEnabled && measuredPower > 0 {
+		estimates = append(estimates, d.splitPower(measuredPower, "measured")...)
+	}
+
+	return estimates
+}
+
+// splitPower returns power of physical GPU with label source and, when MIG
+// is enabled, power of each MIG instance with label source suffixed by _mig.
+func (d *Device) splitPower(power float64, source string) []gpuPowerEstimate {
+	estimates := []gpuPowerEstimate{
+		{index: d.localIndex, uuid: d.uuid, source: source, power: power},
+	}
+
+	if !d.migEnabled {
+		return estimates
+	}
+
+	// We use same gpuuuid format <gpu_uuid>/<mig_instance_id> as in
+	// compute unit GPU flag metric so that estimated power can be
+	// attributed to compute units
+	for _, mig := range d.migInstances {
+		estimates = append(estimates, gpuPowerEstimate{
+			index:  mig.globalIndex,
+			uuid:   fmt.Sprintf("%s/%d", d.uuid, mig.gpuInstID),
+			source: source + "_mig",
+			power:  power * mig.smFraction,
+		})
+	}
+
+	return estimates
+}
+
+// updateGPUPower updates the metrics channel with estimated power of GPUs.
+func updateGPUPower(ch chan<- prometheus.Metric, devs []Device, manager string, hostname string, logger *slog.Logger) {
+	// Measured power is only needed for MIG enabled GPUs which are only
+	// supported on NVIDIA GPUs
+	var measuredPowers map[string]float64
+
+	if slices.ContainsFunc(devs, func(d Device) bool { return d.migEnabled }) {
+		var err error
+		if measuredPowers, err = nvidiaGPUPower(); err != nil {
+			logger.Error("Failed to get measured power of GPUs", "err", err)
+		}
+	}
+
+	for _, dev := range devs {
+		for _, e := range dev.estimatedPower(measuredPowers[dev.uuid]) {
+			ch <- prometheus.MustNewConstMetric(
+				gpuPowerDesc,
+				prometheus.GaugeValue,
+				e.power,
+				manager,
+				hostname,
+				e.index,
+				e.uuid,
+				e.source,
+			)
+		}
+	}
+}
+
+// nvidiaGPUPower returns measured power in Watts of NVIDIA GPUs keyed by their
+// UUIDs. GPUs that do not report their power are omitted. Outputs of nvidia-smi
+// are cached and hence, power is not read more than once per cache TTL.
+func nvidiaGPUPower() (map[string]float64, error) {
+	nvidiaSmiCmd, err := lookupNvidiaSmiCmd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find nvidia-smi command: %w", err)
+	}
+
+	nvidiaSmiOutput, err := smiOutputCache.execute(nvidiaSmiCmd, []string{"--query", "--xml-format"})
+	if err != nil {
+		return nil, err
+	}
+
+	var nvidiaSMILog NVIDIASMILog
+	if err := xml.Unmarshal(nvidiaSmiOutput, &nvidiaSMILog); err != nil {
+		return nil, err
+	}
+
+	powers := make(map[string]float64)
+
+	for _, gpu := range nvidiaSMILog.GPUs {
+		powerDraw := gpu.PowerReadings.PowerDraw
+		if powerDraw == "" {
+			powerDraw = gpu.LegacyPowerReadings.PowerDraw
+		}
+
+		// Power is reported as 65.43 W or N/A when not supported
+		if power, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(powerDraw), "W")), 64); err == nil {
+			powers[gpu.UUID] = power
+		}
+	}
+
+	return powers, nil
+}
+
 // GetGPUDevices returns GPU devices.
 func GetGPUDevices(gpuType string, logger *slog.Logger) ([]Device, error) {
-	if gpuType == "nvidia" {
-		return GetNvidiaGPUDevices(logger)
-	} else if gpuType == "amd" {
-		return GetAMDGPUDevices(logger)
+	var devs []Device
+
+	var err error
+
+	switch gpuType {
+	case "nvidia":
+		devs, err = GetNvidiaGPUDevices(logger)
+	case "amd":
+		devs, err = GetAMDGPUDevices(logger)
+	case "intel":
+		devs, err = GetIntelGPUDevices(logger)
+	default:
+		return nil, fmt.Errorf("unknown GPU Type %s. Only nVIDIA, AMD and Intel GPU devices are supported", gpuType)
+	}
+
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("unknown GPU Type %s. Only nVIDIA and AMD GPU devices are supported", gpuType)
+	return updateGPUStaticPower(devs, logger), nil
+}
+
+// updateGPUStaticPower sets estimated power of GPU devices whose model is configured
+// in --collector.gpu.static-power. When several models match the name of a GPU,
+// the longest one is used.
+func updateGPUStaticPower(devs []Device, logger *slog.Logger) []Device {
+	if len(*gpuStaticPower) == 0 {
+		return devs
+	}
+
+	powers := make(map[string]float64)
+
+	for model, value := range *gpuStaticPower {
+		power, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || power < 0 {
+			logger.Error("Invalid static power of GPU model. Ignoring it", "model", model, "power", value)
+
+			continue
+		}
+
+		powers[model] = power
+	}
+
+	for idev := range devs {
+		var match string
+
+		for model := range powers {
+			if strings.Contains(devs[idev].name, model) && len(model) > len(match) {
+				match = model
+			}
+		}
+
+		if match != "" {
+			devs[idev].staticPower = powers[match]
+
+			logger.Debug("Static power set for GPU", "gpu", devs[idev].uuid, "model", match, "power", powers[match])
+		}
+	}
+
+	return devs
 }
 
 // GetNvidiaGPUDevices returns all physical or MIG devices using nvidia-smi command
@@ -209,12 +504,17 @@ func GetNvidiaGPUDevices(logger *slog.Logger) ([]Device, error) {
 	// Execute nvidia-smi command to get available GPUs
 	args := []string{"--query", "--xml-format"}
 
-	nvidiaSmiOutput, err := osexec.Execute(nvidiaSmiCmd, args, nil)
+	nvidiaSmiOutput, err := smiOutputCache.execute(nvidiaSmiCmd, args)
+	if err != nil {
+		return nil, err
+	}
+
+	gpuDevices, err := parseNvidiaSmiOutput(nvidiaSmiOutput, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	return parseNvidiaSmiOutput(nvidiaSmiOutput, logger)
+	return updateMIGProfiles(nvidiaSmiCmd, gpuDevices, logger), nil
 }
 
 // GetAMDGPUDevices returns all GPU devices using rocm-smi command
@@ -242,6 +542,39 @@ func GetAMDGPUDevices(logger *slog.Logger) ([]Device, error) {
 	return parseAmdSmioutput(string(rocmSmiOutput), logger), nil
 }
 
+// GetIntelGPUDevices returns all GPU devices using xpu-smi command
+// Example output:
+// bash-4.4$ xpu-smi discovery --json
+//
+//	{
+//	    "device_list": [
+//	        {
+//	            "device_id": 0,
+//	            "device_name": "Intel(R) Data Center GPU Max 1550",
+//	            "pci_bdf_address": "0000:29:00.0",
+//	            "uuid": "01000000-0000-0000-0000-000000290000",
+//	            ...
+//	        }
+//	    ]
+//	}
+func GetIntelGPUDevices(logger *slog.Logger) ([]Device, error) {
+	// Look up xpu-smi command
+	xpuSmiCmd, err := lookupXpuSmiCmd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find xpu-smi command: %w", err)
+	}
+
+	// Execute xpu-smi command to get available GPUs
+	args := []string{"discovery", "--json"}
+
+	xpuSmiOutput, err := osexec.Execute(xpuSmiCmd, args, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseXpuSmiOutput(xpuSmiOutput, logger)
+}
+
 // lookupNvidiaSmiCmd checks if nvidia-smi path provided by CLI exists and falls back
 // to `nvidia-smi` command on host.
 func lookupNvidiaSmiCmd() (string, error) {
@@ -280,6 +613,25 @@ func lookupRocmSmiCmd() (string, error) {
 	}
 }
 
+// lookupXpuSmiCmd checks if xpu-smi path provided by CLI exists and falls back
+// to `xpu-smi` command on host.
+func lookupXpuSmiCmd() (string, error) {
+	if *xpuSmiPath != "" {
+		if _, err := os.Stat(*xpuSmiPath); err != nil {
+			return "", err
+		}
+
+		return *xpuSmiPath, nil
+	}
+
+	xpuSmiCmd := "xpu-smi"
+	if _, err := exec.LookPath(xpuSmiCmd); err != nil {
+		return "", err
+	}
+
+	return xpuSmiCmd, nil
+}
+
 // parseNvidiaSmiOutput parses nvidia-smi output and return GPU Devices map.
 func parseNvidiaSmiOutput(cmdOutput []byte, logger *slog.Logger) ([]Device, error) {
 	// Get all devices
@@ -329,6 +681,8 @@ func parseNvidiaSmiOutput(cmdOutput []byte, logger *slog.Logger) ([]Device, erro
 				globalIndex:   strconv.FormatUint(globalIndex, 10),
 				computeInstID: mig.ComputeInstID,
 				gpuInstID:     mig.GPUInstID,
+				numSMs:        mig.DeviceAttrs.Shared.SMCount,
+				memory:        parseMemoryGiB(mig.FBMemory.Total),
 			}
 
 			totalSMs += float64(mig.DeviceAttrs.Shared.SMCount)
@@ -359,6 +713,112 @@ func parseNvidiaSmiOutput(cmdOutput []byte, logger *slog.Logger) ([]Device, erro
 	return gpuDevices, nil
 }
 
+// parseMemoryGiB parses memory string of nvidia-smi output like 4864 MiB and
+// returns memory in GiB. Returns 0 if memory cannot be parsed.
+func parseMemoryGiB(memory string) float64 {
+	fields := strings.Fields(memory)
+	if len(fields) != 2 {
+		return 0
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+
+	switch fields[1] {
+	case "KiB":
+		return value / (1024 * 1024)
+	case "MiB":
+		return value / 1024
+	case "GiB":
+		return value
+	default:
+		return 0
+	}
+}
+
+// parseMIGProfiles parses output of nvidia-smi mig -lgip command and returns
+// MIG GPU instance profiles keyed by GPU index.
+// Example output:
+// +-----------------------------------------------------------------------------+
+// | GPU instance profiles:                                                      |
+// | GPU   Name             ID    Instances   Memory     P2P    SM    DEC   ENC  |
+// |                              Free/Total   GiB              CE    JPEG  OFA  |
+// |=============================================================================|
+// |   0  MIG 1g.5gb        19     7/7        4.75       No     14     0     0   |
+// |                                                             1     0     0   |
+// +-----------------------------------------------------------------------------+.
+func parseMIGProfiles(cmdOutput string) map[string][]migProfile {
+	profiles := make(map[string][]migProfile)
+
+	for _, line := range strings.Split(cmdOutput, "\n") {
+		matches := migProfileRegex.FindStringSubmatch(line)
+		if len(matches) < 5 {
+			continue
+		}
+
+		memory, err := strconv.ParseFloat(matches[3], 64)
+		if err != nil {
+			continue
+		}
+
+		numSMs, err := strconv.ParseUint(matches[4], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		profiles[matches[1]] = append(profiles[matches[1]], migProfile{name: matches[2], numSMs: numSMs, memory: memory})
+	}
+
+	return profiles
+}
+
+// updateMIGProfiles sets the profile names like 1g.5gb of MIG instances of GPU devices.
+// As nvidia-smi does not report the profile of MIG instances, profile is identified by
+// matching SM count and memory of instance with the ones of GPU instance profiles
+// supported by the GPU. Profile names are left empty when they cannot be identified.
+func updateMIGProfiles(nvidiaSmiCmd string, devs []Device, logger *slog.Logger) []Device {
+	// nvidia-smi mig command fails when there are no MIG enabled GPUs
+	if !slices.ContainsFunc(devs, func(d Device) bool { return d.migEnabled }) {
+		return devs
+	}
+
+	out, err := smiOutputCache.execute(nvidiaSmiCmd, []string{"mig", "-lgip"})
+	if err != nil {
+		logger.Error("Failed to fetch MIG profiles", "err", err)
+
+		return devs
+	}
+
+	profiles := parseMIGProfiles(string(out))
+
+	for idev := range devs {
+		for imig := range devs[idev].migInstances {
+			mig := &devs[idev].migInstances[imig]
+
+			// First matching profile wins. Profiles with media extensions (+me)
+			// are listed after the ones without them and have the same resources.
+			for _, p := range profiles[devs[idev].localIndex] {
+				if p.numSMs == mig.numSMs && math.Abs(p.memory-mig.memory) < 0.5 {
+					mig.profile = p.name
+
+					break
+				}
+			}
+
+			if mig.profile == "" {
+				logger.Debug(
+					"Failed to identify profile of MIG instance", "gpu", devs[idev].uuid,
+					"gpu_instance_id", mig.gpuInstID, "num_sms", mig.numSMs, "memory_gib", mig.memory,
+				)
+			}
+		}
+	}
+
+	return devs
+}
+
 // parseAmdSmioutput parses rocm-smi output and return AMD devices.
 func parseAmdSmioutput(cmdOutput string, logger *slog.Logger) []Device {
 	var gpuDevices []Device
@@ -395,6 +855,44 @@ func parseAmdSmioutput(cmdOutput string, logger *slog.Logger) []Device {
 	return gpuDevices
 }
 
+// xpuSmiDevice is a device returned by xpu-smi discovery command.
+type xpuSmiDevice struct {
+	ID            int    `json:"device_id"`
+	Name          string `json:"device_name"`
+	UUID          string `json:"uuid"`
+	PCIBDFAddress string `json:"pci_bdf_address"`
+}
+
+// parseXpuSmiOutput parses xpu-smi output and return Intel devices.
+func parseXpuSmiOutput(cmdOutput []byte, logger *slog.Logger) ([]Device, error) {
+	var xpuSmiDevices struct {
+		DeviceList []xpuSmiDevice `json:"device_list"`
+	}
+
+	if err := json.Unmarshal(cmdOutput, &xpuSmiDevices); err != nil {
+		return nil, err
+	}
+
+	var gpuDevices []Device
+
+	for _, d := range xpuSmiDevices.DeviceList {
+		devIndx := strconv.FormatInt(int64(d.ID), 10)
+
+		// Parse bus ID
+		busID, err := parseBusID(d.PCIBDFAddress)
+		if err != nil {
+			logger.Error("Failed to parse GPU bus ID", "bus_id", d.PCIBDFAddress, "err", err)
+		}
+
+		dev := Device{localIndex: devIndx, globalIndex: devIndx, name: d.Name, uuid: d.UUID, busID: busID, migEnabled: false}
+		logger.Debug("Found Intel GPU", "gpu", dev)
+
+		gpuDevices = append(gpuDevices, dev)
+	}
+
+	return gpuDevices, nil
+}
+
 // reindexGPUs reindexes GPU globalIndex based on orderMap string.
 func reindexGPUs(orderMap string, devs []Device) []Device {
 	for _, gpuMap := range strings.Split(orderMap, ",") {
@@ -434,7 +932,7 @@ func updateGPUMdevs(devs []Device) ([]Device, error) {
 	}
 
 	// Execute command
-	stdOut, err := osexec.Execute(nvidiaSmiCmd, []string{"vgpu", "--query"}, nil)
+	stdOut, err := smiOutputCache.execute(nvidiaSmiCmd, []string{"vgpu", "--query"})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute nvidia-smi vgpu command: %w", err)
 	}
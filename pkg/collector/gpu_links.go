@@ -0,0 +1,352 @@
+//go:build !nogpulinks
+// +build !nogpulinks
+
+package collector
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mahendrapaipuri/ceems/internal/osexec"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const gpuLinksCollectorSubsystem = "gpu_links"
+
+// Used for e2e tests.
+var amdSmiPath = CEEMSExporterApp.Flag(
+	"collector.gpu.amd-smi-path",
+	"Absolute path to amd-smi binary. Use only for testing.",
+).Hidden().Default("").String()
+
+// Regexes to parse `nvidia-smi nvlink -gt d` output.
+var (
+	nvlinkGPURegex  = regexp.MustCompile(`^GPU ([0-9]+): .*\(UUID: ([a-zA-Z0-9\-]+)\)`)
+	nvlinkLinkRegex = regexp.MustCompile(`^\s+Link ([0-9]+): Data (Tx|Rx): ([0-9]+) (KiB|MiB|GiB|B)`)
+)
+
+// Units reported by nvidia-smi and amd-smi for link counters.
+var linkUnitMultipliers = map[string]float64{
+	"B":   1,
+	"KB":  1024,
+	"KiB": 1024,
+	"MB":  1024 * 1024,
+	"MiB": 1024 * 1024,
+	"GB":  1024 * 1024 * 1024,
+	"GiB": 1024 * 1024 * 1024,
+}
+
+// gpuLinkCounter is the cumulative data transferred on a given
+// link of a GPU in a given direction.
+type gpuLinkCounter struct {
+	index     string
+	uuid      string
+	bdf       string
+	link      string
+	direction string
+	bytes     float64
+}
+
+type gpuLinksCollector struct {
+	logger      *slog.Logger
+	hostname    string
+	gpuType     string
+	smiCmd      string
+	devs        []Device
+	nvlinkBytes *prometheus.Desc
+	xgmiBytes   *prometheus.Desc
+}
+
+func init() {
+	RegisterCollector(gpuLinksCollectorSubsystem, defaultDisabled, NewGPULinksCollector)
+}
+
+// NewGPULinksCollector returns a new Collector exposing inter GPU link
+// (NVLink and XGMI) traffic metrics.
+func NewGPULinksCollector(logger *slog.Logger) (Collector, error) {
+	var gpuTypes []string
+
+	// If gpuType is set, use it to find the SMI command. Else
+	// attempt to find an nvidia SMI command first and then AMD.
+	if *gpuType != "" {
+		gpuTypes = []string{*gpuType}
+	} else {
+		gpuTypes = []string{"nvidia", "amd"}
+	}
+
+	collector := gpuLinksCollector{
+		logger:   logger,
+		hostname: hostname,
+		nvlinkBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "gpu", "nvlink_bytes_total"),
+			"Total number of bytes transferred on NVLink",
+			[]string{"hostname", "index", "gpuuuid", "link", "direction"}, nil,
+		),
+		xgmiBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "gpu", "xgmi_bytes_total"),
+			"Total number of bytes transferred on XGMI link",
+			[]string{"hostname", "index", "gpuuuid", "bdf", "link", "direction"}, nil,
+		),
+	}
+
+	for _, typ := range gpuTypes {
+		var cmd string
+
+		var err error
+
+		switch typ {
+		case "nvidia":
+			cmd, err = lookupNvidiaSmiCmd()
+		case "amd":
+			cmd, err = lookupAmdSmiCmd()
+		default:
+			err = fmt.Errorf("GPU links metrics are not supported for %s GPUs", typ)
+		}
+
+		if err == nil {
+			collector.gpuType = typ
+			collector.smiCmd = cmd
+
+			// amd-smi xgmi does not report UUIDs of GPUs and they are found
+			// from GPU devices using bus IDs
+			if typ == "amd" {
+				if collector.devs, err = GetAMDGPUDevices(logger); err != nil {
+					logger.Warn("Failed to fetch AMD GPU devices. gpuuuid label of XGMI metrics will be empty", "err", err)
+				}
+			}
+
+			logger.Info("GPU links metrics will be collected", "type", typ, "cmd", cmd)
+
+			return &collector, nil
+		}
+	}
+
+	logger.Error("Failed to find nvidia-smi or amd-smi command to fetch GPU link metrics")
+
+	return nil, errors.New("no nvidia-smi or amd-smi command found")
+}
+
+// Update implements Collector and exposes GPU link metrics.
+func (c *gpuLinksCollector) Update(ch chan<- prometheus.Metric) error {
+	var counters []gpuLinkCounter
+
+	var desc *prometheus.Desc
+
+	var err error
+
+	switch c.gpuType {
+	case "nvidia":
+		desc = c.nvlinkBytes
+		counters, err = c.nvlinkCounters()
+	case "amd":
+		desc = c.xgmiBytes
+		counters, err = c.xgmiCounters()
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch GPU link metrics: %w", err)
+	}
+
+	for _, counter := range counters {
+		labels := []string{c.hostname, counter.index, counter.uuid, counter.link, counter.direction}
+		if c.gpuType == "amd" {
+			labels = []string{c.hostname, counter.index, counter.uuid, counter.bdf, counter.link, counter.direction}
+		}
+
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, counter.bytes, labels...)
+	}
+
+	return nil
+}
+
+// Stop releases system resources used by the collector.
+func (c *gpuLinksCollector) Stop(_ context.Context) error {
+	c.logger.Debug("Stopping", "collector", gpuLinksCollectorSubsystem)
+
+	return nil
+}
+
+// nvlinkCounters returns NVLink data counters of all GPUs.
+func (c *gpuLinksCollector) nvlinkCounters() ([]gpuLinkCounter, error) {
+	out, err := osexec.Execute(c.smiCmd, []string{"nvlink", "-gt", "d"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseNvlinkOutput(string(out)), nil
+}
+
+// xgmiCounters returns XGMI data counters of all GPUs.
+func (c *gpuLinksCollector) xgmiCounters() ([]gpuLinkCounter, error) {
+	out, err := osexec.Execute(c.smiCmd, []string{"xgmi", "--metric", "--json"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	counters, err := parseXgmiOutput(out)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set UUID of GPU using its bus ID
+	for i := range counters {
+		for _, dev := range c.devs {
+			if dev.CompareBusID(counters[i].bdf) {
+				counters[i].uuid = dev.uuid
+
+				break
+			}
+		}
+	}
+
+	return counters, nil
+}
+
+// parseNvlinkOutput parses output of `nvidia-smi nvlink -gt d` command.
+//
+// Typical output looks as follows:
+//
+//	GPU 0: NVIDIA A100-SXM4-80GB (UUID: GPU-61a65011-6571-a6d2-5ab8-66cbb6f7f9c3)
+//		 Link 0: Data Tx: 2063842155 KiB
+//		 Link 0: Data Rx: 2063865725 KiB
+//
+// Links whose counters are not available (N/A) are ignored.
+func parseNvlinkOutput(cmdOutput string) []gpuLinkCounter {
+	var counters []gpuLinkCounter
+
+	var index, uuid string
+
+	scanner := bufio.NewScanner(strings.NewReader(cmdOutput))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if matches := nvlinkGPURegex.FindStringSubmatch(line); len(matches) == 3 {
+			index = matches[1]
+			uuid = matches[2]
+
+			continue
+		}
+
+		// Ignore link lines if we have not found a GPU yet
+		if index == "" {
+			continue
+		}
+
+		if matches := nvlinkLinkRegex.FindStringSubmatch(line); len(matches) == 5 {
+			val, err := strconv.ParseFloat(matches[3], 64)
+			if err != nil {
+				continue
+			}
+
+			counters = append(counters, gpuLinkCounter{
+				index:     index,
+				uuid:      uuid,
+				link:      matches[1],
+				direction: strings.ToLower(matches[2]),
+				bytes:     val * linkUnitMultipliers[matches[4]],
+			})
+		}
+	}
+
+	return counters
+}
+
+// amdSmiValue is a value reported by amd-smi along with its unit.
+type amdSmiValue struct {
+	Value json.RawMessage `json:"value"`
+	Unit  string          `json:"unit"`
+}
+
+// bytes returns the value in bytes. Returns false when value is not available.
+func (v amdSmiValue) bytes() (float64, bool) {
+	val, err := strconv.ParseFloat(string(v.Value), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	multiplier, ok := linkUnitMultipliers[v.Unit]
+	if !ok {
+		return 0, false
+	}
+
+	return val * multiplier, true
+}
+
+// amdSmiXgmiOutput is the JSON output of `amd-smi xgmi --metric --json`.
+type amdSmiXgmiOutput struct {
+	XgmiMetric []struct {
+		GPU         int    `json:"gpu"`
+		BDF         string `json:"bdf"`
+		LinkMetrics struct {
+			LinkType string `json:"link_type"`
+			Links    []struct {
+				GPU   int         `json:"gpu"`
+				BDF   string      `json:"bdf"`
+				Read  amdSmiValue `json:"read"`
+				Write amdSmiValue `json:"write"`
+			} `json:"links"`
+		} `json:"link_metrics"`
+	} `json:"xgmi_metric"`
+}
+
+// parseXgmiOutput parses output of `amd-smi xgmi --metric --json` command.
+//
+// Each link is identified by the index of the peer GPU. Links to the
+// GPU itself report N/A and they are ignored.
+func parseXgmiOutput(cmdOutput []byte) ([]gpuLinkCounter, error) {
+	var output amdSmiXgmiOutput
+	if err := json.Unmarshal(cmdOutput, &output); err != nil {
+		return nil, err
+	}
+
+	var counters []gpuLinkCounter
+
+	for _, gpu := range output.XgmiMetric {
+		for _, link := range gpu.LinkMetrics.Links {
+			for _, value := range []struct {
+				direction string
+				amdSmiValue
+			}{{"rx", link.Read}, {"tx", link.Write}} {
+				if val, ok := value.bytes(); ok {
+					counters = append(counters, gpuLinkCounter{
+						index:     strconv.Itoa(gpu.GPU),
+						bdf:       gpu.BDF,
+						link:      strconv.Itoa(link.GPU),
+						direction: value.direction,
+						bytes:     val,
+					})
+				}
+			}
+		}
+	}
+
+	return counters, nil
+}
+
+// lookupAmdSmiCmd checks if amd-smi path provided by CLI exists and falls back
+// to `amd-smi` command on host.
+func lookupAmdSmiCmd() (string, error) {
+	if *amdSmiPath != "" {
+		if _, err := os.Stat(*amdSmiPath); err != nil {
+			return "", err
+		}
+
+		return *amdSmiPath, nil
+	} else {
+		amdSmiCmd := "amd-smi"
+		if _, err := exec.LookPath(amdSmiCmd); err != nil {
+			return "", err
+		} else {
+			return amdSmiCmd, nil
+		}
+	}
+}
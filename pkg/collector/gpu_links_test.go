@@ -0,0 +1,93 @@
+//go:build !nogpulinks
+// +build !nogpulinks
+
+package collector
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGPULinksCollector(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse([]string{
+		"--collector.gpu.type", "nvidia",
+		"--collector.gpu.nvidia-smi-path", "testdata/nvidia-smi",
+		"--collector.empty-hostname-label",
+	})
+	require.NoError(t, err)
+
+	collector, err := NewGPULinksCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// Setup background goroutine to capture metrics.
+	metrics := make(chan prometheus.Metric)
+	defer close(metrics)
+
+	go func() {
+		i := 0
+		for range metrics {
+			i++
+		}
+	}()
+
+	err = collector.Update(metrics)
+	require.NoError(t, err)
+
+	err = collector.Stop(context.Background())
+	require.NoError(t, err)
+}
+
+func TestParseNvlinkOutput(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse([]string{
+		"--collector.gpu.nvidia-smi-path", "testdata/nvidia-smi",
+	})
+	require.NoError(t, err)
+
+	c := gpuLinksCollector{gpuType: "nvidia", smiCmd: "testdata/nvidia-smi"}
+
+	counters, err := c.nvlinkCounters()
+	require.NoError(t, err)
+
+	expected := []gpuLinkCounter{
+		{index: "0", uuid: "GPU-f124aa59-d406-d45b-9481-8fcd694e6c9e", link: "0", direction: "tx", bytes: 2063842155 * 1024},
+		{index: "0", uuid: "GPU-f124aa59-d406-d45b-9481-8fcd694e6c9e", link: "0", direction: "rx", bytes: 2063865725 * 1024},
+		{index: "0", uuid: "GPU-f124aa59-d406-d45b-9481-8fcd694e6c9e", link: "1", direction: "tx", bytes: 1024 * 1024},
+		{index: "0", uuid: "GPU-f124aa59-d406-d45b-9481-8fcd694e6c9e", link: "1", direction: "rx", bytes: 2048 * 1024},
+		{index: "1", uuid: "GPU-61a65011-6571-a6d2-5ab8-66cbb6f7f9c3", link: "0", direction: "tx", bytes: 4096 * 1024},
+		{index: "1", uuid: "GPU-61a65011-6571-a6d2-5ab8-66cbb6f7f9c3", link: "0", direction: "rx", bytes: 8192 * 1024},
+	}
+	assert.Equal(t, expected, counters)
+}
+
+func TestParseXgmiOutput(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse([]string{
+		"--collector.gpu.amd-smi-path", "testdata/amd-smi",
+		"--collector.gpu.rocm-smi-path", "testdata/rocm-smi",
+	})
+	require.NoError(t, err)
+
+	cmd, err := lookupAmdSmiCmd()
+	require.NoError(t, err)
+
+	devs, err := GetAMDGPUDevices(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	c := gpuLinksCollector{gpuType: "amd", smiCmd: cmd, devs: devs}
+
+	counters, err := c.xgmiCounters()
+	require.NoError(t, err)
+
+	expected := []gpuLinkCounter{
+		{index: "0", uuid: "20170000800c", bdf: "0000:c5:00.0", link: "1", direction: "rx", bytes: 1024 * 1024},
+		{index: "0", uuid: "20170000800c", bdf: "0000:c5:00.0", link: "1", direction: "tx", bytes: 2048 * 1024},
+		{index: "1", uuid: "20170003580c", bdf: "0000:c8:00.0", link: "0", direction: "rx", bytes: 4096 * 1024},
+		{index: "1", uuid: "20170003580c", bdf: "0000:c8:00.0", link: "0", direction: "tx", bytes: 8192 * 1024},
+	}
+	assert.Equal(t, expected, counters)
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,9 +39,9 @@ func getExpectedNvidiaDevs() []Device {
 			uuid:       "GPU-956348bc-d43d-23ed-53d4-857749fa2b67",
 			busID:      BusID{domain: 0x0, bus: 0x21, device: 0x0, function: 0x0},
 			migInstances: []MIGInstance{
-				{localIndex: 0x0, globalIndex: "2", computeInstID: 0x0, gpuInstID: 0x1, smFraction: 0.6},
-				{localIndex: 0x1, globalIndex: "3", computeInstID: 0x0, gpuInstID: 0x5, smFraction: 0.2},
-				{localIndex: 0x2, globalIndex: "4", computeInstID: 0x0, gpuInstID: 0xd, smFraction: 0.2},
+				{localIndex: 0x0, globalIndex: "2", computeInstID: 0x0, gpuInstID: 0x1, smFraction: 0.6, numSMs: 42, memory: 19.5, profile: "3g.20gb"},
+				{localIndex: 0x1, globalIndex: "3", computeInstID: 0x0, gpuInstID: 0x5, smFraction: 0.2, numSMs: 14, memory: 9.625, profile: "1g.10gb"},
+				{localIndex: 0x2, globalIndex: "4", computeInstID: 0x0, gpuInstID: 0xd, smFraction: 0.2, numSMs: 14, memory: 4.75, profile: "1g.5gb"},
 			},
 			migEnabled:  true,
 			vgpuEnabled: true,
@@ -51,9 +52,9 @@ func getExpectedNvidiaDevs() []Device {
 			uuid:       "GPU-feba7e40-d724-01ff-b00f-3a439a28a6c7",
 			busID:      BusID{domain: 0x0, bus: 0x81, device: 0x0, function: 0x0},
 			migInstances: []MIGInstance{
-				{localIndex: 0x0, globalIndex: "5", computeInstID: 0x0, gpuInstID: 0x1, smFraction: 0.5714285714285714},
-				{localIndex: 0x1, globalIndex: "6", computeInstID: 0x0, gpuInstID: 0x5, smFraction: 0.2857142857142857},
-				{localIndex: 0x2, globalIndex: "7", computeInstID: 0x0, gpuInstID: 0x6, smFraction: 0.14285714285714285},
+				{localIndex: 0x0, globalIndex: "5", computeInstID: 0x0, gpuInstID: 0x1, smFraction: 0.5714285714285714, numSMs: 56, memory: 19.5, profile: "4g.20gb"},
+				{localIndex: 0x1, globalIndex: "6", computeInstID: 0x0, gpuInstID: 0x5, smFraction: 0.2857142857142857, numSMs: 28, memory: 9.625, profile: "2g.10gb"},
+				{localIndex: 0x2, globalIndex: "7", computeInstID: 0x0, gpuInstID: 0x6, smFraction: 0.14285714285714285, numSMs: 14, memory: 9.625, profile: "1g.10gb"},
 			},
 			migEnabled:  true,
 			vgpuEnabled: true,
@@ -289,6 +290,141 @@ func TestParseAmdSmiOutput(t *testing.T) {
 	assert.Equal(t, getExpectedAmdDevs(), gpuDevices)
 }
 
+func TestUpdateGPUStaticPower(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--collector.gpu.rocm-smi-path", "testdata/rocm-smi",
+			"--collector.gpu.static-power", "MI50=300",
+			"--collector.gpu.static-power", "MI50 32GB=250",
+			"--collector.gpu.static-power", "MI100=invalid",
+		},
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { *gpuStaticPower = make(map[string]string) })
+
+	gpuDevices, err := GetGPUDevices("amd", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// Longest matching model must be used
+	for _, dev := range gpuDevices {
+		assert.Equal(t, float64(250), dev.staticPower, dev.uuid)
+	}
+}
+
+func TestDeviceEstimatedPower(t *testing.T) {
+	dev := Device{
+		localIndex: "1",
+		uuid:       "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3",
+		migEnabled: true,
+		migInstances: []MIGInstance{
+			{globalIndex: "2", gpuInstID: 0x1, smFraction: 0.6},
+			{globalIndex: "3", gpuInstID: 0x5, smFraction: 0.2},
+			{globalIndex: "4", gpuInstID: 0xd, smFraction: 0.2},
+		},
+		staticPower: 400,
+	}
+
+	expected := []gpuPowerEstimate{
+		{index: "1", uuid: "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3", source: "static", power: 400},
+		{index: "2", uuid: "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3/1", source: "static_mig", power: 240},
+		{index: "3", uuid: "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3/5", source: "static_mig", power: 80},
+		{index: "4", uuid: "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3/13", source: "static_mig", power: 80},
+	}
+	assert.Equal(t, expected, dev.estimatedPower(0))
+
+	// Measured power must be split as well
+	measured := []gpuPowerEstimate{
+		{index: "1", uuid: "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3", source: "measured", power: 200},
+		{index: "2", uuid: "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3/1", source: "measured_mig", power: 120},
+		{index: "3", uuid: "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3/5", source: "measured_mig", power: 40},
+		{index: "4", uuid: "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3/13", source: "measured_mig", power: 40},
+	}
+	assert.Equal(t, append(expected, measured...), dev.estimatedPower(200))
+
+	// Without MIG only physical GPU static power must be returned
+	dev.migEnabled = false
+	assert.Equal(t, expected[:1], dev.estimatedPower(200))
+
+	// Without static power nothing must be returned
+	dev.staticPower = 0
+	assert.Empty(t, dev.estimatedPower(200))
+}
+
+func TestNvidiaGPUPower(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--collector.gpu.nvidia-smi-path", "testdata/nvidia-smi",
+		},
+	)
+	require.NoError(t, err)
+
+	gpuDevices, err := GetNvidiaGPUDevices(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// Both new and legacy power readings must be parsed
+	powers, err := nvidiaGPUPower()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{
+		"GPU-956348bc-d43d-23ed-53d4-857749fa2b67": 200,
+		"GPU-feba7e40-d724-01ff-b00f-3a439a28a6c7": 150,
+	}, powers)
+
+	// Measured power of MIG GPU must be split by SM share
+	var estimates []gpuPowerEstimate
+	for _, dev := range gpuDevices {
+		estimates = append(estimates, dev.estimatedPower(powers[dev.uuid])...)
+	}
+
+	expected := []gpuPowerEstimate{
+		{index: "2", uuid: "GPU-956348bc-d43d-23ed-53d4-857749fa2b67", source: "measured", power: 200},
+		{index: "2", uuid: "GPU-956348bc-d43d-23ed-53d4-857749fa2b67/1", source: "measured_mig", power: 120},
+		{index: "3", uuid: "GPU-956348bc-d43d-23ed-53d4-857749fa2b67/5", source: "measured_mig", power: 40},
+		{index: "4", uuid: "GPU-956348bc-d43d-23ed-53d4-857749fa2b67/13", source: "measured_mig", power: 40},
+		{index: "3", uuid: "GPU-feba7e40-d724-01ff-b00f-3a439a28a6c7", source: "measured", power: 150},
+		{index: "5", uuid: "GPU-feba7e40-d724-01ff-b00f-3a439a28a6c7/1", source: "measured_mig", power: 85.714},
+		{index: "6", uuid: "GPU-feba7e40-d724-01ff-b00f-3a439a28a6c7/5", source: "measured_mig", power: 42.857},
+		{index: "7", uuid: "GPU-feba7e40-d724-01ff-b00f-3a439a28a6c7/6", source: "measured_mig", power: 21.429},
+	}
+	require.Len(t, estimates, len(expected))
+
+	for i, e := range expected {
+		assert.Equal(t, e.uuid, estimates[i].uuid)
+		assert.Equal(t, e.index, estimates[i].index)
+		assert.Equal(t, e.source, estimates[i].source)
+		assert.InDelta(t, e.power, estimates[i].power, 1e-3, e.uuid)
+	}
+}
+
+func TestParseXpuSmiOutput(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--collector.gpu.xpu-smi-path", "testdata/xpu-smi",
+		},
+	)
+	require.NoError(t, err)
+	gpuDevices, err := GetIntelGPUDevices(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	expectedDevs := []Device{
+		{
+			localIndex:  "0",
+			globalIndex: "0",
+			name:        "Intel(R) Data Center GPU Max 1550",
+			uuid:        "01000000-0000-0000-0000-000000290000",
+			busID:       BusID{domain: 0x0, bus: 0x29, device: 0x0, function: 0x0},
+		},
+		{
+			localIndex:  "1",
+			globalIndex: "1",
+			name:        "Intel(R) Data Center GPU Max 1550",
+			uuid:        "01000000-0000-0000-0000-0000003a0000",
+			busID:       BusID{domain: 0x0, bus: 0x3a, device: 0x0, function: 0x0},
+		},
+	}
+	assert.Equal(t, expectedDevs, gpuDevices)
+}
+
 func TestReindexGPUs(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -559,9 +695,9 @@ func TestUpdateMdevs(t *testing.T) {
 			localIndex: "2", globalIndex: "", name: "NVIDIA A100-PCIE-40GB NVIDIA Ampere", uuid: "GPU-956348bc-d43d-23ed-53d4-857749fa2b67",
 			busID: BusID{domain: 0x0, bus: 0x21, device: 0x0, function: 0x0},
 			migInstances: []MIGInstance{
-				{localIndex: 0x0, globalIndex: "2", computeInstID: 0x0, gpuInstID: 0x1, smFraction: 0.6, mdevUUIDs: []string{"f0f4b97c-6580-48a6-ae1b-a807d6dfe08f"}},
-				{localIndex: 0x1, globalIndex: "3", computeInstID: 0x0, gpuInstID: 0x5, smFraction: 0.2, mdevUUIDs: []string{"3b356d38-854e-48be-b376-00c72c7d119c", "5bb3bad7-ce3b-4aa5-84d7-b5b33cf9d45e"}},
-				{localIndex: 0x2, globalIndex: "4", computeInstID: 0x0, gpuInstID: 0xd, smFraction: 0.2, mdevUUIDs: []string{}},
+				{localIndex: 0x0, globalIndex: "2", computeInstID: 0x0, gpuInstID: 0x1, smFraction: 0.6, numSMs: 42, memory: 19.5, profile: "3g.20gb", mdevUUIDs: []string{"f0f4b97c-6580-48a6-ae1b-a807d6dfe08f"}},
+				{localIndex: 0x1, globalIndex: "3", computeInstID: 0x0, gpuInstID: 0x5, smFraction: 0.2, numSMs: 14, memory: 9.625, profile: "1g.10gb", mdevUUIDs: []string{"3b356d38-854e-48be-b376-00c72c7d119c", "5bb3bad7-ce3b-4aa5-84d7-b5b33cf9d45e"}},
+				{localIndex: 0x2, globalIndex: "4", computeInstID: 0x0, gpuInstID: 0xd, smFraction: 0.2, numSMs: 14, memory: 4.75, profile: "1g.5gb", mdevUUIDs: []string{}},
 			},
 			migEnabled: true, vgpuEnabled: true,
 		},
@@ -569,9 +705,9 @@ func TestUpdateMdevs(t *testing.T) {
 			localIndex: "3", globalIndex: "", name: "NVIDIA A100-PCIE-40GB NVIDIA Ampere", uuid: "GPU-feba7e40-d724-01ff-b00f-3a439a28a6c7",
 			busID: BusID{domain: 0x0, bus: 0x81, device: 0x0, function: 0x0},
 			migInstances: []MIGInstance{
-				{localIndex: 0x0, globalIndex: "5", computeInstID: 0x0, gpuInstID: 0x1, smFraction: 0.5714285714285714, mdevUUIDs: []string{"4f84d324-5897-48f3-a4ef-94c9ddf23d78"}},
-				{localIndex: 0x1, globalIndex: "6", computeInstID: 0x0, gpuInstID: 0x5, smFraction: 0.2857142857142857, mdevUUIDs: []string{"3058eb95-0899-4c3d-90e9-e20b6c14789f"}},
-				{localIndex: 0x2, globalIndex: "7", computeInstID: 0x0, gpuInstID: 0x6, smFraction: 0.14285714285714285, mdevUUIDs: []string{"9f0d5993-9778-40c7-a721-3fec93d6b3a9"}},
+				{localIndex: 0x0, globalIndex: "5", computeInstID: 0x0, gpuInstID: 0x1, smFraction: 0.5714285714285714, numSMs: 56, memory: 19.5, profile: "4g.20gb", mdevUUIDs: []string{"4f84d324-5897-48f3-a4ef-94c9ddf23d78"}},
+				{localIndex: 0x1, globalIndex: "6", computeInstID: 0x0, gpuInstID: 0x5, smFraction: 0.2857142857142857, numSMs: 28, memory: 9.625, profile: "2g.10gb", mdevUUIDs: []string{"3058eb95-0899-4c3d-90e9-e20b6c14789f"}},
+				{localIndex: 0x2, globalIndex: "7", computeInstID: 0x0, gpuInstID: 0x6, smFraction: 0.14285714285714285, numSMs: 14, memory: 9.625, profile: "1g.10gb", mdevUUIDs: []string{"9f0d5993-9778-40c7-a721-3fec93d6b3a9"}},
 			},
 			migEnabled: true, vgpuEnabled: true,
 		},
@@ -619,9 +755,11 @@ echo """%s"""
 `, nvidiaVGPULog)
 	os.WriteFile(nvidiaSMIPath, []byte(content), 0o700) // #nosec
 
+	// Disable cache as nvidia-smi output is changed without changing mdevs on host
 	_, err := CEEMSExporterApp.Parse(
 		[]string{
 			"--collector.gpu.nvidia-smi-path", nvidiaSMIPath,
+			"--collector.gpu.smi-cache-ttl", "0s",
 		},
 	)
 	require.NoError(t, err)
@@ -680,6 +818,81 @@ echo """%s"""
 	assert.EqualValues(t, []string{"741ac383-27e9-49a9-9955-b513ad2e2e16"}, updatedGPUDevs[0].mdevUUIDs)
 }
 
+func TestSMIOutputCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	procDir := filepath.Join(tmpDir, "proc")
+	sysDir := filepath.Join(tmpDir, "sys")
+	countFile := filepath.Join(tmpDir, "count")
+
+	// Mock nvidia-smi that records each invocation
+	nvidiaSMIPath := filepath.Join(tmpDir, "nvidia-smi")
+	content := fmt.Sprintf(`#!/bin/bash
+echo 1 >> %s
+echo "GPU 00000000:10:00.0"
+`, countFile)
+	require.NoError(t, os.WriteFile(nvidiaSMIPath, []byte(content), 0o700)) // #nosec
+
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.procfs", procDir,
+			"--path.sysfs", sysDir,
+			"--collector.gpu.smi-cache-ttl", "1h",
+		},
+	)
+	require.NoError(t, err)
+
+	numInvocations := func() int {
+		data, err := os.ReadFile(countFile)
+		require.NoError(t, err)
+
+		return len(strings.Split(strings.TrimSpace(string(data)), "\n"))
+	}
+
+	cache := &smiCache{entries: make(map[string]smiCacheEntry)}
+
+	// Repeated invocations must use cached output
+	for range 3 {
+		out, err := cache.execute(nvidiaSMIPath, []string{"vgpu", "--query"})
+		require.NoError(t, err)
+		assert.Equal(t, "GPU 00000000:10:00.0\n", string(out))
+	}
+
+	assert.Equal(t, 1, numInvocations())
+
+	// Different arguments must not use cached output
+	_, err = cache.execute(nvidiaSMIPath, []string{"--query", "--xml-format"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, numInvocations())
+
+	// Creating a MIG instance must invalidate cache
+	require.NoError(t, os.MkdirAll(filepath.Join(procDir, "driver", "nvidia", "capabilities", "gpu0", "mig", "gi1"), 0o755))
+
+	_, err = cache.execute(nvidiaSMIPath, []string{"vgpu", "--query"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, numInvocations())
+
+	// Creating a mdev must invalidate cache
+	require.NoError(t, os.MkdirAll(filepath.Join(sysDir, "bus", "mdev", "devices", "c73f1fa6-489e-4834-9476-d70dabd98c40"), 0o755))
+
+	_, err = cache.execute(nvidiaSMIPath, []string{"vgpu", "--query"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, numInvocations())
+
+	// Disabled cache must always execute command
+	_, err = CEEMSExporterApp.Parse(
+		[]string{
+			"--path.procfs", procDir,
+			"--path.sysfs", sysDir,
+			"--collector.gpu.smi-cache-ttl", "0s",
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = cache.execute(nvidiaSMIPath, []string{"vgpu", "--query"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, numInvocations())
+}
+
 func TestParseBusIDPass(t *testing.T) {
 	id := "00000000:AD:00.0"
 	busID, err := parseBusID(id)
@@ -53,6 +53,8 @@ type impiCollector struct {
 	client           *ipmi.IPMIClient
 	securityContexts map[string]*security.SecurityContext
 	cachedMetric     map[string]float64
+	cacheDuration    time.Duration
+	lastReadingTime  time.Time
 	metricDesc       map[string]*prometheus.Desc
 }
 
@@ -154,6 +156,10 @@ var (
 		"collector.ipmi_dcmi.force-native-mode",
 		"Force native mode using OpenIPMI driver.",
 	).Default("false").Bool()
+	ipmiCacheDuration = CEEMSExporterApp.Flag(
+		"collector.ipmi_dcmi.cache-duration",
+		"Power readings are cached for this duration and BMC is not queried until cache expires. Set to 0 to disable (default: 0s).",
+	).Default("0s").Duration()
 
 	// test flags. Hidden.
 	ipmiDcmiTestMode = CEEMSExporterApp.Flag(
@@ -321,6 +327,7 @@ outside:
 		execMode:         execMode,
 		metricDesc:       metricDesc,
 		cachedMetric:     cachedMetric,
+		cacheDuration:    *ipmiCacheDuration,
 		securityContexts: make(map[string]*security.SecurityContext),
 	}
 
@@ -369,10 +376,23 @@ outside:
 
 // Update implements Collector and exposes IPMI DCMI power related metrics.
 func (c *impiCollector) Update(ch chan<- prometheus.Metric) error {
+	// Avoid querying BMC when cached readings are still valid
+	if c.cacheDuration > 0 && len(c.cachedMetric) > 0 && time.Since(c.lastReadingTime) < c.cacheDuration {
+		c.logger.Debug("Using cached power statistics", "cached_metrics", fmt.Sprintf("%#v", c.cachedMetric))
+
+		for rType, rValue := range c.cachedMetric {
+			ch <- prometheus.MustNewConstMetric(c.metricDesc[rType], prometheus.GaugeValue, rValue, c.hostname)
+		}
+
+		return nil
+	}
+
 	// Get power consumption from IPMI
 	// IPMI commands tend to fail frequently. If that happens we use last cached metric
 	powerReadings, err := c.getPowerReadings()
-	if err != nil {
+	if err == nil {
+		c.lastReadingTime = time.Now()
+	} else {
 		// If there is no cached metric return
 		if len(c.cachedMetric) == 0 {
 			return ErrNoData
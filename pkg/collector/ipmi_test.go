@@ -124,6 +124,48 @@ func TestIPMICollector(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestIPMICollectorCache(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse([]string{
+		"--collector.ipmi_dcmi.cmd", "testdata/ipmi/capmc/capmc",
+		"--collector.ipmi_dcmi.test-mode",
+		"--collector.ipmi_dcmi.cache-duration", "1h",
+	})
+	require.NoError(t, err)
+
+	collector, err := NewIPMICollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	c, ok := collector.(*impiCollector)
+	require.True(t, ok)
+
+	// Setup background goroutine to capture metrics.
+	metrics := make(chan prometheus.Metric)
+	defer close(metrics)
+
+	go func() {
+		i := 0
+		for range metrics {
+			i++
+		}
+	}()
+
+	err = collector.Update(metrics)
+	require.NoError(t, err)
+
+	lastReadingTime := c.lastReadingTime
+	assert.False(t, lastReadingTime.IsZero())
+
+	// Second update must be served from cache without querying BMC
+	c.ipmiCmd = []string{"testdata/ipmi/capmc/non-existent"}
+
+	err = collector.Update(metrics)
+	require.NoError(t, err)
+	assert.Equal(t, lastReadingTime, c.lastReadingTime)
+
+	err = collector.Stop(context.Background())
+	require.NoError(t, err)
+}
+
 func TestIpmiMetrics(t *testing.T) {
 	c := impiCollector{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
 
@@ -39,6 +39,10 @@ var (
 		"collector.libvirt.psi-metrics",
 		"Enables collection of PSI metrics (default: disabled)",
 	).Default("false").Bool()
+	libvirtCollectNetStats = CEEMSExporterApp.Flag(
+		"collector.libvirt.net-metrics",
+		"Enables collection of network metrics of instances using net_cls controller on cgroups v1. Only transmitted traffic is accounted (default: disabled)",
+	).Default("false").Bool()
 
 	// testing flags.
 	libvirtXMLDir = CEEMSExporterApp.Flag(
@@ -98,10 +102,17 @@ type libvirtReadXMLSecurityCtxData struct {
 	instanceProps instanceProps
 }
 
+// vGPU contains the details of vGPU (mdev) bound to instance.
+type vGPU struct {
+	mdevUUID string
+	gpuUUID  string // Of format <gpu_uuid>/<mig_instance_id>
+}
+
 // instanceProps contains VM properties.
 type instanceProps struct {
 	uuid        string   // This is Openstack's specific UUID
 	gpuOrdinals []string // GPU ordinals bound to instance
+	vGPUs       []vGPU   // vGPUs bound to instance
 }
 
 type libvirtMetrics struct {
@@ -121,6 +132,7 @@ type libvirtCollector struct {
 	gpuDevs                     []Device
 	vGPUActivated               bool
 	instanceGpuFlag             *prometheus.Desc
+	instanceVGPUMap             *prometheus.Desc
 	collectError                *prometheus.Desc
 	instancePropsCache          map[string]instanceProps
 	instancePropsCacheTTL       time.Duration
@@ -149,6 +161,7 @@ func NewLibvirtCollector(logger *slog.Logger) (Collector, error) {
 		collectSwapMemStats: *libvirtCollectSwapMemoryStats,
 		collectBlockIOStats: *libvirtCollectBlkIOStats,
 		collectPSIStats:     *libvirtCollectPSIStats,
+		collectNetStats:     *libvirtCollectNetStats,
 	}
 
 	// Start new instance of cgroupCollector
@@ -203,7 +216,7 @@ func NewLibvirtCollector(logger *slog.Logger) (Collector, error) {
 	if *gpuType != "" {
 		gpuTypes = []string{*gpuType}
 	} else {
-		gpuTypes = []string{"nvidia", "amd"}
+		gpuTypes = []string{"nvidia", "amd", "intel"}
 	}
 
 	for _, gpuType := range gpuTypes {
@@ -261,6 +274,19 @@ func NewLibvirtCollector(logger *slog.Logger) (Collector, error) {
 				"index",
 				"hindex",
 				"gpuuuid",
+				"migprofile",
+			},
+			nil,
+		),
+		instanceVGPUMap: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "gpu", "vgpu_mapping"),
+			"Maps vGPU (mdev) identified by mdev UUID to the running instance that it is bound to",
+			[]string{
+				"manager",
+				"hostname",
+				"uuid",
+				"mdev",
+				"gpuuuid",
 			},
 			nil,
 		),
@@ -278,6 +304,9 @@ func NewLibvirtCollector(logger *slog.Logger) (Collector, error) {
 func (c *libvirtCollector) Update(ch chan<- prometheus.Metric) error {
 	metrics, err := c.instanceMetrics()
 	if err != nil {
+		// Export discovery errors even when discovery fails
+		c.cgroupCollector.updateDiscoveryErrors(ch)
+
 		return err
 	}
 
@@ -293,9 +322,15 @@ func (c *libvirtCollector) Update(ch chan<- prometheus.Metric) error {
 			c.logger.Error("Failed to update cgroup stats", "err", err)
 		}
 
-		// Update instance GPU ordinals
+		// Update instance GPU ordinals and estimated GPU power
 		if len(c.gpuDevs) > 0 {
 			c.updateGPUOrdinals(ch, metrics.instanceProps)
+			updateGPUPower(ch, c.gpuDevs, c.cgroupManager.manager, c.hostname, c.logger)
+		}
+
+		// Update vGPU to instance mappings
+		if c.vGPUActivated {
+			c.updateVGPUMappings(ch, metrics.instanceProps)
 		}
 	}()
 
@@ -384,7 +419,7 @@ func (c *libvirtCollector) updateGPUOrdinals(ch chan<- prometheus.Metric, instan
 	for _, p := range instanceProps {
 		// GPU instance mapping
 		for _, gpuOrdinal := range p.gpuOrdinals {
-			var gpuuuid, miggid string
+			var gpuuuid, miggid, migprofile string
 
 			flagValue := float64(1)
 			// Check the int index of devices where gpuOrdinal == dev.index
@@ -394,6 +429,7 @@ func (c *libvirtCollector) updateGPUOrdinals(ch chan<- prometheus.Metric, instan
 					if gpuOrdinal == mig.globalIndex {
 						gpuuuid = dev.uuid
 						miggid = strconv.FormatUint(mig.gpuInstID, 10)
+						migprofile = mig.profile
 
 						// For MIG, we export SM fraction as flag value
 						// For vGPU enabled GPUs this fraction must be
@@ -434,6 +470,25 @@ func (c *libvirtCollector) updateGPUOrdinals(ch chan<- prometheus.Metric, instan
 				gpuOrdinal,
 				fmt.Sprintf("%s/gpu-%s", c.hostname, gpuOrdinal),
 				fmt.Sprintf("%s/%s", gpuuuid, miggid),
+				migprofile,
+			)
+		}
+	}
+}
+
+// updateVGPUMappings updates the metrics channel with vGPUs bound to instance.
+func (c *libvirtCollector) updateVGPUMappings(ch chan<- prometheus.Metric, instanceProps []instanceProps) {
+	for _, p := range instanceProps {
+		for _, vgpu := range p.vGPUs {
+			ch <- prometheus.MustNewConstMetric(
+				c.instanceVGPUMap,
+				prometheus.GaugeValue,
+				1,
+				c.cgroupManager.manager,
+				c.hostname,
+				p.uuid,
+				vgpu.mdevUUID,
+				vgpu.gpuUUID,
 			)
 		}
 	}
@@ -457,6 +512,9 @@ func (c *libvirtCollector) instanceProperties(cgroups []cgroup) libvirtMetrics {
 		c.instancePropslastUpdateTime = time.Now()
 	}
 
+	// Read boot time once to compute start times of processes
+	bootTime := c.cgroupManager.bootTime()
+
 	for icgrp := range cgroups {
 		instanceID := cgroups[icgrp].id
 
@@ -475,7 +533,7 @@ func (c *libvirtCollector) instanceProperties(cgroups []cgroup) libvirtMetrics {
 			activeInstanceIDs = append(activeInstanceIDs, instanceID)
 		}
 
-		cgMetrics = append(cgMetrics, cgMetric{uuid: cgroups[icgrp].uuid, path: "/" + cgroups[icgrp].path.rel})
+		cgMetrics = append(cgMetrics, cgMetric{uuid: cgroups[icgrp].uuid, path: "/" + cgroups[icgrp].path.rel, numProcs: len(cgroups[icgrp].procs), numGPUs: len(c.instancePropsCache[instanceID].gpuOrdinals), startTime: procsStartTime(cgroups[icgrp].procs, bootTime)})
 	}
 
 	// Remove terminated instances from instancePropsCache
@@ -569,6 +627,8 @@ func readLibvirtXMLFile(data interface{}) error {
 	// Loop over hostdevs to get GPU IDs
 	var gpuOrdinals []string
 
+	var vGPUs []vGPU
+
 	for _, hostDev := range domain.Devices.HostDevs {
 		// PCIe pass through
 		if hostDev.Type == "pci" {
@@ -597,6 +657,7 @@ func readLibvirtXMLFile(data interface{}) error {
 					for _, mig := range dev.migInstances {
 						if slices.Contains(mig.mdevUUIDs, mdevUUID) {
 							gpuOrdinals = append(gpuOrdinals, mig.globalIndex)
+							vGPUs = append(vGPUs, vGPU{mdevUUID: mdevUUID, gpuUUID: fmt.Sprintf("%s/%d", dev.uuid, mig.gpuInstID)})
 
 							break
 						}
@@ -604,6 +665,7 @@ func readLibvirtXMLFile(data interface{}) error {
 				} else {
 					if slices.Contains(dev.mdevUUIDs, mdevUUID) {
 						gpuOrdinals = append(gpuOrdinals, dev.globalIndex)
+						vGPUs = append(vGPUs, vGPU{mdevUUID: mdevUUID, gpuUUID: dev.uuid + "/"})
 
 						break
 					}
@@ -616,6 +678,7 @@ func readLibvirtXMLFile(data interface{}) error {
 	d.instanceProps = instanceProps{
 		uuid:        domain.UUID,
 		gpuOrdinals: gpuOrdinals,
+		vGPUs:       vGPUs,
 	}
 
 	return nil
@@ -111,8 +111,19 @@ func TestLibvirtInstanceProps(t *testing.T) {
 
 	expectedProps := []instanceProps{
 		{uuid: "57f2d45e-8ddf-4338-91df-62d0044ff1b5", gpuOrdinals: []string{"1", "8"}},
-		{uuid: "b674a0a2-c300-4dc6-8c9c-65df16da6d69", gpuOrdinals: []string{"0", "3"}},
-		{uuid: "2896bdd5-dbc2-4339-9d8e-ddd838bf35d3", gpuOrdinals: []string{"11", "9"}},
+		{
+			uuid: "b674a0a2-c300-4dc6-8c9c-65df16da6d69", gpuOrdinals: []string{"0", "3"},
+			vGPUs: []vGPU{
+				{mdevUUID: "c73f1fa6-489e-4834-9476-d70dabd98c40", gpuUUID: "GPU-f124aa59-d406-d45b-9481-8fcd694e6c9e/"},
+				{mdevUUID: "3b356d38-854e-48be-b376-00c72c7d119c", gpuUUID: "GPU-956348bc-d43d-23ed-53d4-857749fa2b67/5"},
+			},
+		},
+		{
+			uuid: "2896bdd5-dbc2-4339-9d8e-ddd838bf35d3", gpuOrdinals: []string{"11", "9"},
+			vGPUs: []vGPU{
+				{mdevUUID: "64c3c4ae-44e1-45b8-8d46-5f76a1fa9824", gpuUUID: "GPU-61a65011-6571-a64n-5ab8-66cbb6f7f9c3/"},
+			},
+		},
 		{uuid: "4de89c5b-50d7-4d30-a630-14e135380fe8", gpuOrdinals: []string(nil)},
 	}
 
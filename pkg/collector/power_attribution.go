@@ -0,0 +1,291 @@
+package collector
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/internal/security"
+	"github.com/prometheus/procfs/sysfs"
+)
+
+// Power attribution models.
+const (
+	powerAttributionCPU    = "cpu"
+	powerAttributionMemory = "memory"
+	powerAttributionGPU    = "gpu"
+	powerAttributionEnergy = "energy"
+	powerAttributionEqual  = "equal"
+)
+
+// Security context names.
+const (
+	powerAttributionReadEnergyCounter = "power_attribution_read_energy_counters"
+)
+
+// CLI options.
+var (
+	powerAttributionModel = CEEMSExporterApp.Flag(
+		"collector.cgroups.power-attribution",
+		"Attribute node power measured by RAPL counters to compute units and export it. "+
+			"cpu: proportional to CPU time of units, memory: proportional to memory usage of units, "+
+			"gpu: proportional to number of GPUs bound to units, energy: package power proportional to "+
+			"CPU time and DRAM power proportional to memory usage, equal: equal split between units (default: disabled).",
+	).Default("").Enum("", powerAttributionCPU, powerAttributionMemory, powerAttributionGPU, powerAttributionEnergy, powerAttributionEqual)
+
+	powerAttributionIdleWatts = CEEMSExporterApp.Flag(
+		"collector.cgroups.power-attribution.idle-watts",
+		"Idle power of the node in watts. Idle power is always split equally between compute units and "+
+			"only the remaining power is attributed using the configured model.",
+	).Default("0").Float64()
+)
+
+// powerAttributor estimates the share of node power of each compute unit.
+type powerAttributor struct {
+	logger           *slog.Logger
+	fs               sysfs.FS
+	model            string
+	idleWatts        float64
+	securityContexts map[string]*security.SecurityContext
+	mu               sync.Mutex
+	lastTime         time.Time
+	lastEnergy       map[sysfs.RaplZone]uint64
+	lastCPUTime      map[string]float64
+}
+
+// unitUsage contains the usage of a compute unit used to split node power.
+type unitUsage struct {
+	cpuTime float64 // CPU time consumed since last scrape
+	memory  float64
+	gpus    float64
+}
+
+// newPowerAttributor returns a new instance of powerAttributor.
+func newPowerAttributor(manager string, logger *slog.Logger) (*powerAttributor, error) {
+	fs, err := sysfs.NewFS(*sysPath)
+	if err != nil {
+		return nil, err
+	}
+
+	securityContexts := make(map[string]*security.SecurityContext)
+
+	// Starting from kernel 5.10, RAPL counters are read only by root.
+	// So we need CAP_DAC_READ_SEARCH capability to read them.
+	if currentKernelVer, err := KernelVersion(); err == nil && currentKernelVer >= KernelStringToNumeric("5.10") {
+		reqCaps := setupCollectorCaps(logger, manager, []string{"cap_dac_read_search"})
+
+		securityContexts[powerAttributionReadEnergyCounter], err = security.NewSecurityContext(
+			powerAttributionReadEnergyCounter,
+			reqCaps,
+			readCounters,
+			logger,
+		)
+		if err != nil {
+			logger.Error("Failed to create a security context for reading rapl counters", "err", err)
+
+			return nil, err
+		}
+	}
+
+	return &powerAttributor{
+		logger:           logger,
+		fs:               fs,
+		model:            *powerAttributionModel,
+		idleWatts:        *powerAttributionIdleWatts,
+		securityContexts: securityContexts,
+		lastEnergy:       make(map[sysfs.RaplZone]uint64),
+		lastCPUTime:      make(map[string]float64),
+	}, nil
+}
+
+// attributionZones returns the RAPL zones whose energy is attributed to compute
+// units. When psys zone is present, it covers the entire SoC and hence, it is
+// the only zone used. Otherwise, top level package zones and DRAM zones are used
+// as DRAM is a separate RAPL domain that is not accounted in package counters.
+// Core and uncore zones are already included in package counters and MMIO zones
+// duplicate the package counters. Hence, they are ignored to avoid double counting.
+func attributionZones(zones []sysfs.RaplZone) []sysfs.RaplZone {
+	var psys, selected []sysfs.RaplZone
+
+	for _, zone := range zones {
+		dir := filepath.Base(zone.Path)
+		if !strings.HasPrefix(dir, "intel-rapl:") {
+			continue
+		}
+
+		// Top level zones have paths like intel-rapl:0 and sub zones
+		// have paths like intel-rapl:0:0
+		topLevel := strings.Count(dir, ":") == 1
+
+		switch {
+		case topLevel && zone.Name == "psys":
+			psys = append(psys, zone)
+		case topLevel && zone.Name == "package", !topLevel && zone.Name == "dram":
+			selected = append(selected, zone)
+		}
+	}
+
+	if len(psys) > 0 {
+		return psys
+	}
+
+	return selected
+}
+
+// nodePower returns the average package (or psys) and DRAM power in watts since
+// last call based on the RAPL energy counters. Returns false when power cannot be
+// estimated, for instance, on the very first call.
+func (p *powerAttributor) nodePower() (float64, float64, bool) {
+	zones, err := sysfs.GetRaplZones(p.fs)
+	if err != nil {
+		p.logger.Debug("Failed to fetch RAPL zones for power attribution", "err", err)
+
+		return 0, 0, false
+	}
+
+	dataPtr := &raplCountersSecurityCtxData{
+		zones:    attributionZones(zones),
+		counters: make(map[sysfs.RaplZone]uint64),
+	}
+
+	if securityCtx, ok := p.securityContexts[powerAttributionReadEnergyCounter]; ok {
+		err = securityCtx.Exec(dataPtr)
+	} else {
+		err = readCounters(dataPtr)
+	}
+
+	if err != nil || len(dataPtr.counters) == 0 {
+		p.logger.Debug("Failed to read RAPL energy counters for power attribution", "err", err)
+
+		return 0, 0, false
+	}
+
+	now := time.Now()
+
+	var pkgEnergy, dramEnergy float64
+
+	for zone, microJoules := range dataPtr.counters {
+		last, ok := p.lastEnergy[zone]
+		if !ok {
+			continue
+		}
+
+		// Handle counter wrap around
+		var energy float64
+		if microJoules >= last {
+			energy = float64(microJoules - last)
+		} else {
+			energy = float64(zone.MaxMicrojoules - last + microJoules)
+		}
+
+		if zone.Name == "dram" {
+			dramEnergy += energy
+		} else {
+			pkgEnergy += energy
+		}
+	}
+
+	interval := now.Sub(p.lastTime).Seconds()
+	found := !p.lastTime.IsZero() && interval > 0
+
+	p.lastTime = now
+	p.lastEnergy = dataPtr.counters
+
+	if !found {
+		return 0, 0, false
+	}
+
+	return pkgEnergy / 1000000.0 / interval, dramEnergy / 1000000.0 / interval, true
+}
+
+// attribute returns a map of compute unit UUIDs to their share of node power
+// in watts.
+func (p *powerAttributor) attribute(metrics []cgMetric) map[string]float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Get CPU time consumed by each unit since last call
+	cpuTime := make(map[string]float64)
+	usage := make(map[string]unitUsage)
+
+	for _, m := range metrics {
+		if m.err {
+			continue
+		}
+
+		cpuTime[m.uuid] = m.cpuUser + m.cpuSystem
+
+		// Units that appeared after last call consumed all of
+		// their CPU time since last call
+		usage[m.uuid] = unitUsage{
+			cpuTime: max(cpuTime[m.uuid]-p.lastCPUTime[m.uuid], 0),
+			memory:  m.memoryUsed,
+			gpus:    float64(m.numGPUs),
+		}
+	}
+
+	p.lastCPUTime = cpuTime
+
+	pkgPower, dramPower, ok := p.nodePower()
+	if !ok || len(usage) == 0 {
+		return nil
+	}
+
+	return splitPower(pkgPower, dramPower, p.idleWatts, p.model, usage)
+}
+
+// splitPower splits node power between compute units. Idle power is split
+// equally between all units and the rest is split based on the model. Idle
+// power is deducted from package and DRAM power proportionally to their
+// share of node power. When none of the units consumed the resource used
+// by the model, it falls back to equal split.
+func splitPower(pkgPower float64, dramPower float64, idleWatts float64, model string, usage map[string]unitUsage) map[string]float64 {
+	power := pkgPower + dramPower
+	idle := min(idleWatts, power)
+	numUnits := float64(len(usage))
+
+	var activePkg, activeDRAM float64
+	if power > 0 {
+		activePkg = pkgPower * (1 - idle/power)
+		activeDRAM = dramPower * (1 - idle/power)
+	}
+
+	var total unitUsage
+	for _, u := range usage {
+		total.cpuTime += u.cpuTime
+		total.memory += u.memory
+		total.gpus += u.gpus
+	}
+
+	// share returns the share of power based on the weight of unit.
+	share := func(power, weight, totalWeight float64) float64 {
+		if totalWeight > 0 {
+			return power * weight / totalWeight
+		}
+
+		return power / numUnits
+	}
+
+	shares := make(map[string]float64, len(usage))
+
+	for uuid, u := range usage {
+		switch model {
+		case powerAttributionCPU:
+			shares[uuid] = share(activePkg+activeDRAM, u.cpuTime, total.cpuTime)
+		case powerAttributionMemory:
+			shares[uuid] = share(activePkg+activeDRAM, u.memory, total.memory)
+		case powerAttributionGPU:
+			shares[uuid] = share(activePkg+activeDRAM, u.gpus, total.gpus)
+		case powerAttributionEnergy:
+			shares[uuid] = share(activePkg, u.cpuTime, total.cpuTime) + share(activeDRAM, u.memory, total.memory)
+		default:
+			shares[uuid] = (activePkg + activeDRAM) / numUnits
+		}
+
+		shares[uuid] += idle / numUnits
+	}
+
+	return shares
+}
@@ -0,0 +1,174 @@
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/procfs/sysfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPower(t *testing.T) {
+	tests := []struct {
+		name      string
+		pkgPower  float64
+		dramPower float64
+		idleWatts float64
+		model     string
+		usage     map[string]unitUsage
+		expected  map[string]float64
+	}{
+		{
+			name:     "cpu model",
+			pkgPower: 300,
+			model:    powerAttributionCPU,
+			usage: map[string]unitUsage{
+				"1": {cpuTime: 10},
+				"2": {cpuTime: 20},
+			},
+			expected: map[string]float64{"1": 100, "2": 200},
+		},
+		{
+			name:      "cpu model with idle power",
+			pkgPower:  300,
+			idleWatts: 100,
+			model:     powerAttributionCPU,
+			usage: map[string]unitUsage{
+				"1": {cpuTime: 0},
+				"2": {cpuTime: 40},
+			},
+			expected: map[string]float64{"1": 50, "2": 250},
+		},
+		{
+			name:     "cpu model without cpu usage",
+			pkgPower: 300,
+			model:    powerAttributionCPU,
+			usage: map[string]unitUsage{
+				"1": {cpuTime: 0},
+				"2": {cpuTime: 0},
+			},
+			expected: map[string]float64{"1": 150, "2": 150},
+		},
+		{
+			name:      "memory model",
+			pkgPower:  200,
+			dramPower: 100,
+			model:     powerAttributionMemory,
+			usage: map[string]unitUsage{
+				"1": {cpuTime: 10, memory: 1000},
+				"2": {cpuTime: 0, memory: 2000},
+			},
+			expected: map[string]float64{"1": 100, "2": 200},
+		},
+		{
+			name:      "gpu model",
+			pkgPower:  300,
+			idleWatts: 60,
+			model:     powerAttributionGPU,
+			usage: map[string]unitUsage{
+				"1": {cpuTime: 10, gpus: 1},
+				"2": {cpuTime: 10, gpus: 3},
+				"3": {cpuTime: 10},
+			},
+			expected: map[string]float64{"1": 80, "2": 200, "3": 20},
+		},
+		{
+			name:      "energy model",
+			pkgPower:  200,
+			dramPower: 100,
+			model:     powerAttributionEnergy,
+			usage: map[string]unitUsage{
+				"1": {cpuTime: 30, memory: 1000},
+				"2": {cpuTime: 10, memory: 3000},
+			},
+			expected: map[string]float64{"1": 175, "2": 125},
+		},
+		{
+			name:      "energy model with idle power",
+			pkgPower:  200,
+			dramPower: 100,
+			idleWatts: 150,
+			model:     powerAttributionEnergy,
+			usage: map[string]unitUsage{
+				"1": {cpuTime: 30, memory: 0},
+				"2": {cpuTime: 10, memory: 0},
+			},
+			expected: map[string]float64{"1": 175, "2": 125},
+		},
+		{
+			name:      "equal model",
+			pkgPower:  300,
+			idleWatts: 500,
+			model:     powerAttributionEqual,
+			usage: map[string]unitUsage{
+				"1": {cpuTime: 10},
+				"2": {cpuTime: 20},
+				"3": {cpuTime: 0},
+			},
+			expected: map[string]float64{"1": 100, "2": 100, "3": 100},
+		},
+	}
+
+	for _, test := range tests {
+		assert.InDeltaMapValues(t, test.expected, splitPower(test.pkgPower, test.dramPower, test.idleWatts, test.model, test.usage), 1e-9, test.name)
+	}
+}
+
+func TestAttributionZones(t *testing.T) {
+	zones := []sysfs.RaplZone{
+		{Name: "package", Index: 0, Path: "/sys/class/powercap/intel-rapl:0"},
+		{Name: "core", Index: 0, Path: "/sys/class/powercap/intel-rapl:0:0"},
+		{Name: "uncore", Index: 0, Path: "/sys/class/powercap/intel-rapl:0:1"},
+		{Name: "dram", Index: 0, Path: "/sys/class/powercap/intel-rapl:0:2"},
+		{Name: "package", Index: 1, Path: "/sys/class/powercap/intel-rapl:1"},
+		{Name: "dram", Index: 1, Path: "/sys/class/powercap/intel-rapl:1:0"},
+		{Name: "package", Index: 0, Path: "/sys/class/powercap/intel-rapl-mmio:0"},
+	}
+
+	// Only package and DRAM zones must be used
+	assert.Equal(t, []sysfs.RaplZone{zones[0], zones[3], zones[4], zones[5]}, attributionZones(zones))
+
+	// When psys is present, it must be used alone
+	psys := sysfs.RaplZone{Name: "psys", Index: 0, Path: "/sys/class/powercap/intel-rapl:2"}
+	assert.Equal(t, []sysfs.RaplZone{psys}, attributionZones(append(zones, psys)))
+}
+
+func TestPowerAttributor(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse([]string{
+		"--path.sysfs", "testdata/sys",
+		"--collector.cgroups.power-attribution", "cpu",
+	})
+	require.NoError(t, err)
+
+	p, err := newPowerAttributor("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	metrics := []cgMetric{
+		{uuid: "1", cpuUser: 10, cpuSystem: 0},
+		{uuid: "2", cpuUser: 20, cpuSystem: 10},
+		{uuid: "3", err: true},
+	}
+
+	// First call must not return any power as there is no previous reading
+	assert.Nil(t, p.attribute(metrics))
+
+	// Rewind last readings by 1 joule per zone and 1 second
+	for zone := range p.lastEnergy {
+		p.lastEnergy[zone] -= 1000000
+	}
+
+	p.lastTime = p.lastTime.Add(-time.Second)
+
+	metrics[0].cpuUser = 20
+	metrics[1].cpuUser = 40
+
+	shares := p.attribute(metrics)
+	require.Len(t, shares, 2)
+
+	// Unit 2 consumed twice the CPU time of unit 1
+	assert.InDelta(t, 2*shares["1"], shares["2"], 1e-6)
+	assert.InDelta(t, float64(len(p.lastEnergy)), shares["1"]+shares["2"], 0.1)
+}
@@ -0,0 +1,115 @@
+package collector
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+// runtimeConfig is the part of exporter configuration that can be reloaded
+// without restarting the exporter.
+type runtimeConfig struct {
+	collectors  map[string]bool // enabled state of each registered collector
+	ignoreProcs []string        // regular expressions of processes to ignore
+}
+
+// parseRuntimeConfig parses CLI args into runtimeConfig. Args are only tokenized
+// and hence the current values of CLI flags are not modified. Flags from files
+// passed as @file are read again.
+func parseRuntimeConfig(app *kingpin.Application, args []string) (*runtimeConfig, error) {
+	pctx, err := app.ParseContext(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CLI flags: %w", err)
+	}
+
+	// Get last value of each flag and all values of repeatable flags
+	values := make(map[string][]string)
+
+	for _, element := range pctx.Elements {
+		if clause, ok := element.Clause.(*kingpin.FlagClause); ok && element.Value != nil {
+			name := clause.Model().Name
+			values[name] = append(values[name], *element.Value)
+		}
+	}
+
+	lastBool := func(name string, defaultValue bool) (bool, error) {
+		v, ok := values[name]
+		if !ok {
+			return defaultValue, nil
+		}
+
+		b, err := strconv.ParseBool(v[len(v)-1])
+		if err != nil {
+			return false, fmt.Errorf("invalid value for --%s: %w", name, err)
+		}
+
+		return b, nil
+	}
+
+	disableDefaults, err := lastBool("collector.disable-defaults", false)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &runtimeConfig{
+		collectors:  make(map[string]bool, len(factories)),
+		ignoreProcs: values["collector.cgroups.ignore-procs"],
+	}
+
+	for name := range factories {
+		flagName := "collector." + name
+
+		// When default collectors are disabled, only explicitly enabled
+		// collectors are enabled
+		var defaultValue bool
+		if !disableDefaults {
+			if flag := app.GetFlag(flagName); flag != nil {
+				defaultValue = slices.Contains(flag.Model().Default, "true")
+			}
+		}
+
+		if cfg.collectors[name], err = lastBool(flagName, defaultValue); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// reloadConfig parses CLI args and applies the reloadable configuration to
+// collector. Configuration is validated entirely before applying it so that an
+// invalid configuration does not leave exporter in a partially reloaded state.
+func reloadConfig(app *kingpin.Application, args []string, collector *CEEMSCollector, logger *slog.Logger) error {
+	cfg, err := parseRuntimeConfig(app, args)
+	if err != nil {
+		return err
+	}
+
+	regexes, err := compileIgnoreProcs(cfg.ignoreProcs)
+	if err != nil {
+		return err
+	}
+
+	// Update regexes of processes to ignore
+	var current []string
+	if old := extraIgnoreProcs.Load(); old != nil {
+		for _, regex := range *old {
+			current = append(current, regex.String())
+		}
+	}
+
+	if !slices.Equal(current, cfg.ignoreProcs) {
+		logger.Info("Regular expressions of ignored processes changed", "ignore_procs", strings.Join(cfg.ignoreProcs, ","))
+	}
+
+	extraIgnoreProcs.Store(&regexes)
+
+	// Enable/disable collectors
+	collector.SetEnabled(cfg.collectors)
+
+	return nil
+}
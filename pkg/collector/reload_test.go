@@ -0,0 +1,113 @@
+package collector
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockReloadCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c *mockReloadCollector) Update(ch chan<- prometheus.Metric) error {
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, 1)
+
+	return nil
+}
+
+func (c *mockReloadCollector) Stop(_ context.Context) error {
+	return nil
+}
+
+// collectedMetrics returns descriptions of metrics returned by collector excluding
+// the scrape metrics.
+func collectedMetrics(c *CEEMSCollector) []string {
+	ch := make(chan prometheus.Metric)
+
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	var names []string
+
+	for m := range ch {
+		if m.Desc() == scrapeDurationDesc || m.Desc() == scrapeSuccessDesc {
+			continue
+		}
+
+		names = append(names, m.Desc().String())
+	}
+
+	return names
+}
+
+func TestParseRuntimeConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Flags from files must be read
+	flagsFile := filepath.Join(tmpDir, "flags")
+	require.NoError(t, os.WriteFile(flagsFile, []byte("--collector.cgroups.ignore-procs=^/opt/wrapper\n"), 0o600))
+
+	cfg, err := parseRuntimeConfig(&CEEMSExporterApp, []string{
+		"--no-collector.cpu",
+		"--collector.cgroups.ignore-procs", "prolog.sh$",
+		"@" + flagsFile,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, cfg.collectors["cpu"])
+	assert.True(t, cfg.collectors["meminfo"])
+	assert.Equal(t, []string{"prolog.sh$", "^/opt/wrapper"}, cfg.ignoreProcs)
+
+	// Unknown flags must return error
+	_, err = parseRuntimeConfig(&CEEMSExporterApp, []string{"--collector.unknown"})
+	require.Error(t, err)
+}
+
+func TestReloadConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Cleanup(func() { extraIgnoreProcs.Store(nil) })
+
+	collector := &CEEMSCollector{
+		Collectors: map[string]Collector{
+			"cpu":     &mockReloadCollector{desc: prometheus.NewDesc("ceems_mock_cpu", "Mock metric", nil, nil)},
+			"meminfo": &mockReloadCollector{desc: prometheus.NewDesc("ceems_mock_meminfo", "Mock metric", nil, nil)},
+		},
+		logger: logger,
+	}
+	assert.Len(t, collectedMetrics(collector), 2)
+
+	// Disable cpu collector and add ignored processes
+	err := reloadConfig(&CEEMSExporterApp, []string{
+		"--no-collector.cpu",
+		"--collector.cgroups.ignore-procs", "^/opt/wrapper",
+	}, collector, logger)
+	require.NoError(t, err)
+
+	metrics := collectedMetrics(collector)
+	require.Len(t, metrics, 1)
+	assert.Contains(t, metrics[0], "ceems_mock_meminfo")
+	assert.True(t, withIgnoreProcs(func(string) bool { return false })("/opt/wrapper job.sh"))
+
+	// Invalid regex must not change current config
+	err = reloadConfig(&CEEMSExporterApp, []string{"--collector.cgroups.ignore-procs", "wrapper("}, collector, logger)
+	require.Error(t, err)
+	assert.Len(t, collectedMetrics(collector), 1)
+	assert.True(t, withIgnoreProcs(func(string) bool { return false })("/opt/wrapper job.sh"))
+
+	// Enable cpu collector again and remove ignored processes
+	err = reloadConfig(&CEEMSExporterApp, []string{}, collector, logger)
+	require.NoError(t, err)
+	assert.Len(t, collectedMetrics(collector), 2)
+	assert.False(t, withIgnoreProcs(func(string) bool { return false })("/opt/wrapper job.sh"))
+}
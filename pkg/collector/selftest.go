@@ -0,0 +1,134 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Metrics that are either not produced by the collectors or are not
+// reproducible and hence, they are ignored while comparing against a golden
+// file. These are the same metrics that are skipped in e2e tests which
+// allows to use e2e test fixtures and output of metrics endpoint as golden files.
+var selfTestIgnoreRegex = regexp.MustCompile(`^(go_|process_|promhttp_|ceems_exporter_build_info|ceems_scrape_collector_duration_seconds)`)
+
+// selfTest runs all enabled collectors once and writes the rendered metrics
+// to w. When goldenFile is not empty, the series (metric names and labels) of
+// rendered metrics are compared against the ones in the golden file and an
+// error is returned when they differ. Values of the metrics are ignored in
+// the comparison.
+func selfTest(collector *CEEMSCollector, goldenFile string, w io.Writer, logger *slog.Logger) error {
+	// Release resources of collectors once done
+	defer func() {
+		if err := collector.Close(context.Background()); err != nil {
+			logger.Error("Failed to stop collectors", "err", err)
+		}
+	}()
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		return fmt.Errorf("failed to register collectors: %w", err)
+	}
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		return fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	for _, mf := range mfs {
+		if _, err := expfmt.MetricFamilyToText(&buf, mf); err != nil {
+			return fmt.Errorf("failed to render metrics: %w", err)
+		}
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	if goldenFile == "" {
+		return nil
+	}
+
+	golden, err := os.ReadFile(goldenFile)
+	if err != nil {
+		return fmt.Errorf("failed to read golden file: %w", err)
+	}
+
+	expected, err := seriesFromText(golden)
+	if err != nil {
+		return fmt.Errorf("failed to parse golden file: %w", err)
+	}
+
+	got, err := seriesFromText(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to parse rendered metrics: %w", err)
+	}
+
+	var errs error
+
+	for _, s := range expected {
+		if !slices.Contains(got, s) {
+			errs = errors.Join(errs, fmt.Errorf("missing series: %s", s))
+		}
+	}
+
+	for _, s := range got {
+		if !slices.Contains(expected, s) {
+			errs = errors.Join(errs, fmt.Errorf("unexpected series: %s", s))
+		}
+	}
+
+	if errs != nil {
+		return fmt.Errorf("metrics do not match golden file %s:\n%w", goldenFile, errs)
+	}
+
+	logger.Info("Metrics match golden file", "golden_file", goldenFile, "num_series", len(got))
+
+	return nil
+}
+
+// seriesFromText returns a sorted slice of series identifiers, i.e., metric
+// name along with its labels, from metrics in text exposition format.
+func seriesFromText(content []byte) ([]string, error) {
+	var parser expfmt.TextParser
+
+	mfs, err := parser.TextToMetricFamilies(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var series []string
+
+	for name, mf := range mfs {
+		if selfTestIgnoreRegex.MatchString(name) {
+			continue
+		}
+
+		for _, m := range mf.GetMetric() {
+			labels := make([]string, len(m.GetLabel()))
+			for i, l := range m.GetLabel() {
+				labels[i] = fmt.Sprintf("%s=%q", l.GetName(), l.GetValue())
+			}
+
+			slices.Sort(labels)
+
+			series = append(series, fmt.Sprintf("%s{%s}", name, strings.Join(labels, ",")))
+		}
+	}
+
+	slices.Sort(series)
+
+	return series, nil
+}
@@ -0,0 +1,84 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSelfTestCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c *mockSelfTestCollector) Update(ch chan<- prometheus.Metric) error {
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, 1, "", "1000")
+
+	return nil
+}
+
+func (c *mockSelfTestCollector) Stop(_ context.Context) error {
+	return nil
+}
+
+func TestSelfTest(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	collector := &CEEMSCollector{
+		Collectors: map[string]Collector{
+			"mock": &mockSelfTestCollector{
+				desc: prometheus.NewDesc("ceems_compute_unit_mock", "Mock metric", []string{"hostname", "uuid"}, nil),
+			},
+		},
+		logger: logger,
+	}
+
+	// Run self test without golden file
+	var buf bytes.Buffer
+
+	err := selfTest(collector, "", &buf, logger)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `ceems_compute_unit_mock{hostname="",uuid="1000"} 1`)
+	assert.Contains(t, buf.String(), `ceems_scrape_collector_success{collector="mock"} 1`)
+
+	// Golden file with different values but same series must match.
+	// Exporter metrics in golden file must be ignored
+	goldenFile := filepath.Join(tmpDir, "golden.txt")
+	golden := `# HELP ceems_compute_unit_mock Mock metric
+# TYPE ceems_compute_unit_mock gauge
+ceems_compute_unit_mock{hostname="",uuid="1000"} 10
+# HELP ceems_scrape_collector_duration_seconds ceems_exporter: Duration of a collector scrape.
+# TYPE ceems_scrape_collector_duration_seconds gauge
+ceems_scrape_collector_duration_seconds{collector="mock"} 0.1
+# HELP ceems_scrape_collector_success ceems_exporter: Whether a collector succeeded.
+# TYPE ceems_scrape_collector_success gauge
+ceems_scrape_collector_success{collector="mock"} 1
+# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 8
+`
+	require.NoError(t, os.WriteFile(goldenFile, []byte(golden), 0o600))
+
+	err = selfTest(collector, goldenFile, io.Discard, logger)
+	require.NoError(t, err)
+
+	// Renamed label in golden file must be reported
+	golden = `# HELP ceems_compute_unit_mock Mock metric
+# TYPE ceems_compute_unit_mock gauge
+ceems_compute_unit_mock{hostname="",jobid="1000"} 1
+`
+	require.NoError(t, os.WriteFile(goldenFile, []byte(golden), 0o600))
+
+	err = selfTest(collector, goldenFile, io.Discard, logger)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `missing series: ceems_compute_unit_mock{hostname="",jobid="1000"}`)
+	assert.Contains(t, err.Error(), `unexpected series: ceems_compute_unit_mock{hostname="",uuid="1000"}`)
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -41,6 +42,22 @@ var (
 		"collector.slurm.psi-metrics",
 		"Enables collection of PSI metrics (default: disabled)",
 	).Default("false").Bool()
+	slurmCollectNetStats = CEEMSExporterApp.Flag(
+		"collector.slurm.net-metrics",
+		"Enables collection of network metrics of jobs using net_cls controller on cgroups v1. Only transmitted traffic is accounted (default: disabled)",
+	).Default("false").Bool()
+	slurmCollectStepStats = CEEMSExporterApp.Flag(
+		"collector.slurm.step-metrics",
+		"Enables collection of CPU and memory metrics of each job step. This can increase cardinality of metrics considerably (default: disabled)",
+	).Default("false").Bool()
+	slurmCgroupEvents = CEEMSExporterApp.Flag(
+		"collector.slurm.cgroup-events",
+		"Enables watching creation and removal of job cgroups using inotify to export accurate start and end times of jobs (default: disabled)",
+	).Default("false").Bool()
+	slurmCgroupEventsMaxWatches = CEEMSExporterApp.Flag(
+		"collector.slurm.cgroup-events.max-watches",
+		"Maximum number of inotify watches used to watch cgroup events.",
+	).Default("256").Int()
 
 	// GPU opts.
 	slurmGPUOrdering = CEEMSExporterApp.Flag(
@@ -50,6 +67,11 @@ It should be of format <slurm_gpu_index>: <nvidia_or_rocm_smi_index>[.<mig_gpu_i
 	).Default("").PlaceHolder("0:1,1:0.3,2:0.4,3:0.5,4:0.6").String()
 )
 
+// Regular expression to capture step name from cgroup path of a job step. Only
+// direct children of job cgroup are considered as steps. For instance,
+// /system.slice/slurmstepd.scope/job_211/step_batch.
+var slurmStepPathRegex = regexp.MustCompile("^.*/job_[0-9]+/step_([^/]+)$")
+
 // Security context names.
 const (
 	slurmReadProcCtx = "slurm_read_procs"
@@ -121,11 +143,23 @@ func NewSlurmCollector(logger *slog.Logger) (Collector, error) {
 
 	logger.Info("cgroup: " + cgroupManager.String())
 
+	// Start watching cgroup events
+	if *slurmCgroupEvents {
+		cgroupManager.watcher, err = newCgroupWatcher(
+			cgroupManager.mountPoint, cgroupManager.idRegex, cgroupManager.isChild,
+			*slurmCgroupEventsMaxWatches, logger.With("sub_collector", "cgroup_events"),
+		)
+		if err != nil {
+			logger.Error("Failed to create cgroup events watcher", "err", err)
+		}
+	}
+
 	// Set cgroup options
 	opts := cgroupOpts{
 		collectSwapMemStats: *slurmCollectSwapMemoryStatsDepre || *slurmCollectSwapMemoryStats,
 		collectPSIStats:     *slurmCollectPSIStatsDepre || *slurmCollectPSIStats,
 		collectBlockIOStats: false, // SLURM does not support blkio controller.
+		collectNetStats:     *slurmCollectNetStats,
 	}
 
 	// Start new instance of cgroupCollector
@@ -180,7 +214,7 @@ func NewSlurmCollector(logger *slog.Logger) (Collector, error) {
 	if *gpuType != "" {
 		gpuTypes = []string{*gpuType}
 	} else {
-		gpuTypes = []string{"nvidia", "amd"}
+		gpuTypes = []string{"nvidia", "amd", "intel"}
 	}
 
 	for _, gpuType := range gpuTypes {
@@ -240,6 +274,7 @@ func NewSlurmCollector(logger *slog.Logger) (Collector, error) {
 				"index",
 				"hindex",
 				"gpuuuid",
+				"migprofile",
 			},
 			nil,
 		),
@@ -258,6 +293,9 @@ func (c *slurmCollector) Update(ch chan<- prometheus.Metric) error {
 	// Initialise job metrics
 	metrics, err := c.jobMetrics()
 	if err != nil {
+		// Export discovery errors even when discovery fails
+		c.cgroupCollector.updateDiscoveryErrors(ch)
+
 		return err
 	}
 
@@ -273,9 +311,10 @@ func (c *slurmCollector) Update(ch chan<- prometheus.Metric) error {
 			c.logger.Error("Failed to update cgroup stats", "err", err)
 		}
 
-		// Update slurm job GPU ordinals
+		// Update slurm job GPU ordinals and estimated GPU power
 		if len(c.gpuDevs) > 0 {
 			c.updateGPUOrdinals(ch, metrics.jobProps)
+			updateGPUPower(ch, c.gpuDevs, c.cgroupManager.manager, c.hostname, c.logger)
 		}
 	}()
 
@@ -364,7 +403,7 @@ func (c *slurmCollector) updateGPUOrdinals(ch chan<- prometheus.Metric, jobProps
 	for _, p := range jobProps {
 		// GPU job mapping
 		for _, gpuOrdinal := range p.gpuOrdinals {
-			var gpuuuid, miggid string
+			var gpuuuid, miggid, migprofile string
 
 			flagValue := float64(1)
 			// Check the int index of devices where gpuOrdinal == dev.index
@@ -374,6 +413,7 @@ func (c *slurmCollector) updateGPUOrdinals(ch chan<- prometheus.Metric, jobProps
 					if gpuOrdinal == mig.globalIndex {
 						gpuuuid = dev.uuid
 						miggid = strconv.FormatUint(mig.gpuInstID, 10)
+						migprofile = mig.profile
 
 						// For MIG, we export SM fraction as flag value
 						flagValue = mig.smFraction
@@ -404,6 +444,7 @@ func (c *slurmCollector) updateGPUOrdinals(ch chan<- prometheus.Metric, jobProps
 				gpuOrdinal,
 				fmt.Sprintf("%s/gpu-%s", c.hostname, gpuOrdinal),
 				fmt.Sprintf("%s/%s", gpuuuid, miggid),
+				migprofile,
 			)
 		}
 	}
@@ -420,6 +461,9 @@ func (c *slurmCollector) jobProperties(cgroups []cgroup) slurmMetrics {
 
 	var gpuOrdinals []string
 
+	// Read boot time once to compute start times of processes
+	bootTime := c.cgroupManager.bootTime()
+
 	// Iterate over all active cgroups and get job properties
 	for _, cgrp := range cgroups {
 		jobuuid := cgrp.uuid
@@ -441,7 +485,16 @@ func (c *slurmCollector) jobProperties(cgroups []cgroup) slurmMetrics {
 		}
 
 		// Add to cgroups only if it is a root cgroup
-		cgMetrics = append(cgMetrics, cgMetric{uuid: jobuuid, path: "/" + cgrp.path.rel})
+		cgMetrics = append(cgMetrics, cgMetric{uuid: jobuuid, path: "/" + cgrp.path.rel, numProcs: len(cgrp.procs), numGPUs: len(c.jobPropsCache[jobuuid].gpuOrdinals), startTime: procsStartTime(cgrp.procs, bootTime)})
+
+		// Add steps of the job when enabled
+		if *slurmCollectStepStats {
+			for _, child := range cgrp.children {
+				if matches := slurmStepPathRegex.FindStringSubmatch(child.rel); len(matches) > 1 {
+					cgMetrics = append(cgMetrics, cgMetric{uuid: jobuuid, step: matches[1], path: "/" + child.rel})
+				}
+			}
+		}
 	}
 
 	// Remove expired jobs from jobPropsCache
@@ -183,6 +183,41 @@ func TestSlurmJobPropsWithProcsFS(t *testing.T) {
 	assert.Equal(t, expectedProps, metrics.jobProps)
 }
 
+func TestSlurmJobStepMetrics(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse(
+		[]string{
+			"--path.cgroupfs", "testdata/sys/fs/cgroup",
+			"--path.procfs", "testdata/proc",
+			"--collector.cgroups.force-version", "v2",
+			"--collector.slurm.step-metrics",
+		},
+	)
+	require.NoError(t, err)
+
+	// cgroup manager
+	cgManager, err := NewCgroupManager("slurm", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	c := slurmCollector{
+		cgroupManager: cgManager,
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		jobPropsCache: make(map[string]jobProps),
+	}
+
+	metrics, err := c.jobMetrics()
+	require.NoError(t, err)
+
+	var steps []string
+
+	for _, m := range metrics.cgMetrics {
+		if m.uuid == "1009249" && m.step != "" {
+			steps = append(steps, m.step)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"3", "batch", "extern"}, steps)
+}
+
 func TestJobPropsCaching(t *testing.T) {
 	path := t.TempDir()
 
@@ -0,0 +1,251 @@
+//go:build !notextfile
+// +build !notextfile
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const textFileCollectorSubsystem = "textfile"
+
+// CLI opts.
+var (
+	textFileDirectory = CEEMSExporterApp.Flag(
+		"collector.textfile.directory",
+		"Directory to read text files with metrics in Prometheus text exposition format.",
+	).Default("").String()
+)
+
+type textFileCollector struct {
+	logger        *slog.Logger
+	hostname      string
+	directory     string
+	mtimeDesc     *prometheus.Desc
+	scrapeErrDesc *prometheus.Desc
+}
+
+func init() {
+	RegisterCollector(textFileCollectorSubsystem, defaultDisabled, NewTextFileCollector)
+}
+
+// NewTextFileCollector returns a new Collector exposing metrics read from
+// text files in a directory.
+func NewTextFileCollector(logger *slog.Logger) (Collector, error) {
+	if *textFileDirectory == "" {
+		return nil, errors.New("directory of text files must be set using --collector.textfile.directory flag")
+	}
+
+	mtimeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, textFileCollectorSubsystem, "mtime_seconds"),
+		"Unixtime mtime of text files successfully read.",
+		[]string{"hostname", "file"}, nil,
+	)
+
+	scrapeErrDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, textFileCollectorSubsystem, "scrape_error"),
+		"1 if there was an error opening or reading a file, 0 otherwise",
+		[]string{"hostname"}, nil,
+	)
+
+	collector := textFileCollector{
+		logger:        logger,
+		hostname:      hostname,
+		directory:     *textFileDirectory,
+		mtimeDesc:     mtimeDesc,
+		scrapeErrDesc: scrapeErrDesc,
+	}
+
+	return &collector, nil
+}
+
+// Update implements Collector and exposes metrics read from text files.
+func (c *textFileCollector) Update(ch chan<- prometheus.Metric) error {
+	var errored float64
+
+	files, err := filepath.Glob(filepath.Join(c.directory, "*.prom"))
+	if err != nil {
+		return fmt.Errorf("failed to list text files in %s: %w", c.directory, err)
+	}
+
+	// Metric families of all files. Files are sorted by Glob and the first
+	// file that exposes a metric family wins
+	families := make(map[string]*dto.MetricFamily)
+
+	for _, file := range files {
+		mtime, fileFamilies, err := c.parseFile(file)
+		if err != nil {
+			c.logger.Error("Failed to read text file", "file", file, "err", err)
+
+			errored = 1
+
+			continue
+		}
+
+		for name, mf := range fileFamilies {
+			if _, ok := families[name]; ok {
+				c.logger.Error("Metric is exposed by more than one text file. Ignoring", "metric", name, "file", file)
+
+				errored = 1
+
+				continue
+			}
+
+			families[name] = mf
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.mtimeDesc, prometheus.GaugeValue, mtime, c.hostname, filepath.Base(file))
+	}
+
+	for _, mf := range families {
+		c.exportMetricFamily(ch, mf)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.scrapeErrDesc, prometheus.GaugeValue, errored, c.hostname)
+
+	return nil
+}
+
+// Stop releases system resources used by the collector.
+func (c *textFileCollector) Stop(_ context.Context) error {
+	c.logger.Debug("Stopping", "collector", textFileCollectorSubsystem)
+
+	return nil
+}
+
+// parseFile parses metric families in text file and returns them along with
+// modification time of the file.
+func (c *textFileCollector) parseFile(path string) (float64, map[string]*dto.MetricFamily, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer f.Close()
+
+	var parser expfmt.TextParser
+
+	families, err := parser.TextToMetricFamilies(f)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to parse text file: %w", err)
+	}
+
+	for name, mf := range families {
+		// Timestamps are not supported as metrics are exported at scrape time
+		for _, m := range mf.GetMetric() {
+			if m.TimestampMs != nil {
+				return 0, nil, fmt.Errorf("metric %s has timestamp which is not supported", name)
+			}
+		}
+
+		// Prevent text files from shadowing metrics of exporter
+		if strings.HasPrefix(name, Namespace+"_") {
+			return 0, nil, fmt.Errorf("metric %s uses reserved prefix %s_", name, Namespace)
+		}
+	}
+
+	// Get mtime only after parsing file so that partially written files
+	// are not reported
+	stat, err := f.Stat()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return float64(stat.ModTime().UnixNano()) / 1e9, families, nil
+}
+
+// exportMetricFamily converts metric family to constant metrics with
+// hostname label merged in and sends them to channel.
+func (c *textFileCollector) exportMetricFamily(ch chan<- prometheus.Metric, mf *dto.MetricFamily) {
+	// All metrics of a family must have the same label names. Gather the union
+	// of label names and set missing labels to empty values
+	var labelNames []string
+
+	for _, m := range mf.GetMetric() {
+		for _, l := range m.GetLabel() {
+			if !slices.Contains(labelNames, l.GetName()) {
+				labelNames = append(labelNames, l.GetName())
+			}
+		}
+	}
+
+	slices.Sort(labelNames)
+
+	// Merge hostname label unless file sets it already
+	addHostname := !slices.Contains(labelNames, "hostname")
+	if addHostname {
+		labelNames = append([]string{"hostname"}, labelNames...)
+	}
+
+	desc := prometheus.NewDesc(mf.GetName(), mf.GetHelp(), labelNames, nil)
+
+	for _, m := range mf.GetMetric() {
+		values := make([]string, len(labelNames))
+
+		for i, name := range labelNames {
+			if addHostname && name == "hostname" {
+				values[i] = c.hostname
+
+				continue
+			}
+
+			for _, l := range m.GetLabel() {
+				if l.GetName() == name {
+					values[i] = l.GetValue()
+
+					break
+				}
+			}
+		}
+
+		var (
+			metric prometheus.Metric
+			err    error
+		)
+
+		switch mf.GetType() {
+		case dto.MetricType_COUNTER:
+			metric, err = prometheus.NewConstMetric(desc, prometheus.CounterValue, m.GetCounter().GetValue(), values...)
+		case dto.MetricType_GAUGE:
+			metric, err = prometheus.NewConstMetric(desc, prometheus.GaugeValue, m.GetGauge().GetValue(), values...)
+		case dto.MetricType_SUMMARY:
+			quantiles := make(map[float64]float64, len(m.GetSummary().GetQuantile()))
+			for _, q := range m.GetSummary().GetQuantile() {
+				quantiles[q.GetQuantile()] = q.GetValue()
+			}
+
+			metric, err = prometheus.NewConstSummary(
+				desc, m.GetSummary().GetSampleCount(), m.GetSummary().GetSampleSum(), quantiles, values...,
+			)
+		case dto.MetricType_HISTOGRAM:
+			buckets := make(map[float64]uint64, len(m.GetHistogram().GetBucket()))
+			for _, b := range m.GetHistogram().GetBucket() {
+				buckets[b.GetUpperBound()] = b.GetCumulativeCount()
+			}
+
+			metric, err = prometheus.NewConstHistogram(
+				desc, m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum(), buckets, values...,
+			)
+		default:
+			metric, err = prometheus.NewConstMetric(desc, prometheus.UntypedValue, m.GetUntyped().GetValue(), values...)
+		}
+
+		if err != nil {
+			c.logger.Error("Failed to export metric from text file", "metric", mf.GetName(), "err", err)
+
+			continue
+		}
+
+		ch <- metric
+	}
+}
@@ -0,0 +1,132 @@
+//go:build !notextfile
+// +build !notextfile
+
+package collector
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextFileCollector(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"pdu.prom": `# HELP site_pdu_power_watts Power reading of PDU
+# TYPE site_pdu_power_watts gauge
+site_pdu_power_watts{outlet="1"} 250
+site_pdu_power_watts{outlet="2",phase="L1"} 300
+`,
+		"cooling.prom": `# TYPE site_cooling_cycles_total counter
+site_cooling_cycles_total{hostname="rack1"} 10
+`,
+		// Malformed file
+		"invalid.prom": `site_invalid_metric{ 1
+`,
+		// Reserved prefix
+		"reserved.prom": `ceems_rapl_package_joules_total 1
+`,
+		// Files without prom extension must be ignored
+		"ignored.txt": `site_ignored 1
+`,
+	}
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600))
+	}
+
+	_, err := CEEMSExporterApp.Parse([]string{
+		"--collector.textfile.directory", tmpDir,
+	})
+	require.NoError(t, err)
+
+	collector, err := NewTextFileCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// Setup background goroutine to capture metrics.
+	metrics := make(chan prometheus.Metric)
+
+	got := make(map[string]float64)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for metric := range metrics {
+			m := &dto.Metric{}
+			require.NoError(t, metric.Write(m))
+
+			name := metric.Desc().String()
+			for _, l := range m.GetLabel() {
+				name += "," + l.GetName() + "=" + l.GetValue()
+			}
+
+			switch {
+			case m.GetGauge() != nil:
+				got[name] = m.GetGauge().GetValue()
+			case m.GetCounter() != nil:
+				got[name] = m.GetCounter().GetValue()
+			}
+		}
+	}()
+
+	err = collector.Update(metrics)
+	require.NoError(t, err)
+
+	close(metrics)
+	<-done
+
+	var pduSeries, coolingSeries, mtimeSeries int
+
+	for name, value := range got {
+		switch {
+		case strings.Contains(name, `fqName: "site_pdu_power_watts"`):
+			pduSeries++
+
+			assert.Contains(t, name, "hostname="+hostname)
+			assert.Contains(t, []float64{250, 300}, value)
+		case strings.Contains(name, `fqName: "site_cooling_cycles_total"`):
+			coolingSeries++
+
+			// Hostname label set in file must be preserved
+			assert.Contains(t, name, "hostname=rack1")
+			assert.InEpsilon(t, 10, value, 0)
+		case strings.Contains(name, `fqName: "ceems_textfile_mtime_seconds"`):
+			mtimeSeries++
+
+			assert.Positive(t, value)
+		case strings.Contains(name, `fqName: "ceems_textfile_scrape_error"`):
+			// Malformed and reserved files must be reported
+			assert.InEpsilon(t, 1, value, 0)
+		default:
+			t.Errorf("unexpected metric %s", name)
+		}
+	}
+
+	assert.Equal(t, 2, pduSeries)
+	assert.Equal(t, 1, coolingSeries)
+	assert.Equal(t, 2, mtimeSeries)
+
+	err = collector.Stop(context.Background())
+	require.NoError(t, err)
+}
+
+func TestTextFileCollectorWithoutDirectory(t *testing.T) {
+	_, err := CEEMSExporterApp.Parse([]string{
+		"--collector.textfile.directory", "",
+	})
+	require.NoError(t, err)
+
+	_, err = NewTextFileCollector(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.Error(t, err)
+}
@@ -17,6 +17,7 @@ type pyroServer struct {
 	alive           bool
 	mux             sync.RWMutex
 	connections     int
+	weight          int
 	reverseProxy    *httputil.ReverseProxy
 	basicAuthHeader string
 	client          *http.Client
@@ -45,6 +46,7 @@ func NewPyroscope(webURL *url.URL, p *httputil.ReverseProxy, logger *slog.Logger
 	return &pyroServer{
 		url:             webURL,
 		alive:           true,
+		weight:          1,
 		reverseProxy:    p,
 		basicAuthHeader: basicAuthHeader,
 		client:          pyroClient,
@@ -77,6 +79,22 @@ func (b *pyroServer) ActiveConnections() int {
 	return connections
 }
 
+// Returns weight of backend Pyroscope server.
+func (b *pyroServer) Weight() int {
+	b.mux.RLock()
+	weight := b.weight
+	b.mux.RUnlock()
+
+	return weight
+}
+
+// Sets weight of backend Pyroscope server.
+func (b *pyroServer) SetWeight(weight int) {
+	b.mux.Lock()
+	b.weight = weight
+	b.mux.Unlock()
+}
+
 // Sets the backend Pyroscope server as alive.
 func (b *pyroServer) SetAlive(alive bool) {
 	b.mux.Lock()
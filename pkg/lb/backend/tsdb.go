@@ -23,6 +23,7 @@ type tsdbServer struct {
 	alive           bool
 	mux             sync.RWMutex
 	connections     int
+	weight          int
 	retentionPeriod time.Duration
 	lastUpdate      time.Time
 	updateInterval  time.Duration
@@ -55,6 +56,7 @@ func NewTSDB(webURL *url.URL, p *httputil.ReverseProxy, logger *slog.Logger) Ser
 	server := &tsdbServer{
 		url:             webURL,
 		alive:           true,
+		weight:          1,
 		reverseProxy:    p,
 		basicAuthHeader: basicAuthHeader,
 		updateInterval:  3 * time.Hour,
@@ -117,6 +119,22 @@ func (b *tsdbServer) ActiveConnections() int {
 	return connections
 }
 
+// Returns weight of backend TSDB server.
+func (b *tsdbServer) Weight() int {
+	b.mux.RLock()
+	weight := b.weight
+	b.mux.RUnlock()
+
+	return weight
+}
+
+// Sets weight of backend TSDB server.
+func (b *tsdbServer) SetWeight(weight int) {
+	b.mux.Lock()
+	b.weight = weight
+	b.mux.Unlock()
+}
+
 // Sets the backend TSDB server as alive.
 func (b *tsdbServer) SetAlive(alive bool) {
 	b.mux.Lock()
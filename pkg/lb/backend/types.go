@@ -19,6 +19,8 @@ type Server interface {
 	URL() *url.URL
 	String() string
 	ActiveConnections() int
+	Weight() int
+	SetWeight(weight int)
 	RetentionPeriod() time.Duration
 	Serve(w http.ResponseWriter, r *http.Request)
 }
@@ -3,6 +3,7 @@ package base
 
 import (
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/common/model"
 )
 
 // CEEMSLoadBalancerAppName is kingpin app name.
@@ -16,9 +17,32 @@ var CEEMSLoadBalancerApp = *kingpin.New(
 
 // Backend defines backend server.
 type Backend struct {
-	ID       string   `yaml:"id"`
-	TSDBURLs []string `yaml:"tsdb_urls"`
-	PyroURLs []string `yaml:"pyroscope_urls"`
+	ID          string   `yaml:"id"`
+	TSDBURLs    []string `yaml:"tsdb_urls"`
+	TSDBWeights []int    `yaml:"tsdb_weights"`
+	PyroURLs    []string `yaml:"pyroscope_urls"`
+	PyroWeights []int    `yaml:"pyroscope_weights"`
+	Limits      Limits   `yaml:"limits"`
+}
+
+// Limits defines the limits imposed on requests proxied to backend servers.
+// Zero values mean no limit.
+type Limits struct {
+	MaxRequestBodySize int64          `yaml:"max_request_body_bytes"`
+	Timeout            model.Duration `yaml:"timeout"`
+}
+
+// Override returns a copy of limits where non zero limits of o take precedence.
+func (l Limits) Override(o Limits) Limits {
+	if o.MaxRequestBodySize > 0 {
+		l.MaxRequestBodySize = o.MaxRequestBodySize
+	}
+
+	if o.Timeout > 0 {
+		l.Timeout = o.Timeout
+	}
+
+	return l
 }
 
 // LBType is type of load balancer server.
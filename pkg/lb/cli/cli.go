@@ -39,8 +39,10 @@ import (
 
 // Custom errors.
 var (
-	ErrMissingIDs  = errors.New("missing ID for backend(s)")
-	ErrMissingURLs = errors.New("missing TSDB and Pyroscope URL(s) for backend(s)")
+	ErrMissingIDs     = errors.New("missing ID for backend(s)")
+	ErrMissingURLs    = errors.New("missing TSDB and Pyroscope URL(s) for backend(s)")
+	ErrInvalidWeights = errors.New("weights of backend(s) must be positive and one for each URL")
+	ErrInvalidLimits  = errors.New("limits must not be negative")
 )
 
 // CEEMSLBAppConfig contains the configuration of CEEMS load balancer app.
@@ -66,6 +68,10 @@ func (c *CEEMSLBAppConfig) Validate() error {
 		}
 	}
 
+	if c.LB.Limits.MaxRequestBodySize < 0 {
+		return ErrInvalidLimits
+	}
+
 	// Preflight checks for backends
 	for _, backend := range c.LB.Backends {
 		if backend.ID == "" {
@@ -76,6 +82,22 @@ func (c *CEEMSLBAppConfig) Validate() error {
 			return ErrMissingURLs
 		}
 
+		// Weights are optional. When provided, there must be one for each URL
+		for _, t := range []base.LBType{base.PromLB, base.PyroLB} {
+			weights := backendWeights(t, backend)
+			if len(weights) == 0 {
+				continue
+			}
+
+			if len(weights) != len(backendURLs(t, backend)) || slices.Min(weights) <= 0 {
+				return fmt.Errorf("%w: %s", ErrInvalidWeights, backend.ID)
+			}
+		}
+
+		if backend.Limits.MaxRequestBodySize < 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidLimits, backend.ID)
+		}
+
 		// Clusters config is not always present. Validate only when it is available
 		if len(clusterIDs) > 0 && !slices.Contains(clusterIDs, backend.ID) {
 			return fmt.Errorf(
@@ -126,8 +148,10 @@ func (c *CEEMSLBAppConfig) UnmarshalYAML(unmarshal func(interface{}) error) erro
 
 // CEEMSLBConfig contains the CEEMS load balancer config.
 type CEEMSLBConfig struct {
-	Backends []base.Backend `yaml:"backends"`
-	Strategy string         `yaml:"strategy"`
+	Backends      []base.Backend `yaml:"backends"`
+	Strategy      string         `yaml:"strategy"`
+	StickyQueries bool           `yaml:"sticky_queries"`
+	Limits        base.Limits    `yaml:"limits"`
 }
 
 // CEEMSLoadBalancer represents the `ceems_lb` cli.
@@ -269,6 +293,12 @@ func (lb *CEEMSLoadBalancer) Main() error {
 	managers := make(map[base.LBType]serverpool.Manager, 2)
 	lbs := make(map[base.LBType]frontend.LoadBalancer, 2)
 
+	// Limits of each backend with global limits as defaults
+	limits := make(map[string]base.Limits, len(config.LB.Backends))
+	for _, backend := range config.LB.Backends {
+		limits[backend.ID] = config.LB.Limits.Override(backend.Limits)
+	}
+
 	for i, lbType := range lbTypes {
 		// Create a pool of backend servers
 		managers[lbType], err = serverpool.New(config.LB.Strategy, logger.With("backend_type", lbType))
@@ -287,6 +317,8 @@ func (lb *CEEMSLoadBalancer) Main() error {
 			WebConfigFile:    webConfigFilePath,
 			APIServer:        config.Server,
 			Manager:          managers[lbType],
+			StickyQueries:    config.LB.StickyQueries,
+			Limits:           limits,
 		}
 
 		// Create frontend instance for load balancer
@@ -299,7 +331,9 @@ func (lb *CEEMSLoadBalancer) Main() error {
 
 		// Add backend servers to serverPool
 		for _, backend := range config.LB.Backends {
-			for _, backendURL := range backendURLs(lbType, backend) {
+			weights := backendWeights(lbType, backend)
+
+			for iURL, backendURL := range backendURLs(lbType, backend) {
 				webURL, err := url.Parse(backendURL)
 				if err != nil {
 					// If we dont unwrap original error, the URL string will be printed to log which
@@ -320,6 +354,10 @@ func (lb *CEEMSLoadBalancer) Main() error {
 
 				rp.ErrorHandler = frontend.ErrorHandler(webURL, backendServer, lbs[lbType], logger.With("backend_type", lbType))
 
+				if len(weights) > 0 {
+					backendServer.SetWeight(weights[iURL])
+				}
+
 				managers[lbType].Add(backend.ID, backendServer)
 			}
 		}
@@ -407,3 +445,15 @@ func backendURLs(t base.LBType, backend base.Backend) []string {
 
 	return nil
 }
+
+// backendWeights returns slice of backend weights based on backend type `t`.
+func backendWeights(t base.LBType, backend base.Backend) []int {
+	switch t {
+	case base.PromLB:
+		return backend.TSDBWeights
+	case base.PyroLB:
+		return backend.PyroWeights
+	}
+
+	return nil
+}
@@ -15,6 +15,8 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/mahendrapaipuri/ceems/internal/common"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -219,3 +221,83 @@ clusters:
 	_, err := common.MakeConfig[CEEMSLBAppConfig](configFilePath)
 	require.Error(t, err)
 }
+
+func TestCEEMSLBWeights(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Make config file
+	configFileTmpl := `
+---
+ceems_lb:
+  strategy: "weighted-round-robin"
+  backends:
+    - id: "default"
+      tsdb_urls:
+        - http://localhost:9090
+        - http://localhost:9091
+      tsdb_weights: %s`
+
+	for _, test := range []struct {
+		weights string
+		valid   bool
+	}{
+		{weights: "[3, 1]", valid: true},
+		{weights: "[]", valid: true},
+		{weights: "[3]"},
+		{weights: "[3, 0]"},
+	} {
+		configFilePath := makeConfigFile(fmt.Sprintf(configFileTmpl, test.weights), tmpDir)
+
+		_, err := common.MakeConfig[CEEMSLBAppConfig](configFilePath)
+		if test.valid {
+			require.NoError(t, err, test.weights)
+		} else {
+			require.ErrorIs(t, err, ErrInvalidWeights, test.weights)
+		}
+	}
+}
+
+func TestCEEMSLBLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Make config file
+	configFileTmpl := `
+---
+ceems_lb:
+  limits:
+    max_request_body_bytes: %d
+    timeout: 2m
+  backends:
+    - id: "default"
+      tsdb_urls:
+        - http://localhost:9090
+      limits:
+        max_request_body_bytes: %d`
+
+	for _, test := range []struct {
+		global  int64
+		backend int64
+		valid   bool
+	}{
+		{global: 1024, backend: 2048, valid: true},
+		{global: 0, backend: 0, valid: true},
+		{global: -1, backend: 0},
+		{global: 0, backend: -1},
+	} {
+		configFilePath := makeConfigFile(fmt.Sprintf(configFileTmpl, test.global, test.backend), tmpDir)
+
+		config, err := common.MakeConfig[CEEMSLBAppConfig](configFilePath)
+		if !test.valid {
+			require.ErrorIs(t, err, ErrInvalidLimits)
+
+			continue
+		}
+
+		require.NoError(t, err)
+
+		// Backend limits must take precedence over global ones
+		limits := config.LB.Limits.Override(config.LB.Backends[0].Limits)
+		assert.Equal(t, max(test.global, test.backend), limits.MaxRequestBodySize)
+		assert.Equal(t, model.Duration(2*time.Minute), limits.Timeout)
+	}
+}
@@ -20,15 +20,25 @@ import (
 	ceems_api_cli "github.com/mahendrapaipuri/ceems/pkg/api/cli"
 	ceems_api_http "github.com/mahendrapaipuri/ceems/pkg/api/http"
 	"github.com/mahendrapaipuri/ceems/pkg/api/models"
+	"github.com/mahendrapaipuri/ceems/pkg/lb/backend"
 	"github.com/mahendrapaipuri/ceems/pkg/lb/base"
 	"github.com/mahendrapaipuri/ceems/pkg/lb/serverpool"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/exporter-toolkit/web"
 )
 
 // Custom errors.
 var (
 	ErrUnknownClusterID = errors.New("unknown cluster ID")
+	ErrUpstreamTimeout  = errors.New("upstream request timed out")
+)
+
+// Reasons of rejecting requests.
+const (
+	rejectRequestTooLarge = "request_too_large"
+	rejectUpstreamTimeout = "upstream_timeout"
 )
 
 // RetryContextKey is the key used to set context value for retry.
@@ -43,6 +53,7 @@ type ReqParams struct {
 	uuids       []string
 	time        int64
 	queryPeriod time.Duration
+	fingerprint string
 }
 
 // LoadBalancer is the interface to implement.
@@ -62,6 +73,8 @@ type Config struct {
 	WebConfigFile    string
 	APIServer        ceems_api_cli.CEEMSAPIServerConfig
 	Manager          serverpool.Manager
+	StickyQueries    bool
+	Limits           map[string]base.Limits
 }
 
 // loadBalancer struct.
@@ -72,6 +85,10 @@ type loadBalancer struct {
 	server    *http.Server
 	webConfig *web.FlagConfig
 	amw       *authenticationMiddleware
+	sticky    bool
+	limits    map[string]base.Limits
+	registry  *prometheus.Registry
+	metrics   *lbMetrics
 }
 
 // New returns a new instance of load balancer.
@@ -82,6 +99,13 @@ func New(c *Config) (LoadBalancer, error) {
 		return nil, fmt.Errorf("failed to setup auth middleware: %w", err)
 	}
 
+	// Register metrics of load balancer
+	metrics := newLBMetrics(c.Manager)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(amw.rejections)
+	registry.MustRegister(metrics.collectors()...)
+
 	return &loadBalancer{
 		logger: c.Logger,
 		lbType: c.LBType,
@@ -94,8 +118,12 @@ func New(c *Config) (LoadBalancer, error) {
 			WebSystemdSocket:   &c.WebSystemdSocket,
 			WebConfigFile:      &c.WebConfigFile,
 		},
-		manager: c.Manager,
-		amw:     amw,
+		manager:  c.Manager,
+		amw:      amw,
+		sticky:   c.StickyQueries,
+		limits:   c.Limits,
+		registry: registry,
+		metrics:  metrics,
 	}, nil
 }
 
@@ -216,7 +244,20 @@ validate:
 // Start server.
 func (lb *loadBalancer) Start() error {
 	// Apply middleware
-	lb.server.Handler = lb.amw.Middleware(http.HandlerFunc(lb.Serve))
+	proxy := lb.amw.Middleware(http.HandlerFunc(lb.Serve))
+	metrics := promhttp.HandlerFor(lb.registry, promhttp.HandlerOpts{})
+
+	// Requests to metrics endpoint without cluster ID header are served by
+	// load balancer itself. Rest of them are proxied to backends
+	lb.server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metrics" && r.Header.Get(ceemsClusterIDHeader) == "" {
+			metrics.ServeHTTP(w, r)
+
+			return
+		}
+
+		proxy.ServeHTTP(w, r)
+	})
 	lb.logger.Info("Starting "+base.CEEMSLoadBalancerAppName, "listening", lb.server.Addr)
 
 	// Listen for requests
@@ -266,23 +307,62 @@ func (lb *loadBalancer) Serve(w http.ResponseWriter, r *http.Request) {
 	// Middleware ensures that query parameters are always set in request's context
 	var queryPeriod time.Duration
 
-	var id string
+	var id, fingerprint string
 
 	if v, ok := queryParams.(*ReqParams); ok {
 		queryPeriod = v.queryPeriod
 		id = v.clusterID
+		fingerprint = v.fingerprint
 	} else {
 		http.Error(w, "Invalid query parameters", http.StatusBadRequest)
 
 		return
 	}
 
+	// Impose timeout on upstream request. Retried requests inherit the
+	// timeout of original request
+	if timeout := time.Duration(lb.limits[id].Timeout); timeout > 0 && AllowRetry(r) {
+		ctx, cancel := context.WithTimeoutCause(r.Context(), timeout, ErrUpstreamTimeout)
+		defer cancel()
+
+		r = r.WithContext(ctx)
+
+		defer func() {
+			if errors.Is(context.Cause(ctx), ErrUpstreamTimeout) {
+				lb.amw.rejections.WithLabelValues(id, rejectUpstreamTimeout).Inc()
+			}
+		}()
+	}
+
+	// When sticky queries are enabled, route identical queries to the same
+	// backend to exploit its query cache. If that backend is not available,
+	// fallback to the configured strategy
+	start := time.Now()
+
+	if lb.sticky && fingerprint != "" {
+		if target := serverpool.HashTarget(lb.manager.Backends()[id], queryPeriod, fingerprint); target != nil {
+			lb.logger.Debug("Sticky query", "cluster_id", id, "selected_backend", target.String())
+			lb.serveTarget(w, r, id, target, start)
+
+			return
+		}
+	}
+
 	// Choose target based on query Period
 	if target := lb.manager.Target(id, queryPeriod); target != nil {
-		target.Serve(w, r)
+		lb.serveTarget(w, r, id, target, start)
 
 		return
 	}
 
 	http.Error(w, "Service not available", http.StatusServiceUnavailable)
 }
+
+// serveTarget records metrics of the selected backend and serves the request
+// using it.
+func (lb *loadBalancer) serveTarget(w http.ResponseWriter, r *http.Request, id string, target backend.Server, start time.Time) {
+	lb.metrics.selection.WithLabelValues(id).Observe(time.Since(start).Seconds())
+	lb.metrics.requests.WithLabelValues(id, backendLabel(target)).Inc()
+
+	target.Serve(w, r)
+}
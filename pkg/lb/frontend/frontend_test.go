@@ -22,8 +22,11 @@ import (
 	ceems_api_http "github.com/mahendrapaipuri/ceems/pkg/api/http"
 	"github.com/mahendrapaipuri/ceems/pkg/api/models"
 	"github.com/mahendrapaipuri/ceems/pkg/lb/backend"
+	"github.com/mahendrapaipuri/ceems/pkg/lb/base"
 	"github.com/mahendrapaipuri/ceems/pkg/lb/serverpool"
 	"github.com/mahendrapaipuri/ceems/pkg/tsdb"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -177,6 +180,80 @@ func TestNewFrontendSingleGroup(t *testing.T) {
 	assert.Equal(t, 503, responseRecorder.Code)
 }
 
+func TestStickyQueries(t *testing.T) {
+	clusterID := "default"
+
+	// Start manager
+	manager, err := serverpool.New("round-robin", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// Backends that respond with their names
+	backends := make(map[string]backend.Server)
+
+	for _, name := range []string{"b0", "b1", "b2"} {
+		dummyServer := dummyTSDBServer(name)
+		defer dummyServer.Close()
+
+		backendURL, err := url.Parse(dummyServer.URL)
+		require.NoError(t, err)
+
+		rp := httputil.NewSingleHostReverseProxy(backendURL)
+		backends[name] = backend.NewTSDB(backendURL, rp, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		manager.Add(clusterID, backends[name])
+	}
+
+	// make minimal config
+	config := &Config{
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Manager:       manager,
+		Address:       "localhost:9030", // dummy address
+		StickyQueries: true,
+	}
+
+	// New load balancer
+	lb, err := New(config)
+	require.NoError(t, err)
+
+	serve := func(fingerprint string) (int, string) {
+		request := httptest.NewRequest(http.MethodGet, "/test", nil)
+		newReq := request.WithContext(
+			context.WithValue(
+				request.Context(), ReqParamsContextKey{},
+				&ReqParams{clusterID: clusterID, fingerprint: fingerprint},
+			),
+		)
+
+		responseRecorder := httptest.NewRecorder()
+		http.HandlerFunc(lb.Serve).ServeHTTP(responseRecorder, newReq)
+
+		return responseRecorder.Code, responseRecorder.Body.String()
+	}
+
+	// Identical queries must land on same backend
+	_, target := serve("foo")
+	for range 5 {
+		code, body := serve("foo")
+		assert.Equal(t, 200, code)
+		assert.Equal(t, target, body)
+	}
+
+	// Different queries must be spread across backends
+	targets := make(map[string]bool)
+	for i := range 30 {
+		_, body := serve(fmt.Sprintf("query-%d", i))
+		targets[body] = true
+	}
+
+	assert.Greater(t, len(targets), 1)
+
+	// When target is down, query must be served by another backend
+	backends[target].SetAlive(false)
+
+	code, body := serve("foo")
+	assert.Equal(t, 200, code)
+	assert.NotEqual(t, target, body)
+}
+
 func TestNewFrontendTwoGroups(t *testing.T) {
 	// Backends for group 1
 	dummyServer1 := dummyTSDBServer("rm-0")
@@ -468,3 +545,161 @@ func TestValidateClusterIDsWithAPIFail(t *testing.T) {
 	// Validate cluster IDs
 	require.Error(t, lb.ValidateClusterIDs(context.Background()))
 }
+
+func TestLimits(t *testing.T) {
+	clusterID := "default"
+
+	// Backend that responds slowly to queries with sleep param
+	dummyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sleep") != "" {
+			time.Sleep(500 * time.Millisecond)
+		}
+
+		w.Write([]byte(clusterID))
+	}))
+	defer dummyServer.Close()
+
+	backendURL, err := url.Parse(dummyServer.URL)
+	require.NoError(t, err)
+
+	manager, err := serverpool.New("round-robin", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// make minimal config
+	config := &Config{
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Manager: manager,
+		Address: "localhost:9030", // dummy address
+		Limits: map[string]base.Limits{
+			clusterID: {MaxRequestBodySize: 10, Timeout: model.Duration(100 * time.Millisecond)},
+		},
+	}
+
+	// New load balancer
+	lb, err := New(config)
+	require.NoError(t, err)
+
+	rp := httputil.NewSingleHostReverseProxy(backendURL)
+	backendServer := backend.NewTSDB(backendURL, rp, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	rp.ErrorHandler = ErrorHandler(backendURL, backendServer, lb, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	manager.Add(clusterID, backendServer)
+
+	// Set cluster IDs
+	require.NoError(t, lb.ValidateClusterIDs(context.Background()))
+
+	handler := lb.(*loadBalancer).amw.Middleware(http.HandlerFunc(lb.Serve))
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		code   int
+		reason string
+	}{
+		{name: "within limits", path: "/api/v1/query", body: "query=up", code: 200},
+		{name: "large body", path: "/api/v1/query", body: "query=up{instance=\"localhost\"}", code: 413, reason: rejectRequestTooLarge},
+		{name: "timeout", path: "/api/v1/query?sleep=1", body: "query=up", code: 504, reason: rejectUpstreamTimeout},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.body))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request.Header.Set(ceemsClusterIDHeader, clusterID)
+
+		responseRecorder := httptest.NewRecorder()
+		handler.ServeHTTP(responseRecorder, request)
+
+		assert.Equal(t, test.code, responseRecorder.Code, test.name)
+	}
+
+	// Rejections must be exported as metrics
+	responseRecorder := httptest.NewRecorder()
+	promhttp.HandlerFor(lb.(*loadBalancer).registry, promhttp.HandlerOpts{}).ServeHTTP(
+		responseRecorder, httptest.NewRequest(http.MethodGet, "/metrics", nil),
+	)
+
+	for _, test := range tests {
+		if test.reason != "" {
+			assert.Contains(
+				t, responseRecorder.Body.String(),
+				fmt.Sprintf(`ceems_lb_rejected_requests_total{cluster_id="%s",reason="%s"} 1`, clusterID, test.reason),
+				test.name,
+			)
+		}
+	}
+
+	// Timed out backend must not be marked as dead
+	assert.True(t, backendServer.IsAlive())
+}
+
+func TestLoadBalancerMetrics(t *testing.T) {
+	clusterID := "default"
+
+	// Start manager
+	manager, err := serverpool.New("round-robin", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// make minimal config
+	config := &Config{
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Manager: manager,
+		Address: "localhost:9030", // dummy address
+	}
+
+	// New load balancer
+	lb, err := New(config)
+	require.NoError(t, err)
+
+	// One healthy backend and one backend that is unreachable
+	liveServer := dummyTSDBServer(clusterID)
+	defer liveServer.Close()
+
+	deadServer := dummyTSDBServer(clusterID)
+	deadServer.Close()
+
+	backendURLs := make([]*url.URL, 2)
+
+	for i, server := range []*httptest.Server{liveServer, deadServer} {
+		backendURLs[i], err = url.Parse(server.URL)
+		require.NoError(t, err)
+
+		rp := httputil.NewSingleHostReverseProxy(backendURLs[i])
+		backendServer := backend.NewTSDB(backendURLs[i], rp, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		rp.ErrorHandler = ErrorHandler(backendURLs[i], backendServer, lb, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		manager.Add(clusterID, backendServer)
+	}
+
+	// With round robin, unreachable backend is selected at least once and
+	// request must be retried on healthy backend
+	for range 2 {
+		request := httptest.NewRequest(http.MethodGet, "/test", nil)
+		newReq := request.WithContext(
+			context.WithValue(request.Context(), ReqParamsContextKey{}, &ReqParams{clusterID: clusterID}),
+		)
+
+		responseRecorder := httptest.NewRecorder()
+		http.HandlerFunc(lb.Serve).ServeHTTP(responseRecorder, newReq)
+
+		assert.Equal(t, 200, responseRecorder.Code)
+	}
+
+	responseRecorder := httptest.NewRecorder()
+	promhttp.HandlerFor(lb.(*loadBalancer).registry, promhttp.HandlerOpts{}).ServeHTTP(
+		responseRecorder, httptest.NewRequest(http.MethodGet, "/metrics", nil),
+	)
+
+	metrics := responseRecorder.Body.String()
+
+	for _, expected := range []string{
+		fmt.Sprintf(`ceems_lb_backend_requests_total{backend="%s",cluster_id="%s"} 1`, backendURLs[1], clusterID),
+		fmt.Sprintf(`ceems_lb_backend_request_errors_total{backend="%s",cluster_id="%s"} 1`, backendURLs[1], clusterID),
+		fmt.Sprintf(`ceems_lb_backend_up{backend="%s",cluster_id="%s"} 1`, backendURLs[0], clusterID),
+		fmt.Sprintf(`ceems_lb_backend_up{backend="%s",cluster_id="%s"} 0`, backendURLs[1], clusterID),
+		fmt.Sprintf(`ceems_lb_backend_selection_duration_seconds_count{cluster_id="%s"} 3`, clusterID),
+	} {
+		assert.Contains(t, metrics, expected)
+	}
+
+	// Healthy backend must not have any errors
+	assert.NotContains(t, metrics, fmt.Sprintf(`ceems_lb_backend_request_errors_total{backend="%s"`, backendURLs[0]))
+}
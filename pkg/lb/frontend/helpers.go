@@ -14,6 +14,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"path"
 	"slices"
 	"strconv"
 	"strings"
@@ -22,9 +23,18 @@ import (
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	"github.com/mahendrapaipuri/ceems/pkg/lb/backend"
 	"github.com/mahendrapaipuri/ceems/pkg/lb/serverpool"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
+// pyroRequest is the common interface of Pyroscope requests that select profiles.
+type pyroRequest interface {
+	proto.Message
+	GetProfileTypeID() string
+	GetLabelSelector() string
+	GetStart() int64
+}
+
 // These functions are nicked from https://github.com/prometheus/prometheus/blob/main/web/api/v1/api.go
 var (
 	// MinTime is the default timestamp used for the begin of optional time ranges.
@@ -73,6 +83,21 @@ func Monitor(ctx context.Context, manager serverpool.Manager, logger *slog.Logge
 // ErrorHandler returns a custom error handler for reverse proxy.
 func ErrorHandler(u *url.URL, backendServer backend.Server, lb LoadBalancer, logger *slog.Logger) func(http.ResponseWriter, *http.Request, error) {
 	return func(writer http.ResponseWriter, request *http.Request, err error) {
+		// Count failed request of backend
+		if l, ok := lb.(*loadBalancer); ok {
+			if p, ok := request.Context().Value(ReqParamsContextKey{}).(*ReqParams); ok {
+				l.metrics.errors.WithLabelValues(p.clusterID, backendLabel(backendServer)).Inc()
+			}
+		}
+
+		// Timed out requests are neither retried nor mark backend as dead
+		if errors.Is(context.Cause(request.Context()), ErrUpstreamTimeout) {
+			logger.Error("Request to backend timed out", "host", u.Host, "path", request.URL.Path, "err", err)
+			http.Error(writer, "Gateway timeout", http.StatusGatewayTimeout)
+
+			return
+		}
+
 		logger.Error("Failed to handle the request", "host", u.Host, "err", err)
 		backendServer.SetAlive(false)
 
@@ -146,6 +171,10 @@ func parseTSDBRequest(p *ReqParams, r *http.Request) error {
 	// Parse TSDB's query in request query params
 	if val := clonedReq.FormValue(targetQueryParam); val != "" {
 		parseReqParams(p, val)
+
+		// Start and end times change at each refresh of dashboard and hence
+		// they are not part of fingerprint
+		p.fingerprint = queryFingerprint(clonedReq.URL.Path, val, clonedReq.FormValue("step"))
 	}
 
 	// Parse TSDB's start query in request query params
@@ -179,15 +208,37 @@ func parsePyroRequest(p *ReqParams, r *http.Request) error {
 	// clone body to existing request
 	r.Body = io.NopCloser(bytes.NewReader(body))
 
-	// Read body into request data
-	data := querierv1.SelectMergeStacktracesRequest{}
-	if err := proto.Unmarshal(body, &data); err != nil {
+	// Pick request message based on the endpoint
+	var data pyroRequest
+
+	switch path.Base(r.URL.Path) {
+	case "SelectMergeProfile":
+		data = &querierv1.SelectMergeProfileRequest{}
+	case "SelectSeries":
+		data = &querierv1.SelectSeriesRequest{}
+	case "SelectMergeSpanProfile":
+		data = &querierv1.SelectMergeSpanProfileRequest{}
+	default:
+		data = &querierv1.SelectMergeStacktracesRequest{}
+	}
+
+	// Read body into request data. Connect protocol allows both binary and
+	// JSON encoded messages
+	if strings.Contains(r.Header.Get("Content-Type"), "json") {
+		err = protojson.Unmarshal(body, data)
+	} else {
+		err = proto.Unmarshal(body, data)
+	}
+
+	if err != nil {
 		return fmt.Errorf("failed to umarshall request body: %w", err)
 	}
 
 	// Parse Pyroscope's LabelSelector in request data
 	if val := data.GetLabelSelector(); val != "" {
 		parseReqParams(p, val)
+
+		p.fingerprint = queryFingerprint(r.URL.Path, val, data.GetProfileTypeID())
 	}
 
 	// Parse Pyroscope's start query in request query params
@@ -232,6 +283,18 @@ func parseReqParams(p *ReqParams, req string) {
 	}
 }
 
+// queryFingerprint returns a normalized fingerprint of the query made of parts.
+// Repeated whitespace in parts is collapsed so that formatting differences of
+// the same query do not change the fingerprint.
+func queryFingerprint(parts ...string) string {
+	normalized := make([]string, len(parts))
+	for i, part := range parts {
+		normalized[i] = strings.Join(strings.Fields(part), " ")
+	}
+
+	return strings.Join(normalized, "\x00")
+}
+
 // Parse time parameter in request.
 func parseTimeParam(r *http.Request, paramName string, defaultValue time.Time) (time.Time, error) {
 	val := r.FormValue(paramName)
@@ -15,6 +15,7 @@ import (
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -232,6 +233,7 @@ func TestParseTSDBQueryParams(t *testing.T) {
 
 		assert.Equal(t, test.uuids, p.uuids)
 		assert.Equal(t, test.rmID, p.clusterID)
+		assert.Equal(t, queryFingerprint(test.path, test.query, ""), p.fingerprint)
 
 		// Set parameters to request's context
 		newReq := setQueryParams(req, p)
@@ -248,12 +250,15 @@ func TestParseTSDBQueryParams(t *testing.T) {
 
 func TestParsePyroQueryParams(t *testing.T) {
 	tests := []struct {
-		message *querierv1.SelectMergeStacktracesRequest
+		path    string
+		message pyroRequest
+		json    bool
 		uuids   []string
 		start   int64
 		rmIDs   string
 	}{
 		{
+			path: "/querier.v1.QuerierService/SelectMergeStacktraces",
 			message: &querierv1.SelectMergeStacktracesRequest{
 				LabelSelector: `{service_name="123"}`,
 				Start:         1735209190,
@@ -262,6 +267,7 @@ func TestParsePyroQueryParams(t *testing.T) {
 			start: 1735209190000,
 		},
 		{
+			path: "/querier.v1.QuerierService/SelectMergeStacktraces",
 			message: &querierv1.SelectMergeStacktracesRequest{
 				LabelSelector: `{service_name="123", ceems_id="default"}`,
 				Start:         1735209190,
@@ -270,22 +276,81 @@ func TestParsePyroQueryParams(t *testing.T) {
 			rmIDs: "default",
 			start: 1735209190000,
 		},
+		{
+			path: "/querier.v1.QuerierService/SelectSeries",
+			message: &querierv1.SelectSeriesRequest{
+				LabelSelector: `{service_name="456"}`,
+				Start:         1735209190,
+				Step:          15,
+			},
+			uuids: []string{"456"},
+			start: 1735209190000,
+		},
+		{
+			path: "/querier.v1.QuerierService/SelectMergeProfile",
+			message: &querierv1.SelectMergeProfileRequest{
+				LabelSelector: `{service_name="789"}`,
+				Start:         1735209190,
+			},
+			json:  true,
+			uuids: []string{"789"},
+			start: 1735209190000,
+		},
 	}
 
 	for _, test := range tests {
 		// Query params
-		data, err := proto.Marshal(test.message)
+		var data []byte
+
+		var err error
+
+		if test.json {
+			data, err = protojson.Marshal(test.message)
+		} else {
+			data, err = proto.Marshal(test.message)
+		}
+
 		require.NoError(t, err)
 
-		req, err := http.NewRequest(http.MethodPost, "http://localhost:9090", bytes.NewBuffer(data)) //nolint:noctx
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:9090"+test.path, bytes.NewBuffer(data)) //nolint:noctx
 		require.NoError(t, err)
 
+		if test.json {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
 		p := &ReqParams{}
 		err = parsePyroRequest(p, req)
-		require.NoError(t, err)
+		require.NoError(t, err, test.path)
 
-		assert.Equal(t, test.uuids, p.uuids)
-		assert.Equal(t, test.rmIDs, p.clusterID)
-		assert.Equal(t, test.start, p.time)
+		assert.Equal(t, test.uuids, p.uuids, test.path)
+		assert.Equal(t, test.rmIDs, p.clusterID, test.path)
+		assert.Equal(t, test.start, p.time, test.path)
 	}
 }
+
+func TestQueryFingerprint(t *testing.T) {
+	// Formatting differences must not change fingerprint
+	assert.Equal(
+		t,
+		queryFingerprint("/api/v1/query_range", `sum(rate(foo{uuid="123"}[5m]))`, "15"),
+		queryFingerprint("/api/v1/query_range", "  sum(rate(foo{uuid=\"123\"}[5m]))\n", "15"),
+	)
+
+	// Different queries, steps and paths must have different fingerprints
+	assert.NotEqual(
+		t,
+		queryFingerprint("/api/v1/query_range", `foo{uuid="123"}`, "15"),
+		queryFingerprint("/api/v1/query_range", `foo{uuid="456"}`, "15"),
+	)
+	assert.NotEqual(
+		t,
+		queryFingerprint("/api/v1/query_range", `foo{uuid="123"}`, "15"),
+		queryFingerprint("/api/v1/query_range", `foo{uuid="123"}`, "30"),
+	)
+	assert.NotEqual(
+		t,
+		queryFingerprint("/api/v1/query", `foo{uuid="123"}`, ""),
+		queryFingerprint("/api/v1/query_range", `foo{uuid="123"}`, ""),
+	)
+}
@@ -0,0 +1,103 @@
+//go:build cgo
+// +build cgo
+
+package frontend
+
+import (
+	"net/url"
+
+	"github.com/mahendrapaipuri/ceems/pkg/lb/backend"
+	"github.com/mahendrapaipuri/ceems/pkg/lb/serverpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace of load balancer metrics.
+const metricsNamespace = "ceems_lb"
+
+// lbMetrics contains metrics of backend selection of load balancer.
+type lbMetrics struct {
+	requests  *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	selection *prometheus.HistogramVec
+	health    *healthCollector
+}
+
+// newLBMetrics returns metrics of load balancer for backends in manager.
+func newLBMetrics(manager serverpool.Manager) *lbMetrics {
+	return &lbMetrics{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "backend_requests_total",
+				Help:      "Total number of requests proxied to backend",
+			},
+			[]string{"cluster_id", "backend"},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "backend_request_errors_total",
+				Help:      "Total number of requests that failed to be proxied to backend",
+			},
+			[]string{"cluster_id", "backend"},
+		),
+		selection: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "backend_selection_duration_seconds",
+				Help:      "Time taken to select a backend for requests",
+				Buckets:   prometheus.ExponentialBuckets(1e-5, 4, 10),
+			},
+			[]string{"cluster_id"},
+		),
+		health: &healthCollector{
+			manager: manager,
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(metricsNamespace, "", "backend_up"),
+				"Current health of backend. 1 if backend is alive, 0 otherwise",
+				[]string{"cluster_id", "backend"}, nil,
+			),
+		},
+	}
+}
+
+// collectors returns all the collectors of load balancer metrics.
+func (m *lbMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.requests, m.errors, m.selection, m.health}
+}
+
+// healthCollector exports current health of backends at scrape time.
+type healthCollector struct {
+	manager serverpool.Manager
+	desc    *prometheus.Desc
+}
+
+// Describe implements prometheus.Collector interface.
+func (c *healthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector interface.
+func (c *healthCollector) Collect(ch chan<- prometheus.Metric) {
+	for id, backends := range c.manager.Backends() {
+		for _, b := range backends {
+			var up float64
+			if b.IsAlive() {
+				up = 1
+			}
+
+			ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, up, id, backendLabel(b))
+		}
+	}
+}
+
+// backendLabel returns the URL of backend without credentials and query
+// parameters to be used as label value.
+func backendLabel(b backend.Server) string {
+	u := b.URL()
+	if u == nil {
+		return ""
+	}
+
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+}
@@ -4,11 +4,13 @@
 package frontend
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -22,6 +24,7 @@ import (
 	ceems_api_base "github.com/mahendrapaipuri/ceems/pkg/api/base"
 	ceems_api "github.com/mahendrapaipuri/ceems/pkg/api/http"
 	"github.com/mahendrapaipuri/ceems/pkg/lb/base"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/config"
 )
 
@@ -47,7 +50,10 @@ const (
 // - series
 //
 // For Pyroscope following end points are controlled
-// - SelectMergeStacktraces.
+// - SelectMergeStacktraces
+// - SelectMergeProfile
+// - SelectMergeSpanProfile
+// - SelectSeries.
 var (
 	restrictedTSDBPathSuffices = []string{
 		"query",
@@ -58,6 +64,9 @@ var (
 	}
 	restrictedPyroPathSuffices = []string{
 		"SelectMergeStacktraces",
+		"SelectMergeProfile",
+		"SelectMergeSpanProfile",
+		"SelectSeries",
 	}
 )
 
@@ -107,6 +116,9 @@ type authenticationMiddleware struct {
 	clusterIDs    []string
 	pathsACLRegex *regexp.Regexp
 	parseRequest  func(*ReqParams, *http.Request) error
+	stickyQueries bool
+	limits        map[string]base.Limits
+	rejections    *prometheus.CounterVec
 }
 
 // newAuthMiddleware setups new auth middleware.
@@ -161,6 +173,16 @@ func newAuthMiddleware(c *Config) (*authenticationMiddleware, error) {
 			webURL: ceemsWebURL,
 			client: ceemsClient,
 		},
+		stickyQueries: c.StickyQueries,
+		limits:        c.Limits,
+		rejections: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "rejected_requests_total",
+				Help:      "Total number of requests rejected by load balancer due to limits",
+			},
+			[]string{"cluster_id", "reason"},
+		),
 	}
 
 	// Setup parsing functions based on LB type
@@ -264,6 +286,41 @@ func (amw *authenticationMiddleware) Middleware(next http.Handler) http.Handler
 			return
 		}
 
+		// Reject requests with body larger than configured limit. Body is read
+		// here so that it is never partially proxied to backend
+		if limit := amw.limits[reqParams.clusterID].MaxRequestBodySize; limit > 0 && r.Body != nil {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					amw.logger.Debug(
+						"Request body too large", "cluster_id", reqParams.clusterID,
+						"limit", limit, "url", r.URL,
+					)
+					amw.rejections.WithLabelValues(reqParams.clusterID, rejectRequestTooLarge).Inc()
+
+					// Write an error and stop the handler chain
+					w.WriteHeader(http.StatusRequestEntityTooLarge)
+
+					response := ceems_api.Response[any]{
+						Status:    "error",
+						ErrorType: "request_too_large",
+						Error:     fmt.Sprintf("request body exceeds limit of %d bytes", limit),
+					}
+					if err := json.NewEncoder(w).Encode(&response); err != nil {
+						amw.logger.Error("Failed to encode response", "err", err)
+						w.Write([]byte("KO"))
+					}
+
+					return
+				}
+
+				amw.logger.Error("Failed to read request body", "err", err)
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
 		// Apply middleware only for restricted endpoints
 		if !amw.pathsACLRegex.MatchString(r.URL.Path) {
 			goto end
@@ -337,6 +394,14 @@ func (amw *authenticationMiddleware) Middleware(next http.Handler) http.Handler
 		}
 
 	end:
+		// Sticky queries need fingerprint of the query even when no access
+		// control is imposed
+		if amw.stickyQueries && reqParams.fingerprint == "" && amw.pathsACLRegex.MatchString(r.URL.Path) {
+			if err := amw.parseRequest(reqParams, r); err != nil {
+				amw.logger.Debug("Failed to parse query in the request", "err", err)
+			}
+		}
+
 		// Set query params to request's context before passing down request
 		r = setQueryParams(r, reqParams)
 
@@ -4,6 +4,7 @@
 package frontend
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
@@ -17,9 +18,11 @@ import (
 	"testing"
 	"time"
 
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	http_api "github.com/mahendrapaipuri/ceems/pkg/api/http"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func setupTestDB(d string) (*sql.DB, error) {
@@ -325,3 +328,115 @@ func TestMiddlewareWithDB(t *testing.T) {
 		assert.Equal(t, test.code, resAPI.StatusCode, "%s with API", test.name)
 	}
 }
+
+func TestMiddlewarePyro(t *testing.T) {
+	// Setup test DB
+	db, err := setupTestDB(t.TempDir())
+	require.NoError(t, err)
+
+	// Setup test CEEMS API server
+	ceemsServer := setupCEEMSAPI(db)
+	defer ceemsServer.Close()
+
+	ceemsURL, err := url.Parse(ceemsServer.URL)
+	require.NoError(t, err)
+
+	// Middlewares verifying with DB and API
+	handlers := make(map[string]http.Handler)
+
+	for name, c := range map[string]ceems{
+		"DB":  {db: db},
+		"API": {webURL: ceemsURL, client: http.DefaultClient},
+	} {
+		amw := authenticationMiddleware{
+			logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+			clusterIDs:    []string{"rm-0", "rm-1"},
+			ceems:         c,
+			parseRequest:  parsePyroRequest,
+			pathsACLRegex: regexpPyroRestrictedPath,
+		}
+
+		handlers[name] = amw.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	}
+
+	tests := []struct {
+		name string
+		path string
+		uuid string
+		user string
+		code int
+	}{
+		{
+			name: "pass due to correct uuid",
+			path: "SelectMergeStacktraces",
+			uuid: "1479763",
+			user: "usr1",
+			code: 200,
+		},
+		{
+			name: "pass due to uuid from same project",
+			path: "SelectSeries",
+			uuid: "1481508",
+			user: "usr1",
+			code: 200,
+		},
+		{
+			name: "forbid due to mismatch uuid",
+			path: "SelectMergeStacktraces",
+			uuid: "1481510",
+			user: "usr1",
+			code: 403,
+		},
+		{
+			name: "forbid series due to mismatch uuid",
+			path: "SelectSeries",
+			uuid: "1479765",
+			user: "usr1",
+			code: 403,
+		},
+		{
+			name: "forbid profile due to mismatch uuid",
+			path: "SelectMergeProfile",
+			uuid: "1481510",
+			user: "usr2",
+			code: 403,
+		},
+		{
+			name: "allow query for admins",
+			path: "SelectMergeStacktraces",
+			uuid: "1481510",
+			user: "adm1",
+			code: 200,
+		},
+		{
+			name: "forbid due to missing header",
+			path: "SelectMergeStacktraces",
+			uuid: "1479763",
+			code: 401,
+		},
+	}
+
+	for _, test := range tests {
+		data, err := proto.Marshal(&querierv1.SelectMergeStacktracesRequest{
+			LabelSelector: fmt.Sprintf(`{service_name="%s"}`, test.uuid),
+			Start:         1735045414,
+		})
+		require.NoError(t, err)
+
+		for name, handler := range handlers {
+			request := httptest.NewRequest(
+				http.MethodPost, "/querier.v1.QuerierService/"+test.path, bytes.NewReader(data),
+			)
+			request.Header.Set(ceemsClusterIDHeader, "rm-0")
+
+			if test.user != "" {
+				request.Header.Set(grafanaUserHeader, test.user)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, request)
+
+			assert.Equal(t, test.code, responseRecorder.Code, "%s with %s", test.name, name)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package serverpool
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/pkg/lb/backend"
+)
+
+// HashTarget returns the backend server to send the request based on the
+// rendezvous hash of key and backend URLs. Only alive backends whose retention
+// period, when known, covers query duration d are considered.
+//
+// The same key always maps to the same backend as long as it is alive. When the
+// backend goes down, requests with that key are spread over the remaining
+// backends without affecting the keys of other backends. Nil is returned when
+// no backend is eligible.
+func HashTarget(backends []backend.Server, d time.Duration, key string) backend.Server {
+	var targetBackend backend.Server
+
+	var maxScore uint64
+
+	for _, b := range backends {
+		if !b.IsAlive() {
+			continue
+		}
+
+		if retentionPeriod := b.RetentionPeriod(); retentionPeriod > 0 && d >= retentionPeriod {
+			continue
+		}
+
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		h.Write([]byte(b.URL().String()))
+
+		if score := h.Sum64(); targetBackend == nil || score > maxScore {
+			targetBackend = b
+			maxScore = score
+		}
+	}
+
+	return targetBackend
+}
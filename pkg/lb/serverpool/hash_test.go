@@ -0,0 +1,64 @@
+package serverpool
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+
+	"github.com/mahendrapaipuri/ceems/pkg/lb/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashTarget(t *testing.T) {
+	// Make dummy backend servers
+	backends := make([]backend.Server, 3)
+
+	for i := range backends {
+		backendURL, err := url.Parse(fmt.Sprintf("http://localhost:%d", 3333*(i+1)))
+		require.NoError(t, err)
+
+		rp := httputil.NewSingleHostReverseProxy(backendURL)
+		backends[i] = backend.NewTSDB(backendURL, rp, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+
+	// Same key must always map to same backend
+	target := HashTarget(backends, 0, "foo")
+	require.NotNil(t, target)
+
+	for range 5 {
+		assert.Equal(t, target, HashTarget(backends, 0, "foo"))
+	}
+
+	// Remember targets of other keys that do not map to target
+	otherTargets := make(map[string]backend.Server)
+
+	for i := range 20 {
+		key := fmt.Sprintf("key-%d", i)
+		if b := HashTarget(backends, 0, key); b != target {
+			otherTargets[key] = b
+		}
+	}
+
+	// When target is dead, key must map to another backend and keys of other
+	// backends must not be affected
+	target.SetAlive(false)
+
+	newTarget := HashTarget(backends, 0, "foo")
+	require.NotNil(t, newTarget)
+	assert.NotEqual(t, target, newTarget)
+
+	for key, b := range otherTargets {
+		assert.Equal(t, b, HashTarget(backends, 0, key), key)
+	}
+
+	// When all backends are dead expect nil
+	for _, b := range backends {
+		b.SetAlive(false)
+	}
+
+	assert.Nil(t, HashTarget(backends, 0, "foo"))
+}
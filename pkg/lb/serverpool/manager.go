@@ -37,6 +37,12 @@ func New(strategy string, logger *slog.Logger) (Manager, error) {
 			backends: make(map[string][]backend.Server, 0),
 			logger:   logger,
 		}, nil
+	case "weighted-round-robin":
+		return &weightedRoundRobin{
+			backends:       make(map[string][]backend.Server, 0),
+			currentWeights: make(map[string][]int, 0),
+			logger:         logger,
+		}, nil
 	case "resource-based":
 		return &resourceBased{
 			backends: make(map[string][]backend.Server, 0),
@@ -23,7 +23,7 @@ var h = http.HandlerFunc(SleepHandler)
 // w   = httptest.NewRecorder()
 
 func TestNew(t *testing.T) {
-	for _, strategy := range []string{"round-robin", "least-connection", "resource-based"} {
+	for _, strategy := range []string{"round-robin", "least-connection", "weighted-round-robin", "resource-based"} {
 		m, _ := New(strategy, slog.New(slog.NewTextHandler(io.Discard, nil)))
 		url, _ := url.Parse("http://localhost:3333")
 		b := backend.NewTSDB(url, httputil.NewSingleHostReverseProxy(url), slog.New(slog.NewTextHandler(io.Discard, nil)))
@@ -0,0 +1,92 @@
+package serverpool
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/pkg/lb/backend"
+)
+
+// weightedRoundRobin implements smooth weighted round robin load balancer strategy.
+//
+// Requests are distributed among alive backend servers in proportion to their
+// weights. Selections are interleaved so that a backend with a large weight does
+// not receive a burst of consecutive requests.
+type weightedRoundRobin struct {
+	backends       map[string][]backend.Server
+	currentWeights map[string][]int
+	mux            sync.Mutex
+	logger         *slog.Logger
+}
+
+// Target returns the backend server to send the request if it is alive.
+func (s *weightedRoundRobin) Target(id string, _ time.Duration) backend.Server {
+	// If the ID is unknown return
+	if _, ok := s.backends[id]; !ok {
+		s.logger.Error("Weighted Round Robin strategy", "err", fmt.Errorf("unknown backend ID: %s", id))
+
+		return nil
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	// Increase current weight of each alive backend by its weight and choose
+	// the one with maximum current weight
+	totalWeight := 0
+	target := -1
+
+	for i, b := range s.backends[id] {
+		if !b.IsAlive() {
+			continue
+		}
+
+		weight := b.Weight()
+		s.currentWeights[id][i] += weight
+		totalWeight += weight
+
+		if target == -1 || s.currentWeights[id][i] > s.currentWeights[id][target] {
+			target = i
+		}
+	}
+
+	if target == -1 {
+		return nil
+	}
+
+	// Decrease current weight of chosen backend by total weight
+	s.currentWeights[id][target] -= totalWeight
+
+	s.logger.Debug(
+		"Weighted Round Robin strategy", "cluster_id", id,
+		"selected_backend", s.backends[id][target].String(),
+	)
+
+	return s.backends[id][target]
+}
+
+// List all backend servers in pool.
+func (s *weightedRoundRobin) Backends() map[string][]backend.Server {
+	return s.backends
+}
+
+// Add a backend server to pool.
+func (s *weightedRoundRobin) Add(id string, b backend.Server) {
+	s.logger.Debug(
+		"Backend added", "strategy", "weighted-round-robin", "cluster_id", id,
+		"backend", b.String(), "weight", b.Weight(),
+	)
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.backends[id] = append(s.backends[id], b)
+	s.currentWeights[id] = append(s.currentWeights[id], 0)
+}
+
+// Total number of backend servers in pool.
+func (s *weightedRoundRobin) Size(id string) int {
+	return len(s.backends[id])
+}
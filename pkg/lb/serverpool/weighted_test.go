@@ -0,0 +1,62 @@
+package serverpool
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mahendrapaipuri/ceems/pkg/lb/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightedRoundRobin(t *testing.T) {
+	d := 0 * time.Second
+	manager, err := New("weighted-round-robin", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// Make dummy backend servers with weights 5, 1 and 1
+	weights := []int{5, 1, 1}
+	backends := make([]backend.Server, len(weights))
+
+	for i, weight := range weights {
+		backendURL, err := url.Parse(fmt.Sprintf("http://localhost:%d", 3333*(i+1)))
+		require.NoError(t, err)
+
+		rp := httputil.NewSingleHostReverseProxy(backendURL)
+		backends[i] = backend.NewTSDB(backendURL, rp, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		backends[i].SetWeight(weight)
+		manager.Add("default", backends[i])
+	}
+
+	// Requests must be distributed in proportion to weights and in
+	// interleaved fashion
+	var targets []string
+	for range 7 {
+		targets = append(targets, manager.Target("default", d).URL().String())
+	}
+
+	expected := []int{0, 0, 1, 0, 2, 0, 0}
+	for i, j := range expected {
+		assert.Equal(t, backends[j].URL().String(), targets[i])
+	}
+
+	// Dead backends must not be selected
+	backends[0].SetAlive(false)
+
+	for range 4 {
+		assert.NotEqual(t, backends[0].URL().String(), manager.Target("default", d).URL().String())
+	}
+
+	// When all are dead, expect nil
+	backends[1].SetAlive(false)
+	backends[2].SetAlive(false)
+	assert.Empty(t, manager.Target("default", d))
+
+	// For unknown ID expect nil
+	assert.Empty(t, manager.Target("unknown", d))
+}
@@ -0,0 +1,140 @@
+package tsdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrUnsupportedCompression is returned when compression algorithm is not supported.
+var ErrUnsupportedCompression = errors.New("unsupported compression")
+
+// Compression algorithms supported by TSDB client.
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+)
+
+// Request bodies smaller than minCompressionSize are never compressed as the
+// overhead of compression outweighs the gain.
+const minCompressionSize = 1024
+
+// SetCompression sets the compression algorithm of request and response bodies.
+// An empty algorithm disables compression.
+func (t *TSDB) SetCompression(algo string) error {
+	switch algo {
+	case "", CompressionNone:
+		t.compression = ""
+	case CompressionGzip:
+		t.compression = algo
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedCompression, algo)
+	}
+
+	t.requestCompressionOff.Store(false)
+
+	return nil
+}
+
+// postForm makes a POST request with form values to endpoint and returns status
+// code and body of response.
+//
+// When compression is enabled, compressed responses are requested and large
+// request bodies are compressed. If TSDB rejects a compressed request, it is
+// retried uncompressed and request compression is disabled for subsequent requests.
+func (t *TSDB) postForm(ctx context.Context, endpoint *url.URL, values url.Values) (int, []byte, error) {
+	payload := values.Encode()
+
+	compress := t.compression != "" && !t.requestCompressionOff.Load() && len(payload) >= minCompressionSize
+
+	statusCode, body, err := t.doPostForm(ctx, endpoint, payload, compress)
+	if err != nil || !compress || (statusCode != http.StatusBadRequest && statusCode != http.StatusUnsupportedMediaType) {
+		return statusCode, body, err
+	}
+
+	// Retry without compressing request body
+	retryStatusCode, retryBody, err := t.doPostForm(ctx, endpoint, payload, false)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// If uncompressed request succeeded, TSDB does not support compressed requests
+	if retryStatusCode != statusCode {
+		t.Logger.Warn("TSDB does not support compressed requests. Disabling request compression", "tsdb", t.URL.Redacted())
+		t.requestCompressionOff.Store(true)
+	}
+
+	return retryStatusCode, retryBody, nil
+}
+
+// doPostForm makes a POST request with payload to endpoint compressing the
+// payload when compress is true.
+func (t *TSDB) doPostForm(ctx context.Context, endpoint *url.URL, payload string, compress bool) (int, []byte, error) {
+	var reqBody io.Reader = strings.NewReader(payload)
+
+	if compress {
+		buf := &bytes.Buffer{}
+
+		gw := gzip.NewWriter(buf)
+		if _, err := gw.Write([]byte(payload)); err != nil {
+			return 0, nil, err
+		}
+
+		if err := gw.Close(); err != nil {
+			return 0, nil, err
+		}
+
+		reqBody = buf
+	}
+
+	// Create a new POST request
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), reqBody)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Add necessary headers
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	if compress {
+		req.Header.Add("Content-Encoding", t.compression)
+	}
+
+	if t.compression != "" {
+		req.Header.Add("Accept-Encoding", t.compression)
+	}
+
+	// Make request
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decompress response body if TSDB compressed it
+	var respBody io.Reader = resp.Body
+
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), CompressionGzip) {
+		gr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to decompress response body: %w", err)
+		}
+		defer gr.Close()
+
+		respBody = gr
+	}
+
+	// Read response body
+	body, err := io.ReadAll(respBody)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return resp.StatusCode, body, nil
+}
@@ -0,0 +1,165 @@
+package tsdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownResultType is returned when result type of query is not supported.
+var ErrUnknownResultType = errors.New("unknown result type")
+
+// Result types of TSDB queries.
+const (
+	ResultTypeVector = "vector"
+	ResultTypeMatrix = "matrix"
+	ResultTypeScalar = "scalar"
+)
+
+// Sample is a single sample of a time series.
+type Sample struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// Series is a time series with its labels and samples ordered by time.
+type Series struct {
+	Labels  map[string]string
+	Samples []Sample
+}
+
+// Result is the typed result of instant and range queries. Instant vectors
+// and scalars are represented as series with a single sample.
+type Result struct {
+	Type   string
+	Series []Series
+}
+
+// rawResult is the result of query as returned by TSDB API.
+type rawResult struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// rawSeries is a series of vector and matrix results.
+type rawSeries struct {
+	Metric map[string]string   `json:"metric"`
+	Value  []json.RawMessage   `json:"value,omitempty"`
+	Values [][]json.RawMessage `json:"values,omitempty"`
+}
+
+// ParseResponse parses body of TSDB query response into Result.
+func ParseResponse(body []byte) (*Result, error) {
+	var resp struct {
+		Status    string          `json:"status"`
+		Data      json.RawMessage `json:"data"`
+		ErrorType string          `json:"errorType"`
+		Error     string          `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Status == "error" {
+		return nil, fmt.Errorf("error response from TSDB: %s: %s", resp.ErrorType, resp.Error)
+	}
+
+	if len(resp.Data) == 0 {
+		return nil, ErrMissingData
+	}
+
+	return ParseResult(resp.Data)
+}
+
+// ParseResult parses the data of TSDB query response into Result. Values are
+// parsed from their string representation and hence `NaN`, `+Inf` and `-Inf`
+// are supported. Query API of TSDB never returns stale markers and hence, they
+// need not be handled here.
+func ParseResult(data []byte) (*Result, error) {
+	var raw rawResult
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Type: raw.ResultType}
+
+	switch raw.ResultType {
+	case ResultTypeScalar:
+		var pair []json.RawMessage
+		if err := json.Unmarshal(raw.Result, &pair); err != nil {
+			return nil, fmt.Errorf("failed to parse scalar result: %w", err)
+		}
+
+		sample, err := parseSample(pair)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Series = []Series{{Labels: map[string]string{}, Samples: []Sample{sample}}}
+	case ResultTypeVector, ResultTypeMatrix:
+		var series []rawSeries
+		if err := json.Unmarshal(raw.Result, &series); err != nil {
+			return nil, fmt.Errorf("failed to parse %s result: %w", raw.ResultType, err)
+		}
+
+		result.Series = make([]Series, 0, len(series))
+
+		for _, s := range series {
+			pairs := s.Values
+			if raw.ResultType == ResultTypeVector {
+				pairs = [][]json.RawMessage{s.Value}
+			}
+
+			samples := make([]Sample, 0, len(pairs))
+
+			for _, pair := range pairs {
+				sample, err := parseSample(pair)
+				if err != nil {
+					return nil, err
+				}
+
+				samples = append(samples, sample)
+			}
+
+			if s.Metric == nil {
+				s.Metric = make(map[string]string)
+			}
+
+			result.Series = append(result.Series, Series{Labels: s.Metric, Samples: samples})
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownResultType, raw.ResultType)
+	}
+
+	return result, nil
+}
+
+// parseSample parses a pair of timestamp and value into Sample.
+func parseSample(pair []json.RawMessage) (Sample, error) {
+	if len(pair) != 2 {
+		return Sample{}, fmt.Errorf("invalid sample: expected timestamp and value, got %d items", len(pair))
+	}
+
+	// Timestamp is a float of seconds since epoch
+	ts, err := strconv.ParseFloat(string(pair[0]), 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("invalid sample timestamp %s: %w", pair[0], err)
+	}
+
+	// Value is always a string to support non finite values
+	var value string
+	if err := json.Unmarshal(pair[1], &value); err != nil {
+		return Sample{}, fmt.Errorf("invalid sample value %s: %w", pair[1], err)
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("invalid sample value %s: %w", value, err)
+	}
+
+	return Sample{Timestamp: time.UnixMilli(int64(math.Round(ts * 1000))), Value: v}, nil
+}
@@ -6,14 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	config_util "github.com/prometheus/common/config"
@@ -26,6 +25,7 @@ var (
 	ErrMissingData         = errors.New("missing data in TSDB response")
 	ErrMissingConfig       = errors.New("global config not found in TSDB config")
 	ErrFailedTypeAssertion = errors.New("failed type assertion")
+	ErrBadQuery            = errors.New("invalid query")
 )
 
 var settingsLock = sync.RWMutex{}
@@ -64,6 +64,9 @@ type TSDB struct {
 	settingsCacheTTL time.Duration
 	lastUpdate       time.Time
 	available        bool
+	compression      string
+	// Set when TSDB does not support compressed requests
+	requestCompressionOff atomic.Bool
 }
 
 const (
@@ -136,6 +139,11 @@ func (t *TSDB) queryRangeEndpoint() *url.URL {
 	return t.URL.JoinPath("/api/v1/query_range")
 }
 
+// Format query endpoint.
+func (t *TSDB) formatQueryEndpoint() *url.URL {
+	return t.URL.JoinPath("/api/v1/format_query")
+}
+
 // Config endpoint.
 func (t *TSDB) configEndpoint() *url.URL {
 	return t.URL.JoinPath("/api/v1/status/config")
@@ -322,29 +330,8 @@ func (t *TSDB) Query(ctx context.Context, query string, queryTime time.Time) (Me
 		"time":  []string{queryTime.UTC().Format(time.RFC3339Nano)},
 	}
 
-	// Create a new POST request
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		t.queryEndpoint().String(),
-		strings.NewReader(values.Encode()),
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	// Add necessary headers
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-
 	// Make request
-	resp, err := t.Client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	statusCode, body, err := t.postForm(ctx, t.queryEndpoint(), values)
 	if err != nil {
 		return nil, err
 	}
@@ -366,8 +353,8 @@ func (t *TSDB) Query(ctx context.Context, query string, queryTime time.Time) (Me
 	}
 
 	// Check response code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("query returned status: %d", resp.StatusCode)
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("query returned status: %d", statusCode)
 	}
 
 	// Parse data
@@ -432,6 +419,43 @@ func (t *TSDB) Query(ctx context.Context, query string, queryTime time.Time) (Me
 	return queriedValues, nil
 }
 
+// FormatQuery formats the query using TSDB and returns formatted query. It can
+// be used to validate the syntax of a query without executing it. When query is
+// invalid, returned error wraps ErrBadQuery.
+func (t *TSDB) FormatQuery(ctx context.Context, query string) (string, error) {
+	values := url.Values{
+		"query": []string{query},
+	}
+
+	// Make request
+	statusCode, body, err := t.postForm(ctx, t.formatQueryEndpoint(), values)
+	if err != nil {
+		return "", err
+	}
+
+	// Unpack into data
+	var data Response
+	if err = json.Unmarshal(body, &data); err != nil {
+		return "", err
+	}
+
+	// Check if query is invalid
+	if data.Status == "error" && data.ErrorType == "bad_data" {
+		return "", fmt.Errorf("%w: %s", ErrBadQuery, data.Error)
+	}
+
+	// Check response code
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("format query returned status: %d", statusCode)
+	}
+
+	if formattedQuery, ok := data.Data.(string); ok {
+		return formattedQuery, nil
+	}
+
+	return "", fmt.Errorf("%w on data: %v", ErrFailedTypeAssertion, data.Data)
+}
+
 // RangeQuery makes a TSDB range query.
 func (t *TSDB) RangeQuery(
 	ctx context.Context,
@@ -449,29 +473,8 @@ func (t *TSDB) RangeQuery(
 		"step":  []string{step},
 	}
 
-	// Create a new POST request
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		t.queryRangeEndpoint().String(),
-		strings.NewReader(values.Encode()),
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	// Add necessary headers
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-
 	// Make request
-	resp, err := t.Client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	statusCode, body, err := t.postForm(ctx, t.queryRangeEndpoint(), values)
 	if err != nil {
 		return nil, err
 	}
@@ -493,8 +496,8 @@ func (t *TSDB) RangeQuery(
 	}
 
 	// Check response code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("query returned status: %d", resp.StatusCode)
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("query returned status: %d", statusCode)
 	}
 
 	queryData, ok := data.Data.(map[string]interface{})
@@ -550,6 +553,46 @@ func (t *TSDB) RangeQuery(
 	return queriedRangeValues, nil
 }
 
+// RangeQuerySeries makes a TSDB range query and returns all the series of
+// result with their labels. Unlike RangeQuery, series with same metric name
+// are not merged.
+func (t *TSDB) RangeQuerySeries(
+	ctx context.Context,
+	query string,
+	startTime time.Time,
+	endTime time.Time,
+	step string,
+) (*Result, error) {
+	// Add form data to request
+	// TSDB expects time stamps in UTC zone
+	values := url.Values{
+		"query": []string{query},
+		"start": []string{startTime.UTC().Format(time.RFC3339Nano)},
+		"end":   []string{endTime.UTC().Format(time.RFC3339Nano)},
+		"step":  []string{step},
+	}
+
+	// Make request
+	statusCode, body, err := t.postForm(ctx, t.queryRangeEndpoint(), values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse response before checking status code as error responses
+	// contain the reason of failure
+	result, err := ParseResponse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check response code
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("query returned status: %d", statusCode)
+	}
+
+	return result, nil
+}
+
 // Delete time series with given labels.
 func (t *TSDB) Delete(ctx context.Context, startTime time.Time, endTime time.Time, matchers []string) error {
 	// Add form data to request
@@ -560,27 +603,14 @@ func (t *TSDB) Delete(ctx context.Context, startTime time.Time, endTime time.Tim
 		"end":     []string{endTime.UTC().Format(time.RFC3339Nano)},
 	}
 
-	// Create a new POST request
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		t.deleteEndpoint().String(),
-		strings.NewReader(values.Encode()),
-	)
+	// Make request and check status code which is supposed to be 204
+	statusCode, _, err := t.postForm(ctx, t.deleteEndpoint(), values)
 	if err != nil {
 		return err
 	}
 
-	// Add necessary headers
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-
-	// Make request and check status code which is supposed to be 204
-	if resp, err := t.Client.Do(req); err != nil {
-		return err
-	} else if resp.StatusCode != http.StatusNoContent {
-		defer resp.Body.Close()
-
-		return fmt.Errorf("expected 204 after deletion of time series received %d", resp.StatusCode)
+	if statusCode != http.StatusNoContent {
+		return fmt.Errorf("expected 204 after deletion of time series received %d", statusCode)
 	}
 
 	return nil
@@ -1,12 +1,16 @@
 package tsdb
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -295,6 +299,38 @@ func TestTSDBQueryFail(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTSDBFormatQuery(t *testing.T) {
+	// Start test server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		var resp Response
+
+		if r.Form.Get("query") == "sum(foo" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			resp = Response{Status: "error", ErrorType: "bad_data", Error: "unclosed left parenthesis"}
+		} else {
+			resp = Response{Status: "success", Data: r.Form.Get("query")}
+		}
+
+		if err := json.NewEncoder(w).Encode(&resp); err != nil {
+			w.Write([]byte("KO"))
+		}
+	}))
+	defer server.Close()
+
+	tsdb, err := New(server.URL, config_util.HTTPClientConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	query, err := tsdb.FormatQuery(context.Background(), "sum(foo)")
+	require.NoError(t, err)
+	assert.Equal(t, "sum(foo)", query)
+
+	_, err = tsdb.FormatQuery(context.Background(), "sum(foo")
+	require.ErrorIs(t, err, ErrBadQuery)
+}
+
 func TestTSDBQueryRangeSuccess(t *testing.T) {
 	// Start test server
 	expected := Response{
@@ -359,6 +395,46 @@ func TestTSDBQueryRangeFail(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTSDBQueryRangeSeries(t *testing.T) {
+	// Two series of same metric must not be merged
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[` +
+			`{"metric":{"__name__":"up","instance":"host0"},"values":[[1727367964.929,"1"]]},` +
+			`{"metric":{"__name__":"up","instance":"host1"},"values":[[1727367964.929,"0"]]}]}}`))
+	}))
+	defer server.Close()
+
+	tsdb, err := New(server.URL, config_util.HTTPClientConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	result, err := tsdb.RangeQuerySeries(context.Background(), "up", time.Now(), time.Now(), "300")
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		&Result{
+			Type: ResultTypeMatrix,
+			Series: []Series{
+				{Labels: map[string]string{"__name__": "up", "instance": "host0"}, Samples: []Sample{{Timestamp: time.UnixMilli(1727367964929), Value: 1}}},
+				{Labels: map[string]string{"__name__": "up", "instance": "host1"}, Samples: []Sample{{Timestamp: time.UnixMilli(1727367964929), Value: 0}}},
+			},
+		},
+		result,
+	)
+
+	// Error responses must return error
+	errServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+	}))
+	defer errServer.Close()
+
+	tsdb, err = New(errServer.URL, config_util.HTTPClientConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	_, err = tsdb.RangeQuerySeries(context.Background(), "up", time.Now(), time.Now(), "300")
+	assert.Error(t, err)
+}
+
 func TestTSDBDeleteSuccess(t *testing.T) {
 	// Start test server
 	expected := []string{"metric1", "metric2"}
@@ -413,3 +489,201 @@ func TestTSDBDeleteFail(t *testing.T) {
 	err = tsdb.Delete(context.Background(), time.Now(), time.Now(), expected)
 	require.Error(t, err)
 }
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected *Result
+		err      error
+	}{
+		{
+			name: "matrix",
+			body: `{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{"__name__":"up","uuid":"123"},"values":[[1735045414,"1"],[1735045429.5,"NaN"],[1735045444,"+Inf"]]},` +
+				`{"metric":{"uuid":"456"},"values":[[1735045414,"-Inf"]]}]}}`,
+			expected: &Result{
+				Type: ResultTypeMatrix,
+				Series: []Series{
+					{
+						Labels: map[string]string{"__name__": "up", "uuid": "123"},
+						Samples: []Sample{
+							{Timestamp: time.UnixMilli(1735045414000), Value: 1},
+							{Timestamp: time.UnixMilli(1735045429500), Value: math.NaN()},
+							{Timestamp: time.UnixMilli(1735045444000), Value: math.Inf(1)},
+						},
+					},
+					{
+						Labels:  map[string]string{"uuid": "456"},
+						Samples: []Sample{{Timestamp: time.UnixMilli(1735045414000), Value: math.Inf(-1)}},
+					},
+				},
+			},
+		},
+		{
+			name: "vector",
+			body: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"uuid":"123"},"value":[1735045414.123,"2.5"]},{"metric":{},"value":[1735045414.123,"3"]}]}}`,
+			expected: &Result{
+				Type: ResultTypeVector,
+				Series: []Series{
+					{
+						Labels:  map[string]string{"uuid": "123"},
+						Samples: []Sample{{Timestamp: time.UnixMilli(1735045414123), Value: 2.5}},
+					},
+					{
+						Labels:  map[string]string{},
+						Samples: []Sample{{Timestamp: time.UnixMilli(1735045414123), Value: 3}},
+					},
+				},
+			},
+		},
+		{
+			name: "scalar",
+			body: `{"status":"success","data":{"resultType":"scalar","result":[1735045414,"42"]}}`,
+			expected: &Result{
+				Type: ResultTypeScalar,
+				Series: []Series{
+					{
+						Labels:  map[string]string{},
+						Samples: []Sample{{Timestamp: time.UnixMilli(1735045414000), Value: 42}},
+					},
+				},
+			},
+		},
+		{
+			name:     "empty matrix",
+			body:     `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			expected: &Result{Type: ResultTypeMatrix, Series: []Series{}},
+		},
+		{
+			name: "unknown result type",
+			body: `{"status":"success","data":{"resultType":"string","result":[1735045414,"foo"]}}`,
+			err:  ErrUnknownResultType,
+		},
+		{
+			name: "missing data",
+			body: `{"status":"success"}`,
+			err:  ErrMissingData,
+		},
+	}
+
+	for _, test := range tests {
+		result, err := ParseResponse([]byte(test.body))
+		if test.err != nil {
+			require.ErrorIs(t, err, test.err, test.name)
+
+			continue
+		}
+
+		require.NoError(t, err, test.name)
+		assert.Equal(t, test.expected.Type, result.Type, test.name)
+		require.Len(t, result.Series, len(test.expected.Series), test.name)
+
+		for i, series := range test.expected.Series {
+			assert.Equal(t, series.Labels, result.Series[i].Labels, test.name)
+			require.Len(t, result.Series[i].Samples, len(series.Samples), test.name)
+
+			for j, sample := range series.Samples {
+				assert.Equal(t, sample.Timestamp, result.Series[i].Samples[j].Timestamp, test.name)
+
+				// NaN values cannot be compared directly
+				if math.IsNaN(sample.Value) {
+					assert.True(t, math.IsNaN(result.Series[i].Samples[j].Value), test.name)
+				} else {
+					assert.Equal(t, sample.Value, result.Series[i].Samples[j].Value, test.name)
+				}
+			}
+		}
+	}
+}
+
+func TestParseResponseFail(t *testing.T) {
+	for _, body := range []string{
+		`{"status":"error","errorType":"bad_data","error":"invalid query"}`,
+		`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1735045414]]}]}}`,
+		`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1735045414,"foo"]]}]}}`,
+		`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":["foo","1"]}]}}`,
+	} {
+		_, err := ParseResponse([]byte(body))
+		assert.Error(t, err, body)
+	}
+}
+
+func TestTSDBCompression(t *testing.T) {
+	// A large query that will be compressed
+	query := fmt.Sprintf(`foo{uuid=~"%s"}`, strings.Repeat("1234|", 500))
+
+	for _, supported := range []bool{true, false} {
+		var compressedRequests int
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body io.Reader = r.Body
+
+			if r.Header.Get("Content-Encoding") == CompressionGzip {
+				compressedRequests++
+
+				// Mimic TSDB that does not support compressed requests
+				if !supported {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				gr, err := gzip.NewReader(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				body = gr
+			}
+
+			values, err := io.ReadAll(body)
+			if err != nil || !strings.Contains(string(values), "1234") {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			// Compress response when client accepts it
+			var respWriter io.Writer = w
+
+			if r.Header.Get("Accept-Encoding") == CompressionGzip {
+				w.Header().Set("Content-Encoding", CompressionGzip)
+
+				gw := gzip.NewWriter(w)
+				defer gw.Close()
+
+				respWriter = gw
+			}
+
+			respWriter.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"uuid":"1"},"value":[12345,"1.1"]}]}}`))
+		}))
+		defer server.Close()
+
+		tsdb, err := New(server.URL, config_util.HTTPClientConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		require.NoError(t, err)
+		require.NoError(t, tsdb.SetCompression(CompressionGzip))
+
+		for range 3 {
+			m, err := tsdb.Query(context.Background(), query, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, Metric{"1": 1.1}, m)
+		}
+
+		// When TSDB does not support compressed requests, client must stop
+		// compressing requests after first failure
+		if supported {
+			assert.Equal(t, 3, compressedRequests)
+		} else {
+			assert.Equal(t, 1, compressedRequests)
+		}
+	}
+
+	// Unknown algorithm must return error
+	tsdb, err := New("", config_util.HTTPClientConfig{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.ErrorIs(t, tsdb.SetCompression("snappy"), ErrUnsupportedCompression)
+}
@@ -188,6 +188,38 @@ then
   then
     desc="/units/admin end point test for denied request"
     fixture='pkg/api/testdata/output/e2e-test-api-server-admin--denied-query.txt'
+  elif [ "${scenario}" = "api-units-count-query" ]
+  then
+    desc="/units/count end point test"
+    fixture='pkg/api/testdata/output/e2e-test-api-server-units-count-query.txt'
+  elif [ "${scenario}" = "api-units-count-admin-query" ]
+  then
+    desc="/units/count/admin end point test"
+    fixture='pkg/api/testdata/output/e2e-test-api-server-units-count-admin-query.txt'
+  elif [ "${scenario}" = "api-units-paginated-admin-query" ]
+  then
+    desc="/units/admin end point test with pagination"
+    fixture='pkg/api/testdata/output/e2e-test-api-server-units-paginated-admin-query.txt'
+  elif [ "${scenario}" = "api-units-cursor-admin-query" ]
+  then
+    desc="/units/admin end point test with cursor pagination"
+    fixture='pkg/api/testdata/output/e2e-test-api-server-units-cursor-admin-query.txt'
+  elif [ "${scenario}" = "api-units-export-admin-query" ]
+  then
+    desc="/units/export/admin end point test"
+    fixture='pkg/api/testdata/output/e2e-test-api-server-units-export-admin-query.txt'
+  elif [ "${scenario}" = "api-units-state-admin-query" ]
+  then
+    desc="/units/admin end point test with state filter"
+    fixture='pkg/api/testdata/output/e2e-test-api-server-units-state-admin-query.txt'
+  elif [ "${scenario}" = "api-units-threshold-admin-query" ]
+  then
+    desc="/units/admin end point test with metric thresholds"
+    fixture='pkg/api/testdata/output/e2e-test-api-server-units-threshold-admin-query.txt'
+  elif [ "${scenario}" = "api-usage-metrics-admin-query" ]
+  then
+    desc="/metrics/usage end point test"
+    fixture='pkg/api/testdata/output/e2e-test-api-server-usage-metrics-admin-query.txt'
   elif [ "${scenario}" = "api-current-usage-query" ]
   then
     desc="/usage/current end point test"
@@ -204,6 +236,10 @@ then
   then
     desc="/usage/current/admin end point test"
     fixture='pkg/api/testdata/output/e2e-test-api-server-current-usage-admin-query.txt'
+  elif [ "${scenario}" = "api-current-usage-admin-groupby-query" ]
+  then
+    desc="/usage/current/admin end point test with additional groupby"
+    fixture='pkg/api/testdata/output/e2e-test-api-server-current-usage-admin-groupby-query.txt'
   elif [ "${scenario}" = "api-current-usage-admin-experimental-query" ]
   then
     desc="/usage/current/admin end point test with experimental aggregation"
@@ -535,6 +571,7 @@ then
         --collector.slurm \
         --collector.gpu.type="amd" \
         --collector.gpu.rocm-smi-path="pkg/collector/testdata/rocm-smi" \
+        --collector.gpu.static-power="MI50=300" \
         --collector.slurm.swap.memory.metrics \
         --collector.slurm.psi.metrics \
         --collector.ipmi_dcmi \
@@ -721,9 +758,30 @@ then
   elif [ "${scenario}" = "api-admin-denied-query" ]
   then
     get -H "X-Grafana-User: usr1" "127.0.0.1:${port}/api/${api_version}/units/admin" > "${fixture_output}"
+  elif [ "${scenario}" = "api-units-count-query" ]
+  then
+    get -H "X-Grafana-User: usr3" "127.0.0.1:${port}/api/${api_version}/units/count?cluster_id=slurm-0&project=acc3&from=1676934000&to=1677538800" > "${fixture_output}"
+  elif [ "${scenario}" = "api-units-count-admin-query" ]
+  then
+    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/units/count/admin?cluster_id=slurm-1&from=1676934000&to=1677538800" > "${fixture_output}"
+  elif [ "${scenario}" = "api-units-paginated-admin-query" ]
+  then
+    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/units/admin?cluster_id=slurm-1&from=1676934000&to=1677538800&limit=3&offset=2&field=uuid&field=project" > "${fixture_output}"
+  elif [ "${scenario}" = "api-units-cursor-admin-query" ]
+  then
+    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/units/admin?cluster_id=slurm-1&from=1676934000&to=1677538800&limit=3&cursor=WyJzbHVybS0xIiwiMTQ3OTc2NSJd&field=uuid&field=project" > "${fixture_output}"
+  elif [ "${scenario}" = "api-units-export-admin-query" ]
+  then
+    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/units/export/admin?cluster_id=slurm-1&from=1676934000&to=1677538800&field=uuid&field=project&field=username&field=total_time_seconds" > "${fixture_output}"
+  elif [ "${scenario}" = "api-units-state-admin-query" ]
+  then
+    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/units/admin?cluster_id=slurm-1&from=1676934000&to=1677538800&state=cancelled&state=COMPLETED&field=uuid&field=state" > "${fixture_output}"
+  elif [ "${scenario}" = "api-units-threshold-admin-query" ]
+  then
+    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/units/admin?cluster_id=slurm-1&from=1676934000&to=1677538800&min_total_cpu_energy_usage_kwh=20&max_total_time_seconds.walltime=10000&field=uuid&field=total_time_seconds&field=total_cpu_energy_usage_kwh" > "${fixture_output}"
   elif [ "${scenario}" = "api-current-usage-query" ]
   then
-    get -H "X-Grafana-User: usr1" "127.0.0.1:${port}/api/${api_version}/usage/current?cluster_id=slurm-1&from=${usage_from}&to=${usage_to}&__terminated" > "${fixture_output}"
+    get -H "X-Grafana-User: usr1" "127.0.0.1:${port}/api/${api_version}/usage/current?cluster_id=slurm-1&from=${usage_from}&to=${usage_to}&status=terminated" > "${fixture_output}"
   elif [ "${scenario}" = "api-current-usage-experimental-query" ]
   then
     get -H "X-Grafana-User: test-user-4" "127.0.0.1:${port}/api/${api_version}/usage/current?cluster_id=os-1&from=${usage_from}&to=${usage_to}&experimental" > "${fixture_output}"
@@ -732,7 +790,13 @@ then
     get -H "X-Grafana-User: usr1" "127.0.0.1:${port}/api/${api_version}/usage/global?cluster_id=slurm-0&field=username&field=project&field=num_units" > "${fixture_output}"
   elif [ "${scenario}" = "api-current-usage-admin-query" ]
   then
-    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/usage/current/admin?cluster_id=slurm-1&user=usr15&user=usr3&from=${usage_from}&to=${usage_to}&__terminated" > "${fixture_output}"
+    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/usage/current/admin?cluster_id=slurm-1&user=usr15&user=usr3&from=${usage_from}&to=${usage_to}&status=terminated" > "${fixture_output}"
+  elif [ "${scenario}" = "api-current-usage-admin-groupby-query" ]
+  then
+    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/usage/current/admin?cluster_id=slurm-1&from=${usage_from}&to=${usage_to}&groupby=cluster_id&groupby=groupname&field=num_units&field=total_cpu_energy_usage_kwh" > "${fixture_output}"
+  elif [ "${scenario}" = "api-usage-metrics-admin-query" ]
+  then
+    get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/metrics/usage?cluster_id=slurm-1&from=${usage_from}&to=${usage_to}" > "${fixture_output}"
   elif [ "${scenario}" = "api-current-usage-admin-experimental-query" ]
   then
     get -H "X-Grafana-User: grafana" "127.0.0.1:${port}/api/${api_version}/usage/current/admin?cluster_id=slurm-1&user=usr15&user=usr4&cluster_id=os-1&user=test-user-4&from=${usage_from}&to=${usage_to}&experimental" > "${fixture_output}"
@@ -0,0 +1,24 @@
+#!/usr/bin/env bash
+#
+# Generate Go code from protobuf definitions. It requires buf, protoc-gen-go
+# and protoc-gen-go-grpc in PATH.
+
+set -euo pipefail
+shopt -s nullglob
+
+if ! [[ "$0" =~ "scripts/genproto.sh" ]]; then
+	echo "must be run from repository root"
+	exit 255
+fi
+
+for tool in buf protoc-gen-go protoc-gen-go-grpc; do
+	if ! command -v "${tool}" > /dev/null; then
+		echo "${tool} not found in PATH"
+		exit 255
+	fi
+done
+
+for dir in pkg/api/grpcpb; do
+	echo ">> generating code in ${dir}"
+	buf generate --template scripts/buf.gen.yaml --output "${dir}" "${dir}"
+done